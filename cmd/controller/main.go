@@ -21,10 +21,14 @@ import (
 	"log"
 	"net/http"
 	"os"
+	// Embed the time zone database for the time zones of the PipelineRunSchedules
+	_ "time/tzdata"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerunschedule"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
@@ -104,6 +108,9 @@ func main() {
 	sharedmain.MainWithConfig(ctx, ControllerLogKey, cfg,
 		taskrun.NewController(opts),
 		pipelinerun.NewController(opts),
+		pipelinerunschedule.NewController(),
+		pruner.NewPipelineRunController(),
+		pruner.NewTaskRunController(),
 	)
 }
 
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"log"
 	"os"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/tektoncd/pipeline/cmd/entrypoint/subcommands"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/credentials"
 	"github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
 	"github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
@@ -41,12 +43,16 @@ var (
 	postFile            = flag.String("post_file", "", "If specified, file to write upon completion")
 	terminationPath     = flag.String("termination_path", "/tekton/termination", "If specified, file to write upon termination")
 	results             = flag.String("results", "", "If specified, list of file names that might contain task results")
+	resultTypes         = flag.String("result_types", "", "If specified, comma-separated list of <name>:<type> pairs of the task results which are not strings e.g. \"images:array\"")
+	stepResults         = flag.String("step_results", "", "If specified, list of file names that might contain the results of the step")
 	timeout             = flag.Duration("timeout", time.Duration(0), "If specified, sets timeout for step")
 	breakpointOnFailure = flag.Bool("breakpoint_on_failure", false, "If specified, expect steps to not skip on failure")
 	onError             = flag.String("on_error", "", "Set to \"continue\" to ignore an error and continue when a container terminates with a non-zero exit code."+
 		" Set to \"stopAndFail\" to declare a failure with a step error and stop executing the rest of the steps.")
 	stepMetadataDir     = flag.String("step_metadata_dir", "", "If specified, create directory to store the step metadata e.g. /tekton/steps/<step-name>/")
 	stepMetadataDirLink = flag.String("step_metadata_dir_link", "", "creates a symbolic link to the specified step_metadata_dir e.g. /tekton/steps/<step-index>/")
+	skip                = flag.Bool("skip", false, "If specified, skip the step because its when expressions evaluated to false")
+	when                = flag.String("when", "", "If specified, JSON list of when expressions referring to the results of previous steps, the step is skipped if they evaluate to false")
 )
 
 const (
@@ -110,11 +116,15 @@ func main() {
 		Runner:              &realRunner{},
 		PostWriter:          &realPostWriter{},
 		Results:             strings.Split(*results, ","),
+		ResultTypes:         parseResultTypes(*resultTypes),
+		StepResults:         strings.Split(*stepResults, ","),
 		Timeout:             timeout,
 		BreakpointOnFailure: *breakpointOnFailure,
 		OnError:             *onError,
 		StepMetadataDir:     *stepMetadataDir,
 		StepMetadataDirLink: *stepMetadataDirLink,
+		Skip:                *skip,
+		When:                parseWhenExpressions(*when),
 	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
@@ -156,3 +166,27 @@ func main() {
 		}
 	}
 }
+
+// parseResultTypes parses a comma-separated list of <name>:<type> pairs into a map of result
+// name to type.
+func parseResultTypes(resultTypes string) map[string]v1beta1.ResultsType {
+	types := map[string]v1beta1.ResultsType{}
+	for _, pair := range strings.Split(resultTypes, ",") {
+		if parts := strings.SplitN(pair, ":", 2); len(parts) == 2 {
+			types[parts[0]] = v1beta1.ResultsType(parts[1])
+		}
+	}
+	return types
+}
+
+// parseWhenExpressions parses the JSON list of when expressions of the step.
+func parseWhenExpressions(when string) v1beta1.WhenExpressions {
+	if when == "" {
+		return nil
+	}
+	var wes v1beta1.WhenExpressions
+	if err := json.Unmarshal([]byte(when), &wes); err != nil {
+		log.Fatalf("Error parsing when expressions %q: %v", when, err)
+	}
+	return wes
+}
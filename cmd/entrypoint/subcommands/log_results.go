@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subcommands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// LogResultsCommand is the command name for logging the results of the steps.
+const LogResultsCommand = "log-results"
+
+// logResultsPollInterval is how often the post file of the last step is checked for.
+var logResultsPollInterval = 100 * time.Millisecond
+
+// logResults waits for the last step to write its post file, then writes the results
+// found in resultsDir to w, one JSON encoded result per line. Results which were not
+// written by any step are skipped.
+func logResults(resultsDir, postFile string, names []string, w io.Writer) error {
+	for !fileExists(postFile) && !fileExists(postFile+".err") {
+		time.Sleep(logResultsPollInterval)
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, name := range names {
+		value, err := ioutil.ReadFile(filepath.Join(resultsDir, name))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("error reading result %q: %w", name, err)
+		}
+		if err := encoder.Encode(v1beta1.PipelineResourceResult{
+			Key:        name,
+			Value:      string(value),
+			ResultType: v1beta1.TaskRunResultType,
+		}); err != nil {
+			return fmt.Errorf("error logging result %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// splitResultNames returns the result names from their comma separated list.
+func splitResultNames(names string) []string {
+	if names == "" {
+		return nil
+	}
+	return strings.Split(names, ",")
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subcommands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLogResults(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		postFile string
+		results  map[string]string
+		names    string
+		want     string
+	}{{
+		desc:     "results of successful steps",
+		postFile: "out",
+		results:  map[string]string{"foo": "hello", "bar": "[\"a\",\"b\"]"},
+		names:    "foo,bar",
+		want: `{"key":"foo","value":"hello","type":1}
+{"key":"bar","value":"[\"a\",\"b\"]","type":1}
+`,
+	}, {
+		desc:     "results of a failed step",
+		postFile: "out.err",
+		results:  map[string]string{"foo": "hello"},
+		names:    "foo",
+		want: `{"key":"foo","value":"hello","type":1}
+`,
+	}, {
+		desc:     "missing results are skipped",
+		postFile: "out",
+		results:  map[string]string{"bar": "world"},
+		names:    "foo,bar",
+		want: `{"key":"bar","value":"world","type":1}
+`,
+	}, {
+		desc:     "no results",
+		postFile: "out",
+		names:    "",
+		want:     "",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			tmp, err := ioutil.TempDir("", "log-results-test-*")
+			if err != nil {
+				t.Fatalf("error creating temp directory: %v", err)
+			}
+			defer os.RemoveAll(tmp)
+			for name, value := range tc.results {
+				if err := ioutil.WriteFile(filepath.Join(tmp, name), []byte(value), 0666); err != nil {
+					t.Fatalf("error writing result %q: %v", name, err)
+				}
+			}
+			if err := ioutil.WriteFile(filepath.Join(tmp, tc.postFile), nil, 0666); err != nil {
+				t.Fatalf("error writing post file: %v", err)
+			}
+
+			var out bytes.Buffer
+			if err := logResults(tmp, filepath.Join(tmp, "out"), splitResultNames(tc.names), &out); err != nil {
+				t.Fatalf("unexpected error logging results: %v", err)
+			}
+			if out.String() != tc.want {
+				t.Errorf("expected logged results %q received %q", tc.want, out.String())
+			}
+		})
+	}
+}
+
+func TestLogResultsWaitsForPostFile(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "log-results-test-*")
+	if err != nil {
+		t.Fatalf("error creating temp directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+	postFile := filepath.Join(tmp, "out")
+
+	done := make(chan error)
+	var out bytes.Buffer
+	go func() {
+		done <- logResults(tmp, postFile, []string{"foo"}, &out)
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("logResults returned before the post file was written: %v", err)
+	case <-time.After(2 * logResultsPollInterval):
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(tmp, "foo"), []byte("hello"), 0666); err != nil {
+		t.Fatalf("error writing result: %v", err)
+	}
+	if err := ioutil.WriteFile(postFile, nil, 0666); err != nil {
+		t.Fatalf("error writing post file: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error logging results: %v", err)
+	}
+	if want := "{\"key\":\"foo\",\"value\":\"hello\",\"type\":1}\n"; out.String() != want {
+		t.Errorf("expected logged results %q received %q", want, out.String())
+	}
+}
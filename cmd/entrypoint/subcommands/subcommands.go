@@ -18,6 +18,7 @@ package subcommands
 
 import (
 	"fmt"
+	"os"
 )
 
 // SubcommandSuccessful is returned for successful subcommand executions.
@@ -72,6 +73,18 @@ func Process(args []string) error {
 			}
 			return SubcommandSuccessful{message: fmt.Sprintf("Decoded script %s", src)}
 		}
+	case LogResultsCommand:
+		// If invoked in "log-results" mode (`entrypoint log-results <results-dir> <post-file> <names>`),
+		// wait for the last step to write <post-file> and log the comma separated results <names>
+		// read from <results-dir>. This is used by the results sidecar so that the controller can
+		// read the results from its logs instead of from the termination messages of the steps.
+		if len(args) == 4 {
+			resultsDir, postFile, names := args[1], args[2], args[3]
+			if err := logResults(resultsDir, postFile, splitResultNames(names), os.Stdout); err != nil {
+				return SubcommandError{subcommand: LogResultsCommand, message: err.Error()}
+			}
+			return SubcommandSuccessful{message: fmt.Sprintf("Logged results from %s", resultsDir)}
+		}
 	default:
 	}
 	return nil
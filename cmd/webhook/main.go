@@ -21,6 +21,8 @@ import (
 	"log"
 	"net/http"
 	"os"
+	// Embed the time zone database for the time zones of the PipelineRunSchedules
+	_ "time/tzdata"
 
 	defaultconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -46,14 +48,15 @@ import (
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// v1alpha1
-	v1alpha1.SchemeGroupVersion.WithKind("Pipeline"):         &v1alpha1.Pipeline{},
-	v1alpha1.SchemeGroupVersion.WithKind("Task"):             &v1alpha1.Task{},
-	v1alpha1.SchemeGroupVersion.WithKind("ClusterTask"):      &v1alpha1.ClusterTask{},
-	v1alpha1.SchemeGroupVersion.WithKind("TaskRun"):          &v1alpha1.TaskRun{},
-	v1alpha1.SchemeGroupVersion.WithKind("PipelineRun"):      &v1alpha1.PipelineRun{},
-	v1alpha1.SchemeGroupVersion.WithKind("Condition"):        &v1alpha1.Condition{},
-	v1alpha1.SchemeGroupVersion.WithKind("PipelineResource"): &v1alpha1.PipelineResource{},
-	v1alpha1.SchemeGroupVersion.WithKind("Run"):              &v1alpha1.Run{},
+	v1alpha1.SchemeGroupVersion.WithKind("Pipeline"):            &v1alpha1.Pipeline{},
+	v1alpha1.SchemeGroupVersion.WithKind("Task"):                &v1alpha1.Task{},
+	v1alpha1.SchemeGroupVersion.WithKind("ClusterTask"):         &v1alpha1.ClusterTask{},
+	v1alpha1.SchemeGroupVersion.WithKind("TaskRun"):             &v1alpha1.TaskRun{},
+	v1alpha1.SchemeGroupVersion.WithKind("PipelineRun"):         &v1alpha1.PipelineRun{},
+	v1alpha1.SchemeGroupVersion.WithKind("Condition"):           &v1alpha1.Condition{},
+	v1alpha1.SchemeGroupVersion.WithKind("PipelineResource"):    &v1alpha1.PipelineResource{},
+	v1alpha1.SchemeGroupVersion.WithKind("Run"):                 &v1alpha1.Run{},
+	v1alpha1.SchemeGroupVersion.WithKind("PipelineRunSchedule"): &v1alpha1.PipelineRunSchedule{},
 	// v1beta1
 	v1beta1.SchemeGroupVersion.WithKind("Pipeline"):    &v1beta1.Pipeline{},
 	v1beta1.SchemeGroupVersion.WithKind("Task"):        &v1beta1.Task{},
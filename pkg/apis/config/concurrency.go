@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/configmap"
+)
+
+const (
+	// ConcurrencyStrategyQueue queues the PipelineRuns exceeding the limit of a
+	// concurrency policy, and starts them in the order they were created
+	ConcurrencyStrategyQueue = "queue"
+	// ConcurrencyStrategyCancelOldest cancels the oldest running PipelineRuns to
+	// start the PipelineRuns exceeding the limit of a concurrency policy
+	ConcurrencyStrategyCancelOldest = "cancel-oldest"
+	// ConcurrencyStrategyCancelNewest cancels the PipelineRuns exceeding the limit
+	// of a concurrency policy
+	ConcurrencyStrategyCancelNewest = "cancel-newest"
+
+	// DefaultConcurrencyStrategy is the strategy of a concurrency policy
+	// when it isn't specified in the configmap
+	DefaultConcurrencyStrategy = ConcurrencyStrategyQueue
+)
+
+// ConcurrencyPolicy limits how many of the PipelineRuns it matches run at the
+// same time in a namespace
+// +k8s:deepcopy-gen=true
+type ConcurrencyPolicy struct {
+	// Name is the key of the policy in the configmap
+	Name string `json:"-"`
+	// PipelineName matches the PipelineRuns of the Pipeline with this name
+	PipelineName string `json:"pipelineName,omitempty"`
+	// Selector matches the PipelineRuns with these labels
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// MaxRuns is the number of matching PipelineRuns which may run at the same time
+	MaxRuns int `json:"maxRuns"`
+	// Strategy is what happens to the PipelineRuns exceeding MaxRuns, one of
+	// "queue", "cancel-oldest" or "cancel-newest"
+	Strategy string `json:"strategy,omitempty"`
+}
+
+// Concurrency holds the concurrency policies of PipelineRuns
+// +k8s:deepcopy-gen=true
+type Concurrency struct {
+	// Policies are sorted by name
+	Policies []ConcurrencyPolicy
+}
+
+// GetConcurrencyConfigName returns the name of the configmap containing all
+// concurrency policies of PipelineRuns.
+func GetConcurrencyConfigName() string {
+	if e := os.Getenv("CONFIG_CONCURRENCY_NAME"); e != "" {
+		return e
+	}
+	return "config-concurrency"
+}
+
+// Matches returns true if the policy applies to the PipelineRuns of the given
+// Pipeline with the given labels
+func (p *ConcurrencyPolicy) Matches(pipelineName string, lbls map[string]string) bool {
+	if p.PipelineName != "" && p.PipelineName != pipelineName {
+		return false
+	}
+	if p.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(p.Selector)
+		if err != nil || !selector.Matches(labels.Set(lbls)) {
+			return false
+		}
+	}
+	return true
+}
+
+// PolicyFor returns the first policy, sorted by name, which applies to the
+// PipelineRuns of the given Pipeline with the given labels, or nil if none does
+func (cfg *Concurrency) PolicyFor(pipelineName string, lbls map[string]string) *ConcurrencyPolicy {
+	if cfg == nil {
+		return nil
+	}
+	for i := range cfg.Policies {
+		if cfg.Policies[i].Matches(pipelineName, lbls) {
+			return &cfg.Policies[i]
+		}
+	}
+	return nil
+}
+
+// NewConcurrencyFromMap returns a Config given a map corresponding to a ConfigMap
+func NewConcurrencyFromMap(cfgMap map[string]string) (*Concurrency, error) {
+	tc := Concurrency{}
+	for name, value := range cfgMap {
+		if name == configmap.ExampleKey {
+			continue
+		}
+		policy := ConcurrencyPolicy{}
+		if err := yaml.Unmarshal([]byte(value), &policy); err != nil {
+			return nil, fmt.Errorf("failed to parse concurrency policy %q: %w", name, err)
+		}
+		policy.Name = name
+		if policy.Strategy == "" {
+			policy.Strategy = DefaultConcurrencyStrategy
+		}
+		if err := policy.validate(); err != nil {
+			return nil, fmt.Errorf("invalid concurrency policy %q: %w", name, err)
+		}
+		tc.Policies = append(tc.Policies, policy)
+	}
+	sort.Slice(tc.Policies, func(i, j int) bool {
+		return tc.Policies[i].Name < tc.Policies[j].Name
+	})
+	return &tc, nil
+}
+
+// NewConcurrencyFromConfigMap returns a Config for the given configmap
+func NewConcurrencyFromConfigMap(config *corev1.ConfigMap) (*Concurrency, error) {
+	return NewConcurrencyFromMap(config.Data)
+}
+
+func (p *ConcurrencyPolicy) validate() error {
+	if p.PipelineName == "" && p.Selector == nil {
+		return fmt.Errorf("expected pipelineName or selector to be set")
+	}
+	if p.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(p.Selector); err != nil {
+			return fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+	if p.MaxRuns < 1 {
+		return fmt.Errorf("maxRuns should be >= 1 but got %d", p.MaxRuns)
+	}
+	switch p.Strategy {
+	case ConcurrencyStrategyQueue, ConcurrencyStrategyCancelOldest, ConcurrencyStrategyCancelNewest:
+	default:
+		return fmt.Errorf("strategy should be one of %q, %q or %q but got %q",
+			ConcurrencyStrategyQueue, ConcurrencyStrategyCancelOldest, ConcurrencyStrategyCancelNewest, p.Strategy)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewConcurrencyFromConfigMap(t *testing.T) {
+	expectedConfig := &config.Concurrency{
+		Policies: []config.ConcurrencyPolicy{{
+			Name:         "deploy",
+			PipelineName: "deploy",
+			MaxRuns:      1,
+			Strategy:     config.ConcurrencyStrategyQueue,
+		}, {
+			Name: "production",
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"environment": "production"},
+			},
+			MaxRuns:  2,
+			Strategy: config.ConcurrencyStrategyCancelOldest,
+		}},
+	}
+	verifyConfigFileWithExpectedConcurrencyConfig(t, config.GetConcurrencyConfigName(), expectedConfig)
+}
+
+func TestNewConcurrencyFromEmptyConfigMap(t *testing.T) {
+	verifyConfigFileWithExpectedConcurrencyConfig(t, "config-concurrency-empty", &config.Concurrency{})
+}
+
+func TestNewConcurrencyConfigMapErrors(t *testing.T) {
+	for _, tc := range []struct {
+		fileName string
+	}{{
+		fileName: "config-concurrency-invalid-max-runs",
+	}, {
+		fileName: "config-concurrency-invalid-strategy",
+	}, {
+		fileName: "config-concurrency-invalid-no-match",
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			if _, err := config.NewConcurrencyFromConfigMap(cm); err == nil {
+				t.Error("expected error but received nil")
+			}
+		})
+	}
+}
+
+func TestConcurrencyPolicyFor(t *testing.T) {
+	cfg := &config.Concurrency{
+		Policies: []config.ConcurrencyPolicy{{
+			Name:         "deploy",
+			PipelineName: "deploy",
+			MaxRuns:      1,
+		}, {
+			Name: "production",
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"environment": "production"},
+			},
+			MaxRuns: 2,
+		}},
+	}
+	for _, tc := range []struct {
+		name         string
+		pipelineName string
+		labels       map[string]string
+		want         string
+	}{{
+		name:         "matching pipeline name",
+		pipelineName: "deploy",
+		want:         "deploy",
+	}, {
+		name:         "matching labels",
+		pipelineName: "build",
+		labels:       map[string]string{"environment": "production"},
+		want:         "production",
+	}, {
+		name:         "first matching policy",
+		pipelineName: "deploy",
+		labels:       map[string]string{"environment": "production"},
+		want:         "deploy",
+	}, {
+		name:         "no matching policy",
+		pipelineName: "build",
+		labels:       map[string]string{"environment": "staging"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ""
+			if p := cfg.PolicyFor(tc.pipelineName, tc.labels); p != nil {
+				got = p.Name
+			}
+			if got != tc.want {
+				t.Errorf("PolicyFor() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func verifyConfigFileWithExpectedConcurrencyConfig(t *testing.T, fileName string, expectedConfig *config.Concurrency) {
+	cm := test.ConfigMapFromTestFile(t, fileName)
+	if c, err := config.NewConcurrencyFromConfigMap(cm); err == nil {
+		if d := cmp.Diff(expectedConfig, c); d != "" {
+			t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+		}
+	} else {
+		t.Errorf("NewConcurrencyFromConfigMap(actual) = %v", err)
+	}
+}
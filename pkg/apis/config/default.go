@@ -38,13 +38,21 @@ const (
 	DefaultManagedByLabelValue = "tekton-pipelines"
 	// DefaultCloudEventSinkValue is the default value for cloud event sinks.
 	DefaultCloudEventSinkValue = ""
-
-	defaultTimeoutMinutesKey       = "default-timeout-minutes"
-	defaultServiceAccountKey       = "default-service-account"
-	defaultManagedByLabelValueKey  = "default-managed-by-label-value"
-	defaultPodTemplateKey          = "default-pod-template"
-	defaultCloudEventsSinkKey      = "default-cloud-events-sink"
-	defaultTaskRunWorkspaceBinding = "default-task-run-workspace-binding"
+	// DefaultMaxMatrixCombinationsCount is used when no max matrix combinations count is specified.
+	DefaultMaxMatrixCombinationsCount = 256
+	// DefaultMutexGracePeriodSeconds is used when no mutex grace period is specified.
+	DefaultMutexGracePeriodSeconds = 60
+
+	defaultTimeoutMinutesKey             = "default-timeout-minutes"
+	defaultServiceAccountKey             = "default-service-account"
+	defaultManagedByLabelValueKey        = "default-managed-by-label-value"
+	defaultPodTemplateKey                = "default-pod-template"
+	defaultCloudEventsSinkKey            = "default-cloud-events-sink"
+	defaultTaskRunWorkspaceBinding       = "default-task-run-workspace-binding"
+	defaultTTLSecondsAfterFinished       = "default-ttl-seconds-after-finished"
+	defaultRunsHistoryLimit              = "default-runs-history-limit"
+	defaultMaxMatrixCombinationsCountKey = "default-max-matrix-combinations-count"
+	defaultMutexGracePeriodSecondsKey    = "default-mutex-grace-period-seconds"
 )
 
 // Defaults holds the default configurations
@@ -56,6 +64,17 @@ type Defaults struct {
 	DefaultPodTemplate             *pod.Template
 	DefaultCloudEventsSink         string
 	DefaultTaskRunWorkspaceBinding string
+	// DefaultTTLSecondsAfterFinished is how long completed runs are kept, if set
+	DefaultTTLSecondsAfterFinished *int32
+	// DefaultRunsHistoryLimit is how many completed runs of the same Pipeline or
+	// Task are kept, if set
+	DefaultRunsHistoryLimit *int32
+	// DefaultMaxMatrixCombinationsCount is how many TaskRuns or Runs a matrixed PipelineTask
+	// may fan out into
+	DefaultMaxMatrixCombinationsCount int
+	// DefaultMutexGracePeriodSeconds is how long a mutex is considered held after being
+	// acquired for a TaskRun which can't be found
+	DefaultMutexGracePeriodSeconds int
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -82,16 +101,29 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultManagedByLabelValue == cfg.DefaultManagedByLabelValue &&
 		other.DefaultPodTemplate.Equals(cfg.DefaultPodTemplate) &&
 		other.DefaultCloudEventsSink == cfg.DefaultCloudEventsSink &&
-		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding
+		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding &&
+		equalInt32Ptr(other.DefaultTTLSecondsAfterFinished, cfg.DefaultTTLSecondsAfterFinished) &&
+		equalInt32Ptr(other.DefaultRunsHistoryLimit, cfg.DefaultRunsHistoryLimit) &&
+		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount &&
+		other.DefaultMutexGracePeriodSeconds == cfg.DefaultMutexGracePeriodSeconds
+}
+
+func equalInt32Ptr(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
 func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 	tc := Defaults{
-		DefaultTimeoutMinutes:      DefaultTimeoutMinutes,
-		DefaultServiceAccount:      DefaultServiceAccountValue,
-		DefaultManagedByLabelValue: DefaultManagedByLabelValue,
-		DefaultCloudEventsSink:     DefaultCloudEventSinkValue,
+		DefaultTimeoutMinutes:             DefaultTimeoutMinutes,
+		DefaultServiceAccount:             DefaultServiceAccountValue,
+		DefaultManagedByLabelValue:        DefaultManagedByLabelValue,
+		DefaultCloudEventsSink:            DefaultCloudEventSinkValue,
+		DefaultMaxMatrixCombinationsCount: DefaultMaxMatrixCombinationsCount,
+		DefaultMutexGracePeriodSeconds:    DefaultMutexGracePeriodSeconds,
 	}
 
 	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
@@ -125,9 +157,66 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 	if bindingYAML, ok := cfgMap[defaultTaskRunWorkspaceBinding]; ok {
 		tc.DefaultTaskRunWorkspaceBinding = bindingYAML
 	}
+
+	if defaultMaxMatrixCombinationsCount, ok := cfgMap[defaultMaxMatrixCombinationsCountKey]; ok {
+		count, err := strconv.ParseInt(defaultMaxMatrixCombinationsCount, 10, 0)
+		if err != nil || count < 1 {
+			return nil, fmt.Errorf("failed parsing defaults config %q: expected an integer >= 1 but got %q", defaultMaxMatrixCombinationsCountKey, defaultMaxMatrixCombinationsCount)
+		}
+		tc.DefaultMaxMatrixCombinationsCount = int(count)
+	}
+
+	if defaultMutexGracePeriodSeconds, ok := cfgMap[defaultMutexGracePeriodSecondsKey]; ok {
+		v, err := parseNonNegativeInt32(defaultMutexGracePeriodSecondsKey, defaultMutexGracePeriodSeconds)
+		if err != nil {
+			return nil, err
+		}
+		tc.DefaultMutexGracePeriodSeconds = int(v)
+	}
+
+	if err := tc.setPruningDefaults(cfgMap); err != nil {
+		return nil, err
+	}
 	return &tc, nil
 }
 
+// WithNamespaceOverrides returns a copy of the defaults overridden by the values of a map
+// corresponding to a ConfigMap of a namespace. Only the defaults of how completed runs are
+// pruned may be overridden in a namespace.
+func (cfg *Defaults) WithNamespaceOverrides(cfgMap map[string]string) (*Defaults, error) {
+	tc := cfg.DeepCopy()
+	if err := tc.setPruningDefaults(cfgMap); err != nil {
+		return nil, err
+	}
+	return tc, nil
+}
+
+func (cfg *Defaults) setPruningDefaults(cfgMap map[string]string) error {
+	if ttl, ok := cfgMap[defaultTTLSecondsAfterFinished]; ok {
+		v, err := parseNonNegativeInt32(defaultTTLSecondsAfterFinished, ttl)
+		if err != nil {
+			return err
+		}
+		cfg.DefaultTTLSecondsAfterFinished = &v
+	}
+	if limit, ok := cfgMap[defaultRunsHistoryLimit]; ok {
+		v, err := parseNonNegativeInt32(defaultRunsHistoryLimit, limit)
+		if err != nil {
+			return err
+		}
+		cfg.DefaultRunsHistoryLimit = &v
+	}
+	return nil
+}
+
+func parseNonNegativeInt32(key, value string) (int32, error) {
+	i, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("failed parsing defaults config %q: expected an integer >= 0 but got %q", key, value)
+	}
+	return int32(i), nil
+}
+
 // NewDefaultsFromConfigMap returns a Config for the given configmap
 func NewDefaultsFromConfigMap(config *corev1.ConfigMap) (*Defaults, error) {
 	return NewDefaultsFromMap(config.Data)
@@ -36,17 +36,21 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 	testCases := []testCase{
 		{
 			expectedConfig: &config.Defaults{
-				DefaultTimeoutMinutes:      50,
-				DefaultServiceAccount:      "tekton",
-				DefaultManagedByLabelValue: "something-else",
+				DefaultTimeoutMinutes:             50,
+				DefaultServiceAccount:             "tekton",
+				DefaultManagedByLabelValue:        "something-else",
+				DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
+				DefaultMutexGracePeriodSeconds:    config.DefaultMutexGracePeriodSeconds,
 			},
 			fileName: config.GetDefaultsConfigName(),
 		},
 		{
 			expectedConfig: &config.Defaults{
-				DefaultTimeoutMinutes:      50,
-				DefaultServiceAccount:      "tekton",
-				DefaultManagedByLabelValue: config.DefaultManagedByLabelValue,
+				DefaultTimeoutMinutes:             50,
+				DefaultServiceAccount:             "tekton",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
+				DefaultMutexGracePeriodSeconds:    config.DefaultMutexGracePeriodSeconds,
 				DefaultPodTemplate: &pod.Template{
 					NodeSelector: map[string]string{
 						"label": "value",
@@ -55,6 +59,46 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 			},
 			fileName: "config-defaults-with-pod-template",
 		},
+		{
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             config.DefaultTimeoutMinutes,
+				DefaultServiceAccount:             config.DefaultServiceAccountValue,
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
+				DefaultMutexGracePeriodSeconds:    config.DefaultMutexGracePeriodSeconds,
+				DefaultTTLSecondsAfterFinished:    int32Ptr(3600),
+				DefaultRunsHistoryLimit:           int32Ptr(5),
+			},
+			fileName: "config-defaults-with-pruning",
+		},
+		{
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             config.DefaultTimeoutMinutes,
+				DefaultServiceAccount:             config.DefaultServiceAccountValue,
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 16,
+				DefaultMutexGracePeriodSeconds:    config.DefaultMutexGracePeriodSeconds,
+			},
+			fileName: "config-defaults-with-max-matrix-combinations-count",
+		},
+		{
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             config.DefaultTimeoutMinutes,
+				DefaultServiceAccount:             config.DefaultServiceAccountValue,
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
+				DefaultMutexGracePeriodSeconds:    10,
+			},
+			fileName: "config-defaults-with-mutex-grace-period",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-pruning-err",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-max-matrix-combinations-count-err",
+		},
 		// the github.com/ghodss/yaml package in the vendor directory does not support UnmarshalStrict
 		// update it, switch to UnmarshalStrict in defaults.go, then uncomment these tests
 		// {
@@ -79,9 +123,11 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 func TestNewDefaultsFromEmptyConfigMap(t *testing.T) {
 	DefaultsConfigEmptyName := "config-defaults-empty"
 	expectedConfig := &config.Defaults{
-		DefaultTimeoutMinutes:      60,
-		DefaultManagedByLabelValue: "tekton-pipelines",
-		DefaultServiceAccount:      "default",
+		DefaultTimeoutMinutes:             60,
+		DefaultManagedByLabelValue:        "tekton-pipelines",
+		DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
+		DefaultMutexGracePeriodSeconds:    config.DefaultMutexGracePeriodSeconds,
+		DefaultServiceAccount:             "default",
 	}
 	verifyConfigFileWithExpectedConfig(t, DefaultsConfigEmptyName, expectedConfig)
 }
@@ -183,6 +229,56 @@ func TestEquals(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "different default ttl after finished",
+			left: &config.Defaults{
+				DefaultTTLSecondsAfterFinished: int32Ptr(60),
+			},
+			right: &config.Defaults{
+				DefaultTTLSecondsAfterFinished: int32Ptr(0),
+			},
+			expected: false,
+		},
+		{
+			name: "default runs history limit only set on one side",
+			left: &config.Defaults{
+				DefaultRunsHistoryLimit: int32Ptr(3),
+			},
+			right:    &config.Defaults{},
+			expected: false,
+		},
+		{
+			name: "same default pruning",
+			left: &config.Defaults{
+				DefaultTTLSecondsAfterFinished: int32Ptr(60),
+				DefaultRunsHistoryLimit:        int32Ptr(3),
+			},
+			right: &config.Defaults{
+				DefaultTTLSecondsAfterFinished: int32Ptr(60),
+				DefaultRunsHistoryLimit:        int32Ptr(3),
+			},
+			expected: true,
+		},
+		{
+			name: "different default max matrix combinations count",
+			left: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+			},
+			right: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 16,
+			},
+			expected: false,
+		},
+		{
+			name: "different default mutex grace period",
+			left: &config.Defaults{
+				DefaultMutexGracePeriodSeconds: 60,
+			},
+			right: &config.Defaults{
+				DefaultMutexGracePeriodSeconds: 10,
+			},
+			expected: false,
+		},
 		{
 			name: "same default workspace",
 			left: &config.Defaults{
@@ -205,6 +301,40 @@ func TestEquals(t *testing.T) {
 	}
 }
 
+func TestWithNamespaceOverrides(t *testing.T) {
+	defaults := &config.Defaults{
+		DefaultTimeoutMinutes:          config.DefaultTimeoutMinutes,
+		DefaultTTLSecondsAfterFinished: int32Ptr(3600),
+		DefaultRunsHistoryLimit:        int32Ptr(5),
+	}
+	got, err := defaults.WithNamespaceOverrides(map[string]string{
+		"default-timeout-minutes":            "10",
+		"default-ttl-seconds-after-finished": "0",
+	})
+	if err != nil {
+		t.Fatalf("WithNamespaceOverrides() = %v", err)
+	}
+	want := &config.Defaults{
+		DefaultTimeoutMinutes:          config.DefaultTimeoutMinutes,
+		DefaultTTLSecondsAfterFinished: int32Ptr(0),
+		DefaultRunsHistoryLimit:        int32Ptr(5),
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+	}
+	if *defaults.DefaultTTLSecondsAfterFinished != 3600 {
+		t.Errorf("Expected the overridden defaults to be unchanged but got %d", *defaults.DefaultTTLSecondsAfterFinished)
+	}
+
+	if _, err := defaults.WithNamespaceOverrides(map[string]string{"default-runs-history-limit": "many"}); err == nil {
+		t.Error("WithNamespaceOverrides() was expected to return an error")
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
 func verifyConfigFileWithExpectedConfig(t *testing.T, fileName string, expectedConfig *config.Defaults) {
 	t.Helper()
 	cm := test.ConfigMapFromTestFile(t, fileName)
@@ -48,8 +48,23 @@ const (
 	DefaultEnableCustomTasks = false
 	// DefaultScopeWhenExpressionsToTask is the default value for "scope-when-expressions-to-task".
 	DefaultScopeWhenExpressionsToTask = false
+	// DefaultEnableClusterScopedCache is the default value for "enable-cluster-scoped-cache".
+	DefaultEnableClusterScopedCache = false
 	// DefaultEnableAPIFields is the default value for "enable-api-fields".
 	DefaultEnableAPIFields = StableAPIFields
+	// ResultExtractionMethodTerminationMessage is the value used for "results-from" when the results of a
+	// TaskRun are read from the termination messages of its steps.
+	ResultExtractionMethodTerminationMessage = "termination-message"
+	// ResultExtractionMethodSidecarLogs is the value used for "results-from" when the results of a TaskRun
+	// are read from the logs of a sidecar injected in its Pod.
+	ResultExtractionMethodSidecarLogs = "sidecar-logs"
+	// DefaultResultExtractionMethod is the default value for "results-from".
+	DefaultResultExtractionMethod = ResultExtractionMethodTerminationMessage
+	// DefaultMaxResultSize is the default value in bytes for "max-result-size".
+	DefaultMaxResultSize = 4096
+	// MaxResultSizeLimit is the highest value in bytes accepted for "max-result-size". The results are
+	// stored in the status of the TaskRun, which must fit in a single etcd object.
+	MaxResultSizeLimit = 1572864
 
 	disableHomeEnvOverwriteKey          = "disable-home-env-overwrite"
 	disableWorkingDirOverwriteKey       = "disable-working-directory-overwrite"
@@ -61,6 +76,9 @@ const (
 	enableCustomTasks                   = "enable-custom-tasks"
 	enableAPIFields                     = "enable-api-fields"
 	scopeWhenExpressionsToTask          = "scope-when-expressions-to-task"
+	enableClusterScopedCache            = "enable-cluster-scoped-cache"
+	resultExtractionMethod              = "results-from"
+	maxResultSize                       = "max-result-size"
 )
 
 // FeatureFlags holds the features configurations
@@ -75,7 +93,10 @@ type FeatureFlags struct {
 	EnableTektonOCIBundles           bool
 	EnableCustomTasks                bool
 	ScopeWhenExpressionsToTask       bool
+	EnableClusterScopedCache         bool
 	EnableAPIFields                  string
+	ResultExtractionMethod           string
+	MaxResultSize                    int
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -124,9 +145,18 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(scopeWhenExpressionsToTask, DefaultScopeWhenExpressionsToTask, &tc.ScopeWhenExpressionsToTask); err != nil {
 		return nil, err
 	}
+	if err := setFeature(enableClusterScopedCache, DefaultEnableClusterScopedCache, &tc.EnableClusterScopedCache); err != nil {
+		return nil, err
+	}
 	if err := setEnabledAPIFields(cfgMap, DefaultEnableAPIFields, &tc.EnableAPIFields); err != nil {
 		return nil, err
 	}
+	if err := setResultExtractionMethod(cfgMap, DefaultResultExtractionMethod, &tc.ResultExtractionMethod); err != nil {
+		return nil, err
+	}
+	if err := setMaxResultSize(cfgMap, DefaultMaxResultSize, &tc.MaxResultSize); err != nil {
+		return nil, err
+	}
 
 	// Given that they are alpha features, Tekton Bundles and Custom Tasks should be switched on if
 	// enable-api-fields is "alpha". If enable-api-fields is not "alpha" then fall back to the value of
@@ -164,6 +194,40 @@ func setEnabledAPIFields(cfgMap map[string]string, defaultValue string, feature
 	return nil
 }
 
+// setResultExtractionMethod sets the "results-from" flag based on the content of a given map.
+// If the feature gate is invalid then an error is returned.
+func setResultExtractionMethod(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[resultExtractionMethod]; ok {
+		value = strings.ToLower(cfg)
+	}
+	switch value {
+	case ResultExtractionMethodTerminationMessage, ResultExtractionMethodSidecarLogs:
+		*feature = value
+	default:
+		return fmt.Errorf("invalid value for feature flag %q: %q", resultExtractionMethod, value)
+	}
+	return nil
+}
+
+// setMaxResultSize sets the "max-result-size" flag based on the content of a given map.
+// If the value is not a positive integer or is higher than MaxResultSizeLimit then an error is returned.
+func setMaxResultSize(cfgMap map[string]string, defaultValue int, feature *int) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[maxResultSize]; ok {
+		v, err := strconv.Atoi(cfg)
+		if err != nil {
+			return fmt.Errorf("failed parsing feature flags config %q: %v", cfg, err)
+		}
+		value = v
+	}
+	if value <= 0 || value > MaxResultSizeLimit {
+		return fmt.Errorf("invalid value for feature flag %q: %d must be between 1 and %d", maxResultSize, value, MaxResultSizeLimit)
+	}
+	*feature = value
+	return nil
+}
+
 // NewFeatureFlagsFromConfigMap returns a Config for the given configmap
 func NewFeatureFlagsFromConfigMap(config *corev1.ConfigMap) (*FeatureFlags, error) {
 	return NewFeatureFlagsFromMap(config.Data)
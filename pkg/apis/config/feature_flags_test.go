@@ -40,6 +40,8 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
 				ScopeWhenExpressionsToTask:       config.DefaultScopeWhenExpressionsToTask,
 				EnableAPIFields:                  "stable",
+				ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+				MaxResultSize:                    config.DefaultMaxResultSize,
 			},
 			fileName: config.GetFeatureFlagsConfigName(),
 		},
@@ -53,7 +55,10 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				EnableTektonOCIBundles:           true,
 				EnableCustomTasks:                true,
 				ScopeWhenExpressionsToTask:       true,
+				EnableClusterScopedCache:         true,
 				EnableAPIFields:                  "alpha",
+				ResultExtractionMethod:           "sidecar-logs",
+				MaxResultSize:                    8192,
 			},
 			fileName: "feature-flags-all-flags-set",
 		},
@@ -69,6 +74,8 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				DisableWorkingDirOverwrite:       true,
 				RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
 				ScopeWhenExpressionsToTask:       config.DefaultScopeWhenExpressionsToTask,
+				ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+				MaxResultSize:                    config.DefaultMaxResultSize,
 			},
 			fileName: "feature-flags-enable-api-fields-overrides-bundles-and-custom-tasks",
 		},
@@ -82,6 +89,8 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				DisableWorkingDirOverwrite:       true,
 				RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
 				ScopeWhenExpressionsToTask:       config.DefaultScopeWhenExpressionsToTask,
+				ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+				MaxResultSize:                    config.DefaultMaxResultSize,
 			},
 			fileName: "feature-flags-bundles-and-custom-tasks",
 		},
@@ -104,6 +113,8 @@ func TestNewFeatureFlagsFromEmptyConfigMap(t *testing.T) {
 		RunningInEnvWithInjectedSidecars: true,
 		ScopeWhenExpressionsToTask:       config.DefaultScopeWhenExpressionsToTask,
 		EnableAPIFields:                  "stable",
+		ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+		MaxResultSize:                    config.DefaultMaxResultSize,
 	}
 	verifyConfigFileWithExpectedFeatureFlagsConfig(t, FeatureFlagsConfigEmptyName, expectedConfig)
 }
@@ -148,6 +159,12 @@ func TestNewFeatureFlagsConfigMapErrors(t *testing.T) {
 		fileName: "feature-flags-invalid-enable-api-fields",
 	}, {
 		fileName: "feature-flags-invalid-scope-when-expressions-to-task",
+	}, {
+		fileName: "feature-flags-invalid-results-from",
+	}, {
+		fileName: "feature-flags-invalid-max-result-size",
+	}, {
+		fileName: "feature-flags-max-result-size-above-limit",
 	}} {
 		t.Run(tc.fileName, func(t *testing.T) {
 			cm := test.ConfigMapFromTestFile(t, tc.fileName)
@@ -32,6 +32,7 @@ type Config struct {
 	ArtifactBucket *ArtifactBucket
 	ArtifactPVC    *ArtifactPVC
 	Metrics        *Metrics
+	Concurrency    *Concurrency
 }
 
 // FromContext extracts a Config from the provided context.
@@ -54,12 +55,14 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 	artifactBucket, _ := NewArtifactBucketFromMap(map[string]string{})
 	artifactPVC, _ := NewArtifactPVCFromMap(map[string]string{})
 	metrics, _ := newMetricsFromMap(map[string]string{})
+	concurrency, _ := NewConcurrencyFromMap(map[string]string{})
 	return &Config{
 		Defaults:       defaults,
 		FeatureFlags:   featureFlags,
 		ArtifactBucket: artifactBucket,
 		ArtifactPVC:    artifactPVC,
 		Metrics:        metrics,
+		Concurrency:    concurrency,
 	}
 }
 
@@ -87,6 +90,7 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 				GetArtifactBucketConfigName(): NewArtifactBucketFromConfigMap,
 				GetArtifactPVCConfigName():    NewArtifactPVCFromConfigMap,
 				GetMetricsConfigName():        NewMetricsFromConfigMap,
+				GetConcurrencyConfigName():    NewConcurrencyFromConfigMap,
 			},
 			onAfterStore...,
 		),
@@ -123,11 +127,16 @@ func (s *Store) Load() *Config {
 	if metrics == nil {
 		metrics, _ = newMetricsFromMap(map[string]string{})
 	}
+	concurrency := s.UntypedLoad(GetConcurrencyConfigName())
+	if concurrency == nil {
+		concurrency, _ = NewConcurrencyFromMap(map[string]string{})
+	}
 	return &Config{
 		Defaults:       defaults.(*Defaults).DeepCopy(),
 		FeatureFlags:   featureFlags.(*FeatureFlags).DeepCopy(),
 		ArtifactBucket: artifactBucket.(*ArtifactBucket).DeepCopy(),
 		ArtifactPVC:    artifactPVC.(*ArtifactPVC).DeepCopy(),
 		Metrics:        metrics.(*Metrics).DeepCopy(),
+		Concurrency:    concurrency.(*Concurrency).DeepCopy(),
 	}
 }
@@ -33,12 +33,14 @@ func TestStoreLoadWithContext(t *testing.T) {
 	artifactBucketConfig := test.ConfigMapFromTestFile(t, "config-artifact-bucket")
 	artifactPVCConfig := test.ConfigMapFromTestFile(t, "config-artifact-pvc")
 	metricsConfig := test.ConfigMapFromTestFile(t, "config-observability")
+	concurrencyConfig := test.ConfigMapFromTestFile(t, "config-concurrency")
 
 	expectedDefaults, _ := config.NewDefaultsFromConfigMap(defaultConfig)
 	expectedFeatures, _ := config.NewFeatureFlagsFromConfigMap(featuresConfig)
 	expectedArtifactBucket, _ := config.NewArtifactBucketFromConfigMap(artifactBucketConfig)
 	expectedArtifactPVC, _ := config.NewArtifactPVCFromConfigMap(artifactPVCConfig)
 	metrics, _ := config.NewMetricsFromConfigMap(metricsConfig)
+	concurrency, _ := config.NewConcurrencyFromConfigMap(concurrencyConfig)
 
 	expected := &config.Config{
 		Defaults:       expectedDefaults,
@@ -46,6 +48,7 @@ func TestStoreLoadWithContext(t *testing.T) {
 		ArtifactBucket: expectedArtifactBucket,
 		ArtifactPVC:    expectedArtifactPVC,
 		Metrics:        metrics,
+		Concurrency:    concurrency,
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
@@ -54,6 +57,7 @@ func TestStoreLoadWithContext(t *testing.T) {
 	store.OnConfigChanged(artifactBucketConfig)
 	store.OnConfigChanged(artifactPVCConfig)
 	store.OnConfigChanged(metricsConfig)
+	store.OnConfigChanged(concurrencyConfig)
 
 	cfg := config.FromContext(store.ToContext(context.Background()))
 
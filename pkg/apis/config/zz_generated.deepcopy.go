@@ -22,6 +22,7 @@ package config
 
 import (
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -56,6 +57,50 @@ func (in *ArtifactPVC) DeepCopy() *ArtifactPVC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Concurrency) DeepCopyInto(out *Concurrency) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]ConcurrencyPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Concurrency.
+func (in *Concurrency) DeepCopy() *Concurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(Concurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConcurrencyPolicy) DeepCopyInto(out *ConcurrencyPolicy) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyPolicy.
+func (in *ConcurrencyPolicy) DeepCopy() *ConcurrencyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ConcurrencyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Defaults) DeepCopyInto(out *Defaults) {
 	*out = *in
@@ -64,6 +109,16 @@ func (in *Defaults) DeepCopyInto(out *Defaults) {
 		*out = new(pod.Template)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DefaultTTLSecondsAfterFinished != nil {
+		in, out := &in.DefaultTTLSecondsAfterFinished, &out.DefaultTTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DefaultRunsHistoryLimit != nil {
+		in, out := &in.DefaultRunsHistoryLimit, &out.DefaultRunsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
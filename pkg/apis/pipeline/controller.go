@@ -36,4 +36,13 @@ const (
 
 	// RunControllerName holds the name of the Custom Task controller
 	RunControllerName = "Run"
+
+	// PipelineRunScheduleControllerName holds the name of the PipelineRunSchedule controller
+	PipelineRunScheduleControllerName = "PipelineRunSchedule"
+
+	// PipelineRunPrunerControllerName holds the name of the controller deleting completed PipelineRuns
+	PipelineRunPrunerControllerName = "PipelineRunPruner"
+
+	// TaskRunPrunerControllerName holds the name of the controller deleting completed TaskRuns
+	TaskRunPrunerControllerName = "TaskRunPruner"
 )
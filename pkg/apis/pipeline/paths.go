@@ -28,4 +28,8 @@ const (
 	CredsDir = "/tekton/creds"
 	// StepsDir is the directory used for a step to store any metadata related to the step
 	StepsDir = "/tekton/steps"
+	// StepResultsDir is the directory, within the metadata directory of a step, where the step writes its results
+	StepResultsDir = "results"
+	// ScriptsDir is the directory where the scripts of the steps are placed
+	ScriptsDir = "/tekton/scripts"
 )
@@ -49,6 +49,27 @@ const (
 	// RunKey is used as the label identifier for a Run
 	RunKey = GroupName + "/run"
 
+	// MutexLabelKey is used as the label identifier for the mutex held by a TaskRun
+	MutexLabelKey = GroupName + "/mutex"
+
+	// MutexReleasedAnnotationKey is used as the annotation identifier for the completion time of a
+	// TaskRun whose mutex was released
+	MutexReleasedAnnotationKey = GroupName + "/mutexReleased"
+
+	// CacheKeyLabelKey is used as the label identifier for the hash of the inputs of a TaskRun
+	CacheKeyLabelKey = GroupName + "/cacheKey"
+
+	// ConcurrencyAdmittedAnnotationKey is used as the annotation identifier for the concurrency
+	// policy which admitted a PipelineRun
+	ConcurrencyAdmittedAnnotationKey = GroupName + "/concurrencyAdmitted"
+
+	// PipelineAncestryAnnotationKey is used as the annotation identifier for the comma separated names
+	// of the Pipelines a child PipelineRun is nested in, from the outermost one
+	PipelineAncestryAnnotationKey = GroupName + "/pipelineAncestry"
+
+	// PipelineRunScheduleLabelKey is used as the label identifier for a PipelineRunSchedule
+	PipelineRunScheduleLabelKey = GroupName + "/pipelineRunSchedule"
+
 	// MemberOfLabelKey is used as the label identifier for a PipelineTask
 	// Set to Tasks/Finally depending on the position of the PipelineTask
 	MemberOfLabelKey = GroupName + "/memberOf"
@@ -0,0 +1,38 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Defaultable = (*PipelineRunSchedule)(nil)
+
+// SetDefaults implements apis.Defaultable
+func (s *PipelineRunSchedule) SetDefaults(ctx context.Context) {
+	s.Spec.SetDefaults(apis.WithinSpec(ctx))
+}
+
+// SetDefaults implements apis.Defaultable. The template of the PipelineRuns isn't
+// defaulted: the PipelineRuns get the defaults in effect when they are created.
+func (ss *PipelineRunScheduleSpec) SetDefaults(ctx context.Context) {
+	if ss.ConcurrencyPolicy == "" {
+		ss.ConcurrencyPolicy = ScheduleConcurrencyAllow
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// ScheduleConcurrencyPolicy describes how the PipelineRuns of a PipelineRunSchedule
+// are handled when the previous ones are still running.
+type ScheduleConcurrencyPolicy string
+
+const (
+	// ScheduleConcurrencyAllow allows the PipelineRuns of a PipelineRunSchedule to run concurrently
+	ScheduleConcurrencyAllow ScheduleConcurrencyPolicy = "Allow"
+	// ScheduleConcurrencyForbid skips creating a PipelineRun while the previous one is still running
+	ScheduleConcurrencyForbid ScheduleConcurrencyPolicy = "Forbid"
+	// ScheduleConcurrencyReplace cancels the running PipelineRuns to create the new one
+	ScheduleConcurrencyReplace ScheduleConcurrencyPolicy = "Replace"
+)
+
+const (
+	// DefaultSuccessfulRunsHistoryLimit is the number of successful PipelineRuns
+	// kept by a PipelineRunSchedule when it isn't specified
+	DefaultSuccessfulRunsHistoryLimit = 3
+	// DefaultFailedRunsHistoryLimit is the number of failed PipelineRuns kept
+	// by a PipelineRunSchedule when it isn't specified
+	DefaultFailedRunsHistoryLimit = 1
+)
+
+const (
+	// PipelineRunScheduleReasonInvalid indicates that the schedule or the time
+	// zone of a PipelineRunSchedule can't be parsed
+	PipelineRunScheduleReasonInvalid = "InvalidSchedule"
+	// PipelineRunScheduleReasonSuspended indicates that a PipelineRunSchedule
+	// is suspended and doesn't create PipelineRuns
+	PipelineRunScheduleReasonSuspended = "Suspended"
+	// PipelineRunScheduleReasonTooManyMissedSchedules indicates that a PipelineRunSchedule
+	// missed too many times of its schedule to find the latest of them
+	PipelineRunScheduleReasonTooManyMissedSchedules = "TooManyMissedSchedules"
+)
+
+// PipelineRunScheduleSpec defines the desired state of PipelineRunSchedule
+type PipelineRunScheduleSpec struct {
+	// Schedule is a cron expression with five fields, e.g. "0 * * * *"
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the name of the time zone the Schedule is evaluated in, e.g.
+	// "Europe/Paris". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// ConcurrencyPolicy is how a new PipelineRun is handled when the previous ones
+	// are still running, one of "Allow", "Forbid" or "Replace". Defaults to "Allow".
+	// +optional
+	ConcurrencyPolicy ScheduleConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// StartingDeadlineSeconds is how late a PipelineRun can be created after the
+	// time it is scheduled at. The times of the schedule missed by more than this
+	// deadline are skipped.
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// Suspend stops the creation of PipelineRuns. The PipelineRuns already
+	// created are not affected.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// SuccessfulRunsHistoryLimit is the number of successful PipelineRuns to keep.
+	// +optional
+	SuccessfulRunsHistoryLimit *int32 `json:"successfulRunsHistoryLimit,omitempty"`
+
+	// FailedRunsHistoryLimit is the number of failed PipelineRuns to keep.
+	// +optional
+	FailedRunsHistoryLimit *int32 `json:"failedRunsHistoryLimit,omitempty"`
+
+	// PipelineRunTemplate is the template of the PipelineRuns to create
+	PipelineRunTemplate PipelineRunTemplate `json:"pipelineRunTemplate"`
+}
+
+// PipelineRunTemplate describes the PipelineRuns created by a PipelineRunSchedule
+type PipelineRunTemplate struct {
+	// +optional
+	Metadata v1beta1.PipelineTaskMetadata `json:"metadata,omitempty"`
+
+	// Spec is the spec of the PipelineRuns to create. "$(context.schedule.time)"
+	// is replaced by the time they are scheduled at in the values of its params.
+	Spec v1beta1.PipelineRunSpec `json:"spec"`
+}
+
+// PipelineRunScheduleStatus defines the observed state of PipelineRunSchedule
+type PipelineRunScheduleStatus struct {
+	duckv1.Status `json:",inline"`
+
+	// PipelineRunScheduleStatusFields inlines the status fields.
+	PipelineRunScheduleStatusFields `json:",inline"`
+}
+
+// PipelineRunScheduleStatusFields holds the fields of PipelineRunSchedule's status.
+type PipelineRunScheduleStatusFields struct {
+	// LastScheduleTime is the last time a PipelineRun was scheduled at
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// NextScheduleTime is the next time a PipelineRun is scheduled at
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// Active is the list of the PipelineRuns which are running
+	// +optional
+	Active []corev1.ObjectReference `json:"active,omitempty"`
+}
+
+var pipelineRunScheduleCondSet = apis.NewLivingConditionSet()
+
+// GetConditionSet retrieves the condition set for this resource. Implements
+// the KRShaped interface.
+func (s *PipelineRunSchedule) GetConditionSet() apis.ConditionSet {
+	return pipelineRunScheduleCondSet
+}
+
+// GetStatus retrieves the status of the PipelineRunSchedule. Implements the
+// KRShaped interface.
+func (s *PipelineRunSchedule) GetStatus() *duckv1.Status { return &s.Status.Status }
+
+// InitializeConditions will set all conditions in pipelineRunScheduleCondSet to unknown
+func (ss *PipelineRunScheduleStatus) InitializeConditions() {
+	pipelineRunScheduleCondSet.Manage(ss).InitializeConditions()
+}
+
+// MarkReady marks the PipelineRunSchedule as creating PipelineRuns on its schedule
+func (ss *PipelineRunScheduleStatus) MarkReady() {
+	pipelineRunScheduleCondSet.Manage(ss).MarkTrue(apis.ConditionReady)
+}
+
+// MarkNotReady marks the PipelineRunSchedule as not creating PipelineRuns with the given
+// reason, e.g. because it is suspended
+func (ss *PipelineRunScheduleStatus) MarkNotReady(reason, messageFormat string, messageA ...interface{}) {
+	pipelineRunScheduleCondSet.Manage(ss).MarkFalse(apis.ConditionReady, reason, messageFormat, messageA...)
+}
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PipelineRunSchedule creates PipelineRuns from a template on a cron schedule.
+//
+// +k8s:openapi-gen=true
+type PipelineRunSchedule struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec PipelineRunScheduleSpec `json:"spec,omitempty"`
+	// +optional
+	Status PipelineRunScheduleStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PipelineRunScheduleList contains a list of PipelineRunSchedule
+type PipelineRunScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PipelineRunSchedule `json:"items"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*PipelineRunSchedule) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind(pipeline.PipelineRunScheduleControllerName)
+}
+
+// GetSuccessfulRunsHistoryLimit returns the number of successful PipelineRuns to keep.
+func (s *PipelineRunSchedule) GetSuccessfulRunsHistoryLimit() int {
+	if s.Spec.SuccessfulRunsHistoryLimit == nil {
+		return DefaultSuccessfulRunsHistoryLimit
+	}
+	return int(*s.Spec.SuccessfulRunsHistoryLimit)
+}
+
+// GetFailedRunsHistoryLimit returns the number of failed PipelineRuns to keep.
+func (s *PipelineRunSchedule) GetFailedRunsHistoryLimit() int {
+	if s.Spec.FailedRunsHistoryLimit == nil {
+		return DefaultFailedRunsHistoryLimit
+	}
+	return int(*s.Spec.FailedRunsHistoryLimit)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"github.com/tektoncd/pipeline/pkg/cron"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*PipelineRunSchedule)(nil)
+
+// Validate pipelinerunschedule
+func (s *PipelineRunSchedule) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(s.GetObjectMeta()).ViaField("metadata"); err != nil {
+		return err
+	}
+	if apis.IsInDelete(ctx) {
+		return nil
+	}
+	return s.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
+}
+
+// Validate PipelineRunSchedule spec
+func (ss *PipelineRunScheduleSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if ss.Schedule == "" {
+		errs = errs.Also(apis.ErrMissingField("schedule"))
+	} else if _, err := cron.Parse(ss.Schedule); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(err.Error(), "schedule"))
+	}
+	if ss.TimeZone != "" {
+		if _, err := time.LoadLocation(ss.TimeZone); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("unknown time zone %s", ss.TimeZone), "timeZone"))
+		}
+	}
+	switch ss.ConcurrencyPolicy {
+	case "", ScheduleConcurrencyAllow, ScheduleConcurrencyForbid, ScheduleConcurrencyReplace:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %q, %q or %q", ss.ConcurrencyPolicy,
+			ScheduleConcurrencyAllow, ScheduleConcurrencyForbid, ScheduleConcurrencyReplace), "concurrencyPolicy"))
+	}
+	if ss.StartingDeadlineSeconds != nil && *ss.StartingDeadlineSeconds < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", *ss.StartingDeadlineSeconds), "startingDeadlineSeconds"))
+	}
+	if ss.SuccessfulRunsHistoryLimit != nil && *ss.SuccessfulRunsHistoryLimit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", *ss.SuccessfulRunsHistoryLimit), "successfulRunsHistoryLimit"))
+	}
+	if ss.FailedRunsHistoryLimit != nil && *ss.FailedRunsHistoryLimit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", *ss.FailedRunsHistoryLimit), "failedRunsHistoryLimit"))
+	}
+	return errs.Also(ss.PipelineRunTemplate.Spec.Validate(ctx).ViaField("pipelineRunTemplate", "spec"))
+}
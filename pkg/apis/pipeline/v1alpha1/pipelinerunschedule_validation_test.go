@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestPipelineRunSchedule_Valid(t *testing.T) {
+	limit := int32(0)
+	deadline := int64(300)
+	for _, c := range []struct {
+		name string
+		spec v1alpha1.PipelineRunScheduleSpec
+	}{{
+		name: "schedule and template",
+		spec: v1alpha1.PipelineRunScheduleSpec{
+			Schedule: "*/15 * * * *",
+			PipelineRunTemplate: v1alpha1.PipelineRunTemplate{
+				Spec: v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"}},
+			},
+		},
+	}, {
+		name: "all fields",
+		spec: v1alpha1.PipelineRunScheduleSpec{
+			Schedule:                   "@daily",
+			TimeZone:                   "Europe/Paris",
+			ConcurrencyPolicy:          v1alpha1.ScheduleConcurrencyReplace,
+			StartingDeadlineSeconds:    &deadline,
+			Suspend:                    true,
+			SuccessfulRunsHistoryLimit: &limit,
+			FailedRunsHistoryLimit:     &limit,
+			PipelineRunTemplate: v1alpha1.PipelineRunTemplate{
+				Metadata: v1beta1.PipelineTaskMetadata{Labels: map[string]string{"app": "nightly"}},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+					Params: []v1beta1.Param{{
+						Name:  "date",
+						Value: *v1beta1.NewArrayOrString("$(context.schedule.time)"),
+					}},
+				},
+			},
+		},
+	}} {
+		t.Run(c.name, func(t *testing.T) {
+			s := &v1alpha1.PipelineRunSchedule{
+				ObjectMeta: metav1.ObjectMeta{Name: "schedule"},
+				Spec:       c.spec,
+			}
+			if err := s.Validate(context.Background()); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPipelineRunSchedule_Invalid(t *testing.T) {
+	limit := int32(-1)
+	deadline := int64(-1)
+	template := v1alpha1.PipelineRunTemplate{
+		Spec: v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"}},
+	}
+	for _, c := range []struct {
+		name string
+		spec v1alpha1.PipelineRunScheduleSpec
+		want *apis.FieldError
+	}{{
+		name: "missing schedule",
+		spec: v1alpha1.PipelineRunScheduleSpec{PipelineRunTemplate: template},
+		want: apis.ErrMissingField("spec.schedule"),
+	}, {
+		name: "invalid schedule",
+		spec: v1alpha1.PipelineRunScheduleSpec{
+			Schedule:            "* * * *",
+			PipelineRunTemplate: template,
+		},
+		want: apis.ErrInvalidValue("expected exactly 5 fields, found 4: [* * * *]", "spec.schedule"),
+	}, {
+		name: "unknown time zone",
+		spec: v1alpha1.PipelineRunScheduleSpec{
+			Schedule:            "@hourly",
+			TimeZone:            "Mars/Olympus_Mons",
+			PipelineRunTemplate: template,
+		},
+		want: apis.ErrInvalidValue("unknown time zone Mars/Olympus_Mons", "spec.timeZone"),
+	}, {
+		name: "invalid concurrency policy",
+		spec: v1alpha1.PipelineRunScheduleSpec{
+			Schedule:            "@hourly",
+			ConcurrencyPolicy:   "Queue",
+			PipelineRunTemplate: template,
+		},
+		want: apis.ErrInvalidValue(`Queue should be "Allow", "Forbid" or "Replace"`, "spec.concurrencyPolicy"),
+	}, {
+		name: "negative history limits",
+		spec: v1alpha1.PipelineRunScheduleSpec{
+			Schedule:                   "@hourly",
+			SuccessfulRunsHistoryLimit: &limit,
+			FailedRunsHistoryLimit:     &limit,
+			PipelineRunTemplate:        template,
+		},
+		want: apis.ErrInvalidValue("-1 should be >= 0", "spec.successfulRunsHistoryLimit").Also(
+			apis.ErrInvalidValue("-1 should be >= 0", "spec.failedRunsHistoryLimit")),
+	}, {
+		name: "negative starting deadline",
+		spec: v1alpha1.PipelineRunScheduleSpec{
+			Schedule:                "@hourly",
+			StartingDeadlineSeconds: &deadline,
+			PipelineRunTemplate:     template,
+		},
+		want: apis.ErrInvalidValue("-1 should be >= 0", "spec.startingDeadlineSeconds"),
+	}, {
+		name: "invalid template",
+		spec: v1alpha1.PipelineRunScheduleSpec{
+			Schedule: "@hourly",
+		},
+		want: apis.ErrMissingField("spec.pipelineRunTemplate.spec.pipelineref.name", "spec.pipelineRunTemplate.spec.pipelinespec"),
+	}} {
+		t.Run(c.name, func(t *testing.T) {
+			s := &v1alpha1.PipelineRunSchedule{
+				ObjectMeta: metav1.ObjectMeta{Name: "schedule"},
+				Spec:       c.spec,
+			}
+			err := s.Validate(context.Background())
+			if d := cmp.Diff(c.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunSchedule_SetDefaults(t *testing.T) {
+	s := &v1alpha1.PipelineRunSchedule{
+		Spec: v1alpha1.PipelineRunScheduleSpec{Schedule: "@hourly"},
+	}
+	s.SetDefaults(context.Background())
+	if s.Spec.ConcurrencyPolicy != v1alpha1.ScheduleConcurrencyAllow {
+		t.Errorf("Expected concurrency policy %q but got %q", v1alpha1.ScheduleConcurrencyAllow, s.Spec.ConcurrencyPolicy)
+	}
+	if got := s.GetSuccessfulRunsHistoryLimit(); got != v1alpha1.DefaultSuccessfulRunsHistoryLimit {
+		t.Errorf("Expected successful runs history limit %d but got %d", v1alpha1.DefaultSuccessfulRunsHistoryLimit, got)
+	}
+	if got := s.GetFailedRunsHistoryLimit(); got != v1alpha1.DefaultFailedRunsHistoryLimit {
+		t.Errorf("Expected failed runs history limit %d but got %d", v1alpha1.DefaultFailedRunsHistoryLimit, got)
+	}
+}
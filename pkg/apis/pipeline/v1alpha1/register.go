@@ -62,6 +62,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PipelineResourceList{},
 		&Run{},
 		&RunList{},
+		&PipelineRunSchedule{},
+		&PipelineRunScheduleList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
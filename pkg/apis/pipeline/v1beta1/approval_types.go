@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ApproverKindUser is an approver identified by the name of a user
+	ApproverKindUser = "User"
+	// ApproverKindGroup is an approver identified by the name of a group of users
+	ApproverKindGroup = "Group"
+
+	// ApprovalOnTimeoutFail fails the PipelineTask when nobody approved or rejected it in time
+	ApprovalOnTimeoutFail = "fail"
+	// ApprovalOnTimeoutSkip skips the PipelineTask, along with the PipelineTasks depending on it,
+	// when nobody approved or rejected it in time
+	ApprovalOnTimeoutSkip = "skip"
+
+	// ApprovalDecisionApprove approves a PipelineTask, letting the PipelineTasks depending on it run
+	ApprovalDecisionApprove = "approve"
+	// ApprovalDecisionReject rejects a PipelineTask, failing it
+	ApprovalDecisionReject = "reject"
+)
+
+// Approval makes a PipelineTask wait for one of its approvers to approve or reject it,
+// instead of running a Task. The PipelineTasks depending on it only run once it is approved.
+type Approval struct {
+	// Approvers are the users and groups of users allowed to approve or reject the PipelineTask
+	Approvers []Approver `json:"approvers"`
+
+	// Timeout is how long the PipelineTask waits for a decision. It waits until the
+	// PipelineRun times out if it isn't set.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// OnTimeout is what happens to the PipelineTask when it times out, either "fail"
+	// or "skip". Defaults to "fail".
+	// +optional
+	OnTimeout string `json:"onTimeout,omitempty"`
+}
+
+// Approver is a user, or a group of users, allowed to approve or reject a PipelineTask
+type Approver struct {
+	// Kind is either "User" or "Group"
+	Kind string `json:"kind"`
+	// Name is the name of the user or of the group
+	Name string `json:"name"`
+}
+
+// ApprovalDecision approves or rejects a PipelineTask of a PipelineRun waiting for an approval
+type ApprovalDecision struct {
+	// PipelineTask is the name of the PipelineTask
+	PipelineTask string `json:"pipelineTask"`
+
+	// Decision is either "approve" or "reject"
+	Decision string `json:"decision"`
+
+	// Message explains the decision
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// User is the name of the user who made the decision. It is set by the webhook
+	// to the user creating or changing the decision.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// Groups are the groups of the user who made the decision. They are set by the
+	// webhook to the groups of the user creating or changing the decision.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ApprovalState is the state of a PipelineTask waiting for an approval
+type ApprovalState string
+
+const (
+	// ApprovalStatePending means the PipelineTask is waiting for a decision
+	ApprovalStatePending ApprovalState = "Pending"
+	// ApprovalStateApproved means the PipelineTask was approved
+	ApprovalStateApproved ApprovalState = "Approved"
+	// ApprovalStateRejected means the PipelineTask was rejected
+	ApprovalStateRejected ApprovalState = "Rejected"
+	// ApprovalStateTimedOut means nobody approved or rejected the PipelineTask in time
+	ApprovalStateTimedOut ApprovalState = "TimedOut"
+)
+
+// PipelineTaskApprovalStatus reports the approval of a PipelineTask of a PipelineRun
+type PipelineTaskApprovalStatus struct {
+	// Name is the Pipeline Task name
+	Name string `json:"name"`
+
+	// State is one of "Pending", "Approved", "Rejected" or "TimedOut"
+	State ApprovalState `json:"state"`
+
+	// Approver is the name of the user who approved or rejected the PipelineTask
+	// +optional
+	Approver string `json:"approver,omitempty"`
+
+	// Message is the message of the decision
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time the PipelineTask started waiting for a decision
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the PipelineTask was approved, rejected or timed out
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// IsDone returns true if the PipelineTask was approved, rejected or timed out.
+func (s *PipelineTaskApprovalStatus) IsDone() bool {
+	return s.State != ApprovalStatePending
+}
+
+// IsApprover returns true if the user who made a decision is one of the approvers,
+// either by name or through one of their groups.
+func (a *Approval) IsApprover(d ApprovalDecision) bool {
+	if d.User == "" {
+		return false
+	}
+	for _, approver := range a.Approvers {
+		switch approver.Kind {
+		case ApproverKindUser:
+			if approver.Name == d.User {
+				return true
+			}
+		case ApproverKindGroup:
+			for _, group := range d.Groups {
+				if approver.Name == group {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// SkipsOnTimeout returns true if the PipelineTask is skipped, rather than failed, when it times out.
+func (a *Approval) SkipsOnTimeout() bool {
+	return a.OnTimeout == ApprovalOnTimeoutSkip
+}
+
+// TimedOut returns true if nobody approved or rejected the PipelineTask in time, given
+// the time it started waiting for a decision.
+func (a *Approval) TimedOut(startTime, now time.Time) bool {
+	if a.Timeout == nil {
+		return false
+	}
+	return !startTime.Add(a.Timeout.Duration).After(now)
+}
+
+// GetApprovalDecision returns the decision made on a PipelineTask, if any.
+func (prs *PipelineRunSpec) GetApprovalDecision(pipelineTask string) *ApprovalDecision {
+	for i := range prs.Approvals {
+		if prs.Approvals[i].PipelineTask == pipelineTask {
+			return &prs.Approvals[i]
+		}
+	}
+	return nil
+}
+
+// recordDeciders sets the user making a request as the user who made the approval decisions
+// it adds or changes, so that a decision can't be attributed to somebody else. The decisions
+// which are unchanged from the previous ones keep the user who made them.
+func recordDeciders(decisions, previous []ApprovalDecision, ui *authenticationv1.UserInfo) {
+	unchanged := make(map[string]ApprovalDecision, len(previous))
+	for _, d := range previous {
+		unchanged[d.PipelineTask] = d
+	}
+	for i := range decisions {
+		if p, ok := unchanged[decisions[i].PipelineTask]; ok && equality.Semantic.DeepEqual(p, decisions[i]) {
+			continue
+		}
+		decisions[i].User = ui.Username
+		decisions[i].Groups = append([]string(nil), ui.Groups...)
+	}
+}
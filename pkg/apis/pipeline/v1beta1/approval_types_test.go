@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApproval_IsApprover(t *testing.T) {
+	approval := &Approval{Approvers: []Approver{
+		{Kind: ApproverKindUser, Name: "alice"},
+		{Kind: ApproverKindGroup, Name: "release-managers"},
+	}}
+	tests := []struct {
+		name     string
+		decision ApprovalDecision
+		want     bool
+	}{{
+		name:     "approver by name",
+		decision: ApprovalDecision{User: "alice"},
+		want:     true,
+	}, {
+		name:     "approver by group",
+		decision: ApprovalDecision{User: "bob", Groups: []string{"developers", "release-managers"}},
+		want:     true,
+	}, {
+		name:     "not an approver",
+		decision: ApprovalDecision{User: "bob", Groups: []string{"developers"}},
+		want:     false,
+	}, {
+		name:     "group named like an approving user",
+		decision: ApprovalDecision{User: "bob", Groups: []string{"alice"}},
+		want:     false,
+	}, {
+		name:     "unknown user",
+		decision: ApprovalDecision{Groups: []string{"release-managers"}},
+		want:     false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := approval.IsApprover(tt.decision); got != tt.want {
+				t.Errorf("IsApprover() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApproval_TimedOut(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		approval  *Approval
+		startTime time.Time
+		want      bool
+	}{{
+		name:      "no timeout",
+		approval:  &Approval{},
+		startTime: now.Add(-24 * time.Hour),
+		want:      false,
+	}, {
+		name:      "within timeout",
+		approval:  &Approval{Timeout: &metav1.Duration{Duration: time.Hour}},
+		startTime: now.Add(-time.Minute),
+		want:      false,
+	}, {
+		name:      "past timeout",
+		approval:  &Approval{Timeout: &metav1.Duration{Duration: time.Hour}},
+		startTime: now.Add(-time.Hour),
+		want:      true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.approval.TimedOut(tt.startTime, now); got != tt.want {
+				t.Errorf("TimedOut() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
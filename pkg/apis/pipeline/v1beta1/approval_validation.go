@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+func (a *Approval) validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "approval", config.AlphaAPIFields))
+	if len(a.Approvers) == 0 {
+		errs = errs.Also(apis.ErrMissingField("approvers"))
+	}
+	for idx, approver := range a.Approvers {
+		switch approver.Kind {
+		case ApproverKindUser, ApproverKindGroup:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %q or %q", approver.Kind, ApproverKindUser, ApproverKindGroup), "kind").ViaFieldIndex("approvers", idx))
+		}
+		if approver.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("approvers", idx))
+		}
+	}
+	if a.Timeout != nil && a.Timeout.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", a.Timeout.Duration), "timeout"))
+	}
+	switch a.OnTimeout {
+	case "", ApprovalOnTimeoutFail, ApprovalOnTimeoutSkip:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %q or %q", a.OnTimeout, ApprovalOnTimeoutFail, ApprovalOnTimeoutSkip), "onTimeout"))
+	}
+	return errs
+}
+
+func validateApprovalDecisions(decisions []ApprovalDecision) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for idx, d := range decisions {
+		switch {
+		case d.PipelineTask == "":
+			errs = errs.Also(apis.ErrMissingField("pipelineTask").ViaFieldIndex("approvals", idx))
+		case seen.Has(d.PipelineTask):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("duplicate decision for pipeline task %q", d.PipelineTask), "pipelineTask").ViaFieldIndex("approvals", idx))
+		}
+		seen.Insert(d.PipelineTask)
+		switch d.Decision {
+		case ApprovalDecisionApprove, ApprovalDecisionReject:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %q or %q", d.Decision, ApprovalDecisionApprove, ApprovalDecisionReject), "decision").ViaFieldIndex("approvals", idx))
+		}
+	}
+	return errs
+}
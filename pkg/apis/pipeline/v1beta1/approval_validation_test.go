@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestPipelineTask_ValidateApproval(t *testing.T) {
+	approvers := []Approver{{Kind: ApproverKindUser, Name: "alice"}, {Kind: ApproverKindGroup, Name: "release-managers"}}
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "approvers, timeout and onTimeout",
+		pt: &PipelineTask{
+			Name: "approve",
+			Approval: &Approval{
+				Approvers: approvers,
+				Timeout:   &metav1.Duration{Duration: time.Hour},
+				OnTimeout: ApprovalOnTimeoutSkip,
+			},
+			RunAfter: []string{"deploy-staging"},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(params.env)",
+				Operator: "in",
+				Values:   []string{"prod"},
+			}},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "approval requires alpha api fields",
+		pt: &PipelineTask{
+			Name:     "approve",
+			Approval: &Approval{Approvers: approvers},
+		},
+		wantErrs: apis.ErrGeneric(`approval requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaField("approval"),
+	}, {
+		name: "approval with a task",
+		pt: &PipelineTask{
+			Name:     "approve",
+			TaskRef:  &TaskRef{Name: "foo"},
+			Approval: &Approval{Approvers: approvers},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMultipleOneOf("taskRef", "taskSpec", "pipelineRef", "pipelineSpec", "approval"),
+	}, {
+		name: "missing approvers",
+		pt: &PipelineTask{
+			Name:     "approve",
+			Approval: &Approval{},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMissingField("approval.approvers"),
+	}, {
+		name: "invalid approvers",
+		pt: &PipelineTask{
+			Name:     "approve",
+			Approval: &Approval{Approvers: []Approver{{Kind: "ServiceAccount", Name: "deployer"}, {Kind: ApproverKindUser}}},
+		},
+		wc: enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue(`ServiceAccount should be "User" or "Group"`, "approval.approvers[0].kind").Also(
+			apis.ErrMissingField("approval.approvers[1].name")),
+	}, {
+		name: "invalid timeout and onTimeout",
+		pt: &PipelineTask{
+			Name: "approve",
+			Approval: &Approval{
+				Approvers: approvers,
+				Timeout:   &metav1.Duration{},
+				OnTimeout: "retry",
+			},
+		},
+		wc: enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("0s should be > 0", "approval.timeout").Also(
+			apis.ErrInvalidValue(`retry should be "fail" or "skip"`, "approval.onTimeout")),
+	}, {
+		name: "approval with features running a task",
+		pt: &PipelineTask{
+			Name:       "approve",
+			Approval:   &Approval{Approvers: approvers},
+			Retries:    1,
+			Params:     []Param{{Name: "foo", Value: *NewArrayOrString("bar")}},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Workspace: "shared"}},
+			Timeout:    &metav1.Duration{Duration: time.Hour},
+		},
+		wc: enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("approvals do not support retries", "retries").Also(
+			apis.ErrInvalidValue("approvals do not support params", "params")).Also(
+			apis.ErrInvalidValue("approvals do not support workspaces", "workspaces")).Also(
+			apis.ErrInvalidValue("approvals do not support timeout - use approval.timeout instead", "timeout")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.Validate(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateFinalTasks_Approval(t *testing.T) {
+	tasks := []PipelineTask{{Name: "build", TaskRef: &TaskRef{Name: "build"}}}
+	finalTasks := []PipelineTask{{
+		Name:     "approve",
+		Approval: &Approval{Approvers: []Approver{{Kind: ApproverKindUser, Name: "alice"}}},
+	}}
+	want := apis.ErrInvalidValue("no approval allowed under spec.finally, final task approve has approval specified", "finally[0]")
+	if d := cmp.Diff(want.Error(), validateFinalTasks(context.Background(), tasks, finalTasks).Error()); d != "" {
+		t.Errorf("validateFinalTasks() errors diff %s", diff.PrintWantGot(d))
+	}
+}
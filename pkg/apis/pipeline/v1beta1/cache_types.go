@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CacheScopeNamespace reuses the results of the TaskRuns of the namespace of the PipelineRun
+	CacheScopeNamespace = "namespace"
+	// CacheScopeCluster reuses the results of the TaskRuns of any namespace
+	CacheScopeCluster = "cluster"
+)
+
+// Cache configures the reuse of the results of a previous successful TaskRun of a
+// PipelineTask with the same inputs, instead of running it again. The inputs are
+// the resolved TaskSpec, the values of the params and the content of the workspaces.
+type Cache struct {
+	// TTL is how long the results of a successful TaskRun can be reused after it
+	// completed. They can be reused indefinitely if it isn't set.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// Scope is where the TaskRuns whose results are reused are looked up, either
+	// "namespace" or "cluster". Defaults to "namespace". The "cluster" scope requires
+	// the "enable-cluster-scoped-cache" feature flag.
+	// +optional
+	Scope string `json:"scope,omitempty"`
+
+	// Workspaces is the list of workspaces of the PipelineTask whose content is an
+	// input of the Task. Only workspaces bound to a ConfigMap or a Secret are supported.
+	// +optional
+	Workspaces []string `json:"workspaces,omitempty"`
+}
+
+// IsClusterScoped returns true if the results of the TaskRuns of any namespace can be reused.
+func (c *Cache) IsClusterScoped() bool {
+	return c.Scope == CacheScopeCluster
+}
+
+// Expired returns true if the results of a TaskRun which completed at the given time
+// can't be reused anymore at now.
+func (c *Cache) Expired(completionTime, now time.Time) bool {
+	if c.TTL == nil {
+		return false
+	}
+	return completionTime.Add(c.TTL.Duration).Before(now)
+}
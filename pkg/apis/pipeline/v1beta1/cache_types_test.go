@@ -0,0 +1,56 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCache_Expired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name           string
+		cache          *Cache
+		completionTime time.Time
+		want           bool
+	}{{
+		name:           "no ttl",
+		cache:          &Cache{},
+		completionTime: now.Add(-24 * time.Hour),
+		want:           false,
+	}, {
+		name:           "within ttl",
+		cache:          &Cache{TTL: &metav1.Duration{Duration: time.Hour}},
+		completionTime: now.Add(-time.Minute),
+		want:           false,
+	}, {
+		name:           "past ttl",
+		cache:          &Cache{TTL: &metav1.Duration{Duration: time.Hour}},
+		completionTime: now.Add(-2 * time.Hour),
+		want:           true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cache.Expired(tt.completionTime, now); got != tt.want {
+				t.Errorf("Expired() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
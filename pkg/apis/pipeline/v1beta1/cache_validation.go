@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+func (c *Cache) validate(ctx context.Context, workspaces []WorkspacePipelineTaskBinding) (errs *apis.FieldError) {
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "cache", config.AlphaAPIFields))
+	if c.TTL != nil && c.TTL.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", c.TTL.Duration), "ttl"))
+	}
+	switch c.Scope {
+	case "", CacheScopeNamespace:
+	case CacheScopeCluster:
+		if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableClusterScopedCache {
+			errs = errs.Also(apis.ErrGeneric("cluster scope requires \"enable-cluster-scoped-cache\" feature gate to be \"true\"", "scope"))
+		}
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %q or %q", c.Scope, CacheScopeNamespace, CacheScopeCluster), "scope"))
+	}
+	bound := sets.NewString()
+	for _, ws := range workspaces {
+		bound.Insert(ws.Name)
+	}
+	seen := sets.NewString()
+	for idx, ws := range c.Workspaces {
+		switch {
+		case !bound.Has(ws):
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("workspace %q is not bound in the PipelineTask", ws), apis.CurrentField).ViaFieldIndex("workspaces", idx))
+		case seen.Has(ws):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("duplicate workspace %q", ws), apis.CurrentField).ViaFieldIndex("workspaces", idx))
+		}
+		seen.Insert(ws)
+	}
+	return errs
+}
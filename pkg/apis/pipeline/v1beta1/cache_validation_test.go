@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestPipelineTask_ValidateCache(t *testing.T) {
+	workspaces := []WorkspacePipelineTaskBinding{{Name: "config", Workspace: "shared"}}
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "ttl and workspaces",
+		pt: &PipelineTask{
+			Name:       "task",
+			TaskRef:    &TaskRef{Name: "foo"},
+			Workspaces: workspaces,
+			Cache: &Cache{
+				TTL:        &metav1.Duration{Duration: time.Hour},
+				Workspaces: []string{"config"},
+			},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "cluster scope",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Cache:   &Cache{Scope: CacheScopeCluster},
+		},
+		wc: enableClusterScopedCache,
+	}, {
+		name: "cache requires alpha api fields",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Cache:   &Cache{},
+		},
+		wantErrs: apis.ErrGeneric(`cache requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaField("cache"),
+	}, {
+		name: "non-positive ttl",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Cache:   &Cache{TTL: &metav1.Duration{}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("0s should be > 0", "cache.ttl"),
+	}, {
+		name: "invalid scope",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Cache:   &Cache{Scope: "global"},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue(`global should be "namespace" or "cluster"`, "cache.scope"),
+	}, {
+		name: "cluster scope requires enable-cluster-scoped-cache",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Cache:   &Cache{Scope: CacheScopeCluster},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrGeneric(`cluster scope requires "enable-cluster-scoped-cache" feature gate to be "true"`, "cache.scope"),
+	}, {
+		name: "unbound and duplicate workspaces",
+		pt: &PipelineTask{
+			Name:       "task",
+			TaskRef:    &TaskRef{Name: "foo"},
+			Workspaces: workspaces,
+			Cache:      &Cache{Workspaces: []string{"config", "source", "config"}},
+		},
+		wc: enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue(`workspace "source" is not bound in the PipelineTask`, "cache.workspaces[1]").Also(
+			apis.ErrGeneric(`duplicate workspace "config"`, "cache.workspaces[2]")),
+	}, {
+		name: "cache with matrix",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Matrix:  []Param{{Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}}}},
+			Cache:   &Cache{},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMultipleOneOf("matrix", "cache"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.Validate(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func enableClusterScopedCache(ctx context.Context) context.Context {
+	featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+		"enable-api-fields":           "alpha",
+		"enable-cluster-scoped-cache": "true",
+	})
+	return config.ToContext(ctx, &config.Config{FeatureFlags: featureFlags})
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Iterate reruns a PipelineTask, with a new TaskRun for each iteration, until the
+// results of its last iteration satisfy the Until when expressions or the number of
+// iterations reaches MaxIterations. The iteration, starting at 1, is available to the
+// params of the PipelineTask with $(context.iteration).
+type Iterate struct {
+	// Until is a list of when expressions which must all evaluate to true for the
+	// PipelineTask to stop iterating. They can refer to the results of the last
+	// iteration with $(tasks.<pipeline-task-name>.results.<result-name>).
+	Until WhenExpressions `json:"until"`
+
+	// MaxIterations is the maximum number of iterations of the PipelineTask. The
+	// PipelineTask fails if Until still doesn't hold after the last iteration.
+	MaxIterations int `json:"maxIterations"`
+
+	// Delay is how long to wait after an iteration succeeded before starting the
+	// next one. The next iteration starts right away if it isn't set.
+	// +optional
+	Delay *metav1.Duration `json:"delay,omitempty"`
+}
+
+// IsDone returns true if the Until when expressions of the PipelineTask with the
+// given name hold for the given results of its last iteration.
+func (it *Iterate) IsDone(pipelineTaskName string, results []TaskRunResult) bool {
+	replacements := map[string]string{}
+	for _, r := range results {
+		replacements[fmt.Sprintf("%s.%s.%s.%s", ResultTaskPart, pipelineTaskName, ResultResultPart, r.Name)] = r.Value
+	}
+	return append(WhenExpressions{}, it.Until...).ReplaceWhenExpressionsVariables(replacements, nil).AllowsExecution(nil)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+func TestIterate_IsDone(t *testing.T) {
+	it := &Iterate{
+		Until: WhenExpressions{{
+			Input:    "$(tasks.poll.results.ready)",
+			Operator: selection.In,
+			Values:   []string{"true"},
+		}},
+		MaxIterations: 3,
+	}
+	tests := []struct {
+		name    string
+		results []TaskRunResult
+		want    bool
+	}{{
+		name:    "until holds",
+		results: []TaskRunResult{{Name: "ready", Value: "true"}},
+		want:    true,
+	}, {
+		name:    "until doesn't hold",
+		results: []TaskRunResult{{Name: "ready", Value: "false"}},
+		want:    false,
+	}, {
+		name:    "missing result",
+		results: []TaskRunResult{{Name: "other", Value: "true"}},
+		want:    false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := it.IsDone("poll", tt.results); got != tt.want {
+				t.Errorf("IsDone() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+	if it.Until[0].Input != "$(tasks.poll.results.ready)" {
+		t.Errorf("IsDone() modified the until when expressions: %v", it.Until)
+	}
+}
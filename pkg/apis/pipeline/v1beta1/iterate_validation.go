@@ -0,0 +1,54 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"knative.dev/pkg/apis"
+)
+
+// validate checks the Iterate of the PipelineTask with the given name; its Until when
+// expressions can only refer to the results of that PipelineTask
+func (it *Iterate) validate(ctx context.Context, pipelineTaskName string) (errs *apis.FieldError) {
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "iterate", config.AlphaAPIFields))
+	if it.MaxIterations < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 1", it.MaxIterations), "maxIterations"))
+	}
+	if it.Delay != nil && it.Delay.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", it.Delay.Duration), "delay"))
+	}
+	if len(it.Until) == 0 {
+		errs = errs.Also(apis.ErrMissingField("until"))
+	}
+	for idx, we := range it.Until {
+		if we.CEL != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("cel").ViaFieldIndex("until", idx))
+			continue
+		}
+		errs = errs.Also(we.validateWhenExpressionFields(ctx).ViaFieldIndex("until", idx))
+		expressions, _ := we.GetVarSubstitutionExpressions()
+		for _, ref := range NewResultRefs(filter(expressions, looksLikeResultRef)) {
+			if ref.PipelineTask != pipelineTaskName {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("until can only refer to the results of pipeline task %q, not %q", pipelineTaskName, ref.PipelineTask), apis.CurrentField).ViaFieldIndex("until", idx))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/apis"
+)
+
+func TestPipelineTask_ValidateIterate(t *testing.T) {
+	until := WhenExpressions{{
+		Input:    "$(tasks.poll.results.ready)",
+		Operator: selection.In,
+		Values:   []string{"true"},
+	}}
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "until, max iterations and delay",
+		pt: &PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Iterate: &Iterate{
+				Until:         until,
+				MaxIterations: 10,
+				Delay:         &metav1.Duration{Duration: 30 * time.Second},
+			},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "iterate requires alpha api fields",
+		pt: &PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Iterate: &Iterate{Until: until, MaxIterations: 10},
+		},
+		wantErrs: apis.ErrGeneric(`iterate requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaField("iterate"),
+	}, {
+		name: "missing until, max iterations and negative delay",
+		pt: &PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Iterate: &Iterate{Delay: &metav1.Duration{Duration: -time.Second}},
+		},
+		wc: enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("0 should be >= 1", "iterate.maxIterations").Also(
+			apis.ErrInvalidValue("-1s should be >= 0", "iterate.delay")).Also(
+			apis.ErrMissingField("iterate.until")),
+	}, {
+		name: "until referring to the results of another pipeline task",
+		pt: &PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Iterate: &Iterate{
+				Until: WhenExpressions{{
+					Input:    "$(tasks.deploy.results.ready)",
+					Operator: selection.In,
+					Values:   []string{"true"},
+				}},
+				MaxIterations: 10,
+			},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue(`until can only refer to the results of pipeline task "poll", not "deploy"`, "iterate.until[0]"),
+	}, {
+		name: "until using cel",
+		pt: &PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Iterate: &Iterate{
+				Until:         WhenExpressions{{CEL: "'$(tasks.poll.results.ready)' == 'true'"}},
+				MaxIterations: 10,
+			},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrDisallowedFields("iterate.until[0].cel"),
+	}, {
+		name: "iterate with matrix",
+		pt: &PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+			}},
+			Iterate: &Iterate{Until: until, MaxIterations: 10},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMultipleOneOf("matrix", "iterate"),
+	}, {
+		name: "iterate with a pipeline",
+		pt: &PipelineTask{
+			Name:        "poll",
+			PipelineRef: &PipelineRef{Name: "foo"},
+			Iterate:     &Iterate{Until: until, MaxIterations: 10},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("pipelines in pipelines do not support iterate", "iterate"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.Validate(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
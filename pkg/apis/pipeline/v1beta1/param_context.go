@@ -31,7 +31,8 @@ var paramCtxKey struct{}
 type paramCtxVal map[string]ParamSpec
 
 // addContextParams adds the given Params to the param context. This only
-// preserves the fields included in ParamSpec - Name and Type.
+// preserves the fields included in ParamSpec - Name, Type and, for objects,
+// the keys of the value as Properties.
 func addContextParams(ctx context.Context, in []Param) context.Context {
 	if in == nil {
 		return ctx
@@ -58,11 +59,21 @@ func addContextParams(ctx context.Context, in []Param) context.Context {
 			if v.StringVal != "" {
 				p.Value.Type = ParamTypeString
 			}
+			if len(v.ObjectVal) > 0 {
+				p.Value.Type = ParamTypeObject
+			}
 		}
-		out[p.Name] = ParamSpec{
+		cps := ParamSpec{
 			Name: p.Name,
 			Type: p.Value.Type,
 		}
+		if p.Value.Type == ParamTypeObject {
+			cps.Properties = make(map[string]PropertySpec, len(p.Value.ObjectVal))
+			for key := range p.Value.ObjectVal {
+				cps.Properties[key] = PropertySpec{Type: ParamTypeString}
+			}
+		}
+		out[p.Name] = cps
 	}
 	return context.WithValue(ctx, paramCtxKey, out)
 }
@@ -89,8 +100,13 @@ func addContextParamSpec(ctx context.Context, in []ParamSpec) context.Context {
 		cps := ParamSpec{
 			Name:        p.Name,
 			Type:        p.Type,
+			Properties:  p.Properties,
 			Description: p.Description,
 			Default:     p.Default,
+			Enum:        p.Enum,
+			Pattern:     p.Pattern,
+			Minimum:     p.Minimum,
+			Maximum:     p.Maximum,
 		}
 		out[p.Name] = cps
 	}
@@ -130,16 +146,26 @@ func getContextParams(ctx context.Context, overlays ...Param) []Param {
 		}
 
 		// If there is no overlay, pass through the param to the next level.
-		// e.g. for strings $(params.name), for arrays $(params.name[*]).
+		// e.g. for strings $(params.name), for arrays $(params.name[*]) and
+		// for objects {"key": "$(params.name.key)"} for each of their keys.
 		p := Param{
 			Name: ps.Name,
 		}
-		if ps.Type == ParamTypeString {
+		switch ps.Type {
+		case ParamTypeString:
 			p.Value = ArrayOrString{
 				Type:      ParamTypeString,
 				StringVal: fmt.Sprintf("$(params.%s)", ps.Name),
 			}
-		} else {
+		case ParamTypeObject:
+			p.Value = ArrayOrString{
+				Type:      ParamTypeObject,
+				ObjectVal: make(map[string]string, len(ps.Properties)),
+			}
+			for key := range ps.Properties {
+				p.Value.ObjectVal[key] = fmt.Sprintf("$(params.%s.%s)", ps.Name, key)
+			}
+		default:
 			p.Value = ArrayOrString{
 				Type:     ParamTypeArray,
 				ArrayVal: []string{fmt.Sprintf("$(params.%s[*])", ps.Name)},
@@ -164,8 +190,13 @@ func getContextParamSpecs(ctx context.Context) []ParamSpec {
 		out = append(out, ParamSpec{
 			Name:        ps.Name,
 			Type:        ps.Type,
+			Properties:  ps.Properties,
 			Description: ps.Description,
 			Default:     ps.Default,
+			Enum:        ps.Enum,
+			Pattern:     ps.Pattern,
+			Minimum:     ps.Minimum,
+			Maximum:     ps.Maximum,
 		})
 	}
 	return out
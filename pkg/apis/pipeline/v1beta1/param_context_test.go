@@ -86,6 +86,25 @@ func TestAddContextParams(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "add-object-param",
+			params: []Param{{Name: "c", Value: *NewObject(map[string]string{"key1": "val1", "key2": "val2"})}},
+			want: paramCtxVal{
+				"a": ParamSpec{
+					Name: "a",
+					Type: ParamTypeString,
+				},
+				"b": ParamSpec{
+					Name: "b",
+					Type: ParamTypeArray,
+				},
+				"c": ParamSpec{
+					Name:       "c",
+					Type:       ParamTypeObject,
+					Properties: map[string]PropertySpec{"key1": {Type: ParamTypeString}, "key2": {Type: ParamTypeString}},
+				},
+			},
+		},
 		{
 			// This test case doesn't really make sense for typical use-cases,
 			// but exists to document the behavior of how this would be
@@ -104,6 +123,11 @@ func TestAddContextParams(t *testing.T) {
 					Name: "b",
 					Type: ParamTypeArray,
 				},
+				"c": ParamSpec{
+					Name:       "c",
+					Type:       ParamTypeObject,
+					Properties: map[string]PropertySpec{"key1": {Type: ParamTypeString}, "key2": {Type: ParamTypeString}},
+				},
 			},
 		},
 	} {
@@ -205,6 +229,7 @@ func TestGetContextParams(t *testing.T) {
 			Type:        ParamTypeString,
 			Default:     NewArrayOrString("foo"),
 			Description: "tacocat",
+			Enum:        []string{"foo", "bar"},
 		},
 		{
 			Name:        "b",
@@ -212,6 +237,11 @@ func TestGetContextParams(t *testing.T) {
 			Default:     NewArrayOrString("bar"),
 			Description: "racecar",
 		},
+		{
+			Name:       "c",
+			Type:       ParamTypeObject,
+			Properties: map[string]PropertySpec{"key1": {Type: ParamTypeString}, "key2": {Type: ParamTypeString}},
+		},
 	}
 	t.Run("no-alpha", func(t *testing.T) {
 		ctx := addContextParamSpec(ctx, want)
@@ -246,6 +276,10 @@ func TestGetContextParams(t *testing.T) {
 						ArrayVal: []string{"$(params.b[*])"},
 					},
 				},
+				{
+					Name:  "c",
+					Value: *NewObject(map[string]string{"key1": "$(params.c.key1)", "key2": "$(params.c.key2)"}),
+				},
 			},
 		},
 		{
@@ -266,6 +300,10 @@ func TestGetContextParams(t *testing.T) {
 						ArrayVal: []string{"$(params.b[*])"},
 					},
 				},
+				{
+					Name:  "c",
+					Value: *NewObject(map[string]string{"key1": "$(params.c.key1)", "key2": "$(params.c.key2)"}),
+				},
 			},
 		},
 	} {
@@ -20,9 +20,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
@@ -38,9 +42,14 @@ type ParamSpec struct {
 	// Name declares the name by which a parameter is referenced.
 	Name string `json:"name"`
 	// Type is the user-specified type of the parameter. The possible types
-	// are currently "string" and "array", and "string" is the default.
+	// are currently "string", "array" and "object", and "string" is the default.
 	// +optional
 	Type ParamType `json:"type,omitempty"`
+	// Properties is the JSON Schema properties to support key-value pairs parameter.
+	// Every key declared here must be provided, either by the value of the
+	// parameter or by its default value.
+	// +optional
+	Properties map[string]PropertySpec `json:"properties,omitempty"`
 	// Description is a user-facing description of the parameter that may be
 	// used to populate a UI.
 	// +optional
@@ -50,19 +59,163 @@ type ParamSpec struct {
 	// parameter.
 	// +optional
 	Default *ArrayOrString `json:"default,omitempty"`
+	// Enum declares the values the parameter is allowed to take.
+	// +optional
+	Enum []string `json:"enum,omitempty"`
+	// Pattern is a regular expression the value of the parameter must match.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+	// Minimum is the lowest number the value of the parameter is allowed to be.
+	// +optional
+	Minimum *float64 `json:"minimum,omitempty"`
+	// Maximum is the highest number the value of the parameter is allowed to be.
+	// +optional
+	Maximum *float64 `json:"maximum,omitempty"`
+}
+
+// HasConstraints returns true if the ParamSpec declares an enum, a pattern, a minimum or a maximum.
+func (pp *ParamSpec) HasConstraints() bool {
+	return len(pp.Enum) != 0 || pp.Pattern != "" || pp.Minimum != nil || pp.Maximum != nil
+}
+
+// ValidateParamValues checks that the values of the given Params satisfy the constraints declared
+// by the ParamSpecs of the same name.
+func ValidateParamValues(paramSpecs []ParamSpec, params []Param) error {
+	for _, spec := range paramSpecs {
+		for _, param := range params {
+			if param.Name != spec.Name {
+				continue
+			}
+			if err := spec.ValidateValue(param.Value); err != nil {
+				return fmt.Errorf("parameter %q doesn't satisfy its constraints: %w", param.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// MissingKeysObjectParamNames returns the keys declared by object ParamSpecs which are neither
+// provided by the corresponding Params nor by the default values, keyed by parameter name.
+func MissingKeysObjectParamNames(paramSpecs []ParamSpec, params []Param) map[string][]string {
+	providedKeys := map[string][]string{}
+	for _, param := range params {
+		if param.Value.Type == ParamTypeObject {
+			providedKeys[param.Name] = []string{}
+			for key := range param.Value.ObjectVal {
+				providedKeys[param.Name] = append(providedKeys[param.Name], key)
+			}
+		}
+	}
+
+	missings := map[string][]string{}
+	for _, spec := range paramSpecs {
+		provided, ok := providedKeys[spec.Name]
+		if spec.Type != ParamTypeObject || !ok {
+			continue
+		}
+		var neededKeys []string
+		for key := range spec.Properties {
+			if spec.Default != nil {
+				if _, ok := spec.Default.ObjectVal[key]; ok {
+					continue
+				}
+			}
+			neededKeys = append(neededKeys, key)
+		}
+		if missing := list.DiffLeft(neededKeys, provided); len(missing) != 0 {
+			sort.Strings(missing)
+			missings[spec.Name] = missing
+		}
+	}
+	return missings
+}
+
+// ValidateValue checks that the given value satisfies the constraints declared by the ParamSpec.
+// The value of an array parameter is checked element by element. Values which still contain
+// variable references, e.g. to results, are only checked once the references are substituted.
+func (pp *ParamSpec) ValidateValue(value ArrayOrString) error {
+	if !pp.HasConstraints() {
+		return nil
+	}
+	switch value.Type {
+	case ParamTypeArray:
+		for _, v := range value.ArrayVal {
+			if err := pp.validateStringValue(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ParamTypeObject:
+		return fmt.Errorf("constraints are not supported for object parameters")
+	default:
+		return pp.validateStringValue(value.StringVal)
+	}
+}
+
+func (pp *ParamSpec) validateStringValue(v string) error {
+	if variableSubstitutionRegex.MatchString(v) {
+		return nil
+	}
+	if len(pp.Enum) != 0 && !sets.NewString(pp.Enum...).Has(v) {
+		return fmt.Errorf("value %q is not one of the allowed values %v", v, pp.Enum)
+	}
+	if pp.Pattern != "" {
+		re, err := regexp.Compile(pp.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pp.Pattern, err)
+		}
+		if !re.MatchString(v) {
+			return fmt.Errorf("value %q does not match the pattern %q", v, pp.Pattern)
+		}
+	}
+	if pp.Minimum == nil && pp.Maximum == nil {
+		return nil
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		return fmt.Errorf("value %q is not a number", v)
+	}
+	if pp.Minimum != nil && n < *pp.Minimum {
+		return fmt.Errorf("value %q is lower than the minimum %v", v, *pp.Minimum)
+	}
+	if pp.Maximum != nil && n > *pp.Maximum {
+		return fmt.Errorf("value %q is higher than the maximum %v", v, *pp.Maximum)
+	}
+	return nil
+}
+
+// PropertySpec defines the struct for object keys
+type PropertySpec struct {
+	// Type is the type of the value of the key. Only "string" is currently
+	// supported, and it is the default.
+	// +optional
+	Type ParamType `json:"type,omitempty"`
 }
 
 // SetDefaults set the default type
 func (pp *ParamSpec) SetDefaults(ctx context.Context) {
-	if pp != nil && pp.Type == "" {
-		if pp.Default != nil {
+	if pp == nil {
+		return
+	}
+	if pp.Type == "" {
+		switch {
+		case pp.Default != nil:
 			// propagate the parsed ArrayOrString's type to the parent ParamSpec's type
 			pp.Type = pp.Default.Type
-		} else {
+		case len(pp.Properties) > 0:
+			// only object params declare properties
+			pp.Type = ParamTypeObject
+		default:
 			// ParamTypeString is the default value (when no type can be inferred from the default value)
 			pp.Type = ParamTypeString
 		}
 	}
+	for key, property := range pp.Properties {
+		if property.Type == "" {
+			property.Type = ParamTypeString
+			pp.Properties[key] = property
+		}
+	}
 }
 
 // ResourceParam declares a string value to use for the parameter called Name, and is used in
@@ -76,34 +229,40 @@ type Param struct {
 }
 
 // ParamType indicates the type of an input parameter;
-// Used to distinguish between a single string and an array of strings.
+// Used to distinguish between a single string, an array of strings and an object of string values.
 type ParamType string
 
 // Valid ParamTypes:
 const (
 	ParamTypeString ParamType = "string"
 	ParamTypeArray  ParamType = "array"
+	ParamTypeObject ParamType = "object"
 )
 
 // AllParamTypes can be used for ParamType validation.
-var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray}
+var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject}
 
 // ArrayOrString is modeled after IntOrString in kubernetes/apimachinery:
 
-// ArrayOrString is a type that can hold a single string or string array.
+// ArrayOrString is a type that can hold a single string, a string array or an object of strings.
 // Used in JSON unmarshalling so that a single JSON field can accept
-// either an individual string or an array of strings.
+// either an individual string, an array of strings or an object of strings.
 type ArrayOrString struct {
-	Type      ParamType `json:"type"` // Represents the stored type of ArrayOrString.
-	StringVal string    `json:"stringVal"`
-	ArrayVal  []string  `json:"arrayVal"`
+	Type      ParamType         `json:"type"` // Represents the stored type of ArrayOrString.
+	StringVal string            `json:"stringVal"`
+	ArrayVal  []string          `json:"arrayVal"`
+	ObjectVal map[string]string `json:"objectVal"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaller interface.
 func (arrayOrString *ArrayOrString) UnmarshalJSON(value []byte) error {
-	if value[0] == '"' {
+	switch value[0] {
+	case '"':
 		arrayOrString.Type = ParamTypeString
 		return json.Unmarshal(value, &arrayOrString.StringVal)
+	case '{':
+		arrayOrString.Type = ParamTypeObject
+		return json.Unmarshal(value, &arrayOrString.ObjectVal)
 	}
 	arrayOrString.Type = ParamTypeArray
 	return json.Unmarshal(value, &arrayOrString.ArrayVal)
@@ -116,6 +275,8 @@ func (arrayOrString ArrayOrString) MarshalJSON() ([]byte, error) {
 		return json.Marshal(arrayOrString.StringVal)
 	case ParamTypeArray:
 		return json.Marshal(arrayOrString.ArrayVal)
+	case ParamTypeObject:
+		return json.Marshal(arrayOrString.ObjectVal)
 	default:
 		return []byte{}, fmt.Errorf("impossible ArrayOrString.Type: %q", arrayOrString.Type)
 	}
@@ -123,9 +284,16 @@ func (arrayOrString ArrayOrString) MarshalJSON() ([]byte, error) {
 
 // ApplyReplacements applyes replacements for ArrayOrString type
 func (arrayOrString *ArrayOrString) ApplyReplacements(stringReplacements map[string]string, arrayReplacements map[string][]string) {
-	if arrayOrString.Type == ParamTypeString {
+	switch arrayOrString.Type {
+	case ParamTypeString:
 		arrayOrString.StringVal = substitution.ApplyReplacements(arrayOrString.StringVal, stringReplacements)
-	} else {
+	case ParamTypeObject:
+		newObjectVal := make(map[string]string, len(arrayOrString.ObjectVal))
+		for k, v := range arrayOrString.ObjectVal {
+			newObjectVal[k] = substitution.ApplyReplacements(v, stringReplacements)
+		}
+		arrayOrString.ObjectVal = newObjectVal
+	default:
 		var newArrayVal []string
 		for _, v := range arrayOrString.ArrayVal {
 			newArrayVal = append(newArrayVal, substitution.ApplyArrayReplacements(v, stringReplacements, arrayReplacements)...)
@@ -149,6 +317,24 @@ func NewArrayOrString(value string, values ...string) *ArrayOrString {
 	}
 }
 
+// NewObject creates an ArrayOrString of type ParamTypeObject holding the given key-value pairs.
+func NewObject(pairs map[string]string) *ArrayOrString {
+	return &ArrayOrString{
+		Type:      ParamTypeObject,
+		ObjectVal: pairs,
+	}
+}
+
+// sortedObjectKeys returns the keys of the value of an object param in a stable order.
+func sortedObjectKeys(objectVal map[string]string) []string {
+	keys := make([]string, 0, len(objectVal))
+	for k := range objectVal {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // ArrayReference returns the name of the parameter from array parameter reference
 // returns arrayParam from $(params.arrayParam[*])
 func ArrayReference(a string) string {
@@ -157,9 +343,14 @@ func ArrayReference(a string) string {
 
 func validatePipelineParametersVariablesInTaskParameters(params []Param, prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
 	for _, param := range params {
-		if param.Value.Type == ParamTypeString {
+		switch param.Value.Type {
+		case ParamTypeString:
 			errs = errs.Also(validateStringVariable(param.Value.StringVal, prefix, paramNames, arrayParamNames).ViaFieldKey("params", param.Name))
-		} else {
+		case ParamTypeObject:
+			for _, key := range sortedObjectKeys(param.Value.ObjectVal) {
+				errs = errs.Also(validateStringVariable(param.Value.ObjectVal[key], prefix, paramNames, arrayParamNames).ViaFieldKey("value", key).ViaFieldKey("params", param.Name))
+			}
+		default:
 			for idx, arrayElement := range param.Value.ArrayVal {
 				errs = errs.Also(validateArrayVariable(arrayElement, prefix, paramNames, arrayParamNames).ViaFieldIndex("value", idx).ViaFieldKey("params", param.Name))
 			}
@@ -168,6 +359,15 @@ func validatePipelineParametersVariablesInTaskParameters(params []Param, prefix
 	return errs
 }
 
+func validatePipelineParametersVariablesInMatrixParameters(matrix []Param, prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
+	for _, param := range matrix {
+		for idx, arrayElement := range param.Value.ArrayVal {
+			errs = errs.Also(validateArrayVariable(arrayElement, prefix, paramNames, arrayParamNames).ViaFieldIndex("value", idx).ViaFieldKey("matrix", param.Name))
+		}
+	}
+	return errs
+}
+
 func validateStringVariable(value, prefix string, stringVars sets.String, arrayVars sets.String) *apis.FieldError {
 	errs := substitution.ValidateVariableP(value, prefix, stringVars)
 	return errs.Also(substitution.ValidateVariableProhibitedP(value, prefix, arrayVars))
@@ -52,6 +52,17 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 			Type:    v1beta1.ParamTypeArray,
 			Default: v1beta1.NewArrayOrString("an", "array"),
 		},
+	}, {
+		name: "inferred object type from properties",
+		before: &v1beta1.ParamSpec{
+			Name:       "parametername",
+			Properties: map[string]v1beta1.PropertySpec{"key1": {}, "key2": {Type: v1beta1.ParamTypeString}},
+		},
+		defaultsApplied: &v1beta1.ParamSpec{
+			Name:       "parametername",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{"key1": {Type: v1beta1.ParamTypeString}, "key2": {Type: v1beta1.ParamTypeString}},
+		},
 	}, {
 		name: "fully defined ParamSpec",
 		before: &v1beta1.ParamSpec{
@@ -78,6 +89,174 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 	}
 }
 
+func TestParamSpec_ValidateValue(t *testing.T) {
+	one, ten := 1.0, 10.0
+	for _, tc := range []struct {
+		name    string
+		spec    v1beta1.ParamSpec
+		value   *v1beta1.ArrayOrString
+		wantErr string
+	}{{
+		name:  "no constraints",
+		spec:  v1beta1.ParamSpec{Name: "p"},
+		value: v1beta1.NewArrayOrString("anything"),
+	}, {
+		name:  "value in enum",
+		spec:  v1beta1.ParamSpec{Name: "p", Enum: []string{"dev", "prod"}},
+		value: v1beta1.NewArrayOrString("prod"),
+	}, {
+		name:    "value not in enum",
+		spec:    v1beta1.ParamSpec{Name: "p", Enum: []string{"dev", "prod"}},
+		value:   v1beta1.NewArrayOrString("staging"),
+		wantErr: `value "staging" is not one of the allowed values [dev prod]`,
+	}, {
+		name:  "value matching pattern",
+		spec:  v1beta1.ParamSpec{Name: "p", Pattern: `^v[0-9]+$`},
+		value: v1beta1.NewArrayOrString("v12"),
+	}, {
+		name:    "value not matching pattern",
+		spec:    v1beta1.ParamSpec{Name: "p", Pattern: `^v[0-9]+$`},
+		value:   v1beta1.NewArrayOrString("12"),
+		wantErr: `value "12" does not match the pattern "^v[0-9]+$"`,
+	}, {
+		name:  "value in range",
+		spec:  v1beta1.ParamSpec{Name: "p", Minimum: &one, Maximum: &ten},
+		value: v1beta1.NewArrayOrString("2.5"),
+	}, {
+		name:    "value lower than minimum",
+		spec:    v1beta1.ParamSpec{Name: "p", Minimum: &one},
+		value:   v1beta1.NewArrayOrString("0"),
+		wantErr: `value "0" is lower than the minimum 1`,
+	}, {
+		name:    "value higher than maximum",
+		spec:    v1beta1.ParamSpec{Name: "p", Maximum: &ten},
+		value:   v1beta1.NewArrayOrString("10.5"),
+		wantErr: `value "10.5" is higher than the maximum 10`,
+	}, {
+		name:    "value which is not a number",
+		spec:    v1beta1.ParamSpec{Name: "p", Minimum: &one},
+		value:   v1beta1.NewArrayOrString("one"),
+		wantErr: `value "one" is not a number`,
+	}, {
+		name:    "array element not in enum",
+		spec:    v1beta1.ParamSpec{Name: "p", Type: v1beta1.ParamTypeArray, Enum: []string{"us", "eu"}},
+		value:   v1beta1.NewArrayOrString("us", "asia"),
+		wantErr: `value "asia" is not one of the allowed values [us eu]`,
+	}, {
+		name:  "result reference not substituted yet",
+		spec:  v1beta1.ParamSpec{Name: "p", Enum: []string{"linux", "mac"}},
+		value: v1beta1.NewArrayOrString("$(tasks.detect.results.platform)"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.spec.ValidateValue(*tc.value)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateValue() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q but got none", tc.wantErr)
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("ValidateValue() error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateParamValues(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		{Name: "env", Type: v1beta1.ParamTypeString, Enum: []string{"dev", "prod"}},
+		{Name: "version", Type: v1beta1.ParamTypeString, Pattern: `^v[0-9]+$`},
+		{Name: "region", Type: v1beta1.ParamTypeString},
+	}
+	for _, tc := range []struct {
+		name    string
+		params  []v1beta1.Param
+		wantErr string
+	}{{
+		name: "values satisfy the constraints",
+		params: []v1beta1.Param{
+			{Name: "env", Value: *v1beta1.NewArrayOrString("dev")},
+			{Name: "version", Value: *v1beta1.NewArrayOrString("v1")},
+			{Name: "region", Value: *v1beta1.NewArrayOrString("anywhere")},
+		},
+	}, {
+		name: "value not in enum",
+		params: []v1beta1.Param{
+			{Name: "env", Value: *v1beta1.NewArrayOrString("staging")},
+		},
+		wantErr: `parameter "env" doesn't satisfy its constraints: value "staging" is not one of the allowed values [dev prod]`,
+	}, {
+		name: "values not substituted yet",
+		params: []v1beta1.Param{
+			{Name: "env", Value: *v1beta1.NewArrayOrString("$(params.env)")},
+			{Name: "version", Value: *v1beta1.NewArrayOrString("$(tasks.build.results.version)")},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v1beta1.ValidateParamValues(specs, tc.params)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateParamValues() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q but got none", tc.wantErr)
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("ValidateParamValues() error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMissingKeysObjectParamNames(t *testing.T) {
+	specs := []v1beta1.ParamSpec{{
+		Name: "git",
+		Type: v1beta1.ParamTypeObject,
+		Properties: map[string]v1beta1.PropertySpec{
+			"url":      {Type: v1beta1.ParamTypeString},
+			"revision": {Type: v1beta1.ParamTypeString},
+			"depth":    {Type: v1beta1.ParamTypeString},
+		},
+		Default: v1beta1.NewObject(map[string]string{"depth": "1"}),
+	}, {
+		Name: "env",
+		Type: v1beta1.ParamTypeString,
+	}}
+	for _, tc := range []struct {
+		name   string
+		params []v1beta1.Param
+		want   map[string][]string
+	}{{
+		name: "all keys provided",
+		params: []v1beta1.Param{
+			{Name: "git", Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "main"})},
+			{Name: "env", Value: *v1beta1.NewArrayOrString("dev")},
+		},
+		want: map[string][]string{},
+	}, {
+		name: "keys missing without default",
+		params: []v1beta1.Param{
+			{Name: "git", Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"})},
+		},
+		want: map[string][]string{"git": {"revision"}},
+	}, {
+		name: "object param not provided",
+		want: map[string][]string{},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := v1beta1.MissingKeysObjectParamNames(specs, tc.params)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("MissingKeysObjectParamNames() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestArrayOrString_ApplyReplacements(t *testing.T) {
 	type args struct {
 		input              *v1beta1.ArrayOrString
@@ -128,6 +307,14 @@ func TestArrayOrString_ApplyReplacements(t *testing.T) {
 			arrayReplacements:  map[string][]string{"arraykey": {}},
 		},
 		expectedOutput: v1beta1.NewArrayOrString("firstvalue", "lastvalue"),
+	}, {
+		name: "string replacements on object",
+		args: args{
+			input:              v1beta1.NewObject(map[string]string{"url": "$(params.url)", "revision": "main", "depth": "$(some)"}),
+			stringReplacements: map[string]string{"params.url": "https://github.com/tektoncd/pipeline", "some": "1"},
+			arrayReplacements:  map[string][]string{"arraykey": {"array", "value"}},
+		},
+		expectedOutput: v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "main", "depth": "1"}),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -153,6 +340,7 @@ func TestArrayOrString_UnmarshalJSON(t *testing.T) {
 		{"{\"val\":[]}", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{}}},
 		{"{\"val\":[\"oneelement\"]}", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"oneelement"}}},
 		{"{\"val\":[\"multiple\", \"elements\"]}", *v1beta1.NewArrayOrString("multiple", "elements")},
+		{"{\"val\":{\"key1\": \"val1\", \"key2\": \"val2\"}}", *v1beta1.NewObject(map[string]string{"key1": "val1", "key2": "val2"})},
 	}
 
 	for _, c := range cases {
@@ -174,6 +362,7 @@ func TestArrayOrString_MarshalJSON(t *testing.T) {
 		{*v1beta1.NewArrayOrString("123"), "{\"val\":\"123\"}"},
 		{*v1beta1.NewArrayOrString("123", "1234"), "{\"val\":[\"123\",\"1234\"]}"},
 		{*v1beta1.NewArrayOrString("a", "a", "a"), "{\"val\":[\"a\",\"a\",\"a\"]}"},
+		{*v1beta1.NewObject(map[string]string{"key2": "val2", "key1": "val1"}), "{\"val\":{\"key1\":\"val1\",\"key2\":\"val2\"}}"},
 	}
 
 	for _, c := range cases {
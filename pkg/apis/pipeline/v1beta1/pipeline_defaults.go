@@ -38,6 +38,7 @@ func (ps *PipelineSpec) SetDefaults(ctx context.Context) {
 	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == "alpha" {
 		ctx = addContextParamSpec(ctx, ps.Params)
 		ps.Params = getContextParamSpecs(ctx)
+		ctx = addContextWorkspaces(ctx, ps.Workspaces)
 	}
 	for i, pt := range ps.Tasks {
 		ctx := ctx // Ensure local scoping per Task
@@ -53,6 +54,12 @@ func (ps *PipelineSpec) SetDefaults(ctx context.Context) {
 		if pt.TaskSpec != nil {
 			pt.TaskSpec.SetDefaults(ctx)
 		}
+		if pt.PipelineSpec != nil {
+			pt.PipelineSpec.SetDefaults(ctx)
+		}
+		if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == "alpha" {
+			ps.Tasks[i].propagateWorkspaces(ctx)
+		}
 	}
 
 	for i, ft := range ps.Finally {
@@ -69,5 +76,14 @@ func (ps *PipelineSpec) SetDefaults(ctx context.Context) {
 		if ft.TaskSpec != nil {
 			ft.TaskSpec.SetDefaults(ctx)
 		}
+		if ft.PipelineSpec != nil {
+			ft.PipelineSpec.SetDefaults(ctx)
+		}
+		if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == "alpha" {
+			ps.Finally[i].propagateWorkspaces(ctx)
+		}
+	}
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == "alpha" {
+		ps.propagateWorkspaces(ctx)
 	}
 }
@@ -116,6 +116,11 @@ type PipelineResult struct {
 	// Name the given name
 	Name string `json:"name"`
 
+	// Type is the user-specified type of the result. The possible types
+	// are currently "string", "array" and "object", and "string" is the default.
+	// +optional
+	Type ResultsType `json:"type,omitempty"`
+
 	// Description is a human-readable description of the result
 	// +optional
 	Description string `json:"description"`
@@ -166,6 +171,16 @@ type PipelineTask struct {
 	// +optional
 	TaskSpec *EmbeddedTask `json:"taskSpec,omitempty"`
 
+	// PipelineRef is a reference to a pipeline definition, which is run
+	// in a child PipelineRun instead of a task.
+	// +optional
+	PipelineRef *PipelineRef `json:"pipelineRef,omitempty"`
+
+	// PipelineSpec is a specification of a pipeline, which is run in a
+	// child PipelineRun instead of a task.
+	// +optional
+	PipelineSpec *PipelineSpec `json:"pipelineSpec,omitempty"`
+
 	// Conditions is a list of conditions that need to be true for the task to run
 	// Conditions are deprecated, use WhenExpressions instead
 	// +optional
@@ -179,6 +194,11 @@ type PipelineTask struct {
 	// +optional
 	Retries int `json:"retries,omitempty"`
 
+	// RetryPolicy configures which failures are retried and the delay between
+	// retries, up to the number of Retries
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
 	// RunAfter is the list of PipelineTask names that should be executed before
 	// this Task executes. (Used to force a specific ordering in graph execution.)
 	// +optional
@@ -192,6 +212,11 @@ type PipelineTask struct {
 	// +optional
 	Params []Param `json:"params,omitempty"`
 
+	// Matrix declares parameters used to fan out this task: one TaskRun (or
+	// Run) is created for each combination of the values of these array params.
+	// +optional
+	Matrix []Param `json:"matrix,omitempty"`
+
 	// Workspaces maps workspaces from the pipeline spec to the workspaces
 	// declared in the Task.
 	// +optional
@@ -202,10 +227,67 @@ type PipelineTask struct {
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// OnError defines the exiting behavior of the PipelineRun when this task fails
+	// can be set to [ continue | stopAndFail ]
+	// stopAndFail indicates stop scheduling new tasks and fail the PipelineRun when this task fails
+	// continue indicates continue executing the rest of the pipeline irrespective of this task's failure
+	// +optional
+	OnError PipelineTaskOnErrorType `json:"onError,omitempty"`
+
+	// Mutex is the name of a lock shared by the PipelineRuns of the namespace: the TaskRun of
+	// this task is only created once it holds the lock, which is released when the TaskRun is done
+	// +optional
+	Mutex string `json:"mutex,omitempty"`
+
+	// Cache configures the reuse of the results of a previous successful TaskRun
+	// of this task with the same inputs, instead of running it again
+	// +optional
+	Cache *Cache `json:"cache,omitempty"`
+
+	// Approval makes this task wait for one of its approvers to approve or
+	// reject it, instead of running a task
+	// +optional
+	Approval *Approval `json:"approval,omitempty"`
+
+	// Iterate reruns this task, with a new TaskRun for each iteration, until
+	// the results of its last iteration satisfy a list of when expressions
+	// +optional
+	Iterate *Iterate `json:"iterate,omitempty"`
 }
 
-// validateRefOrSpec validates at least one of taskRef or taskSpec is specified
+// PipelineTaskOnErrorType defines the exiting behavior of a PipelineRun when a PipelineTask fails
+type PipelineTaskOnErrorType string
+
+const (
+	// PipelineTaskStopAndFail indicates to stop and fail the PipelineRun if the PipelineTask fails
+	PipelineTaskStopAndFail PipelineTaskOnErrorType = "stopAndFail"
+	// PipelineTaskContinue indicates to continue executing the rest of the PipelineRun if the PipelineTask fails
+	PipelineTaskContinue PipelineTaskOnErrorType = "continue"
+)
+
+// validateRefOrSpec validates at least one of taskRef or taskSpec is specified,
+// or, for a PipelineTask running a Pipeline, exactly one of pipelineRef or pipelineSpec,
+// or, for a PipelineTask waiting for an approval, none of them
 func (pt PipelineTask) validateRefOrSpec() (errs *apis.FieldError) {
+	if pt.IsApproval() {
+		// an approval doesn't run anything
+		if pt.TaskRef != nil || pt.TaskSpec != nil || pt.RunsPipeline() {
+			errs = errs.Also(apis.ErrMultipleOneOf("taskRef", "taskSpec", "pipelineRef", "pipelineSpec", "approval"))
+		}
+		return errs
+	}
+	if pt.RunsPipeline() {
+		// can't run both a task and a pipeline at the same time
+		if pt.TaskRef != nil || pt.TaskSpec != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("taskRef", "taskSpec", "pipelineRef", "pipelineSpec"))
+		}
+		// can't have both pipelineRef and pipelineSpec at the same time
+		if pt.PipelineRef != nil && pt.PipelineSpec != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("pipelineRef", "pipelineSpec"))
+		}
+		return errs
+	}
 	// can't have both taskRef and taskSpec at the same time
 	if pt.TaskRef != nil && pt.TaskSpec != nil {
 		errs = errs.Also(apis.ErrMultipleOneOf("taskRef", "taskSpec"))
@@ -238,11 +320,107 @@ func (pt PipelineTask) validateCustomTask() (errs *apis.FieldError) {
 		errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support conditions - use when expressions instead", "conditions"))
 	}
 	// TODO(#3133): Support these features if possible.
+	if pt.Resources != nil {
+		errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support PipelineResources", "resources"))
+	}
+	if pt.Mutex != "" {
+		errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support mutex", "mutex"))
+	}
+	if pt.Cache != nil {
+		errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support cache", "cache"))
+	}
+	if pt.Iterate != nil {
+		errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support iterate", "iterate"))
+	}
+	return errs
+}
+
+// validatePipeline validates a pipeline task running a Pipeline in a child PipelineRun - checking the
+// pipelineRef or pipelineSpec and fail if not yet supported features specified
+func (pt PipelineTask) validatePipeline(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "pipelines in pipelines", config.AlphaAPIFields))
+	if pt.PipelineSpec != nil {
+		errs = errs.Also(pt.PipelineSpec.Validate(ctx).ViaField("pipelineSpec"))
+	}
+	if pt.PipelineRef != nil {
+		if pt.PipelineRef.Name != "" {
+			// PipelineRef name must be a valid k8s name
+			if errSlice := validation.IsQualifiedName(pt.PipelineRef.Name); len(errSlice) != 0 {
+				errs = errs.Also(apis.ErrInvalidValue(strings.Join(errSlice, ","), "pipelineRef.name"))
+			}
+		} else {
+			errs = errs.Also(apis.ErrInvalidValue("pipelineRef must specify name", "pipelineRef.name"))
+		}
+		if pt.PipelineRef.Bundle != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("pipelineRef.bundle"))
+		}
+	}
+
+	// Conditions are deprecated so the effort to support them with pipelines is not justified.
+	// When expressions should be used instead.
+	if len(pt.Conditions) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support conditions - use when expressions instead", "conditions"))
+	}
 	if pt.Retries > 0 {
-		errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support retries", "retries"))
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support retries", "retries"))
+	}
+	if pt.Mutex != "" {
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support mutex", "mutex"))
+	}
+	if pt.Cache != nil {
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support cache", "cache"))
+	}
+	if pt.RetryPolicy != nil {
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support retries", "retryPolicy"))
 	}
 	if pt.Resources != nil {
-		errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support PipelineResources", "resources"))
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support PipelineResources", "resources"))
+	}
+	if pt.IsMatrixed() {
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support matrix", "matrix"))
+	}
+	if pt.Iterate != nil {
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support iterate", "iterate"))
+	}
+	return errs
+}
+
+// validateApproval validates a pipeline task waiting for an approval - checking the approval
+// and fail if features which only apply to running something are specified
+func (pt PipelineTask) validateApproval(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(pt.Approval.validate(ctx).ViaField("approval"))
+	if len(pt.Conditions) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("approvals do not support conditions - use when expressions instead", "conditions"))
+	}
+	if pt.Retries > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("approvals do not support retries", "retries"))
+	}
+	if pt.RetryPolicy != nil {
+		errs = errs.Also(apis.ErrInvalidValue("approvals do not support retries", "retryPolicy"))
+	}
+	if pt.Resources != nil {
+		errs = errs.Also(apis.ErrInvalidValue("approvals do not support PipelineResources", "resources"))
+	}
+	if len(pt.Params) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("approvals do not support params", "params"))
+	}
+	if pt.IsMatrixed() {
+		errs = errs.Also(apis.ErrInvalidValue("approvals do not support matrix", "matrix"))
+	}
+	if len(pt.Workspaces) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("approvals do not support workspaces", "workspaces"))
+	}
+	if pt.Timeout != nil {
+		errs = errs.Also(apis.ErrInvalidValue("approvals do not support timeout - use approval.timeout instead", "timeout"))
+	}
+	if pt.Mutex != "" {
+		errs = errs.Also(apis.ErrInvalidValue("approvals do not support mutex", "mutex"))
+	}
+	if pt.Cache != nil {
+		errs = errs.Also(apis.ErrInvalidValue("approvals do not support cache", "cache"))
+	}
+	if pt.Iterate != nil {
+		errs = errs.Also(apis.ErrInvalidValue("approvals do not support iterate", "iterate"))
 	}
 	return errs
 }
@@ -267,6 +445,7 @@ func (pt PipelineTask) validateTask(ctx context.Context) (errs *apis.FieldError)
 	// Validate TaskSpec if it's present
 	if pt.TaskSpec != nil {
 		errs = errs.Also(pt.TaskSpec.Validate(ctx).ViaField("taskSpec"))
+		errs = errs.Also(validateParamValuesConstraints(pt.TaskSpec.Params, pt.Params))
 	}
 	if pt.TaskRef != nil {
 		if pt.TaskRef.Name != "" {
@@ -308,7 +487,7 @@ func (pt PipelineTask) ValidateName() *apis.FieldError {
 	return nil
 }
 
-// Validate classifies whether a task is a custom task, bundle, or a regular task(dag/final)
+// Validate classifies whether a task is an approval, a pipeline, custom task, bundle, or a regular task(dag/final)
 // calls the validation routine based on the type of the task
 func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(pt.validateRefOrSpec())
@@ -316,6 +495,10 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// If EnableCustomTasks feature flag is on, validate custom task specifications
 	// pipeline task having taskRef with APIVersion is classified as custom task
 	switch {
+	case pt.IsApproval():
+		errs = errs.Also(pt.validateApproval(ctx))
+	case pt.RunsPipeline():
+		errs = errs.Also(pt.validatePipeline(ctx))
 	case cfg.FeatureFlags.EnableCustomTasks && pt.TaskRef != nil && pt.TaskRef.APIVersion != "":
 		errs = errs.Also(pt.validateCustomTask())
 	case cfg.FeatureFlags.EnableCustomTasks && pt.TaskSpec != nil && pt.TaskSpec.APIVersion != "":
@@ -326,9 +509,125 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 	default:
 		errs = errs.Also(pt.validateTask(ctx))
 	}
+	errs = errs.Also(pt.validateMatrix(ctx))
+	errs = errs.Also(pt.validateOnError(ctx))
+	errs = errs.Also(pt.validateMutex(ctx))
+	if pt.RetryPolicy != nil {
+		errs = errs.Also(pt.RetryPolicy.validate(ctx).ViaField("retryPolicy"))
+	}
+	if pt.Cache != nil {
+		errs = errs.Also(pt.Cache.validate(ctx, pt.Workspaces).ViaField("cache"))
+		if pt.IsMatrixed() {
+			errs = errs.Also(apis.ErrMultipleOneOf("matrix", "cache"))
+		}
+	}
+	if pt.Iterate != nil {
+		errs = errs.Also(pt.Iterate.validate(ctx, pt.Name).ViaField("iterate"))
+		if pt.IsMatrixed() {
+			errs = errs.Also(apis.ErrMultipleOneOf("matrix", "iterate"))
+		}
+		if pt.Cache != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("cache", "iterate"))
+		}
+	}
 	return
 }
 
+// RunsPipeline returns whether the PipelineTask runs a Pipeline in a child PipelineRun instead of a task
+func (pt PipelineTask) RunsPipeline() bool {
+	return pt.PipelineRef != nil || pt.PipelineSpec != nil
+}
+
+// IsApproval returns whether the PipelineTask waits for an approval instead of running a task
+func (pt PipelineTask) IsApproval() bool {
+	return pt.Approval != nil
+}
+
+// IsMatrixed returns whether the PipelineTask fans out over a Matrix
+func (pt PipelineTask) IsMatrixed() bool {
+	return len(pt.Matrix) > 0
+}
+
+// validateMatrix validates that the matrix is only used when alpha features are enabled,
+// that it only contains array params, that it does not overlap with params, that it does
+// not fan out into more combinations than allowed by the defaults, and that it does not
+// reference results, since the TaskRuns of a matrixed PipelineTask are named from the
+// combinations of its matrix before the results are resolved
+func (pt PipelineTask) validateMatrix(ctx context.Context) (errs *apis.FieldError) {
+	if !pt.IsMatrixed() {
+		return nil
+	}
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "matrix", config.AlphaAPIFields))
+	if len(pt.Conditions) > 0 {
+		errs = errs.Also(apis.ErrMultipleOneOf("matrix", "conditions"))
+	}
+	paramNames := sets.NewString()
+	for _, p := range pt.Params {
+		paramNames.Insert(p.Name)
+	}
+	matrixNames := sets.NewString()
+	for _, p := range pt.Matrix {
+		if p.Value.Type != ParamTypeArray {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("parameters of type array only are allowed in matrix, but %s is of type %s", p.Name, p.Value.Type), "").ViaFieldKey("matrix", p.Name))
+		}
+		if paramNames.Has(p.Name) {
+			errs = errs.Also(apis.ErrMultipleOneOf("params", "matrix").ViaFieldKey("matrix", p.Name))
+		}
+		if matrixNames.Has(p.Name) {
+			errs = errs.Also(apis.ErrGeneric("parameter appears more than once", "").ViaFieldKey("matrix", p.Name))
+		}
+		matrixNames.Insert(p.Name)
+		for i, value := range p.Value.ArrayVal {
+			if len(NewResultRefs(validateString(value))) != 0 {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("results cannot be referenced in a matrix, found %q", value), "").ViaFieldIndex("value", i).ViaFieldKey("matrix", p.Name))
+			}
+		}
+	}
+	maxCombinations := config.FromContextOrDefaults(ctx).Defaults.DefaultMaxMatrixCombinationsCount
+	if combinations := pt.matrixCombinationsCount(); combinations > maxCombinations {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(combinations, 0, maxCombinations, "matrix"))
+	}
+	return errs
+}
+
+// matrixCombinationsCount returns the number of combinations the matrix fans out into
+func (pt PipelineTask) matrixCombinationsCount() int {
+	count := 1
+	for _, p := range pt.Matrix {
+		count *= len(p.Value.ArrayVal)
+	}
+	return count
+}
+
+// validateOnError validates that onError is only used when alpha features are enabled
+// and that it is set to either "continue" or "stopAndFail"
+func (pt PipelineTask) validateOnError(ctx context.Context) (errs *apis.FieldError) {
+	if pt.OnError == "" {
+		return nil
+	}
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "pipelinetask onError", config.AlphaAPIFields))
+	if pt.OnError != PipelineTaskContinue && pt.OnError != PipelineTaskStopAndFail {
+		errs = errs.Also(apis.ErrInvalidValue(pt.OnError, "onError", fmt.Sprintf("PipelineTask onError must be either %q or %q", PipelineTaskContinue, PipelineTaskStopAndFail)))
+	}
+	return errs
+}
+
+// validateMutex validates that the mutex is only used when alpha features are enabled,
+// that it is a valid Lease name and that it is not used with a matrix
+func (pt PipelineTask) validateMutex(ctx context.Context) (errs *apis.FieldError) {
+	if pt.Mutex == "" {
+		return nil
+	}
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "mutex", config.AlphaAPIFields))
+	if errSlice := validation.IsDNS1123Label(pt.Mutex); len(errSlice) != 0 {
+		errs = errs.Also(apis.ErrInvalidValue(strings.Join(errSlice, ","), "mutex"))
+	}
+	if pt.IsMatrixed() {
+		errs = errs.Also(apis.ErrMultipleOneOf("matrix", "mutex"))
+	}
+	return errs
+}
+
 // Deps returns all other PipelineTask dependencies of this PipelineTask, based on resource usage or ordering
 func (pt PipelineTask) Deps() []string {
 	deps := []string{}
@@ -375,9 +674,27 @@ func (pt PipelineTask) orderingDeps() []string {
 	for _, runAfter := range pt.RunAfter {
 		orderingDeps = append(orderingDeps, runAfter)
 	}
+	// Add any dependents from execution status references.
+	orderingDeps = append(orderingDeps, pt.ExecutionStatusDeps()...)
 	return orderingDeps
 }
 
+// ExecutionStatusDeps returns the names of the pipeline tasks whose execution status is referenced
+// in the when expressions of the pipeline task using $(tasks.<pipelineTask>.status)
+func (pt PipelineTask) ExecutionStatusDeps() []string {
+	deps := []string{}
+	for _, we := range pt.WhenExpressions {
+		expressions, _ := we.GetVarSubstitutionExpressions()
+		for _, e := range expressions {
+			if e == PipelineTasksAggregateStatus || looksLikeResultRef(e) || !containsExecutionStatusRef(e) {
+				continue
+			}
+			deps = append(deps, strings.TrimSuffix(strings.TrimPrefix(e, "tasks."), ".status"))
+		}
+	}
+	return deps
+}
+
 // PipelineTaskList is a list of PipelineTasks
 type PipelineTaskList []PipelineTask
 
@@ -395,6 +712,26 @@ func (l PipelineTaskList) Deps() map[string][]string {
 	return deps
 }
 
+// FinallyDeps returns a map with key as name of a final task and value as a list of the final tasks
+// it depends on. Final tasks can depend on the results of the tasks under spec.tasks as well, these
+// dependencies are left out since all the tasks under spec.tasks are done before any final task runs.
+func (l PipelineTaskList) FinallyDeps() map[string][]string {
+	names := l.Names()
+	deps := map[string][]string{}
+	for _, pt := range l {
+		d := []string{}
+		for _, dep := range pt.Deps() {
+			if names.Has(dep) {
+				d = append(d, dep)
+			}
+		}
+		if len(d) > 0 {
+			deps[pt.HashKey()] = d
+		}
+	}
+	return deps
+}
+
 // Items returns a slice of all tasks in the PipelineTaskList, converted to dag.Tasks
 func (l PipelineTaskList) Items() []dag.Task {
 	tasks := []dag.Task{}
@@ -22,8 +22,10 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/test/diff"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
@@ -106,6 +108,40 @@ func TestPipelineTask_ValidateRefOrSpec(t *testing.T) {
 			Message: `expected exactly one, got both`,
 			Paths:   []string{"taskRef", "taskSpec"},
 		},
+	}, {
+		name: "valid pipeline task - with pipelineRef only",
+		p: PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+		},
+	}, {
+		name: "valid pipeline task - with pipelineSpec only",
+		p: PipelineTask{
+			Name:         "foo",
+			PipelineSpec: &PipelineSpec{},
+		},
+	}, {
+		name: "invalid pipeline task with both pipelineRef and pipelineSpec",
+		p: PipelineTask{
+			Name:         "foo",
+			PipelineRef:  &PipelineRef{Name: "foo-pipeline"},
+			PipelineSpec: &PipelineSpec{},
+		},
+		expectedError: &apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"pipelineRef", "pipelineSpec"},
+		},
+	}, {
+		name: "invalid pipeline task with both taskRef and pipelineRef",
+		p: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo-task"},
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+		},
+		expectedError: &apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"pipelineRef", "pipelineSpec", "taskRef", "taskSpec"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -183,26 +219,37 @@ func TestPipelineTask_ValidateCustomTask(t *testing.T) {
 			Paths:   []string{"conditions"},
 		},
 	}, {
-		name: "custom task doesn't support retries",
+		name: "custom task doesn't support pipeline resources",
+		task: PipelineTask{
+			Name:      "foo",
+			Resources: &PipelineTaskResources{},
+			TaskRef:   &TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: custom tasks do not support PipelineResources`,
+			Paths:   []string{"resources"},
+		},
+	}, {
+		name: "custom task doesn't support mutex",
 		task: PipelineTask{
 			Name:    "foo",
-			Retries: 3,
+			Mutex:   "deploy",
 			TaskRef: &TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
 		},
 		expectedError: apis.FieldError{
-			Message: `invalid value: custom tasks do not support retries`,
-			Paths:   []string{"retries"},
+			Message: `invalid value: custom tasks do not support mutex`,
+			Paths:   []string{"mutex"},
 		},
 	}, {
-		name: "custom task doesn't support pipeline resources",
+		name: "custom task doesn't support cache",
 		task: PipelineTask{
-			Name:      "foo",
-			Resources: &PipelineTaskResources{},
-			TaskRef:   &TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+			Name:    "foo",
+			Cache:   &Cache{},
+			TaskRef: &TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
 		},
 		expectedError: apis.FieldError{
-			Message: `invalid value: custom tasks do not support PipelineResources`,
-			Paths:   []string{"resources"},
+			Message: `invalid value: custom tasks do not support cache`,
+			Paths:   []string{"cache"},
 		},
 	}}
 	for _, tt := range tests {
@@ -380,6 +427,329 @@ func TestPipelineTask_Validate_Failure(t *testing.T) {
 	}
 }
 
+func TestPipelineTask_ValidateMatrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "parameters in matrix are arrays",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Matrix: []Param{{
+				Name: "foobar", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"foo", "bar"}},
+			}, {
+				Name: "barfoo", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"bar", "foo"}},
+			}},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "matrix requires alpha api fields",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Matrix: []Param{{
+				Name: "foobar", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"foo", "bar"}},
+			}},
+		},
+		wantErrs: apis.ErrGeneric(`matrix requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "parameters in matrix are strings",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Matrix: []Param{{
+				Name: "foo", Value: ArrayOrString{Type: ParamTypeString, StringVal: "foo"},
+			}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("parameters of type array only are allowed in matrix, but foo is of type string", "").ViaFieldKey("matrix", "foo"),
+	}, {
+		name: "parameters duplicated in matrix and params",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Params: []Param{{
+				Name: "foobar", Value: ArrayOrString{Type: ParamTypeString, StringVal: "foo"},
+			}},
+			Matrix: []Param{{
+				Name: "foobar", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"foo", "bar"}},
+			}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMultipleOneOf("params", "matrix").ViaFieldKey("matrix", "foobar"),
+	}, {
+		name: "parameters duplicated in matrix",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Matrix: []Param{{
+				Name: "foobar", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"foo", "bar"}},
+			}, {
+				Name: "foobar", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"bar", "foo"}},
+			}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrGeneric("parameter appears more than once", "").ViaFieldKey("matrix", "foobar"),
+	}, {
+		name: "matrix with conditions",
+		pt: &PipelineTask{
+			Name:       "task",
+			TaskRef:    &TaskRef{Name: "foo"},
+			Conditions: []PipelineTaskCondition{{ConditionRef: "condition"}},
+			Matrix: []Param{{
+				Name: "foobar", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"foo", "bar"}},
+			}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMultipleOneOf("matrix", "conditions"),
+	}, {
+		name: "results referenced in matrix",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "$(tasks.detect.results.platform)"}},
+			}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue(`results cannot be referenced in a matrix, found "$(tasks.detect.results.platform)"`, "").ViaFieldIndex("value", 1).ViaFieldKey("matrix", "platform"),
+	}, {
+		name: "matrix with more combinations than allowed",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+			}, {
+				Name: "browser", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"chrome", "safari"}},
+			}},
+		},
+		wc: func(ctx context.Context) context.Context {
+			ctx = enableAlphaAPIFields(ctx)
+			cfg := config.FromContextOrDefaults(ctx)
+			cfg.Defaults.DefaultMaxMatrixCombinationsCount = 3
+			return config.ToContext(ctx, cfg)
+		},
+		wantErrs: apis.ErrOutOfBoundsValue(4, 0, 3, "matrix"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.validateMatrix(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.validateMatrix() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_ValidateOnError(t *testing.T) {
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "onError continue",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			OnError: PipelineTaskContinue,
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "onError stopAndFail",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			OnError: PipelineTaskStopAndFail,
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "onError requires alpha api fields",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			OnError: PipelineTaskContinue,
+		},
+		wantErrs: apis.ErrGeneric(`pipelinetask onError requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "invalid onError",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			OnError: "ignore",
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("ignore", "onError", `PipelineTask onError must be either "continue" or "stopAndFail"`),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.validateOnError(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.validateOnError() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_ValidateMutex(t *testing.T) {
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "mutex",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Mutex:   "deploy-staging",
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "mutex requires alpha api fields",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Mutex:   "deploy-staging",
+		},
+		wantErrs: apis.ErrGeneric(`mutex requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "invalid mutex",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Mutex:   "Deploy_Staging",
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')", "mutex"),
+	}, {
+		name: "mutex with matrix",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Mutex:   "deploy-staging",
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+			}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMultipleOneOf("matrix", "mutex"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.validateMutex(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.validateMutex() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_ValidatePipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "pipelineRef",
+		pt: &PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "pipelineSpec",
+		pt: &PipelineTask{
+			Name: "foo",
+			PipelineSpec: &PipelineSpec{
+				Tasks: []PipelineTask{{Name: "bar", TaskRef: &TaskRef{Name: "bar-task"}}},
+			},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "pipelines in pipelines require alpha api fields",
+		pt: &PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+		},
+		wantErrs: apis.ErrGeneric(`pipelines in pipelines requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "pipelineRef without name",
+		pt: &PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("pipelineRef must specify name", "pipelineRef.name"),
+	}, {
+		name: "pipelineRef with bundle",
+		pt: &PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline", Bundle: "docker.io/foo"},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrDisallowedFields("pipelineRef.bundle"),
+	}, {
+		name: "invalid pipelineSpec",
+		pt: &PipelineTask{
+			Name: "foo",
+			PipelineSpec: &PipelineSpec{
+				Tasks: []PipelineTask{{Name: "bar"}},
+			},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMissingOneOf("taskRef", "taskSpec").ViaFieldIndex("tasks", 0).ViaField("pipelineSpec"),
+	}, {
+		name: "unsupported fields",
+		pt: &PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+			Conditions:  []PipelineTaskCondition{{ConditionRef: "condition"}},
+			Retries:     1,
+			RetryPolicy: &RetryPolicy{},
+			Resources:   &PipelineTaskResources{},
+			Mutex:       "deploy",
+			Cache:       &Cache{},
+			Matrix: []Param{{
+				Name: "foobar", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"foo", "bar"}},
+			}},
+		},
+		wc: enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("pipelines in pipelines do not support conditions - use when expressions instead", "conditions").Also(
+			apis.ErrInvalidValue("pipelines in pipelines do not support retries", "retries")).Also(
+			apis.ErrInvalidValue("pipelines in pipelines do not support mutex", "mutex")).Also(
+			apis.ErrInvalidValue("pipelines in pipelines do not support cache", "cache")).Also(
+			apis.ErrInvalidValue("pipelines in pipelines do not support retries", "retryPolicy")).Also(
+			apis.ErrInvalidValue("pipelines in pipelines do not support PipelineResources", "resources")).Also(
+			apis.ErrInvalidValue("pipelines in pipelines do not support matrix", "matrix")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.validatePipeline(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.validatePipeline() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestPipelineTaskList_Names(t *testing.T) {
 	tasks := []PipelineTask{
 		{Name: "task-1"},
@@ -415,6 +785,23 @@ func TestPipelineTaskList_Deps(t *testing.T) {
 		expectedDeps: map[string][]string{
 			"task-2": {"task-1"},
 		},
+	}, {
+		name: "valid pipeline with execution status deps - when expressions",
+		tasks: []PipelineTask{
+			{Name: "task-1"},
+			{Name: "task-2", WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.task-1.status)",
+				Operator: selection.In,
+				Values:   []string{"Failed"},
+			}, {
+				Input:    "$(tasks.task-1.results.status)",
+				Operator: selection.In,
+				Values:   []string{"ok"},
+			}}},
+		},
+		expectedDeps: map[string][]string{
+			"task-2": {"task-1"},
+		},
 	}, {
 		name: "valid pipeline with resource deps - Inputs",
 		tasks: []PipelineTask{{
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
@@ -39,9 +40,25 @@ func (p *Pipeline) Validate(ctx context.Context) *apis.FieldError {
 	if apis.IsInDelete(ctx) {
 		return nil
 	}
+	errs = errs.Also(validatePipelineDoesNotRunItself(p.Name, p.Spec).ViaField("spec"))
 	return errs.Also(p.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
+// validatePipelineDoesNotRunItself validates that no PipelineTask of the Pipeline runs the Pipeline itself
+func validatePipelineDoesNotRunItself(name string, ps PipelineSpec) (errs *apis.FieldError) {
+	for i, pt := range ps.Tasks {
+		if pt.PipelineRef != nil && pt.PipelineRef.Name == name {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline %q can't run itself", name), "pipelineRef.name").ViaFieldIndex("tasks", i))
+		}
+	}
+	for i, pt := range ps.Finally {
+		if pt.PipelineRef != nil && pt.PipelineRef.Name == name {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline %q can't run itself", name), "pipelineRef.name").ViaFieldIndex("finally", i))
+		}
+	}
+	return errs
+}
+
 // Validate checks that taskNames in the Pipeline are valid and that the graph
 // of Tasks expressed in the Pipeline makes sense.
 func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
@@ -58,19 +75,23 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// Validate the pipeline task graph
 	errs = errs.Also(validateGraph(ps.Tasks))
 	errs = errs.Also(validateParamResults(ps.Tasks))
+	errs = errs.Also(validateArrayResultRefs(ps.Tasks).ViaField("tasks"))
+	errs = errs.Also(validateArrayResultRefs(ps.Finally).ViaField("finally"))
 	// The parameter variables should be valid
-	errs = errs.Also(validatePipelineParameterVariables(ps.Tasks, ps.Params).ViaField("tasks"))
-	errs = errs.Also(validatePipelineParameterVariables(ps.Finally, ps.Params).ViaField("finally"))
+	errs = errs.Also(validatePipelineParameterVariables(ctx, ps.Tasks, ps.Params).ViaField("tasks"))
+	errs = errs.Also(validatePipelineParameterVariables(ctx, ps.Finally, ps.Params).ViaField("finally"))
 	errs = errs.Also(validatePipelineContextVariables(ps.Tasks).ViaField("tasks"))
 	errs = errs.Also(validatePipelineContextVariables(ps.Finally).ViaField("finally"))
-	errs = errs.Also(validateExecutionStatusVariables(ps.Tasks, ps.Finally))
+	errs = errs.Also(validateExecutionStatusVariables(ctx, ps.Tasks, ps.Finally))
 	// Validate the pipeline's workspaces.
 	errs = errs.Also(validatePipelineWorkspaces(ps.Workspaces, ps.Tasks, ps.Finally))
 	// Validate the pipeline's results
-	errs = errs.Also(validatePipelineResults(ps.Results))
+	errs = errs.Also(validatePipelineResults(ctx, ps.Results))
+	errs = errs.Also(validateFinallyResultsInPipelineResults(ps.Results, ps.Finally))
 	errs = errs.Also(validateTasksAndFinallySection(ps))
-	errs = errs.Also(validateFinalTasks(ps.Tasks, ps.Finally))
-	errs = errs.Also(validateWhenExpressions(ps.Tasks, ps.Finally))
+	errs = errs.Also(validateFinalTasks(ctx, ps.Tasks, ps.Finally))
+	errs = errs.Also(validateWhenExpressions(ctx, ps.Tasks, ps.Finally))
+	errs = errs.Also(validateMatrixedPipelineTaskResults(ps.Tasks, ps.Finally, ps.Results))
 	return errs
 }
 
@@ -129,9 +150,10 @@ func validatePipelineWorkspaces(wss []PipelineWorkspaceDeclaration, pts []Pipeli
 // validatePipelineParameterVariables validates parameters with those specified by each pipeline task,
 // (1) it validates the type of parameter is either string or array (2) parameter default value matches
 // with the type of that param (3) ensures that the referenced param variable is defined is part of the param declarations
-func validatePipelineParameterVariables(tasks []PipelineTask, params []ParamSpec) (errs *apis.FieldError) {
+func validatePipelineParameterVariables(ctx context.Context, tasks []PipelineTask, params []ParamSpec) (errs *apis.FieldError) {
 	parameterNames := sets.NewString()
 	arrayParameterNames := sets.NewString()
+	objectParameterKeys := map[string]sets.String{}
 
 	for _, p := range params {
 		// Verify that p is a valid type.
@@ -154,19 +176,62 @@ func validatePipelineParameterVariables(tasks []PipelineTask, params []ParamSpec
 		if parameterNames.Has(p.Name) {
 			errs = errs.Also(apis.ErrGeneric("parameter appears more than once", "").ViaFieldKey("params", p.Name))
 		}
-		// Add parameter name to parameterNames, and to arrayParameterNames if type is array.
+		errs = errs.Also(p.validateObjectType(ctx).Also(p.validateConstraints(ctx)).ViaFieldKey("params", p.Name))
+		// Add parameter name to parameterNames, and to arrayParameterNames if type is array
+		// or to objectParameterKeys if type is object.
 		parameterNames.Insert(p.Name)
-		if p.Type == ParamTypeArray {
+		switch p.Type {
+		case ParamTypeArray:
 			arrayParameterNames.Insert(p.Name)
+		case ParamTypeObject:
+			objectParameterKeys[p.Name] = sets.StringKeySet(p.Properties)
 		}
 	}
 
-	return errs.Also(validatePipelineParametersVariables(tasks, "params", parameterNames, arrayParameterNames))
+	errs = errs.Also(validatePipelineParametersVariables(tasks, "params", parameterNames, arrayParameterNames))
+	return errs.Also(validatePipelineObjectParametersUsage(tasks, "params", objectParameterKeys))
+}
+
+// validatePipelineObjectParametersUsage validates that object params are only referenced by their keys
+// in the params, matrix and when expressions of the pipeline tasks
+func validatePipelineObjectParametersUsage(tasks []PipelineTask, prefix string, objectKeys map[string]sets.String) (errs *apis.FieldError) {
+	if len(objectKeys) == 0 {
+		return nil
+	}
+	for idx, task := range tasks {
+		for _, param := range task.Params {
+			switch param.Value.Type {
+			case ParamTypeString:
+				errs = errs.Also(substitution.ValidateVariableObjectKeysP(param.Value.StringVal, prefix, objectKeys).ViaFieldKey("params", param.Name).ViaIndex(idx))
+			case ParamTypeArray:
+				for i, value := range param.Value.ArrayVal {
+					errs = errs.Also(substitution.ValidateVariableObjectKeysP(value, prefix, objectKeys).ViaFieldIndex("value", i).ViaFieldKey("params", param.Name).ViaIndex(idx))
+				}
+			case ParamTypeObject:
+				for _, key := range sortedObjectKeys(param.Value.ObjectVal) {
+					errs = errs.Also(substitution.ValidateVariableObjectKeysP(param.Value.ObjectVal[key], prefix, objectKeys).ViaFieldKey("value", key).ViaFieldKey("params", param.Name).ViaIndex(idx))
+				}
+			}
+		}
+		for _, param := range task.Matrix {
+			for i, value := range param.Value.ArrayVal {
+				errs = errs.Also(substitution.ValidateVariableObjectKeysP(value, prefix, objectKeys).ViaFieldIndex("value", i).ViaFieldKey("matrix", param.Name).ViaIndex(idx))
+			}
+		}
+		for i, we := range task.WhenExpressions {
+			errs = errs.Also(substitution.ValidateVariableObjectKeysP(we.Input, prefix, objectKeys).ViaField("input").ViaFieldIndex("when", i).ViaIndex(idx))
+			for j, value := range we.Values {
+				errs = errs.Also(substitution.ValidateVariableObjectKeysP(value, prefix, objectKeys).ViaFieldIndex("values", j).ViaFieldIndex("when", i).ViaIndex(idx))
+			}
+		}
+	}
+	return errs
 }
 
 func validatePipelineParametersVariables(tasks []PipelineTask, prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
 	for idx, task := range tasks {
 		errs = errs.Also(validatePipelineParametersVariablesInTaskParameters(task.Params, prefix, paramNames, arrayParamNames).ViaIndex(idx))
+		errs = errs.Also(validatePipelineParametersVariablesInMatrixParameters(task.Matrix, prefix, paramNames, arrayParamNames).ViaIndex(idx))
 		errs = errs.Also(task.WhenExpressions.validatePipelineParametersVariables(prefix, paramNames, arrayParamNames).ViaIndex(idx))
 	}
 	return errs
@@ -182,16 +247,37 @@ func validatePipelineContextVariables(tasks []PipelineTask) *apis.FieldError {
 		"name",
 	)
 	var paramValues []string
-	for _, task := range tasks {
+	var errs *apis.FieldError
+	for i, task := range tasks {
 		for _, param := range task.Params {
-			paramValues = append(paramValues, param.Value.StringVal)
+			values := append([]string{param.Value.StringVal}, param.Value.ArrayVal...)
+			for _, key := range sortedObjectKeys(param.Value.ObjectVal) {
+				values = append(values, param.Value.ObjectVal[key])
+			}
+			if task.Iterate == nil {
+				errs = errs.Also(validateIterationContextVariableAbsent(values).ViaFieldKey("params", param.Name).ViaIndex(i))
+			}
+			paramValues = append(paramValues, values...)
+		}
+		for _, param := range task.Matrix {
 			paramValues = append(paramValues, param.Value.ArrayVal...)
 		}
 	}
-	errs := validatePipelineContextVariablesInParamValues(paramValues, "context\\.pipelineRun", pipelineRunContextNames)
+	errs = errs.Also(validatePipelineContextVariablesInParamValues(paramValues, "context\\.pipelineRun", pipelineRunContextNames))
 	return errs.Also(validatePipelineContextVariablesInParamValues(paramValues, "context\\.pipeline", pipelineContextNames))
 }
 
+// validateIterationContextVariableAbsent validates that the param values of a PipelineTask which
+// doesn't iterate don't refer to $(context.iteration), which would never be substituted
+func validateIterationContextVariableAbsent(values []string) *apis.FieldError {
+	for _, value := range values {
+		if strings.Contains(value, "$(context.iteration)") {
+			return apis.ErrInvalidValue(fmt.Sprintf("$(context.iteration) can only be used by a PipelineTask with iterate, found %q", value), "value")
+		}
+	}
+	return nil
+}
+
 func containsExecutionStatusRef(p string) bool {
 	if strings.HasPrefix(p, "tasks.") && strings.HasSuffix(p, ".status") {
 		return true
@@ -200,8 +286,11 @@ func containsExecutionStatusRef(p string) bool {
 }
 
 // validate dag pipeline tasks, task params can not access execution status of any other task
-// dag tasks cannot have param value as $(tasks.pipelineTask.status)
-func validateExecutionStatusVariablesInTasks(tasks []PipelineTask) (errs *apis.FieldError) {
+// dag tasks cannot have param value as $(tasks.pipelineTask.status), with the alpha API fields
+// their when expressions can access the execution status of the other dag tasks
+func validateExecutionStatusVariablesInTasks(ctx context.Context, tasks []PipelineTask) (errs *apis.FieldError) {
+	ptNames := PipelineTaskList(tasks).Names()
+	statusAllowed := config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields
 	for idx, t := range tasks {
 		for _, param := range t.Params {
 			// retrieve a list of substitution expression from a param
@@ -222,6 +311,11 @@ func validateExecutionStatusVariablesInTasks(tasks []PipelineTask) (errs *apis.F
 		for i, we := range t.WhenExpressions {
 			// retrieve a list of substitution expression from a when expression
 			if expressions, ok := we.GetVarSubstitutionExpressions(); ok {
+				if statusAllowed {
+					errs = errs.Also(validateExecutionStatusVariablesInDAGWhenExpressions(expressions, ptNames).ViaFieldIndex(
+						"when", i).ViaFieldIndex("tasks", idx))
+					continue
+				}
 				// validate tasks.pipelineTask.status/tasks.status if this expression is not a result reference
 				if !LooksLikeContainsResultRefs(expressions) {
 					for _, e := range expressions {
@@ -239,6 +333,18 @@ func validateExecutionStatusVariablesInTasks(tasks []PipelineTask) (errs *apis.F
 	return errs
 }
 
+// validateExecutionStatusVariablesInDAGWhenExpressions ensures the when expressions of dag tasks only access the
+// execution status of the dag tasks specified in the pipeline, the aggregate status of tasks is only available to
+// finally tasks
+func validateExecutionStatusVariablesInDAGWhenExpressions(expressions []string, ptNames sets.String) (errs *apis.FieldError) {
+	for _, expression := range expressions {
+		if expression == PipelineTasksAggregateStatus {
+			errs = errs.Also(apis.ErrInvalidValue("when expressions in pipeline tasks can not refer to aggregate status of tasks", ""))
+		}
+	}
+	return errs.Also(validateExecutionStatusVariablesExpressions(expressions, ptNames, ""))
+}
+
 // validate finally tasks accessing execution status of a dag task specified in the pipeline
 // $(tasks.pipelineTask.status) is invalid if pipelineTask is not defined as a dag task
 func validateExecutionStatusVariablesInFinally(tasks []PipelineTask, finally []PipelineTask) (errs *apis.FieldError) {
@@ -283,8 +389,8 @@ func validateExecutionStatusVariablesExpressions(expressions []string, ptNames s
 	return errs
 }
 
-func validateExecutionStatusVariables(tasks []PipelineTask, finallyTasks []PipelineTask) (errs *apis.FieldError) {
-	errs = errs.Also(validateExecutionStatusVariablesInTasks(tasks))
+func validateExecutionStatusVariables(ctx context.Context, tasks []PipelineTask, finallyTasks []PipelineTask) (errs *apis.FieldError) {
+	errs = errs.Also(validateExecutionStatusVariablesInTasks(ctx, tasks))
 	errs = errs.Also(validateExecutionStatusVariablesInFinally(tasks, finallyTasks))
 	return errs
 }
@@ -316,6 +422,51 @@ func validateParamResults(tasks []PipelineTask) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateArrayResultRefs ensures that array results, referenced using $(tasks.<taskName>.results.<resultName>[*]),
+// are only used as isolated elements of array params or of the values of when expressions, where they can be
+// expanded into the elements of the array
+func validateArrayResultRefs(tasks []PipelineTask) (errs *apis.FieldError) {
+	for idx, task := range tasks {
+		for _, param := range task.Params {
+			if param.Value.Type == ParamTypeString {
+				errs = errs.Also(validateArrayResultRefsAbsent(param.Value.StringVal, "value").ViaFieldKey("params", param.Name).ViaIndex(idx))
+				continue
+			}
+			if param.Value.Type == ParamTypeObject {
+				for _, key := range sortedObjectKeys(param.Value.ObjectVal) {
+					errs = errs.Also(validateArrayResultRefsAbsent(param.Value.ObjectVal[key], "").ViaFieldKey("value", key).ViaFieldKey("params", param.Name).ViaIndex(idx))
+				}
+				continue
+			}
+			for i, value := range param.Value.ArrayVal {
+				errs = errs.Also(validateArrayResultRefsIsolated(value).ViaFieldIndex("value", i).ViaFieldKey("params", param.Name).ViaIndex(idx))
+			}
+		}
+		for i, we := range task.WhenExpressions {
+			errs = errs.Also(validateArrayResultRefsAbsent(we.Input, "input").ViaFieldIndex("when", i).ViaIndex(idx))
+			for j, value := range we.Values {
+				errs = errs.Also(validateArrayResultRefsIsolated(value).ViaFieldIndex("values", j).ViaFieldIndex("when", i).ViaIndex(idx))
+			}
+		}
+	}
+	return errs
+}
+
+func validateArrayResultRefsAbsent(value string, fieldPath string) *apis.FieldError {
+	if len(NewArrayResultRefs(validateString(value))) != 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("array results can only be expanded in an element of an array, found %q", value), fieldPath)
+	}
+	return nil
+}
+
+func validateArrayResultRefsIsolated(value string) *apis.FieldError {
+	expressions := validateString(value)
+	if len(NewArrayResultRefs(expressions)) != 0 && (len(expressions) != 1 || value != fmt.Sprintf("$(%s)", expressions[0])) {
+		return apis.ErrInvalidValue(fmt.Sprintf("an expanded array result must be isolated in an element of an array, found %q", value), "")
+	}
+	return nil
+}
+
 func filter(arr []string, cond func(string) bool) []string {
 	result := []string{}
 	for i := range arr {
@@ -327,8 +478,10 @@ func filter(arr []string, cond func(string) bool) []string {
 }
 
 // validatePipelineResults ensure that pipeline result variables are properly configured
-func validatePipelineResults(results []PipelineResult) (errs *apis.FieldError) {
+func validatePipelineResults(ctx context.Context, results []PipelineResult) (errs *apis.FieldError) {
 	for idx, result := range results {
+		errs = errs.Also(validateResultsType(ctx, result.Type).ViaFieldIndex("results", idx))
+		errs = errs.Also(validatePipelineResultValue(result).ViaFieldIndex("results", idx))
 		expressions, ok := GetVarSubstitutionExpressionsForPipelineResult(result)
 		if ok {
 			if LooksLikeContainsResultRefs(expressions) {
@@ -345,6 +498,50 @@ func validatePipelineResults(results []PipelineResult) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateFinallyResultsInPipelineResults ensures that the references to the results of final tasks in pipeline
+// results, i.e. $(finally.<taskName>.results.<resultName>), are well formed and point to final tasks
+func validateFinallyResultsInPipelineResults(results []PipelineResult, finalTasks []PipelineTask) (errs *apis.FieldError) {
+	fts := PipelineTaskList(finalTasks).Names()
+	for idx, result := range results {
+		expressions, _ := GetVarSubstitutionExpressionsForPipelineResult(result)
+		expressions = filter(expressions, looksLikeFinallyResultRef)
+		if len(expressions) == 0 {
+			continue
+		}
+		resultRefs := NewFinallyResultRefs(expressions)
+		if len(expressions) != len(resultRefs) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("expected all of the expressions %v to be finally result expressions but only %v were", expressions, resultRefs),
+				"value").ViaFieldIndex("results", idx))
+		}
+		for _, ref := range resultRefs {
+			if !fts.Has(ref.PipelineTask) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("invalid pipeline result reference, %s is not a final task", ref.PipelineTask),
+					"value").ViaFieldIndex("results", idx))
+			}
+		}
+	}
+	return errs
+}
+
+// validatePipelineResultValue ensures that array and object pipeline results are a single reference to
+// a whole task result e.g. $(tasks.<taskName>.results.<resultName>[*]), and that string pipeline results
+// do not expand array results
+func validatePipelineResultValue(result PipelineResult) *apis.FieldError {
+	expressions, _ := GetVarSubstitutionExpressionsForPipelineResult(result)
+	switch result.Type {
+	case ResultsTypeArray, ResultsTypeObject:
+		refs := NewPipelineResultRefs(expressions)
+		if len(expressions) != 1 || len(refs) != 1 || refs[0].Property != "" || result.Value != fmt.Sprintf("$(%s)", expressions[0]) {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s results must be a single reference to a whole task result, found %q", result.Type, result.Value), "value")
+		}
+	default:
+		if len(NewArrayResultRefs(expressions)) != 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("string results cannot expand array results, found %q", result.Value), "value")
+		}
+	}
+	return nil
+}
+
 func validateTasksAndFinallySection(ps *PipelineSpec) *apis.FieldError {
 	if len(ps.Finally) != 0 && len(ps.Tasks) == 0 {
 		return apis.ErrInvalidValue(fmt.Sprintf("spec.tasks is empty but spec.finally has %d tasks", len(ps.Finally)), "finally")
@@ -352,36 +549,59 @@ func validateTasksAndFinallySection(ps *PipelineSpec) *apis.FieldError {
 	return nil
 }
 
-func validateFinalTasks(tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
+func validateFinalTasks(ctx context.Context, tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
+	ts := PipelineTaskList(tasks).Names()
+	fts := PipelineTaskList(finalTasks).Names()
+
+	// final tasks can only be ordered among themselves, using runAfter or task results, with the alpha API fields
+	orderingAllowed := config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields
 	for idx, f := range finalTasks {
-		if len(f.RunAfter) != 0 {
+		if len(f.RunAfter) != 0 && !orderingAllowed {
 			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("no runAfter allowed under spec.finally, final task %s has runAfter specified", f.Name), "").ViaFieldIndex("finally", idx))
 		}
+		if orderingAllowed {
+			for _, ra := range f.RunAfter {
+				if !fts.Has(ra) {
+					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("final task %s can only run after final tasks, %s is not a final task", f.Name, ra), "runAfter").ViaFieldIndex("finally", idx))
+				}
+			}
+		}
 		if len(f.Conditions) != 0 {
 			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("no conditions allowed under spec.finally, final task %s has conditions specified", f.Name), "").ViaFieldIndex("finally", idx))
 		}
+		if f.IsApproval() {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("no approval allowed under spec.finally, final task %s has approval specified", f.Name), "").ViaFieldIndex("finally", idx))
+		}
 	}
 
-	ts := PipelineTaskList(tasks).Names()
-	fts := PipelineTaskList(finalTasks).Names()
-
-	errs = errs.Also(validateTaskResultReferenceInFinallyTasks(finalTasks, ts, fts))
+	errs = errs.Also(validateTaskResultReferenceInFinallyTasks(finalTasks, ts, fts, orderingAllowed))
 	errs = errs.Also(validateTasksInputFrom(finalTasks).ViaField("finally"))
+	if orderingAllowed {
+		errs = errs.Also(validateFinallyGraph(finalTasks))
+	}
 
 	return errs
 }
 
-func validateTaskResultReferenceInFinallyTasks(finalTasks []PipelineTask, ts sets.String, fts sets.String) (errs *apis.FieldError) {
+// validateFinallyGraph ensures the dependencies among the final tasks don't form a cycle
+func validateFinallyGraph(finalTasks []PipelineTask) *apis.FieldError {
+	if _, err := dag.Build(PipelineTaskList(finalTasks), PipelineTaskList(finalTasks).FinallyDeps()); err != nil {
+		return apis.ErrInvalidValue(err.Error(), "finally")
+	}
+	return nil
+}
+
+func validateTaskResultReferenceInFinallyTasks(finalTasks []PipelineTask, ts sets.String, fts sets.String, finalRefsAllowed bool) (errs *apis.FieldError) {
 	for idx, t := range finalTasks {
 		for _, p := range t.Params {
 			if expressions, ok := GetVarSubstitutionExpressionsForParam(p); ok {
-				errs = errs.Also(validateResultsVariablesExpressionsInFinally(expressions, ts, fts, "value").ViaFieldKey(
+				errs = errs.Also(validateResultsVariablesExpressionsInFinally(expressions, ts, fts, finalRefsAllowed, "value").ViaFieldKey(
 					"params", p.Name).ViaFieldIndex("finally", idx))
 			}
 		}
 		for i, we := range t.WhenExpressions {
 			if expressions, ok := we.GetVarSubstitutionExpressions(); ok {
-				errs = errs.Also(validateResultsVariablesExpressionsInFinally(expressions, ts, fts, "").ViaFieldIndex(
+				errs = errs.Also(validateResultsVariablesExpressionsInFinally(expressions, ts, fts, finalRefsAllowed, "").ViaFieldIndex(
 					"when", i).ViaFieldIndex("finally", idx))
 			}
 		}
@@ -389,12 +609,15 @@ func validateTaskResultReferenceInFinallyTasks(finalTasks []PipelineTask, ts set
 	return errs
 }
 
-func validateResultsVariablesExpressionsInFinally(expressions []string, pipelineTasksNames sets.String, finalTasksNames sets.String, fieldPath string) (errs *apis.FieldError) {
+func validateResultsVariablesExpressionsInFinally(expressions []string, pipelineTasksNames sets.String, finalTasksNames sets.String, finalRefsAllowed bool, fieldPath string) (errs *apis.FieldError) {
 	if LooksLikeContainsResultRefs(expressions) {
 		resultRefs := NewResultRefs(expressions)
 		for _, resultRef := range resultRefs {
 			pt := resultRef.PipelineTask
 			if finalTasksNames.Has(pt) {
+				if finalRefsAllowed {
+					continue
+				}
 				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("invalid task result reference, "+
 					"final task has task result reference from a final task %s", pt), fieldPath))
 			} else if !pipelineTasksNames.Has(resultRef.PipelineTask) {
@@ -422,13 +645,51 @@ func validateTasksInputFrom(tasks []PipelineTask) (errs *apis.FieldError) {
 	return errs
 }
 
-func validateWhenExpressions(tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
+func validateWhenExpressions(ctx context.Context, tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
 	for i, t := range tasks {
 		errs = errs.Also(validateOneOfWhenExpressionsOrConditions(t).ViaFieldIndex("tasks", i))
-		errs = errs.Also(t.WhenExpressions.validate().ViaFieldIndex("tasks", i))
+		errs = errs.Also(t.WhenExpressions.validate(ctx).ViaFieldIndex("tasks", i))
 	}
 	for i, t := range finalTasks {
-		errs = errs.Also(t.WhenExpressions.validate().ViaFieldIndex("finally", i))
+		errs = errs.Also(t.WhenExpressions.validate(ctx).ViaFieldIndex("finally", i))
+	}
+	return errs
+}
+
+// validateMatrixedPipelineTaskResults ensures that the results of matrixed pipeline tasks are not
+// consumed by other pipeline tasks or by the pipeline results, since a matrixed pipeline task
+// produces one value per combination rather than a single value
+func validateMatrixedPipelineTaskResults(tasks []PipelineTask, finalTasks []PipelineTask, results []PipelineResult) (errs *apis.FieldError) {
+	matrixed := sets.NewString()
+	for _, t := range append(append([]PipelineTask{}, tasks...), finalTasks...) {
+		if t.IsMatrixed() {
+			matrixed.Insert(t.Name)
+		}
+	}
+	if matrixed.Len() == 0 {
+		return nil
+	}
+	for i, t := range tasks {
+		for _, ref := range PipelineTaskResultRefs(&t) {
+			if matrixed.Has(ref.PipelineTask) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("consuming results from matrixed task %s is not allowed", ref.PipelineTask), "").ViaFieldIndex("tasks", i))
+			}
+		}
+	}
+	for i, t := range finalTasks {
+		for _, ref := range PipelineTaskResultRefs(&t) {
+			if matrixed.Has(ref.PipelineTask) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("consuming results from matrixed task %s is not allowed", ref.PipelineTask), "").ViaFieldIndex("finally", i))
+			}
+		}
+	}
+	for i, r := range results {
+		expressions, _ := GetVarSubstitutionExpressionsForPipelineResult(r)
+		for _, ref := range NewPipelineResultRefs(expressions) {
+			if matrixed.Has(ref.PipelineTask) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("consuming results from matrixed task %s is not allowed", ref.PipelineTask), "value").ViaFieldIndex("results", i))
+			}
+		}
 	}
 	return errs
 }
@@ -55,6 +55,26 @@ func TestPipeline_Validate_Success(t *testing.T) {
 			},
 		},
 		wc: enableFeatures(t, []string{"enable-custom-tasks"}),
+	}, {
+		name: "pipelinetask running a pipeline",
+		p: &Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
+			Spec: PipelineSpec{
+				Tasks: []PipelineTask{{
+					Name:        "foo",
+					PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+				}, {
+					Name: "bar",
+					PipelineSpec: &PipelineSpec{
+						Tasks: []PipelineTask{{Name: "baz", TaskRef: &TaskRef{Name: "baz-task"}}},
+					},
+					Params: []Param{{
+						Name: "param", Value: *NewArrayOrString("$(tasks.foo.results.result)"),
+					}},
+				}},
+			},
+		},
+		wc: enableAlphaAPIFields,
 	}, {
 		name: "pipelinetask custom task spec",
 		p: &Pipeline{
@@ -192,6 +212,20 @@ func TestPipeline_Validate_Failure(t *testing.T) {
 			Message: `expected at least one, got none`,
 			Paths:   []string{"spec.description", "spec.params", "spec.resources", "spec.tasks", "spec.workspaces"},
 		},
+	}, {
+		name: "pipeline task runs the pipeline itself",
+		p: &Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
+			Spec: PipelineSpec{
+				Tasks:   []PipelineTask{{Name: "foo", TaskRef: &TaskRef{Name: "foo-task"}}},
+				Finally: []PipelineTask{{Name: "again", PipelineRef: &PipelineRef{Name: "pipeline"}}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline "pipeline" can't run itself`,
+			Paths:   []string{"spec.finally[0].pipelineRef.name"},
+		},
+		wc: enableAlphaAPIFields,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1197,7 +1231,7 @@ func TestValidatePipelineResults_Success(t *testing.T) {
 		Description: "this is my pipeline result",
 		Value:       "$(tasks.a-task.results.output)",
 	}}
-	if err := validatePipelineResults(results); err != nil {
+	if err := validatePipelineResults(context.Background(), results); err != nil {
 		t.Errorf("Pipeline.validatePipelineResults() returned error for valid pipeline: %s: %v", desc, err)
 	}
 }
@@ -1207,13 +1241,13 @@ func TestValidatePipelineResults_Failure(t *testing.T) {
 	results := []PipelineResult{{
 		Name:        "my-pipeline-result",
 		Description: "this is my pipeline result",
-		Value:       "$(tasks.a-task.results.output.output)",
+		Value:       "$(tasks.a-task.results.output.key.extra)",
 	}}
 	expectedError := apis.FieldError{
-		Message: `invalid value: expected all of the expressions [tasks.a-task.results.output.output] to be result expressions but only [] were`,
+		Message: `invalid value: expected all of the expressions [tasks.a-task.results.output.key.extra] to be result expressions but only [] were`,
 		Paths:   []string{"results[0].value"},
 	}
-	err := validatePipelineResults(results)
+	err := validatePipelineResults(context.Background(), results)
 	if err == nil {
 		t.Errorf("Pipeline.validatePipelineResults() did not return for invalid pipeline: %s", desc)
 	}
@@ -1222,6 +1256,202 @@ func TestValidatePipelineResults_Failure(t *testing.T) {
 	}
 }
 
+func TestValidateFinallyResultsInPipelineResults(t *testing.T) {
+	finalTasks := []PipelineTask{{
+		Name:    "final-task",
+		TaskRef: &TaskRef{Name: "final-task"},
+	}}
+	tests := []struct {
+		name          string
+		results       []PipelineResult
+		expectedError *apis.FieldError
+	}{{
+		name: "pipeline result referencing a final task result",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Value: "$(finally.final-task.results.output)",
+		}},
+	}, {
+		name: "array pipeline result referencing a final task result",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Type:  ResultsTypeArray,
+			Value: "$(finally.final-task.results.output[*])",
+		}},
+	}, {
+		name: "pipeline result referencing a task which is not a final task",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Value: "$(finally.a-task.results.output)",
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: invalid pipeline result reference, a-task is not a final task`,
+			Paths:   []string{"results[0].value"},
+		},
+	}, {
+		name: "malformed final task result reference",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Value: "$(finally.final-task.results)",
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: expected all of the expressions [finally.final-task.results] to be finally result expressions but only [] were`,
+			Paths:   []string{"results[0].value"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFinallyResultsInPipelineResults(tt.results, finalTasks)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("validateFinallyResultsInPipelineResults() returned error for valid pipeline results: %v", err)
+				}
+				if err := validatePipelineResultValue(tt.results[0]); err != nil {
+					t.Errorf("validatePipelineResultValue() returned error for valid pipeline results: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("validateFinallyResultsInPipelineResults() did not return error for invalid pipeline results")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("validateFinallyResultsInPipelineResults() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidatePipelineResults_Typed(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		results []PipelineResult
+		wc      func(context.Context) context.Context
+		wantErr *apis.FieldError
+	}{{
+		name: "array and object results",
+		results: []PipelineResult{{
+			Name:  "images",
+			Type:  ResultsTypeArray,
+			Value: "$(tasks.build.results.images[*])",
+		}, {
+			Name:  "metadata",
+			Type:  ResultsTypeObject,
+			Value: "$(tasks.build.results.metadata)",
+		}, {
+			Name:  "url",
+			Value: "$(tasks.build.results.metadata.url)",
+		}},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "array result without alpha",
+		results: []PipelineResult{{
+			Name:  "images",
+			Type:  ResultsTypeArray,
+			Value: "$(tasks.build.results.images[*])",
+		}},
+		wantErr: apis.ErrGeneric(`array and object results requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "array result which is not a single reference",
+		results: []PipelineResult{{
+			Name:  "images",
+			Type:  ResultsTypeArray,
+			Value: "images: $(tasks.build.results.images[*])",
+		}},
+		wc:      enableAlphaAPIFields,
+		wantErr: apis.ErrInvalidValue(`array results must be a single reference to a whole task result, found "images: $(tasks.build.results.images[*])"`, "results[0].value"),
+	}, {
+		name: "object result referencing a key",
+		results: []PipelineResult{{
+			Name:  "metadata",
+			Type:  ResultsTypeObject,
+			Value: "$(tasks.build.results.metadata.url)",
+		}},
+		wc:      enableAlphaAPIFields,
+		wantErr: apis.ErrInvalidValue(`object results must be a single reference to a whole task result, found "$(tasks.build.results.metadata.url)"`, "results[0].value"),
+	}, {
+		name: "string result expanding an array result",
+		results: []PipelineResult{{
+			Name:  "images",
+			Value: "$(tasks.build.results.images[*])",
+		}},
+		wantErr: apis.ErrInvalidValue(`string results cannot expand array results, found "$(tasks.build.results.images[*])"`, "results[0].value"),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			err := validatePipelineResults(ctx, tt.results)
+			if d := cmp.Diff(tt.wantErr.Error(), err.Error()); d != "" {
+				t.Errorf("validatePipelineResults() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateArrayResultRefs(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		tasks   []PipelineTask
+		wantErr *apis.FieldError
+	}{{
+		name: "array result expanded in array param and when expression values",
+		tasks: []PipelineTask{{
+			Name:    "deploy",
+			TaskRef: &TaskRef{Name: "deploy"},
+			Params: []Param{{
+				Name: "images", Value: *NewArrayOrString("$(tasks.build.results.images[*])", "extra"),
+			}, {
+				Name: "url", Value: *NewArrayOrString("$(tasks.build.results.metadata.url)"),
+			}},
+			WhenExpressions: WhenExpressions{{
+				Input:    "linux",
+				Operator: selection.In,
+				Values:   []string{"$(tasks.build.results.platforms[*])"},
+			}},
+		}},
+	}, {
+		name: "array result expanded in string param",
+		tasks: []PipelineTask{{
+			Name:    "deploy",
+			TaskRef: &TaskRef{Name: "deploy"},
+			Params: []Param{{
+				Name: "images", Value: *NewArrayOrString("$(tasks.build.results.images[*])"),
+			}},
+		}},
+		wantErr: apis.ErrInvalidValue(`array results can only be expanded in an element of an array, found "$(tasks.build.results.images[*])"`, "[0].params[images].value"),
+	}, {
+		name: "array result not isolated in array param",
+		tasks: []PipelineTask{{
+			Name:    "deploy",
+			TaskRef: &TaskRef{Name: "deploy"},
+			Params: []Param{{
+				Name: "images", Value: *NewArrayOrString("image: $(tasks.build.results.images[*])", "extra"),
+			}},
+		}},
+		wantErr: apis.ErrInvalidValue(`an expanded array result must be isolated in an element of an array, found "image: $(tasks.build.results.images[*])"`, "[0].params[images].value[0]"),
+	}, {
+		name: "array result expanded in when expression input",
+		tasks: []PipelineTask{{
+			Name:    "deploy",
+			TaskRef: &TaskRef{Name: "deploy"},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.build.results.platforms[*])",
+				Operator: selection.In,
+				Values:   []string{"linux"},
+			}},
+		}},
+		wantErr: apis.ErrInvalidValue(`array results can only be expanded in an element of an array, found "$(tasks.build.results.platforms[*])"`, "[0].when[0].input"),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateArrayResultRefs(tt.tasks)
+			if d := cmp.Diff(tt.wantErr.Error(), err.Error()); d != "" {
+				t.Errorf("validateArrayResultRefs() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidatePipelineParameterVariables_Success(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -1320,7 +1550,7 @@ func TestValidatePipelineParameterVariables_Success(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validatePipelineParameterVariables(tt.tasks, tt.params)
+			err := validatePipelineParameterVariables(context.Background(), tt.tasks, tt.params)
 			if err != nil {
 				t.Errorf("Pipeline.validatePipelineParameterVariables() returned error for valid pipeline parameters: %v", err)
 			}
@@ -1347,6 +1577,19 @@ func TestValidatePipelineParameterVariables_Failure(t *testing.T) {
 			Message: `non-existent variable in "$(params.does-not-exist)"`,
 			Paths:   []string{"[0].params[a-param]"},
 		},
+	}, {
+		name: "invalid pipeline task with a matrix parameter which is missing from the param declarations",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Matrix: []Param{{
+				Name: "a-param", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"$(params.does-not-exist)"}},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.does-not-exist)"`,
+			Paths:   []string{"[0].matrix[a-param].value[0]"},
+		},
 	}, {
 		name: "invalid string parameter variables in when expression, missing input param from the param declarations",
 		tasks: []PipelineTask{{
@@ -1598,7 +1841,7 @@ func TestValidatePipelineParameterVariables_Failure(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validatePipelineParameterVariables(tt.tasks, tt.params)
+			err := validatePipelineParameterVariables(context.Background(), tt.tasks, tt.params)
 			if err == nil {
 				t.Errorf("Pipeline.validatePipelineParameterVariables() did not return error for invalid pipeline parameters")
 			}
@@ -1609,6 +1852,150 @@ func TestValidatePipelineParameterVariables_Failure(t *testing.T) {
 	}
 }
 
+func TestValidatePipelineParameterVariables_ObjectParams(t *testing.T) {
+	params := []ParamSpec{{
+		Name:       "gitrepo",
+		Type:       ParamTypeObject,
+		Properties: map[string]PropertySpec{"url": {Type: ParamTypeString}, "revision": {Type: ParamTypeString}},
+	}}
+	tests := []struct {
+		name          string
+		tasks         []PipelineTask
+		expectedError *apis.FieldError
+	}{{
+		name: "object keys used in string, array and object params and when expressions",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "url", Value: *NewArrayOrString("$(params.gitrepo.url)"),
+			}, {
+				Name: "args", Value: *NewArrayOrString("--revision", "$(params.gitrepo.revision)"),
+			}, {
+				Name: "repo", Value: *NewObject(map[string]string{"url": "$(params.gitrepo.url)", "revision": "main"}),
+			}},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(params.gitrepo.revision)",
+				Operator: selection.In,
+				Values:   []string{"main"},
+			}},
+		}},
+	}, {
+		name: "whole object used in a string param",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "repo", Value: *NewArrayOrString("$(params.gitrepo)"),
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"[0].params[repo]"},
+		},
+	}, {
+		name: "undeclared key used in an object param",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "repo", Value: *NewObject(map[string]string{"depth": "$(params.gitrepo.depth)"}),
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent object key in "$(params.gitrepo.depth)"`,
+			Paths:   []string{"[0].params[repo].value[depth]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePipelineParameterVariables(enableAlphaAPIFields(context.Background()), tt.tasks, params)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("Pipeline.validatePipelineParameterVariables() returned error for valid pipeline parameters: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Pipeline.validatePipelineParameterVariables() did not return error for invalid pipeline parameters")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateMatrixedPipelineTaskResults(t *testing.T) {
+	matrixedTask := PipelineTask{
+		Name:    "matrixed",
+		TaskRef: &TaskRef{Name: "foo-task"},
+		Matrix: []Param{{
+			Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+		}},
+	}
+	tests := []struct {
+		name          string
+		tasks         []PipelineTask
+		finalTasks    []PipelineTask
+		results       []PipelineResult
+		expectedError *apis.FieldError
+	}{{
+		name: "results of unmatrixed tasks are consumed",
+		tasks: []PipelineTask{matrixedTask, {
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+		}, {
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "a-param", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.foo.results.output)"},
+			}},
+		}},
+		results: []PipelineResult{{
+			Name: "result", Value: "$(tasks.foo.results.output)",
+		}},
+	}, {
+		name: "results of matrixed task are consumed by a task",
+		tasks: []PipelineTask{matrixedTask, {
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "a-param", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.matrixed.results.output)"},
+			}},
+		}},
+		expectedError: apis.ErrInvalidValue("consuming results from matrixed task matrixed is not allowed", "").ViaFieldIndex("tasks", 1),
+	}, {
+		name:  "results of matrixed task are consumed by a final task",
+		tasks: []PipelineTask{matrixedTask},
+		finalTasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			WhenExpressions: []WhenExpression{{
+				Input:    "$(tasks.matrixed.results.output)",
+				Operator: selection.In,
+				Values:   []string{"foo"},
+			}},
+		}},
+		expectedError: apis.ErrInvalidValue("consuming results from matrixed task matrixed is not allowed", "").ViaFieldIndex("finally", 0),
+	}, {
+		name:  "results of matrixed task are consumed by pipeline results",
+		tasks: []PipelineTask{matrixedTask},
+		results: []PipelineResult{{
+			Name: "result", Value: "$(tasks.matrixed.results.output)",
+		}},
+		expectedError: apis.ErrInvalidValue("consuming results from matrixed task matrixed is not allowed", "value").ViaFieldIndex("results", 0),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMatrixedPipelineTaskResults(tt.tasks, tt.finalTasks, tt.results)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("validateMatrixedPipelineTaskResults() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidatePipelineWorkspaces_Success(t *testing.T) {
 	desc := "unused pipeline spec workspaces do not cause an error"
 	workspaces := []PipelineWorkspaceDeclaration{{
@@ -2210,7 +2597,7 @@ func TestValidateFinalTasks_Failure(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateFinalTasks(tt.tasks, tt.finalTasks)
+			err := validateFinalTasks(context.Background(), tt.tasks, tt.finalTasks)
 			if err == nil {
 				t.Errorf("Pipeline.ValidateFinalTasks() did not return error for invalid pipeline")
 			}
@@ -2221,6 +2608,88 @@ func TestValidateFinalTasks_Failure(t *testing.T) {
 	}
 }
 
+func TestValidateFinalTasks_Ordering(t *testing.T) {
+	tasks := []PipelineTask{{
+		Name:    "non-final-task",
+		TaskRef: &TaskRef{Name: "non-final-task"},
+	}}
+	tests := []struct {
+		name          string
+		finalTasks    []PipelineTask
+		expectedError *apis.FieldError
+	}{{
+		name: "final task running after a final task",
+		finalTasks: []PipelineTask{{
+			Name:    "final-task-1",
+			TaskRef: &TaskRef{Name: "final-task"},
+		}, {
+			Name:     "final-task-2",
+			TaskRef:  &TaskRef{Name: "final-task"},
+			RunAfter: []string{"final-task-1"},
+		}},
+	}, {
+		name: "final task consuming the results of a final task and of a dag task",
+		finalTasks: []PipelineTask{{
+			Name:    "final-task-1",
+			TaskRef: &TaskRef{Name: "final-task"},
+		}, {
+			Name:    "final-task-2",
+			TaskRef: &TaskRef{Name: "final-task"},
+			Params: []Param{{
+				Name: "param1", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.final-task-1.results.output)"},
+			}, {
+				Name: "param2", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.non-final-task.results.output)"},
+			}},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.final-task-1.results.output)",
+				Operator: selection.In,
+				Values:   []string{"result"},
+			}},
+		}},
+	}, {
+		name: "final task running after a dag task",
+		finalTasks: []PipelineTask{{
+			Name:     "final-task",
+			TaskRef:  &TaskRef{Name: "final-task"},
+			RunAfter: []string{"non-final-task"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: final task final-task can only run after final tasks, non-final-task is not a final task`,
+			Paths:   []string{"finally[0].runAfter"},
+		},
+	}, {
+		name: "final task depending on itself",
+		finalTasks: []PipelineTask{{
+			Name:    "final-task",
+			TaskRef: &TaskRef{Name: "final-task"},
+			Params: []Param{{
+				Name: "param1", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.final-task.results.output)"},
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: couldn't add link between final-task and final-task: couldn't create link from final-task to final-task: cycle detected; task "final-task" depends on itself`,
+			Paths:   []string{"finally"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFinalTasks(enableAlphaAPIFields(context.Background()), tasks, tt.finalTasks)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("Pipeline.ValidateFinalTasks() returned error for valid final tasks: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Pipeline.ValidateFinalTasks() did not return error for invalid final tasks")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("Pipeline.ValidateFinalTasks() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestContextValid(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -2270,6 +2739,16 @@ func TestContextValid(t *testing.T) {
 				Name: "a-param", Value: ArrayOrString{ArrayVal: []string{"$(context.pipeline.name)", "and", "$(context.pipelineRun.name)"}},
 			}},
 		}},
+	}, {
+		name: "valid iteration context variable in a task which iterates",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Iterate: &Iterate{MaxIterations: 3},
+			Params: []Param{{
+				Name: "a-param", Value: ArrayOrString{StringVal: "attempt-$(context.iteration)"},
+			}},
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2322,6 +2801,16 @@ func TestContextInvalid(t *testing.T) {
 		}},
 		expectedError: *apis.ErrGeneric(`non-existent variable in "$(context.pipeline.missing)"`, "value").Also(
 			apis.ErrGeneric(`non-existent variable in "$(context.pipelineRun.missing)"`, "value")),
+	}, {
+		name: "iteration context variable in a task which doesn't iterate",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "a-param", Value: ArrayOrString{StringVal: "attempt-$(context.iteration)"},
+			}},
+		}},
+		expectedError: *apis.ErrInvalidValue(`$(context.iteration) can only be used by a PipelineTask with iterate, found "attempt-$(context.iteration)"`, "[0].params[a-param].value"),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2554,7 +3043,7 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateExecutionStatusVariables(tt.tasks, tt.finalTasks)
+			err := validateExecutionStatusVariables(context.Background(), tt.tasks, tt.finalTasks)
 			if len(tt.expectedError.Error()) == 0 {
 				if err != nil {
 					t.Errorf("Pipeline.validateExecutionStatusVariables() returned error for valid pipeline variable accessing execution status: %s: %v", tt.name, err)
@@ -2571,6 +3060,91 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 	}
 }
 
+func TestValidateExecutionStatusVariables_DAGWhenExpressions(t *testing.T) {
+	tests := []struct {
+		name          string
+		tasks         []PipelineTask
+		expectedError *apis.FieldError
+	}{{
+		name: "when expression in dag task accessing pipelineTask status",
+		tasks: []PipelineTask{{
+			Name:    "deploy",
+			TaskRef: &TaskRef{Name: "deploy"},
+		}, {
+			Name:    "rollback",
+			TaskRef: &TaskRef{Name: "rollback"},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.deploy.status)",
+				Operator: selection.In,
+				Values:   []string{"Failed"},
+			}},
+		}},
+	}, {
+		name: "when expression in dag task accessing missing pipelineTask status",
+		tasks: []PipelineTask{{
+			Name:    "rollback",
+			TaskRef: &TaskRef{Name: "rollback"},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.notask.status)",
+				Operator: selection.In,
+				Values:   []string{"Failed"},
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: pipeline task notask is not defined in the pipeline`,
+			Paths:   []string{"tasks[0].when[0]"},
+		},
+	}, {
+		name: "when expression in dag task accessing aggregate tasks status",
+		tasks: []PipelineTask{{
+			Name:    "rollback",
+			TaskRef: &TaskRef{Name: "rollback"},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.status)",
+				Operator: selection.In,
+				Values:   []string{"Failed"},
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: when expressions in pipeline tasks can not refer to aggregate status of tasks`,
+			Paths:   []string{"tasks[0].when[0]"},
+		},
+	}, {
+		name: "param in dag task accessing pipelineTask status",
+		tasks: []PipelineTask{{
+			Name:    "deploy",
+			TaskRef: &TaskRef{Name: "deploy"},
+		}, {
+			Name:    "rollback",
+			TaskRef: &TaskRef{Name: "rollback"},
+			Params: []Param{{
+				Name: "deploy-status", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.deploy.status)"},
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks`,
+			Paths:   []string{"tasks[1].params[deploy-status].value"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExecutionStatusVariables(enableAlphaAPIFields(context.Background()), tt.tasks, nil)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("Pipeline.validateExecutionStatusVariables() returned error for valid pipeline variable accessing execution status: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Pipeline.validateExecutionStatusVariables() did not return error for invalid pipeline variable accessing execution status")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("Pipeline.validateExecutionStatusVariables() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func getTaskSpec() TaskSpec {
 	return TaskSpec{
 		Steps: []Step{{
@@ -2593,3 +3167,17 @@ func enableFeatures(t *testing.T, features []string) func(context.Context) conte
 		return s.ToContext(ctx)
 	}
 }
+
+func enableAlphaAPIFields(ctx context.Context) context.Context {
+	featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+		"enable-api-fields": "alpha",
+	})
+	cfg := &config.Config{
+		Defaults: &config.Defaults{
+			DefaultTimeoutMinutes:             60,
+			DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
+		},
+		FeatureFlags: featureFlags,
+	}
+	return config.ToContext(ctx, cfg)
+}
@@ -30,6 +30,16 @@ var _ apis.Defaultable = (*PipelineRun)(nil)
 // SetDefaults implements apis.Defaultable
 func (pr *PipelineRun) SetDefaults(ctx context.Context) {
 	pr.Spec.SetDefaults(ctx)
+
+	// Record who approved or rejected PipelineTasks when the webhook
+	// admits the request creating or changing the decisions
+	if ui := apis.GetUserInfo(ctx); ui != nil && len(pr.Spec.Approvals) > 0 {
+		var previous []ApprovalDecision
+		if old, ok := apis.GetBaseline(ctx).(*PipelineRun); ok && old != nil {
+			previous = old.Spec.Approvals
+		}
+		recordDeciders(pr.Spec.Approvals, previous, ui)
+	}
 }
 
 // SetDefaults implements apis.Defaultable
@@ -54,6 +64,7 @@ func (prs *PipelineRunSpec) SetDefaults(ctx context.Context) {
 	if prs.PipelineSpec != nil {
 		if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == "alpha" {
 			ctx = addContextParams(ctx, prs.Params)
+			ctx = addContextWorkspaceBindings(ctx, prs.Workspaces)
 		}
 		prs.PipelineSpec.SetDefaults(ctx)
 	}
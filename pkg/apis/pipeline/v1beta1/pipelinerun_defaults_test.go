@@ -27,8 +27,10 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 	logtesting "knative.dev/pkg/logging/testing"
 )
 
@@ -177,6 +179,66 @@ func TestPipelineRunSpec_SetDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "implicit workspaces",
+			ctxFn: func(ctx context.Context) context.Context {
+				cfg := config.FromContextOrDefaults(ctx)
+				cfg.FeatureFlags = &config.FeatureFlags{EnableAPIFields: "alpha"}
+				return config.ToContext(ctx, cfg)
+			},
+			prs: &v1beta1.PipelineRunSpec{
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name:     "source",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}, {
+					Name:     "unused",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}},
+				PipelineSpec: &v1beta1.PipelineSpec{
+					Tasks: []v1beta1.PipelineTask{{
+						Name: "build",
+						TaskSpec: &v1beta1.EmbeddedTask{
+							TaskSpec: v1beta1.TaskSpec{
+								Steps: []v1beta1.Step{{
+									Container: corev1.Container{Name: "build", Image: "ubuntu"},
+									Script:    "make -C $(workspaces.source.path)",
+								}},
+							},
+						},
+					}},
+				},
+			},
+			want: &v1beta1.PipelineRunSpec{
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				Timeout:            &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name:     "source",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}, {
+					Name:     "unused",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}},
+				PipelineSpec: &v1beta1.PipelineSpec{
+					Tasks: []v1beta1.PipelineTask{{
+						Name: "build",
+						TaskSpec: &v1beta1.EmbeddedTask{
+							TaskSpec: v1beta1.TaskSpec{
+								Steps: []v1beta1.Step{{
+									Container: corev1.Container{Name: "build", Image: "ubuntu"},
+									Script:    "make -C $(workspaces.source.path)",
+								}},
+								Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+							},
+						},
+						Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+							Name:      "source",
+							Workspace: "source",
+						}},
+					}},
+					Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "source"}},
+				},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -423,3 +485,96 @@ func TestPipelineRunDefaulting(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRunDefaulting_Approvals(t *testing.T) {
+	approver := &authenticationv1.UserInfo{Username: "alice", Groups: []string{"release-managers"}}
+	previous := []v1beta1.ApprovalDecision{{
+		PipelineTask: "approve-staging",
+		Decision:     v1beta1.ApprovalDecisionApprove,
+		User:         "bob",
+		Groups:       []string{"developers"},
+	}}
+	tests := []struct {
+		name      string
+		approvals []v1beta1.ApprovalDecision
+		wc        func(context.Context) context.Context
+		want      []v1beta1.ApprovalDecision
+	}{{
+		name: "no user info",
+		approvals: []v1beta1.ApprovalDecision{{
+			PipelineTask: "approve-prod",
+			Decision:     v1beta1.ApprovalDecisionApprove,
+			User:         "bob",
+		}},
+		want: []v1beta1.ApprovalDecision{{
+			PipelineTask: "approve-prod",
+			Decision:     v1beta1.ApprovalDecisionApprove,
+			User:         "bob",
+		}},
+	}, {
+		name: "create overrides the user",
+		approvals: []v1beta1.ApprovalDecision{{
+			PipelineTask: "approve-prod",
+			Decision:     v1beta1.ApprovalDecisionApprove,
+			User:         "bob",
+		}},
+		wc: func(ctx context.Context) context.Context {
+			return apis.WithUserInfo(apis.WithinCreate(ctx), approver)
+		},
+		want: []v1beta1.ApprovalDecision{{
+			PipelineTask: "approve-prod",
+			Decision:     v1beta1.ApprovalDecisionApprove,
+			User:         "alice",
+			Groups:       []string{"release-managers"},
+		}},
+	}, {
+		name: "update keeps the users of unchanged decisions",
+		approvals: []v1beta1.ApprovalDecision{previous[0], {
+			PipelineTask: "approve-prod",
+			Decision:     v1beta1.ApprovalDecisionReject,
+			Message:      "not today",
+		}},
+		wc: func(ctx context.Context) context.Context {
+			old := &v1beta1.PipelineRun{Spec: v1beta1.PipelineRunSpec{Approvals: previous}}
+			return apis.WithUserInfo(apis.WithinUpdate(ctx, old), approver)
+		},
+		want: []v1beta1.ApprovalDecision{previous[0], {
+			PipelineTask: "approve-prod",
+			Decision:     v1beta1.ApprovalDecisionReject,
+			Message:      "not today",
+			User:         "alice",
+			Groups:       []string{"release-managers"},
+		}},
+	}, {
+		name: "update overrides the users of changed decisions",
+		approvals: []v1beta1.ApprovalDecision{{
+			PipelineTask: "approve-staging",
+			Decision:     v1beta1.ApprovalDecisionReject,
+			User:         "bob",
+			Groups:       []string{"developers"},
+		}},
+		wc: func(ctx context.Context) context.Context {
+			old := &v1beta1.PipelineRun{Spec: v1beta1.PipelineRunSpec{Approvals: previous}}
+			return apis.WithUserInfo(apis.WithinUpdate(ctx, old), approver)
+		},
+		want: []v1beta1.ApprovalDecision{{
+			PipelineTask: "approve-staging",
+			Decision:     v1beta1.ApprovalDecisionReject,
+			User:         "alice",
+			Groups:       []string{"release-managers"},
+		}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{Spec: v1beta1.PipelineRunSpec{Approvals: tc.approvals}}
+			ctx := context.Background()
+			if tc.wc != nil {
+				ctx = tc.wc(ctx)
+			}
+			pr.SetDefaults(ctx)
+			if d := cmp.Diff(tc.want, pr.Spec.Approvals); d != "" {
+				t.Errorf("SetDefaults %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -206,6 +206,30 @@ type PipelineRunSpec struct {
 	// TaskRunSpecs holds a set of runtime specs
 	// +optional
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// Rerun reruns some of the tasks of a previous PipelineRun, reusing the
+	// results of the other tasks
+	// +optional
+	Rerun *PipelineRunRerun `json:"rerun,omitempty"`
+	// TTLSecondsAfterFinished is how long the PipelineRun is kept after it completes,
+	// before it is deleted along with its TaskRuns. Overrides the default TTL.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// Approvals approve or reject the PipelineTasks waiting for an approval
+	// +optional
+	Approvals []ApprovalDecision `json:"approvals,omitempty"`
+	// TaskStatuses cancel, skip or retry individual PipelineTasks of the PipelineRun
+	// +optional
+	TaskStatuses []PipelineRunTaskSpecStatus `json:"taskStatuses,omitempty"`
+}
+
+// PipelineRunRerun references a previous PipelineRun of the same Pipeline and the
+// PipelineTasks to run again. These PipelineTasks and the ones depending on them are
+// run, while the other PipelineTasks which succeeded in the previous PipelineRun are reused.
+type PipelineRunRerun struct {
+	// PipelineRunName is the name of the previous PipelineRun
+	PipelineRunName string `json:"pipelineRunName"`
+	// Tasks are the names of the PipelineTasks to rerun
+	Tasks []string `json:"tasks"`
 }
 
 // TimeoutFields allows granular specification of pipeline, task, and finally timeouts
@@ -244,6 +268,31 @@ const (
 	PipelineRunSpecStatusPending = "PipelineRunPending"
 )
 
+// PipelineTaskSpecStatus defines the status the user can provide for a single PipelineTask of a PipelineRun
+type PipelineTaskSpecStatus string
+
+const (
+	// PipelineTaskSpecStatusCancelled indicates that the user wants to cancel the PipelineTask if it is
+	// running, or not to start it otherwise. The PipelineTasks depending on it are skipped.
+	PipelineTaskSpecStatusCancelled = "Cancelled"
+
+	// PipelineTaskSpecStatusSkipped indicates that the user wants to skip the PipelineTask if it hasn't
+	// started yet. The PipelineTasks depending on it still run, unless they consume its results.
+	PipelineTaskSpecStatusSkipped = "Skipped"
+
+	// PipelineTaskSpecStatusRetry indicates that the user wants to retry the PipelineTask once more
+	// if it failed, after it exhausted its retries
+	PipelineTaskSpecStatusRetry = "Retry"
+)
+
+// PipelineRunTaskSpecStatus is the status the user provides for a PipelineTask of a PipelineRun
+type PipelineRunTaskSpecStatus struct {
+	// PipelineTaskName is the name of the PipelineTask
+	PipelineTaskName string `json:"pipelineTaskName"`
+	// Status is either "Cancelled", "Skipped" or "Retry"
+	Status PipelineTaskSpecStatus `json:"status"`
+}
+
 // PipelineRef can be used to refer to a specific instance of a Pipeline.
 // Copied from CrossVersionObjectReference: https://github.com/kubernetes/kubernetes/blob/169df7434155cbbc22f1532cba8e0a9588e29ad8/pkg/apis/autoscaling/types.go#L64
 type PipelineRef struct {
@@ -380,6 +429,10 @@ type PipelineRunStatusFields struct {
 	// +optional
 	Runs map[string]*PipelineRunRunStatus `json:"runs,omitempty"`
 
+	// map of PipelineRunChildPipelineRunStatus with the child PipelineRun name as the key
+	// +optional
+	ChildPipelineRuns map[string]*PipelineRunChildPipelineRunStatus `json:"childPipelineRuns,omitempty"`
+
 	// PipelineResults are the list of results written out by the pipeline task's containers
 	// +optional
 	PipelineResults []PipelineRunResult `json:"pipelineResults,omitempty"`
@@ -390,6 +443,25 @@ type PipelineRunStatusFields struct {
 	// list of tasks that were skipped due to when expressions evaluating to false
 	// +optional
 	SkippedTasks []SkippedTask `json:"skippedTasks,omitempty"`
+
+	// list of tasks that were reused from the PipelineRun being rerun, instead of being run
+	// +optional
+	ReusedTasks []ReusedTask `json:"reusedTasks,omitempty"`
+
+	// list of tasks waiting for an approval, or which were approved, rejected or timed out
+	// +optional
+	Approvals []PipelineTaskApprovalStatus `json:"approvals,omitempty"`
+}
+
+// ReusedTask describes a Task that wasn't run because its TaskRun from the
+// PipelineRun being rerun was reused, along with its status and results.
+type ReusedTask struct {
+	// Name is the Pipeline Task name
+	Name string `json:"name"`
+	// PipelineRunName is the name of the PipelineRun the TaskRun was reused from
+	PipelineRunName string `json:"pipelineRunName"`
+	// TaskRunName is the name of the reused TaskRun
+	TaskRunName string `json:"taskRunName"`
 }
 
 // SkippedTask is used to describe the Tasks that were skipped due to their When Expressions
@@ -422,9 +494,21 @@ type PipelineRunTaskRunStatus struct {
 	// ConditionChecks maps the name of a condition check to its Status
 	// +optional
 	ConditionChecks map[string]*PipelineRunConditionCheckStatus `json:"conditionChecks,omitempty"`
+	// MatrixParams is the combination of Matrix param values this instance of a
+	// matrixed PipelineTask was created with
+	// +optional
+	MatrixParams []Param `json:"matrixParams,omitempty"`
 	// WhenExpressions is the list of checks guarding the execution of the PipelineTask
 	// +optional
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
+	// WaitingForMutex is the name of the mutex held by another TaskRun, which the
+	// PipelineTask waits for before its TaskRun is created
+	// +optional
+	WaitingForMutex string `json:"waitingForMutex,omitempty"`
+	// Iteration is the iteration, starting at 1, of a PipelineTask with Iterate
+	// this TaskRun was created for
+	// +optional
+	Iteration int `json:"iteration,omitempty"`
 }
 
 // PipelineRunRunStatus contains the name of the PipelineTask for this Run and the Run's Status
@@ -434,6 +518,23 @@ type PipelineRunRunStatus struct {
 	// Status is the RunStatus for the corresponding Run
 	// +optional
 	Status *runv1alpha1.RunStatus `json:"status,omitempty"`
+	// MatrixParams is the combination of Matrix param values this instance of a
+	// matrixed PipelineTask was created with
+	// +optional
+	MatrixParams []Param `json:"matrixParams,omitempty"`
+	// WhenExpressions is the list of checks guarding the execution of the PipelineTask
+	// +optional
+	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
+}
+
+// PipelineRunChildPipelineRunStatus contains the name of the PipelineTask for this child PipelineRun
+// and the Succeeded condition of the child PipelineRun
+type PipelineRunChildPipelineRunStatus struct {
+	// PipelineTaskName is the name of the PipelineTask.
+	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// Condition is the Succeeded condition of the corresponding child PipelineRun
+	// +optional
+	Condition *apis.Condition `json:"condition,omitempty"`
 	// WhenExpressions is the list of checks guarding the execution of the PipelineTask
 	// +optional
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
@@ -480,6 +581,16 @@ type PipelineTaskRunSpec struct {
 	TaskPodTemplate        *PodTemplate `json:"taskPodTemplate,omitempty"`
 }
 
+// GetTaskSpecStatus returns the status the user provided for a PipelineTask, if any
+func (pr *PipelineRun) GetTaskSpecStatus(pipelineTaskName string) PipelineTaskSpecStatus {
+	for _, ts := range pr.Spec.TaskStatuses {
+		if ts.PipelineTaskName == pipelineTaskName {
+			return ts.Status
+		}
+	}
+	return ""
+}
+
 // GetTaskRunSpec returns the task specific spec for a given
 // PipelineTask if configured, otherwise it returns the PipelineRun's default.
 func (pr *PipelineRun) GetTaskRunSpec(pipelineTaskName string) PipelineTaskRunSpec {
@@ -25,6 +25,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
@@ -79,6 +80,7 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	// Validate PipelineSpec if it's present
 	if ps.PipelineSpec != nil {
 		errs = errs.Also(ps.PipelineSpec.Validate(ctx).ViaField("pipelinespec"))
+		errs = errs.Also(validateParamValuesConstraints(ps.PipelineSpec.Params, ps.Params))
 	}
 
 	if ps.Timeout != nil {
@@ -117,6 +119,23 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 
 	errs = errs.Also(validateSpecStatus(ctx, ps.Status))
 
+	if ps.Rerun != nil {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "rerun", config.AlphaAPIFields))
+		errs = errs.Also(ps.Rerun.validate().ViaField("rerun"))
+	}
+
+	errs = errs.Also(validateTTLSecondsAfterFinished(ctx, ps.TTLSecondsAfterFinished))
+
+	if len(ps.Approvals) > 0 {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "approvals", config.AlphaAPIFields))
+		errs = errs.Also(validateApprovalDecisions(ps.Approvals))
+	}
+
+	if len(ps.TaskStatuses) > 0 {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "taskStatuses", config.AlphaAPIFields))
+		errs = errs.Also(validateTaskSpecStatuses(ps.TaskStatuses))
+	}
+
 	if ps.Workspaces != nil {
 		wsNames := make(map[string]int)
 		for idx, ws := range ps.Workspaces {
@@ -131,6 +150,26 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	return errs
 }
 
+func (r *PipelineRunRerun) validate() (errs *apis.FieldError) {
+	if r.PipelineRunName == "" {
+		errs = errs.Also(apis.ErrMissingField("pipelineRunName"))
+	}
+	if len(r.Tasks) == 0 {
+		errs = errs.Also(apis.ErrMissingField("tasks"))
+	}
+	seen := sets.NewString()
+	for idx, task := range r.Tasks {
+		switch {
+		case task == "":
+			errs = errs.Also(apis.ErrInvalidValue("expecting non-empty task name", apis.CurrentField).ViaFieldIndex("tasks", idx))
+		case seen.Has(task):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("duplicate task %q", task), apis.CurrentField).ViaFieldIndex("tasks", idx))
+		}
+		seen.Insert(task)
+	}
+	return errs
+}
+
 func validateSpecStatus(ctx context.Context, status PipelineRunSpecStatus) *apis.FieldError {
 	switch status {
 	case "":
@@ -157,6 +196,28 @@ func validateSpecStatus(ctx context.Context, status PipelineRunSpecStatus) *apis
 		PipelineRunSpecStatusPending), "status")
 }
 
+func validateTaskSpecStatuses(statuses []PipelineRunTaskSpecStatus) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for idx, ts := range statuses {
+		switch {
+		case ts.PipelineTaskName == "":
+			errs = errs.Also(apis.ErrMissingField("pipelineTaskName").ViaFieldIndex("taskStatuses", idx))
+		case seen.Has(ts.PipelineTaskName):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("duplicate status for pipeline task %q", ts.PipelineTaskName), "pipelineTaskName").ViaFieldIndex("taskStatuses", idx))
+		}
+		seen.Insert(ts.PipelineTaskName)
+		switch ts.Status {
+		case PipelineTaskSpecStatusCancelled, PipelineTaskSpecStatusSkipped, PipelineTaskSpecStatusRetry:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s, %s or %s", ts.Status,
+				PipelineTaskSpecStatusCancelled,
+				PipelineTaskSpecStatusSkipped,
+				PipelineTaskSpecStatusRetry), "status").ViaFieldIndex("taskStatuses", idx))
+		}
+	}
+	return errs
+}
+
 func validateTimeoutDuration(field string, d *metav1.Duration) (errs *apis.FieldError) {
 	if d != nil && d.Duration < 0 {
 		fieldPath := fmt.Sprintf("timeouts.%s", field)
@@ -182,3 +243,15 @@ func (ps *PipelineRunSpec) validatePipelineTimeout(timeout time.Duration, errorM
 	}
 	return errs
 }
+
+// validateTTLSecondsAfterFinished validates how long a run is kept after it completes.
+func validateTTLSecondsAfterFinished(ctx context.Context, ttl *int32) (errs *apis.FieldError) {
+	if ttl == nil {
+		return nil
+	}
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "ttlSecondsAfterFinished", config.AlphaAPIFields).ViaField("ttlSecondsAfterFinished"))
+	if *ttl < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", *ttl), "ttlSecondsAfterFinished"))
+	}
+	return errs
+}
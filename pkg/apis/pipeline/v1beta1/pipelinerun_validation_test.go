@@ -600,6 +600,156 @@ func TestPipelineRunWithAlphaFields_Invalid(t *testing.T) {
 			},
 		},
 		want: apis.ErrGeneric(fmt.Sprintf(`timeouts requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`)),
+	}, {
+		name: "rerun when alpha fields not enabled",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				Rerun: &v1beta1.PipelineRunRerun{
+					PipelineRunName: "previous",
+					Tasks:           []string{"deploy"},
+				},
+			},
+		},
+		want: apis.ErrGeneric(`rerun requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "rerun without pipelinerun and tasks",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				Rerun: &v1beta1.PipelineRunRerun{},
+			},
+		},
+		want: apis.ErrMissingField("spec.rerun.pipelineRunName", "spec.rerun.tasks"),
+		wc:   enableAlphaAPIFields,
+	}, {
+		name: "rerun with empty and duplicate tasks",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				Rerun: &v1beta1.PipelineRunRerun{
+					PipelineRunName: "previous",
+					Tasks:           []string{"deploy", "", "deploy"},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("expecting non-empty task name", "spec.rerun.tasks[1]").Also(
+			apis.ErrGeneric(`duplicate task "deploy"`, "spec.rerun.tasks[2]")),
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "ttlSecondsAfterFinished when alpha fields not enabled",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				TTLSecondsAfterFinished: int32Ptr(3600),
+			},
+		},
+		want: apis.ErrGeneric(`ttlSecondsAfterFinished requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "negative ttlSecondsAfterFinished",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				TTLSecondsAfterFinished: int32Ptr(-1),
+			},
+		},
+		want: apis.ErrInvalidValue("-1 should be >= 0", "spec.ttlSecondsAfterFinished"),
+		wc:   enableAlphaAPIFields,
+	}, {
+		name: "approvals when alpha fields not enabled",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				Approvals: []v1beta1.ApprovalDecision{{PipelineTask: "deploy", Decision: v1beta1.ApprovalDecisionApprove}},
+			},
+		},
+		want: apis.ErrGeneric(`approvals requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "invalid approvals",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				Approvals: []v1beta1.ApprovalDecision{
+					{PipelineTask: "deploy", Decision: v1beta1.ApprovalDecisionApprove},
+					{Decision: v1beta1.ApprovalDecisionReject},
+					{PipelineTask: "deploy", Decision: "maybe"},
+				},
+			},
+		},
+		want: apis.ErrMissingField("spec.approvals[1].pipelineTask").Also(
+			apis.ErrGeneric(`duplicate decision for pipeline task "deploy"`, "spec.approvals[2].pipelineTask")).Also(
+			apis.ErrInvalidValue(`maybe should be "approve" or "reject"`, "spec.approvals[2].decision")),
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "taskStatuses when alpha fields not enabled",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				TaskStatuses: []v1beta1.PipelineRunTaskSpecStatus{{PipelineTaskName: "deploy", Status: v1beta1.PipelineTaskSpecStatusCancelled}},
+			},
+		},
+		want: apis.ErrGeneric(`taskStatuses requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "invalid taskStatuses",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				TaskStatuses: []v1beta1.PipelineRunTaskSpecStatus{
+					{PipelineTaskName: "deploy", Status: v1beta1.PipelineTaskSpecStatusSkipped},
+					{Status: v1beta1.PipelineTaskSpecStatusRetry},
+					{PipelineTaskName: "deploy", Status: "Paused"},
+				},
+			},
+		},
+		want: apis.ErrMissingField("spec.taskStatuses[1].pipelineTaskName").Also(
+			apis.ErrGeneric(`duplicate status for pipeline task "deploy"`, "spec.taskStatuses[2].pipelineTaskName")).Also(
+			apis.ErrInvalidValue("Paused should be Cancelled, Skipped or Retry", "spec.taskStatuses[2].status")),
+		wc: enableAlphaAPIFields,
 	}}
 
 	for _, tc := range tests {
@@ -639,6 +789,72 @@ func TestPipelineRunWithAlphaFields_Validate(t *testing.T) {
 			},
 		},
 		wc: enableAlphaAPIFields,
+	}, {
+		name: "rerun",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				Rerun: &v1beta1.PipelineRunRerun{
+					PipelineRunName: "previous",
+					Tasks:           []string{"test", "deploy"},
+				},
+			},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "ttlSecondsAfterFinished",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				TTLSecondsAfterFinished: int32Ptr(0),
+			},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "approvals",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				Approvals: []v1beta1.ApprovalDecision{
+					{PipelineTask: "deploy-staging", Decision: v1beta1.ApprovalDecisionApprove},
+					{PipelineTask: "deploy-prod", Decision: v1beta1.ApprovalDecisionReject, Message: "not today"},
+				},
+			},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "taskStatuses",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				TaskStatuses: []v1beta1.PipelineRunTaskSpecStatus{
+					{PipelineTaskName: "build", Status: v1beta1.PipelineTaskSpecStatusRetry},
+					{PipelineTaskName: "lint", Status: v1beta1.PipelineTaskSpecStatusSkipped},
+					{PipelineTaskName: "deploy", Status: v1beta1.PipelineTaskSpecStatusCancelled},
+				},
+			},
+		},
+		wc: enableAlphaAPIFields,
 	}}
 
 	for _, ts := range tests {
@@ -667,13 +883,18 @@ func enableTektonOCIBundles(t *testing.T) func(context.Context) context.Context
 	}
 }
 
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
 func enableAlphaAPIFields(ctx context.Context) context.Context {
 	featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
 		"enable-api-fields": "alpha",
 	})
 	cfg := &config.Config{
 		Defaults: &config.Defaults{
-			DefaultTimeoutMinutes: 60,
+			DefaultTimeoutMinutes:             60,
+			DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
 		},
 		FeatureFlags: featureFlags,
 	}
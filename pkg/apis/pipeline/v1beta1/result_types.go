@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ResultsType indicates the type of a result;
+// Used to distinguish between a single string, an array of strings and an object.
+type ResultsType string
+
+// Valid ResultsType:
+const (
+	ResultsTypeString ResultsType = "string"
+	ResultsTypeArray  ResultsType = "array"
+	ResultsTypeObject ResultsType = "object"
+)
+
+// AllResultsTypes can be used for ResultsType validation.
+var AllResultsTypes = []ResultsType{ResultsTypeString, ResultsTypeArray, ResultsTypeObject}
+
+// ParseArrayResult parses the value of an array result, which is a JSON array of strings.
+func ParseArrayResult(value string) ([]string, error) {
+	var arrayVal []string
+	if err := json.Unmarshal([]byte(value), &arrayVal); err != nil {
+		return nil, fmt.Errorf("value %q is not a JSON array of strings: %w", value, err)
+	}
+	return arrayVal, nil
+}
+
+// ParseObjectResult parses the value of an object result, which is a JSON object
+// whose values are strings.
+func ParseObjectResult(value string) (map[string]string, error) {
+	var objectVal map[string]string
+	if err := json.Unmarshal([]byte(value), &objectVal); err != nil {
+		return nil, fmt.Errorf("value %q is not a JSON object of strings: %w", value, err)
+	}
+	return objectVal, nil
+}
+
+// ValidateResultValue checks that the value emitted for a result is valid for the given
+// ResultsType. Any value is a valid string result.
+func ValidateResultValue(resultsType ResultsType, value string) error {
+	var err error
+	switch resultsType {
+	case ResultsTypeArray:
+		_, err = ParseArrayResult(value)
+	case ResultsTypeObject:
+		_, err = ParseObjectResult(value)
+	}
+	return err
+}
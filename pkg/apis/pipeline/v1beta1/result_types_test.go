@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestValidateResultValue(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		resultsType v1beta1.ResultsType
+		value       string
+		wantErr     bool
+	}{{
+		name:  "untyped result",
+		value: "anything",
+	}, {
+		name:        "string result",
+		resultsType: v1beta1.ResultsTypeString,
+		value:       `["not", "parsed"`,
+	}, {
+		name:        "array result",
+		resultsType: v1beta1.ResultsTypeArray,
+		value:       "[\"linux\", \"mac\"]\n",
+	}, {
+		name:        "invalid array result",
+		resultsType: v1beta1.ResultsTypeArray,
+		value:       "linux,mac",
+		wantErr:     true,
+	}, {
+		name:        "array result with non-string elements",
+		resultsType: v1beta1.ResultsTypeArray,
+		value:       "[1, 2]",
+		wantErr:     true,
+	}, {
+		name:        "object result",
+		resultsType: v1beta1.ResultsTypeObject,
+		value:       `{"url": "example.com", "digest": "sha256:abc"}`,
+	}, {
+		name:        "invalid object result",
+		resultsType: v1beta1.ResultsTypeObject,
+		value:       `["url"]`,
+		wantErr:     true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v1beta1.ValidateResultValue(tc.resultsType, tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateResultValue() = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseResults(t *testing.T) {
+	arrayVal, err := v1beta1.ParseArrayResult(`["linux", "mac"]`)
+	if err != nil {
+		t.Fatalf("ParseArrayResult() = %v", err)
+	}
+	if d := cmp.Diff([]string{"linux", "mac"}, arrayVal); d != "" {
+		t.Errorf("ParseArrayResult() %s", diff.PrintWantGot(d))
+	}
+	objectVal, err := v1beta1.ParseObjectResult(`{"url": "example.com"}`)
+	if err != nil {
+		t.Fatalf("ParseObjectResult() = %v", err)
+	}
+	if d := cmp.Diff(map[string]string{"url": "example.com"}, objectVal); d != "" {
+		t.Errorf("ParseObjectResult() %s", diff.PrintWantGot(d))
+	}
+}
@@ -26,16 +26,26 @@ import (
 type ResultRef struct {
 	PipelineTask string
 	Result       string
+	// Property is the key of an object result referenced using
+	// tasks.<taskName>.results.<resultName>.<key>
+	Property string
 }
 
 const (
 	resultExpressionFormat = "tasks.<taskName>.results.<resultName>"
+	// finallyResultExpressionFormat is the format of a reference to the result of a final task in a pipeline result
+	finallyResultExpressionFormat = "finally.<taskName>.results.<resultName>"
 	// ResultTaskPart Constant used to define the "tasks" part of a pipeline result reference
 	ResultTaskPart = "tasks"
+	// ResultFinallyPart Constant used to define the "finally" part of a pipeline result reference to a final task
+	ResultFinallyPart = "finally"
 	// ResultResultPart Constant used to define the "results" part of a pipeline result reference
 	ResultResultPart = "results"
+	// arrayResultSuffix is used to expand an array result into the elements of an array
+	// e.g. tasks.<taskName>.results.<resultName>[*]
+	arrayResultSuffix = "[*]"
 	// TODO(#2462) use one regex across all substitutions
-	variableSubstitutionFormat = `\$\([_a-zA-Z0-9.-]+(\.[_a-zA-Z0-9.-]+)*\)`
+	variableSubstitutionFormat = `\$\([_a-zA-Z0-9.-]+(\.[_a-zA-Z0-9.-]+)*(\[\*\])?\)`
 	// ResultNameFormat Constant used to define the the regex Result.Name should follow
 	ResultNameFormat = `^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`
 )
@@ -49,7 +59,7 @@ var resultNameFormatRegex = regexp.MustCompile(ResultNameFormat)
 func NewResultRefs(expressions []string) []*ResultRef {
 	var resultRefs []*ResultRef
 	for _, expression := range expressions {
-		pipelineTask, result, err := parseExpression(expression)
+		pipelineTask, result, property, err := parseExpression(expression)
 		// If the expression isn't a result but is some other expression,
 		// parseExpression will return an error, in which case we just skip that expression,
 		// since although it's not a result ref, it might be some other kind of reference
@@ -57,12 +67,55 @@ func NewResultRefs(expressions []string) []*ResultRef {
 			resultRefs = append(resultRefs, &ResultRef{
 				PipelineTask: pipelineTask,
 				Result:       result,
+				Property:     property,
 			})
 		}
 	}
 	return resultRefs
 }
 
+// NewFinallyResultRefs extracts the ResultReferences to the results of final tasks, i.e. of the form
+// finally.<taskName>.results.<resultName>, from a pipeline result. Expressions which are not references
+// to the results of final tasks are ignored.
+func NewFinallyResultRefs(expressions []string) []*ResultRef {
+	var resultRefs []*ResultRef
+	for _, expression := range expressions {
+		pipelineTask, result, property, err := parseFinallyExpression(expression)
+		if err == nil {
+			resultRefs = append(resultRefs, &ResultRef{
+				PipelineTask: pipelineTask,
+				Result:       result,
+				Property:     property,
+			})
+		}
+	}
+	return resultRefs
+}
+
+// NewPipelineResultRefs extracts all ResultReferences from a pipeline result, pipeline results can reference
+// the results of the tasks under spec.tasks and of the final tasks under spec.finally.
+func NewPipelineResultRefs(expressions []string) []*ResultRef {
+	return append(NewResultRefs(expressions), NewFinallyResultRefs(expressions)...)
+}
+
+// NewArrayResultRefs extracts the ResultReferences which expand an array result, i.e. of the
+// form tasks.<taskName>.results.<resultName>[*], from a param, a when expression or a pipeline result.
+func NewArrayResultRefs(expressions []string) []*ResultRef {
+	var arrayExpressions []string
+	for _, expression := range expressions {
+		if IsArrayResultExpression(expression) {
+			arrayExpressions = append(arrayExpressions, expression)
+		}
+	}
+	return NewResultRefs(arrayExpressions)
+}
+
+// IsArrayResultExpression returns true if the given expression expands an array result
+// e.g. tasks.<taskName>.results.<resultName>[*]
+func IsArrayResultExpression(expression string) bool {
+	return looksLikeResultRef(expression) && strings.HasSuffix(expression, arrayResultSuffix)
+}
+
 // LooksLikeContainsResultRefs attempts to check if param or a pipeline result looks like it contains any
 // result references.
 // This is useful if we want to make sure the param looks like a ResultReference before
@@ -82,6 +135,12 @@ func looksLikeResultRef(expression string) bool {
 	return strings.HasPrefix(expression, "task") && strings.Contains(expression, ".result")
 }
 
+// looksLikeFinallyResultRef checks if the given string looks like a reference to the result of a final task,
+// i.e. finally.<taskName>.results.<resultName>
+func looksLikeFinallyResultRef(expression string) bool {
+	return strings.HasPrefix(expression, ResultFinallyPart+".") && strings.Contains(expression, ".result")
+}
+
 // GetVarSubstitutionExpressionsForParam extracts all the value between "$(" and ")"" for a parameter
 func GetVarSubstitutionExpressionsForParam(param Param) ([]string, bool) {
 	var allExpressions []string
@@ -94,6 +153,11 @@ func GetVarSubstitutionExpressionsForParam(param Param) ([]string, bool) {
 	case ParamTypeString:
 		// string type
 		allExpressions = append(allExpressions, validateString(param.Value.StringVal)...)
+	case ParamTypeObject:
+		// object type
+		for _, key := range sortedObjectKeys(param.Value.ObjectVal) {
+			allExpressions = append(allExpressions, validateString(param.Value.ObjectVal[key])...)
+		}
 	default:
 		return nil, false
 	}
@@ -122,12 +186,28 @@ func stripVarSubExpression(expression string) string {
 	return strings.TrimSuffix(strings.TrimPrefix(expression, "$("), ")")
 }
 
-func parseExpression(substitutionExpression string) (string, string, error) {
-	subExpressions := strings.Split(substitutionExpression, ".")
-	if len(subExpressions) != 4 || subExpressions[0] != ResultTaskPart || subExpressions[2] != ResultResultPart {
-		return "", "", fmt.Errorf("Must be of the form %q", resultExpressionFormat)
+func parseExpression(substitutionExpression string) (string, string, string, error) {
+	return parseResultExpression(substitutionExpression, ResultTaskPart, resultExpressionFormat)
+}
+
+func parseFinallyExpression(substitutionExpression string) (string, string, string, error) {
+	return parseResultExpression(substitutionExpression, ResultFinallyPart, finallyResultExpressionFormat)
+}
+
+func parseResultExpression(substitutionExpression string, taskPart string, format string) (string, string, string, error) {
+	isArray := strings.HasSuffix(substitutionExpression, arrayResultSuffix)
+	subExpressions := strings.Split(strings.TrimSuffix(substitutionExpression, arrayResultSuffix), ".")
+	if len(subExpressions) < 4 || len(subExpressions) > 5 || subExpressions[0] != taskPart || subExpressions[2] != ResultResultPart {
+		return "", "", "", fmt.Errorf("Must be of the form %q", format)
+	}
+	if len(subExpressions) == 5 {
+		// tasks.<taskName>.results.<resultName>.<key> refers to a key of an object result
+		if isArray {
+			return "", "", "", fmt.Errorf("The key %q of an object result cannot be expanded", subExpressions[4])
+		}
+		return subExpressions[1], subExpressions[3], subExpressions[4], nil
 	}
-	return subExpressions[1], subExpressions[3], nil
+	return subExpressions[1], subExpressions[3], "", nil
 }
 
 // PipelineTaskResultRefs walks all the places a result reference can be used
@@ -153,3 +233,36 @@ func PipelineTaskResultRefs(pt *PipelineTask) []*ResultRef {
 
 	return refs
 }
+
+// StepResultRef is a type that represents a reference to the result of a previous step of the
+// same Task, e.g. $(steps.<stepName>.results.<resultName>)
+type StepResultRef struct {
+	Step   string
+	Result string
+}
+
+var stepResultRefRegex = regexp.MustCompile(`\$\(steps\.([^.)]+)\.results\.([^.)]+)\)`)
+
+// ContainsStepResultRefs returns true if the given value refers to the result of a previous step
+func ContainsStepResultRefs(value string) bool {
+	return stepResultRefRegex.MatchString(value)
+}
+
+// ReplaceStepResultRefs replaces the references to step results in the given value with the
+// values returned by resolve. The first error returned by resolve is returned.
+func ReplaceStepResultRefs(value string, resolve func(StepResultRef) (string, error)) (string, error) {
+	var err error
+	replaced := stepResultRefRegex.ReplaceAllStringFunc(value, func(match string) string {
+		if err != nil {
+			return match
+		}
+		groups := stepResultRefRegex.FindStringSubmatch(match)
+		var v string
+		v, err = resolve(StepResultRef{Step: groups[1], Result: groups[2]})
+		return v
+	})
+	if err != nil {
+		return value, err
+	}
+	return replaced, nil
+}
@@ -108,6 +108,34 @@ func TestNewResultReference(t *testing.T) {
 			PipelineTask: "sumTask1",
 			Result:       "sumResult",
 		}},
+	}, {
+		name: "array result expansion",
+		param: v1beta1.Param{
+			Name:  "param",
+			Value: *v1beta1.NewArrayOrString("$(tasks.listTask.results.images[*])", "another"),
+		},
+		want: []*v1beta1.ResultRef{{
+			PipelineTask: "listTask",
+			Result:       "images",
+		}},
+	}, {
+		name: "object result key",
+		param: v1beta1.Param{
+			Name:  "param",
+			Value: *v1beta1.NewArrayOrString("$(tasks.metaTask.results.metadata.url)"),
+		},
+		want: []*v1beta1.ResultRef{{
+			PipelineTask: "metaTask",
+			Result:       "metadata",
+			Property:     "url",
+		}},
+	}, {
+		name: "object result key cannot be expanded",
+		param: v1beta1.Param{
+			Name:  "param",
+			Value: *v1beta1.NewArrayOrString("$(tasks.metaTask.results.metadata.url[*])"),
+		},
+		want: nil,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			expressions, ok := v1beta1.GetVarSubstitutionExpressionsForParam(tt.param)
@@ -594,3 +622,43 @@ func TestPipelineTaskResultRefs(t *testing.T) {
 		t.Errorf("%v", d)
 	}
 }
+
+func TestNewArrayResultRefs(t *testing.T) {
+	expressions := []string{
+		"tasks.listTask.results.images[*]",
+		"tasks.listTask.results.images",
+		"tasks.metaTask.results.metadata.url",
+		"params.list[*]",
+	}
+	want := []*v1beta1.ResultRef{{
+		PipelineTask: "listTask",
+		Result:       "images",
+	}}
+	if d := cmp.Diff(want, v1beta1.NewArrayResultRefs(expressions)); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
+func TestNewPipelineResultRefs(t *testing.T) {
+	expressions := []string{
+		"tasks.buildTask.results.image",
+		"finally.reportTask.results.url",
+		"finally.reportTask.results.summary.passed",
+		"finally.reportTask.results",
+		"params.list",
+	}
+	want := []*v1beta1.ResultRef{{
+		PipelineTask: "buildTask",
+		Result:       "image",
+	}, {
+		PipelineTask: "reportTask",
+		Result:       "url",
+	}, {
+		PipelineTask: "reportTask",
+		Result:       "summary",
+		Property:     "passed",
+	}}
+	if d := cmp.Diff(want, v1beta1.NewPipelineResultRefs(expressions)); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"math"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RetryPolicy configures when and how often the failed TaskRuns or Runs of a
+// PipelineTask are retried, up to the number of Retries of the PipelineTask.
+type RetryPolicy struct {
+	// Backoff is the delay before the first retry, which is doubled for each
+	// subsequent retry. Failures are retried immediately if it isn't set.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// MaxBackoff is the upper bound of the delay between retries.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// Reasons is the list of failure reasons, as reported in the Succeeded
+	// condition of a TaskRun or Run, which are retried. Failures are retried
+	// regardless of their reason if it is empty.
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// ShouldRetry returns true if a failure with the given reason can be retried
+// under the RetryPolicy. A nil RetryPolicy retries any failure.
+func (rp *RetryPolicy) ShouldRetry(reason string) bool {
+	if rp == nil || len(rp.Reasons) == 0 {
+		return true
+	}
+	for _, r := range rp.Reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// Delay returns how long to wait after a failure before retrying it, given
+// the number of retries already done. A nil RetryPolicy doesn't wait.
+func (rp *RetryPolicy) Delay(retriesDone int) time.Duration {
+	if rp == nil || rp.Backoff == nil {
+		return 0
+	}
+	delay := rp.Backoff.Duration
+	for i := 0; i < retriesDone && delay < math.MaxInt64/2; i++ {
+		if rp.MaxBackoff != nil && delay >= rp.MaxBackoff.Duration {
+			break
+		}
+		delay *= 2
+	}
+	if rp.MaxBackoff != nil && delay > rp.MaxBackoff.Duration {
+		return rp.MaxBackoff.Duration
+	}
+	return delay
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		rp     *RetryPolicy
+		reason string
+		want   bool
+	}{{
+		name:   "no retry policy",
+		reason: "Failed",
+		want:   true,
+	}, {
+		name:   "no reasons",
+		rp:     &RetryPolicy{},
+		reason: "Failed",
+		want:   true,
+	}, {
+		name:   "reason listed",
+		rp:     &RetryPolicy{Reasons: []string{"TaskRunTimeout", "PodEvicted"}},
+		reason: "PodEvicted",
+		want:   true,
+	}, {
+		name:   "reason not listed",
+		rp:     &RetryPolicy{Reasons: []string{"TaskRunTimeout", "PodEvicted"}},
+		reason: "Failed",
+		want:   false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rp.ShouldRetry(tt.reason); got != tt.want {
+				t.Errorf("ShouldRetry(%q) = %t, want %t", tt.reason, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	tests := []struct {
+		name        string
+		rp          *RetryPolicy
+		retriesDone int
+		want        time.Duration
+	}{{
+		name:        "no retry policy",
+		retriesDone: 2,
+		want:        0,
+	}, {
+		name:        "no backoff",
+		rp:          &RetryPolicy{Reasons: []string{"TaskRunTimeout"}},
+		retriesDone: 2,
+		want:        0,
+	}, {
+		name:        "first retry",
+		rp:          &RetryPolicy{Backoff: &metav1.Duration{Duration: 10 * time.Second}},
+		retriesDone: 0,
+		want:        10 * time.Second,
+	}, {
+		name:        "backoff doubles for each retry",
+		rp:          &RetryPolicy{Backoff: &metav1.Duration{Duration: 10 * time.Second}},
+		retriesDone: 3,
+		want:        80 * time.Second,
+	}, {
+		name: "backoff capped by max backoff",
+		rp: &RetryPolicy{
+			Backoff:    &metav1.Duration{Duration: 10 * time.Second},
+			MaxBackoff: &metav1.Duration{Duration: time.Minute},
+		},
+		retriesDone: 3,
+		want:        time.Minute,
+	}, {
+		name:        "backoff doesn't overflow",
+		rp:          &RetryPolicy{Backoff: &metav1.Duration{Duration: time.Second}},
+		retriesDone: 100,
+		want:        time.Second << 33,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rp.Delay(tt.retriesDone); got != tt.want {
+				t.Errorf("Delay(%d) = %s, want %s", tt.retriesDone, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+func (rp *RetryPolicy) validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "retryPolicy", config.AlphaAPIFields))
+	if rp.Backoff != nil && rp.Backoff.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", rp.Backoff.Duration), "backoff"))
+	}
+	if rp.MaxBackoff != nil {
+		switch {
+		case rp.MaxBackoff.Duration < 0:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", rp.MaxBackoff.Duration), "maxBackoff"))
+		case rp.Backoff == nil:
+			errs = errs.Also(apis.ErrGeneric("maxBackoff requires backoff to be set", "maxBackoff"))
+		case rp.MaxBackoff.Duration < rp.Backoff.Duration:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= backoff %s", rp.MaxBackoff.Duration, rp.Backoff.Duration), "maxBackoff"))
+		}
+	}
+	seen := sets.NewString()
+	for idx, reason := range rp.Reasons {
+		switch {
+		case reason == "":
+			errs = errs.Also(apis.ErrInvalidValue("expecting non-empty reason", apis.CurrentField).ViaFieldIndex("reasons", idx))
+		case seen.Has(reason):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("duplicate reason %q", reason), apis.CurrentField).ViaFieldIndex("reasons", idx))
+		}
+		seen.Insert(reason)
+	}
+	return errs
+}
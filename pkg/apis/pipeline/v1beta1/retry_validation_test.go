@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestPipelineTask_ValidateRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "backoff, max backoff and reasons",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Retries: 3,
+			RetryPolicy: &RetryPolicy{
+				Backoff:    &metav1.Duration{Duration: 10 * time.Second},
+				MaxBackoff: &metav1.Duration{Duration: time.Minute},
+				Reasons:    []string{"TaskRunTimeout", "PodEvicted"},
+			},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "retryPolicy requires alpha api fields",
+		pt: &PipelineTask{
+			Name:        "task",
+			TaskRef:     &TaskRef{Name: "foo"},
+			Retries:     3,
+			RetryPolicy: &RetryPolicy{Reasons: []string{"TaskRunTimeout"}},
+		},
+		wantErrs: apis.ErrGeneric(`retryPolicy requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaField("retryPolicy"),
+	}, {
+		name: "negative backoff",
+		pt: &PipelineTask{
+			Name:        "task",
+			TaskRef:     &TaskRef{Name: "foo"},
+			Retries:     3,
+			RetryPolicy: &RetryPolicy{Backoff: &metav1.Duration{Duration: -time.Second}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("-1s should be >= 0", "retryPolicy.backoff"),
+	}, {
+		name: "max backoff without backoff",
+		pt: &PipelineTask{
+			Name:        "task",
+			TaskRef:     &TaskRef{Name: "foo"},
+			Retries:     3,
+			RetryPolicy: &RetryPolicy{MaxBackoff: &metav1.Duration{Duration: time.Minute}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrGeneric("maxBackoff requires backoff to be set", "retryPolicy.maxBackoff"),
+	}, {
+		name: "max backoff lower than backoff",
+		pt: &PipelineTask{
+			Name:    "task",
+			TaskRef: &TaskRef{Name: "foo"},
+			Retries: 3,
+			RetryPolicy: &RetryPolicy{
+				Backoff:    &metav1.Duration{Duration: time.Minute},
+				MaxBackoff: &metav1.Duration{Duration: 10 * time.Second},
+			},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("10s should be >= backoff 1m0s", "retryPolicy.maxBackoff"),
+	}, {
+		name: "empty and duplicate reasons",
+		pt: &PipelineTask{
+			Name:        "task",
+			TaskRef:     &TaskRef{Name: "foo"},
+			Retries:     3,
+			RetryPolicy: &RetryPolicy{Reasons: []string{"TaskRunTimeout", "", "TaskRunTimeout"}},
+		},
+		wc: enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("expecting non-empty reason", "retryPolicy.reasons[1]").Also(
+			apis.ErrGeneric(`duplicate reason "TaskRunTimeout"`, "retryPolicy.reasons[2]")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.Validate(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
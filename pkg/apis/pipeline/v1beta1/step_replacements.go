@@ -24,4 +24,7 @@ import (
 func ApplyStepReplacements(step *Step, stringReplacements map[string]string, arrayReplacements map[string][]string) {
 	step.Script = substitution.ApplyReplacements(step.Script, stringReplacements)
 	applyContainerReplacements(&step.Container, stringReplacements, arrayReplacements)
+	if len(step.When) > 0 {
+		step.When = append(WhenExpressions{}, step.When...).ReplaceWhenExpressionsVariables(stringReplacements, arrayReplacements)
+	}
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/selection"
 )
 
 func TestApplyStepReplacements(t *testing.T) {
@@ -35,6 +36,11 @@ func TestApplyStepReplacements(t *testing.T) {
 
 	s := v1beta1.Step{
 		Script: "$(replace.me)",
+		When: v1beta1.WhenExpressions{{
+			Input:    "$(replace.me)",
+			Operator: selection.In,
+			Values:   []string{"$(array.replace.me)"},
+		}},
 		Container: corev1.Container{
 			Name:       "$(replace.me)",
 			Image:      "$(replace.me)",
@@ -81,6 +87,11 @@ func TestApplyStepReplacements(t *testing.T) {
 
 	expected := v1beta1.Step{
 		Script: "replaced!",
+		When: v1beta1.WhenExpressions{{
+			Input:    "replaced!",
+			Operator: selection.In,
+			Values:   []string{"val1", "val2"},
+		}},
 		Container: corev1.Container{
 			Name:       "replaced!",
 			Image:      "replaced!",
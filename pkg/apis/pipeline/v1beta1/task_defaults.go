@@ -38,5 +38,6 @@ func (ts *TaskSpec) SetDefaults(ctx context.Context) {
 	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == "alpha" {
 		ctx = addContextParamSpec(ctx, ts.Params)
 		ts.Params = getContextParamSpecs(ctx)
+		ts.propagateWorkspaces(ctx)
 	}
 }
@@ -31,6 +31,8 @@ const (
 	PipelineResourceResultType = 2
 	// InternalTektonResultType default internal tekton result value
 	InternalTektonResultType = 3
+	// StepResultType default step result value
+	StepResultType = 4
 	// UnknownResultType default unknown result type value
 	UnknownResultType = 10
 )
@@ -125,6 +127,11 @@ type TaskResult struct {
 	// Name the given name
 	Name string `json:"name"`
 
+	// Type is the user-specified type of the result. The possible types
+	// are currently "string", "array" and "object", and "string" is the default.
+	// +optional
+	Type ResultsType `json:"type,omitempty"`
+
 	// Description is a human-readable description of the result
 	// +optional
 	Description string `json:"description"`
@@ -161,6 +168,32 @@ type Step struct {
 	// stopAndFail indicates exit the taskRun if the container exits with non-zero exit code
 	// continue indicates continue executing the rest of the steps irrespective of the container exit code
 	OnError string `json:"onError,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Results are values that this Step can output. Later Steps of the Task can refer to
+	// them with $(steps.<step-name>.results.<result-name>).
+	// +optional
+	Results []StepResult `json:"results,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// When is a list of when expressions which must all evaluate to true for the Step to run,
+	// the Step is skipped otherwise. They can refer to params and to the results of previous Steps.
+	// +optional
+	When WhenExpressions `json:"when,omitempty"`
+}
+
+// StepResult used to describe the results of a step
+type StepResult struct {
+	// Name the given name
+	Name string `json:"name"`
+
+	// Description is a human-readable description of the result
+	// +optional
+	Description string `json:"description,omitempty"`
 }
 
 // Sidecar has nearly the same data structure as Step, consisting of a Container and an optional Script, but does not have the ability to timeout.
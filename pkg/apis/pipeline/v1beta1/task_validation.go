@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -62,10 +64,11 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	errs = errs.Also(validateSteps(ctx, mergedSteps).ViaField("steps"))
 	errs = errs.Also(ts.Resources.Validate(ctx).ViaField("resources"))
-	errs = errs.Also(ValidateParameterTypes(ts.Params).ViaField("params"))
+	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
 	errs = errs.Also(ValidateParameterVariables(ts.Steps, ts.Params))
 	errs = errs.Also(ValidateResourcesVariables(ts.Steps, ts.Resources))
 	errs = errs.Also(validateTaskContextVariables(ts.Steps))
+	errs = errs.Also(validateStepResultReferences(ts.Steps))
 	errs = errs.Also(validateResults(ctx, ts.Results).ViaField("results"))
 	return errs
 }
@@ -78,11 +81,24 @@ func validateResults(ctx context.Context, results []TaskResult) (errs *apis.Fiel
 }
 
 // Validate implements apis.Validatable
-func (tr TaskResult) Validate(_ context.Context) *apis.FieldError {
+func (tr TaskResult) Validate(ctx context.Context) *apis.FieldError {
 	if !resultNameFormatRegex.MatchString(tr.Name) {
 		return apis.ErrInvalidKeyName(tr.Name, "name", fmt.Sprintf("Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", ResultNameFormat))
 	}
-	return nil
+	return validateResultsType(ctx, tr.Type)
+}
+
+// validateResultsType ensures that the type of a result is one of the supported types
+// and that array and object results are only used when alpha features are enabled.
+func validateResultsType(ctx context.Context, resultsType ResultsType) *apis.FieldError {
+	switch resultsType {
+	case "", ResultsTypeString:
+		return nil
+	case ResultsTypeArray, ResultsTypeObject:
+		return ValidateEnabledAPIFields(ctx, "array and object results", config.AlphaAPIFields).ViaField("type")
+	default:
+		return apis.ErrInvalidValue(fmt.Sprintf("result type %q is not one of %v", resultsType, AllResultsTypes), "type")
+	}
 }
 
 // a mount path which conflicts with any other declared workspaces, with the explicitly
@@ -241,19 +257,122 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 			errs = errs.Also(ValidateEnabledAPIFields(ctx, "windows script support", config.AlphaAPIFields).ViaField("script"))
 		}
 	}
+
+	if len(s.Results) != 0 {
+		errs = errs.Also(validateStepResults(ctx, s))
+	}
+
+	if len(s.When) != 0 {
+		errs = errs.Also(validateStepWhenExpressions(ctx, s.When).ViaField("when"))
+	}
+	return errs
+}
+
+// validateStepWhenExpressions checks the fields of the when expressions of a Step; they are evaluated
+// when the pod is built or by the entrypoint, which doesn't evaluate CEL
+func validateStepWhenExpressions(ctx context.Context, wes WhenExpressions) (errs *apis.FieldError) {
+	if err := ValidateEnabledAPIFields(ctx, "step when expressions", config.AlphaAPIFields); err != nil {
+		return err
+	}
+	for idx, we := range wes {
+		if we.CEL != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("cel").ViaIndex(idx))
+			continue
+		}
+		errs = errs.Also(we.validateWhenExpressionFields(ctx).ViaIndex(idx))
+	}
+	return errs
+}
+
+// validateStepResults checks that a Step emitting results has a name, so that later Steps can refer
+// to its results, and that the names of its results are valid and unique
+func validateStepResults(ctx context.Context, s Step) (errs *apis.FieldError) {
+	if err := ValidateEnabledAPIFields(ctx, "step results", config.AlphaAPIFields); err != nil {
+		return err.ViaField("results")
+	}
+	if s.Name == "" {
+		errs = errs.Also(apis.ErrGeneric("a step emitting results must have a name", "name"))
+	}
+	resultNames := sets.NewString()
+	for i, r := range s.Results {
+		if !resultNameFormatRegex.MatchString(r.Name) {
+			errs = errs.Also(apis.ErrInvalidKeyName(r.Name, "name", fmt.Sprintf("Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", ResultNameFormat)).ViaFieldIndex("results", i))
+		}
+		if resultNames.Has(r.Name) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("result %q is declared more than once", r.Name), "name").ViaFieldIndex("results", i))
+		}
+		resultNames.Insert(r.Name)
+	}
+	return errs
+}
+
+// validateStepResultReferences checks that $(step.results.<name>.path) refers to a result declared by the
+// same Step, and that $(steps.<step-name>.results.<name>) refers to a result declared by a previous Step
+func validateStepResultReferences(steps []Step) (errs *apis.FieldError) {
+	previousResults := sets.NewString()
+	for idx, step := range steps {
+		ownResults := sets.NewString()
+		for _, r := range step.Results {
+			ownResults.Insert(r.Name)
+		}
+		errs = errs.Also(validateStepFields(step, func(value string) *apis.FieldError {
+			return validateTaskVariable(value, "step\\.results", ownResults)
+		}).ViaFieldIndex("steps", idx))
+		errs = errs.Also(validateStepFields(step, func(value string) *apis.FieldError {
+			_, err := ReplaceStepResultRefs(value, func(ref StepResultRef) (string, error) {
+				if !previousResults.Has(fmt.Sprintf("%s.%s", ref.Step, ref.Result)) {
+					return "", fmt.Errorf("non-existent step result in %q", value)
+				}
+				return "", nil
+			})
+			if err != nil {
+				return &apis.FieldError{
+					Message: err.Error(),
+					// Empty path is required to make the `ViaField`, … work
+					Paths: []string{""},
+				}
+			}
+			return nil
+		}).ViaFieldIndex("steps", idx))
+		for _, r := range step.Results {
+			previousResults.Insert(fmt.Sprintf("%s.%s", step.Name, r.Name))
+		}
+	}
+	return errs
+}
+
+// validateStepFields applies the given validation to the fields of a Step which the entrypoint
+// runs, i.e. where the references to step results are resolved
+func validateStepFields(step Step, validate func(value string) *apis.FieldError) *apis.FieldError {
+	errs := validate(step.Script).ViaField("script")
+	for i, cmd := range step.Command {
+		errs = errs.Also(validate(cmd).ViaFieldIndex("command", i))
+	}
+	for i, arg := range step.Args {
+		errs = errs.Also(validate(arg).ViaFieldIndex("args", i))
+	}
+	for _, env := range step.Env {
+		errs = errs.Also(validate(env.Value).ViaFieldKey("env", env.Name))
+	}
+	for i, we := range step.When {
+		errs = errs.Also(validate(we.Input).ViaField("input").ViaFieldIndex("when", i))
+		for j, v := range we.Values {
+			errs = errs.Also(validate(v).ViaFieldIndex("values", j).ViaFieldIndex("when", i))
+		}
+	}
 	return errs
 }
 
 // ValidateParameterTypes validates all the types within a slice of ParamSpecs
-func ValidateParameterTypes(params []ParamSpec) (errs *apis.FieldError) {
+func ValidateParameterTypes(ctx context.Context, params []ParamSpec) (errs *apis.FieldError) {
 	for _, p := range params {
-		errs = errs.Also(p.ValidateType())
+		errs = errs.Also(p.ValidateType(ctx))
 	}
 	return errs
 }
 
 // ValidateType checks that the type of a ParamSpec is allowed and its default value matches that type
-func (p ParamSpec) ValidateType() *apis.FieldError {
+func (p ParamSpec) ValidateType(ctx context.Context) *apis.FieldError {
 	// Ensure param has a valid type.
 	validType := false
 	for _, allowedType := range AllParamTypes {
@@ -276,23 +395,147 @@ func (p ParamSpec) ValidateType() *apis.FieldError {
 			},
 		}
 	}
-	return nil
+	return p.validateObjectType(ctx).Also(p.validateConstraints(ctx)).ViaField(p.Name)
+}
+
+// validateConstraints checks that the enum, pattern, minimum and maximum of a ParamSpec are
+// well formed and that its default value satisfies them
+func (p ParamSpec) validateConstraints(ctx context.Context) *apis.FieldError {
+	if !p.HasConstraints() {
+		return nil
+	}
+	if err := ValidateEnabledAPIFields(ctx, "param constraints", config.AlphaAPIFields); err != nil {
+		return err
+	}
+	if p.Type == ParamTypeObject {
+		return apis.ErrGeneric("constraints are not supported for object parameters", "enum", "pattern", "minimum", "maximum")
+	}
+	var errs *apis.FieldError
+	seen := sets.NewString()
+	for _, v := range p.Enum {
+		if seen.Has(v) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("duplicate enum value %q", v), "enum"))
+		}
+		seen.Insert(v)
+	}
+	if p.Pattern != "" {
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q is not a valid regular expression: %v", p.Pattern, err), "pattern"))
+		}
+	}
+	if p.Minimum != nil && p.Maximum != nil && *p.Minimum > *p.Maximum {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("minimum %v is higher than maximum %v", *p.Minimum, *p.Maximum), "minimum"))
+	}
+	if errs == nil && p.Default != nil && !containsVariableReference(*p.Default) {
+		if err := p.ValidateValue(*p.Default); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err.Error(), "default"))
+		}
+	}
+	return errs
+}
+
+// validateParamValuesConstraints checks that the values of the given params satisfy the constraints
+// declared by the corresponding ParamSpecs. Values referring to variables are only known once they
+// are substituted, so they are checked by the reconcilers instead.
+func validateParamValuesConstraints(paramSpecs []ParamSpec, params []Param) (errs *apis.FieldError) {
+	specs := make(map[string]ParamSpec, len(paramSpecs))
+	for _, spec := range paramSpecs {
+		specs[spec.Name] = spec
+	}
+	for _, param := range params {
+		spec, ok := specs[param.Name]
+		if !ok || containsVariableReference(param.Value) {
+			continue
+		}
+		if err := spec.ValidateValue(param.Value); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err.Error(), "value").ViaFieldKey("params", param.Name))
+		}
+	}
+	return errs
+}
+
+// containsVariableReference returns true if the given value refers to any $(...) variable.
+func containsVariableReference(value ArrayOrString) bool {
+	if strings.Contains(value.StringVal, "$(") {
+		return true
+	}
+	for _, v := range value.ArrayVal {
+		if strings.Contains(v, "$(") {
+			return true
+		}
+	}
+	for _, v := range value.ObjectVal {
+		if strings.Contains(v, "$(") {
+			return true
+		}
+	}
+	return false
+}
+
+// validateObjectType checks that only object params declare properties, that the properties
+// are strings and that the default value of an object param provides every declared key
+func (p ParamSpec) validateObjectType(ctx context.Context) *apis.FieldError {
+	if p.Type != ParamTypeObject {
+		if len(p.Properties) != 0 {
+			return apis.ErrDisallowedFields("properties")
+		}
+		return nil
+	}
+	if err := ValidateEnabledAPIFields(ctx, "object type params", config.AlphaAPIFields); err != nil {
+		return err
+	}
+	if len(p.Properties) == 0 {
+		return apis.ErrMissingField("properties")
+	}
+	var errs *apis.FieldError
+	for _, key := range sortedPropertyKeys(p.Properties) {
+		if t := p.Properties[key].Type; t != "" && t != ParamTypeString {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("the type of key %q must be %q but was %q", key, ParamTypeString, t), "properties"))
+		}
+	}
+	if p.Default != nil {
+		var missingKeys []string
+		for _, key := range sortedPropertyKeys(p.Properties) {
+			if _, ok := p.Default.ObjectVal[key]; !ok {
+				missingKeys = append(missingKeys, key)
+			}
+		}
+		if len(missingKeys) != 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("default value is missing keys %v declared in properties", missingKeys), "default"))
+		}
+	}
+	return errs
+}
+
+// sortedPropertyKeys returns the keys declared by the properties of an object param in a stable order.
+func sortedPropertyKeys(properties map[string]PropertySpec) []string {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // ValidateParameterVariables validates all variables within a slice of ParamSpecs against a slice of Steps
 func ValidateParameterVariables(steps []Step, params []ParamSpec) *apis.FieldError {
 	parameterNames := sets.NewString()
 	arrayParameterNames := sets.NewString()
+	objectParameterKeys := map[string]sets.String{}
 
 	for _, p := range params {
 		parameterNames.Insert(p.Name)
-		if p.Type == ParamTypeArray {
+		switch p.Type {
+		case ParamTypeArray:
 			arrayParameterNames.Insert(p.Name)
+		case ParamTypeObject:
+			objectParameterKeys[p.Name] = sets.StringKeySet(p.Properties)
 		}
 	}
 
 	errs := validateVariables(steps, "params", parameterNames)
-	return errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
+	errs = errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
+	return errs.Also(validateObjectUsage(steps, "params", objectParameterKeys))
 }
 
 func validateTaskContextVariables(steps []Step) *apis.FieldError {
@@ -355,6 +598,51 @@ func validateStepArrayUsage(step Step, prefix string, vars sets.String) *apis.Fi
 		errs = errs.Also(validateTaskNoArrayReferenced(v.MountPath, prefix, vars).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(validateTaskNoArrayReferenced(v.SubPath, prefix, vars).ViaField("subPath").ViaFieldIndex("volumeMount", i))
 	}
+	for i, we := range step.When {
+		errs = errs.Also(validateTaskNoArrayReferenced(we.Input, prefix, vars).ViaField("input").ViaFieldIndex("when", i))
+		for j, v := range we.Values {
+			errs = errs.Also(validateTaskArraysIsolated(v, prefix, vars).ViaFieldIndex("values", j).ViaFieldIndex("when", i))
+		}
+	}
+	return errs
+}
+
+// validateObjectUsage validates that object params are only referenced by their keys
+func validateObjectUsage(steps []Step, prefix string, objectKeys map[string]sets.String) (errs *apis.FieldError) {
+	if len(objectKeys) == 0 {
+		return nil
+	}
+	for idx, step := range steps {
+		errs = errs.Also(validateStepObjectUsage(step, prefix, objectKeys).ViaFieldIndex("steps", idx))
+	}
+	return errs
+}
+
+func validateStepObjectUsage(step Step, prefix string, objectKeys map[string]sets.String) *apis.FieldError {
+	errs := validateTaskObjectKeys(step.Name, prefix, objectKeys).ViaField("name")
+	errs = errs.Also(validateTaskObjectKeys(step.Image, prefix, objectKeys).ViaField("image"))
+	errs = errs.Also(validateTaskObjectKeys(step.WorkingDir, prefix, objectKeys).ViaField("workingDir"))
+	errs = errs.Also(validateTaskObjectKeys(step.Script, prefix, objectKeys).ViaField("script"))
+	for i, cmd := range step.Command {
+		errs = errs.Also(validateTaskObjectKeys(cmd, prefix, objectKeys).ViaFieldIndex("command", i))
+	}
+	for i, arg := range step.Args {
+		errs = errs.Also(validateTaskObjectKeys(arg, prefix, objectKeys).ViaFieldIndex("args", i))
+	}
+	for _, env := range step.Env {
+		errs = errs.Also(validateTaskObjectKeys(env.Value, prefix, objectKeys).ViaFieldKey("env", env.Name))
+	}
+	for i, v := range step.VolumeMounts {
+		errs = errs.Also(validateTaskObjectKeys(v.Name, prefix, objectKeys).ViaField("name").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(validateTaskObjectKeys(v.MountPath, prefix, objectKeys).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(validateTaskObjectKeys(v.SubPath, prefix, objectKeys).ViaField("subPath").ViaFieldIndex("volumeMount", i))
+	}
+	for i, we := range step.When {
+		errs = errs.Also(validateTaskObjectKeys(we.Input, prefix, objectKeys).ViaField("input").ViaFieldIndex("when", i))
+		for j, v := range we.Values {
+			errs = errs.Also(validateTaskObjectKeys(v, prefix, objectKeys).ViaFieldIndex("values", j).ViaFieldIndex("when", i))
+		}
+	}
 	return errs
 }
 
@@ -384,6 +672,12 @@ func validateStepVariables(step Step, prefix string, vars sets.String) *apis.Fie
 		errs = errs.Also(validateTaskVariable(v.MountPath, prefix, vars).ViaField("MountPath").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(validateTaskVariable(v.SubPath, prefix, vars).ViaField("SubPath").ViaFieldIndex("volumeMount", i))
 	}
+	for i, we := range step.When {
+		errs = errs.Also(validateTaskVariable(we.Input, prefix, vars).ViaField("input").ViaFieldIndex("when", i))
+		for j, v := range we.Values {
+			errs = errs.Also(validateTaskVariable(v, prefix, vars).ViaFieldIndex("values", j).ViaFieldIndex("when", i))
+		}
+	}
 	return errs
 }
 
@@ -398,3 +692,7 @@ func validateTaskNoArrayReferenced(value, prefix string, arrayNames sets.String)
 func validateTaskArraysIsolated(value, prefix string, arrayNames sets.String) *apis.FieldError {
 	return substitution.ValidateVariableIsolatedP(value, prefix, arrayNames)
 }
+
+func validateTaskObjectKeys(value, prefix string, objectKeys map[string]sets.String) *apis.FieldError {
+	return substitution.ValidateVariableObjectKeysP(value, prefix, objectKeys)
+}
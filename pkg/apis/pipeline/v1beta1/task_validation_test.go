@@ -29,6 +29,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
 	"knative.dev/pkg/apis"
 )
 
@@ -379,6 +380,448 @@ func TestTaskSpecValidate(t *testing.T) {
 	}
 }
 
+func TestTaskSpecValidate_TypedResults(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "my-image"},
+		}},
+		Results: []v1beta1.TaskResult{{
+			Name: "images",
+			Type: v1beta1.ResultsTypeArray,
+		}, {
+			Name: "metadata",
+			Type: v1beta1.ResultsTypeObject,
+		}, {
+			Name: "digest",
+			Type: v1beta1.ResultsTypeString,
+		}},
+	}
+	ctx := enableAlphaAPIFields(context.Background())
+	ts.SetDefaults(ctx)
+	if err := ts.Validate(ctx); err != nil {
+		t.Errorf("TaskSpec.Validate() = %v", err)
+	}
+}
+
+func TestTaskSpecValidate_ObjectParams(t *testing.T) {
+	gitProperties := map[string]v1beta1.PropertySpec{
+		"url":      {Type: v1beta1.ParamTypeString},
+		"revision": {Type: v1beta1.ParamTypeString},
+	}
+	for _, tc := range []struct {
+		name          string
+		params        []v1beta1.ParamSpec
+		steps         []v1beta1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "object param with keys referenced",
+		params: []v1beta1.ParamSpec{{
+			Name:       "gitrepo",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: gitProperties,
+			Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "main"}),
+		}},
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "clone",
+				Image: "my-image",
+				Args:  []string{"--url=$(params.gitrepo.url)", "$(params.gitrepo.revision)"},
+			},
+		}},
+	}, {
+		name: "object param without properties",
+		params: []v1beta1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1beta1.ParamTypeObject,
+		}},
+		expectedError: apis.ErrMissingField("params.gitrepo.properties"),
+	}, {
+		name: "properties on a string param",
+		params: []v1beta1.ParamSpec{{
+			Name:       "gitrepo",
+			Type:       v1beta1.ParamTypeString,
+			Properties: gitProperties,
+		}},
+		expectedError: apis.ErrDisallowedFields("params.gitrepo.properties"),
+	}, {
+		name: "property which is not a string",
+		params: []v1beta1.ParamSpec{{
+			Name:       "gitrepo",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeArray}},
+		}},
+		expectedError: apis.ErrInvalidValue(`the type of key "url" must be "string" but was "array"`, "params.gitrepo.properties"),
+	}, {
+		name: "default value missing keys",
+		params: []v1beta1.ParamSpec{{
+			Name:       "gitrepo",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: gitProperties,
+			Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+		}},
+		expectedError: apis.ErrInvalidValue("default value is missing keys [revision] declared in properties", "params.gitrepo.default"),
+	}, {
+		name: "whole object referenced",
+		params: []v1beta1.ParamSpec{{
+			Name:       "gitrepo",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: gitProperties,
+		}},
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "clone",
+				Image: "my-image",
+				Args:  []string{"$(params.gitrepo)"},
+			},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "undeclared key referenced",
+		params: []v1beta1.ParamSpec{{
+			Name:       "gitrepo",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: gitProperties,
+		}},
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "clone",
+				Image: "my-image",
+				Env:   []corev1.EnvVar{{Name: "DEPTH", Value: "$(params.gitrepo.depth)"}},
+			},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent object key in "$(params.gitrepo.depth)"`,
+			Paths:   []string{"steps[0].env[DEPTH]"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Params: tc.params,
+				Steps:  tc.steps,
+			}
+			if ts.Steps == nil {
+				ts.Steps = validSteps
+			}
+			ctx := enableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tc.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(tc.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ParamConstraints(t *testing.T) {
+	one, ten := 1.0, 10.0
+	for _, tc := range []struct {
+		name          string
+		params        []v1beta1.ParamSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "valid constraints and defaults",
+		params: []v1beta1.ParamSpec{{
+			Name:    "env",
+			Enum:    []string{"dev", "prod"},
+			Default: v1beta1.NewArrayOrString("dev"),
+		}, {
+			Name:    "version",
+			Pattern: `^v[0-9]+$`,
+			Default: v1beta1.NewArrayOrString("v1"),
+		}, {
+			Name:    "replicas",
+			Minimum: &one,
+			Maximum: &ten,
+			Default: v1beta1.NewArrayOrString("3"),
+		}, {
+			Name:    "regions",
+			Type:    v1beta1.ParamTypeArray,
+			Enum:    []string{"us", "eu"},
+			Default: v1beta1.NewArrayOrString("us", "eu"),
+		}},
+	}, {
+		name: "default referring to a variable is not checked",
+		params: []v1beta1.ParamSpec{{
+			Name:    "env",
+			Enum:    []string{"dev", "prod"},
+			Default: v1beta1.NewArrayOrString("$(context.taskRun.namespace)"),
+		}},
+	}, {
+		name: "duplicate enum value",
+		params: []v1beta1.ParamSpec{{
+			Name: "env",
+			Enum: []string{"dev", "dev"},
+		}},
+		expectedError: apis.ErrInvalidValue(`duplicate enum value "dev"`, "params.env.enum"),
+	}, {
+		name: "invalid pattern",
+		params: []v1beta1.ParamSpec{{
+			Name:    "version",
+			Pattern: `^v[0-9+$`,
+		}},
+		expectedError: apis.ErrInvalidValue("\"^v[0-9+$\" is not a valid regular expression: error parsing regexp: missing closing ]: `[0-9+$`", "params.version.pattern"),
+	}, {
+		name: "minimum higher than maximum",
+		params: []v1beta1.ParamSpec{{
+			Name:    "replicas",
+			Minimum: &ten,
+			Maximum: &one,
+		}},
+		expectedError: apis.ErrInvalidValue("minimum 10 is higher than maximum 1", "params.replicas.minimum"),
+	}, {
+		name: "default not in enum",
+		params: []v1beta1.ParamSpec{{
+			Name:    "env",
+			Enum:    []string{"dev", "prod"},
+			Default: v1beta1.NewArrayOrString("staging"),
+		}},
+		expectedError: apis.ErrInvalidValue(`value "staging" is not one of the allowed values [dev prod]`, "params.env.default"),
+	}, {
+		name: "default out of range",
+		params: []v1beta1.ParamSpec{{
+			Name:    "replicas",
+			Maximum: &ten,
+			Default: v1beta1.NewArrayOrString("11"),
+		}},
+		expectedError: apis.ErrInvalidValue(`value "11" is higher than the maximum 10`, "params.replicas.default"),
+	}, {
+		name: "constraints on an object param",
+		params: []v1beta1.ParamSpec{{
+			Name:       "gitrepo",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{"url": {}},
+			Pattern:    "^https://",
+		}},
+		expectedError: apis.ErrGeneric("constraints are not supported for object parameters",
+			"params.gitrepo.enum", "params.gitrepo.maximum", "params.gitrepo.minimum", "params.gitrepo.pattern"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Params: tc.params,
+				Steps:  validSteps,
+			}
+			ctx := enableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tc.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(tc.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_StepResults(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		steps         []v1beta1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "step results written and referenced by a later step",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "build", Image: "my-image"},
+			Script:    "echo -n sha256:1234 > $(step.results.digest.path)",
+			Results:   []v1beta1.StepResult{{Name: "digest"}},
+		}, {
+			Container: corev1.Container{
+				Name:  "push",
+				Image: "my-image",
+				Args:  []string{"--digest=$(steps.build.results.digest)"},
+				Env:   []corev1.EnvVar{{Name: "DIGEST", Value: "$(steps.build.results.digest)"}},
+			},
+		}},
+	}, {
+		name: "unnamed step emitting results",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "my-image"},
+			Results:   []v1beta1.StepResult{{Name: "digest"}},
+		}},
+		expectedError: apis.ErrGeneric("a step emitting results must have a name", "steps[0].name"),
+	}, {
+		name: "invalid and duplicate step result names",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "build", Image: "my-image"},
+			Results:   []v1beta1.StepResult{{Name: "digest"}, {Name: "digest"}, {Name: "-bad"}},
+		}},
+		expectedError: apis.ErrInvalidValue(`result "digest" is declared more than once`, "steps[0].results[1].name").Also(
+			apis.ErrInvalidKeyName("-bad", "steps[0].results[2].name", fmt.Sprintf("Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", v1beta1.ResultNameFormat))),
+	}, {
+		name: "path of a result not declared by the step",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "build", Image: "my-image"},
+			Script:    "echo -n sha256:1234 > $(step.results.image.path)",
+			Results:   []v1beta1.StepResult{{Name: "digest"}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "echo -n sha256:1234 > $(step.results.image.path)"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "reference to the result of a later step",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "push",
+				Image: "my-image",
+				Args:  []string{"--digest=$(steps.build.results.digest)"},
+			},
+		}, {
+			Container: corev1.Container{Name: "build", Image: "my-image"},
+			Results:   []v1beta1.StepResult{{Name: "digest"}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent step result in "--digest=$(steps.build.results.digest)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Steps: tc.steps,
+			}
+			ctx := enableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tc.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(tc.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_StepWhenExpressions(t *testing.T) {
+	build := v1beta1.Step{
+		Container: corev1.Container{Name: "build", Image: "my-image"},
+		Results:   []v1beta1.StepResult{{Name: "digest"}},
+	}
+	for _, tc := range []struct {
+		name          string
+		steps         []v1beta1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "when expressions referring to params and to the results of a previous step",
+		steps: []v1beta1.Step{build, {
+			Container: corev1.Container{Name: "push", Image: "my-image"},
+			When: v1beta1.WhenExpressions{{
+				Input:    "$(params.deploy)",
+				Operator: selection.In,
+				Values:   []string{"true"},
+			}, {
+				Input:    "$(steps.build.results.digest)",
+				Operator: selection.NotIn,
+				Values:   []string{"", "$(params.baseline)"},
+			}},
+		}},
+	}, {
+		name: "invalid operator",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "push", Image: "my-image"},
+			When: v1beta1.WhenExpressions{{
+				Input:    "$(params.deploy)",
+				Operator: selection.Exists,
+				Values:   []string{"true"},
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: operator "exists" is not recognized. valid operators: in,notin`,
+			Paths:   []string{"steps[0].when[0]"},
+		},
+	}, {
+		name: "cel is not supported in steps",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "push", Image: "my-image"},
+			When:      v1beta1.WhenExpressions{{CEL: "'$(params.deploy)' == 'true'"}},
+		}},
+		expectedError: apis.ErrDisallowedFields("steps[0].when[0].cel"),
+	}, {
+		name: "reference to an undeclared param",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "push", Image: "my-image"},
+			When: v1beta1.WhenExpressions{{
+				Input:    "$(params.missing)",
+				Operator: selection.In,
+				Values:   []string{"true"},
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(params.missing)"`,
+			Paths:   []string{"steps[0].when[0].input"},
+		},
+	}, {
+		name: "reference to the result of a later step",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "push", Image: "my-image"},
+			When: v1beta1.WhenExpressions{{
+				Input:    "true",
+				Operator: selection.In,
+				Values:   []string{"$(steps.build.results.digest)"},
+			}},
+		}, build},
+		expectedError: &apis.FieldError{
+			Message: `non-existent step result in "$(steps.build.results.digest)"`,
+			Paths:   []string{"steps[0].when[0].values[0]"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Params: []v1beta1.ParamSpec{{Name: "deploy"}, {Name: "baseline"}},
+				Steps:  tc.steps,
+			}
+			ctx := enableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tc.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing")
+			}
+			if d := cmp.Diff(tc.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_StepWhenExpressionsNotAlpha(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "push", Image: "my-image"},
+			When:      v1beta1.WhenExpressions{{Input: "foo", Operator: selection.In, Values: []string{"foo"}}},
+		}},
+	}
+	ctx := context.Background()
+	ts.SetDefaults(ctx)
+	err := ts.Validate(ctx)
+	if err == nil {
+		t.Fatalf("Expected an error when using step when expressions without the alpha API fields enabled")
+	}
+	if d := cmp.Diff(`step when expressions requires "enable-api-fields" feature gate to be "alpha" but it is "stable": `, err.Error()); d != "" {
+		t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestTaskSpecValidateError(t *testing.T) {
 	type fields struct {
 		Params       []v1beta1.ParamSpec
@@ -507,6 +950,46 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: `invalid value: invalidtype`,
 			Paths:   []string{"params.param-with-invalid-type.type"},
 		},
+	}, {
+		name: "object param without alpha",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "gitrepo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `object type params requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name: "param constraints without alpha",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name: "env",
+				Type: v1beta1.ParamTypeString,
+				Enum: []string{"dev", "prod"},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `param constraints requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name: "step results without alpha",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "build", Image: "my-image"},
+				Results:   []v1beta1.StepResult{{Name: "digest"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `step results requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+			Paths:   []string{""},
+		},
 	}, {
 		name: "param mismatching default/type 1",
 		fields: fields{
@@ -956,6 +1439,32 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Paths:   []string{"results[0].name"},
 			Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
 		},
+	}, {
+		name: "array result without alpha",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1beta1.TaskResult{{
+				Name: "images",
+				Type: v1beta1.ResultsTypeArray,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `array and object results requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name: "invalid result type",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1beta1.TaskResult{{
+				Name: "images",
+				Type: "list",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: result type "list" is not one of [string array object]`,
+			Paths:   []string{"results[0].type"},
+		},
 	}, {
 		name: "context not validate",
 		fields: fields{
@@ -69,6 +69,7 @@ func (trs *TaskRunSpec) SetDefaults(ctx context.Context) {
 	if trs.TaskSpec != nil {
 		if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == "alpha" {
 			ctx = addContextParams(ctx, trs.Params)
+			ctx = addContextWorkspaceBindings(ctx, trs.Workspaces)
 		}
 		trs.TaskSpec.SetDefaults(ctx)
 	}
@@ -184,6 +184,40 @@ func TestTaskRunSpec_SetDefaults(t *testing.T) {
 			ServiceAccountName: config.DefaultServiceAccountValue,
 			Timeout:            &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
 		},
+	}, {
+		desc: "implicit workspaces",
+		ctxFn: func(ctx context.Context) context.Context {
+			cfg := config.FromContextOrDefaults(ctx)
+			cfg.FeatureFlags = &config.FeatureFlags{EnableAPIFields: "alpha"}
+			return config.ToContext(ctx, cfg)
+		},
+		trs: &v1beta1.TaskRunSpec{
+			TaskSpec: &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Container: corev1.Container{Name: "build", Image: "ubuntu"},
+					Script:    "make -C $(workspaces.source.path)",
+				}},
+			},
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name:     "source",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+		want: &v1beta1.TaskRunSpec{
+			TaskSpec: &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Container: corev1.Container{Name: "build", Image: "ubuntu"},
+					Script:    "make -C $(workspaces.source.path)",
+				}},
+				Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+			},
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name:     "source",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+			ServiceAccountName: config.DefaultServiceAccountValue,
+			Timeout:            &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
+		},
 	}}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -60,6 +60,18 @@ type TaskRunSpec struct {
 	// Workspaces is a list of WorkspaceBindings from volumes to workspaces.
 	// +optional
 	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+	// Retries represents how many times this TaskRun should be retried in case of failure
+	// +optional
+	Retries int `json:"retries,omitempty"`
+	// RetryPolicy configures which failures are retried and the delay between
+	// retries, up to the number of Retries
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// TTLSecondsAfterFinished is how long the TaskRun is kept after it completes,
+	// before it is deleted along with its Pod. Overrides the default TTL. It doesn't
+	// apply to the TaskRuns of a PipelineRun, which are deleted with the PipelineRun.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
 }
 
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
@@ -119,6 +131,9 @@ const (
 	TaskRunReasonCancelled TaskRunReason = "TaskRunCancelled"
 	// TaskRunReasonTimedOut is the reason set when the Taskrun has timed out
 	TaskRunReasonTimedOut TaskRunReason = "TaskRunTimeout"
+	// TaskRunReasonCacheHit is the reason set when the results of a previous TaskRun
+	// with the same inputs are reused instead of running the TaskRun
+	TaskRunReasonCacheHit TaskRunReason = "CacheHit"
 )
 
 func (t TaskRunReason) String() string {
@@ -258,12 +273,17 @@ func (trs *TaskRunStatus) SetCondition(newCond *apis.Condition) {
 	}
 }
 
+// StepReasonSkipped is the reason of the terminated state of a Step which was skipped because
+// its when expressions evaluated to false
+const StepReasonSkipped = "Skipped"
+
 // StepState reports the results of running a step in a Task.
 type StepState struct {
 	corev1.ContainerState `json:",inline"`
-	Name                  string `json:"name,omitempty"`
-	ContainerName         string `json:"container,omitempty"`
-	ImageID               string `json:"imageID,omitempty"`
+	Name                  string          `json:"name,omitempty"`
+	ContainerName         string          `json:"container,omitempty"`
+	ImageID               string          `json:"imageID,omitempty"`
+	Results               []TaskRunResult `json:"results,omitempty"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
@@ -383,6 +403,20 @@ func (tr *TaskRun) IsCancelled() bool {
 	return tr.Spec.Status == TaskRunSpecStatusCancelled
 }
 
+// IsRetriable returns true if the TaskRun failed after its pod was created, wasn't cancelled,
+// hasn't exhausted its retries and the reason of its failure is retried by its RetryPolicy.
+// Failures to resolve or validate the TaskRun happen before its pod is created and aren't retried.
+func (tr *TaskRun) IsRetriable() bool {
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if c == nil || !c.IsFalse() || tr.Status.PodName == "" {
+		return false
+	}
+	if tr.IsCancelled() || c.Reason == TaskRunReasonCancelled.String() {
+		return false
+	}
+	return len(tr.Status.RetriesStatus) < tr.Spec.Retries && tr.Spec.RetryPolicy.ShouldRetry(c.Reason)
+}
+
 // HasTimedOut returns true if the TaskRun runtime is beyond the allowed timeout
 func (tr *TaskRun) HasTimedOut(ctx context.Context) bool {
 	if tr.Status.StartTime.IsZero() {
@@ -135,6 +135,112 @@ func TestTaskRunIsCancelled(t *testing.T) {
 	}
 }
 
+func TestTaskRunIsRetriable(t *testing.T) {
+	failed := func(reason string) duckv1beta1.Status {
+		return duckv1beta1.Status{Conditions: []apis.Condition{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: reason,
+		}}}
+	}
+	tcs := []struct {
+		name string
+		tr   *v1beta1.TaskRun
+		want bool
+	}{{
+		name: "failed with retries left",
+		tr: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{Retries: 2},
+			Status: v1beta1.TaskRunStatus{
+				Status:              failed("Failed"),
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{PodName: "pod"},
+			},
+		},
+		want: true,
+	}, {
+		name: "running",
+		tr: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{Retries: 2},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{Conditions: []apis.Condition{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionUnknown,
+				}}},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{PodName: "pod"},
+			},
+		},
+	}, {
+		name: "failed without retries",
+		tr: &v1beta1.TaskRun{
+			Status: v1beta1.TaskRunStatus{
+				Status:              failed("Failed"),
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{PodName: "pod"},
+			},
+		},
+	}, {
+		name: "failed with retries exhausted",
+		tr: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{Retries: 1},
+			Status: v1beta1.TaskRunStatus{
+				Status: failed("Failed"),
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					PodName:       "pod",
+					RetriesStatus: []v1beta1.TaskRunStatus{{Status: failed("Failed")}},
+				},
+			},
+		},
+	}, {
+		name: "failed before the pod was created",
+		tr: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{Retries: 2},
+			Status: v1beta1.TaskRunStatus{
+				Status: failed("TaskRunValidationFailed"),
+			},
+		},
+	}, {
+		name: "cancelled",
+		tr: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{Retries: 2, Status: v1beta1.TaskRunSpecStatusCancelled},
+			Status: v1beta1.TaskRunStatus{
+				Status:              failed(v1beta1.TaskRunReasonCancelled.String()),
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{PodName: "pod"},
+			},
+		},
+	}, {
+		name: "reason retried by the retry policy",
+		tr: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{
+				Retries:     2,
+				RetryPolicy: &v1beta1.RetryPolicy{Reasons: []string{v1beta1.TaskRunReasonTimedOut.String()}},
+			},
+			Status: v1beta1.TaskRunStatus{
+				Status:              failed(v1beta1.TaskRunReasonTimedOut.String()),
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{PodName: "pod"},
+			},
+		},
+		want: true,
+	}, {
+		name: "reason not retried by the retry policy",
+		tr: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{
+				Retries:     2,
+				RetryPolicy: &v1beta1.RetryPolicy{Reasons: []string{v1beta1.TaskRunReasonTimedOut.String()}},
+			},
+			Status: v1beta1.TaskRunStatus{
+				Status:              failed("Failed"),
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{PodName: "pod"},
+			},
+		},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.tr.IsRetriable(); got != tc.want {
+				t.Errorf("IsRetriable() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestTaskRunHasVolumeClaimTemplate(t *testing.T) {
 	tr := &v1beta1.TaskRun{
 		Spec: v1beta1.TaskRunSpec{
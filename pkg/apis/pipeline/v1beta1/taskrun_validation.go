@@ -73,6 +73,7 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// Validate TaskSpec if it's present
 	if ts.TaskSpec != nil {
 		errs = errs.Also(ts.TaskSpec.Validate(ctx).ViaField("taskspec"))
+		errs = errs.Also(validateParamValuesConstraints(ts.TaskSpec.Params, ts.Params))
 	}
 
 	errs = errs.Also(validateParameters(ts.Params).ViaField("params"))
@@ -97,6 +98,16 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", ts.Timeout.Duration.String()), "timeout"))
 		}
 	}
+	if ts.Retries != 0 {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "retries", config.AlphaAPIFields).ViaField("retries"))
+		if ts.Retries < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", ts.Retries), "retries"))
+		}
+	}
+	if ts.RetryPolicy != nil {
+		errs = errs.Also(ts.RetryPolicy.validate(ctx).ViaField("retryPolicy"))
+	}
+	errs = errs.Also(validateTTLSecondsAfterFinished(ctx, ts.TTLSecondsAfterFinished))
 
 	return errs
 }
@@ -262,6 +262,76 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 		},
 		wantErr: apis.ErrInvalidValue("breakito is not a valid breakpoint. Available valid breakpoints include [onFailure]", "debug.breakpoint"),
 		wc:      enableAlphaAPIFields,
+	}, {
+		name: "param value not satisfying the constraints of the embedded taskspec",
+		spec: v1beta1.TaskRunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "env",
+				Value: *v1beta1.NewArrayOrString("staging"),
+			}},
+			TaskSpec: &v1beta1.TaskSpec{
+				Params: []v1beta1.ParamSpec{{
+					Name: "env",
+					Type: v1beta1.ParamTypeString,
+					Enum: []string{"dev", "prod"},
+				}},
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Name:  "mystep",
+					Image: "myimage",
+				}}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue(`value "staging" is not one of the allowed values [dev prod]`, "params[env].value"),
+		wc:      enableAlphaAPIFields,
+	}, {
+		name: "retries when apifields stable",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "my-task",
+			},
+			Retries: 2,
+		},
+		wantErr: apis.ErrGeneric(`retries requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaField("retries"),
+	}, {
+		name: "negative retries",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "my-task",
+			},
+			Retries: -1,
+		},
+		wantErr: apis.ErrInvalidValue("-1 should be >= 0", "retries"),
+		wc:      enableAlphaAPIFields,
+	}, {
+		name: "invalid retry policy",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "my-task",
+			},
+			Retries:     2,
+			RetryPolicy: &v1beta1.RetryPolicy{Backoff: &metav1.Duration{Duration: -time.Second}},
+		},
+		wantErr: apis.ErrInvalidValue("-1s should be >= 0", "retryPolicy.backoff"),
+		wc:      enableAlphaAPIFields,
+	}, {
+		name: "ttlSecondsAfterFinished when apifields stable",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "my-task",
+			},
+			TTLSecondsAfterFinished: int32Ptr(60),
+		},
+		wantErr: apis.ErrGeneric(`ttlSecondsAfterFinished requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaField("ttlSecondsAfterFinished"),
+	}, {
+		name: "negative ttlSecondsAfterFinished",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "my-task",
+			},
+			TTLSecondsAfterFinished: int32Ptr(-60),
+		},
+		wantErr: apis.ErrInvalidValue("-60 should be >= 0", "ttlSecondsAfterFinished"),
+		wc:      enableAlphaAPIFields,
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
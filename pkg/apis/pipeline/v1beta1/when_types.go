@@ -18,6 +18,8 @@ package v1beta1
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"k8s.io/apimachinery/pkg/selection"
@@ -27,14 +29,23 @@ import (
 // to determine whether the Task should be executed or skipped
 type WhenExpression struct {
 	// Input is the string for guard checking which can be a static input or an output from a parent Task
-	Input string `json:"input"`
+	// +optional
+	Input string `json:"input,omitempty"`
 
 	// Operator that represents an Input's relationship to the values
-	Operator selection.Operator `json:"operator"`
+	// +optional
+	Operator selection.Operator `json:"operator,omitempty"`
 
 	// Values is an array of strings, which is compared against the input, for guard checking
-	// It must be non-empty
-	Values []string `json:"values"`
+	// It must be non-empty when Input and Operator are used
+	// +optional
+	Values []string `json:"values,omitempty"`
+
+	// CEL is a Common Expression Language expression which must evaluate to a boolean; it is
+	// evaluated after the variables, such as Parameters and Results, are substituted in it as string literals.
+	// It can't be combined with Input, Operator and Values.
+	// +optional
+	CEL string `json:"cel,omitempty"`
 }
 
 func (we *WhenExpression) isInputInValues() bool {
@@ -46,7 +57,10 @@ func (we *WhenExpression) isInputInValues() bool {
 	return false
 }
 
-func (we *WhenExpression) isTrue() bool {
+func (we *WhenExpression) isTrue(evaluatedCEL map[string]bool) bool {
+	if we.CEL != "" {
+		return evaluatedCEL[we.CEL]
+	}
 	if we.Operator == selection.In {
 		return we.isInputInValues()
 	}
@@ -61,15 +75,34 @@ func (we *WhenExpression) applyReplacements(replacements map[string]string, arra
 	for _, val := range we.Values {
 		// arrayReplacements holds a list of array parameters with a pattern - params.arrayParam1
 		// array params are referenced using $(params.arrayParam1[*])
-		// check if the param exist in the arrayReplacements to replace it with a list of values
-		if _, ok := arrayReplacements[fmt.Sprintf("%s.%s", ParamsPrefix, ArrayReference(val))]; ok {
+		// array results are referenced using $(tasks.task1.results.arrayResult1[*]) and held as is
+		// check if the param or result exist in the arrayReplacements to replace it with a list of values
+		_, isArrayParam := arrayReplacements[fmt.Sprintf("%s.%s", ParamsPrefix, ArrayReference(val))]
+		_, isArrayResult := arrayReplacements[stripVarSubExpression(val)]
+		if isArrayParam || isArrayResult {
 			replacedValues = append(replacedValues, substitution.ApplyArrayReplacements(val, replacements, arrayReplacements)...)
 		} else {
 			replacedValues = append(replacedValues, substitution.ApplyReplacements(val, replacements))
 		}
 	}
 
-	return WhenExpression{Input: replacedInput, Operator: we.Operator, Values: replacedValues}
+	var replacedCEL string
+	if we.CEL != "" {
+		replacedCEL = substitution.ApplyReplacements(we.CEL, celStringLiterals(replacements))
+	}
+
+	return WhenExpression{Input: replacedInput, Operator: we.Operator, Values: replacedValues, CEL: replacedCEL}
+}
+
+// celStringLiterals returns the replacements as CEL string literals, so that substituting them in a CEL
+// expression can't change the expression itself. "$" is escaped as well so that the variables in the
+// values aren't substituted in turn.
+func celStringLiterals(replacements map[string]string) map[string]string {
+	literals := make(map[string]string, len(replacements))
+	for k, v := range replacements {
+		literals[k] = strings.ReplaceAll(strconv.Quote(v), "$", `\x24`)
+	}
+	return literals
 }
 
 // GetVarSubstitutionExpressions extracts all the values between "$(" and ")" in a When Expression
@@ -79,6 +112,7 @@ func (we *WhenExpression) GetVarSubstitutionExpressions() ([]string, bool) {
 	for _, value := range we.Values {
 		allExpressions = append(allExpressions, validateString(value)...)
 	}
+	allExpressions = append(allExpressions, validateString(we.CEL)...)
 	return allExpressions, len(allExpressions) != 0
 }
 
@@ -88,10 +122,11 @@ type WhenExpressions []WhenExpression
 
 // AllowsExecution evaluates an Input's relationship to an array of Values, based on the Operator,
 // to determine whether all the When Expressions are True. If they are all True, the guarded Task is
-// executed, otherwise it is skipped.
-func (wes WhenExpressions) AllowsExecution() bool {
+// executed, otherwise it is skipped. The When Expressions using CEL are looked up in evaluatedCEL,
+// which maps each CEL expression to the result of its evaluation; a missing CEL expression is False.
+func (wes WhenExpressions) AllowsExecution(evaluatedCEL map[string]bool) bool {
 	for _, we := range wes {
-		if !we.isTrue() {
+		if !we.isTrue(evaluatedCEL) {
 			return false
 		}
 	}
@@ -99,7 +134,7 @@ func (wes WhenExpressions) AllowsExecution() bool {
 }
 
 // ReplaceWhenExpressionsVariables interpolates variables, such as Parameters and Results, in
-// the Input and Values.
+// the Input, Values and CEL.
 func (wes WhenExpressions) ReplaceWhenExpressionsVariables(replacements map[string]string, arrayReplacements map[string][]string) WhenExpressions {
 	replaced := wes
 	for i := range wes {
@@ -28,6 +28,7 @@ func TestAllowsExecution(t *testing.T) {
 	tests := []struct {
 		name            string
 		whenExpressions WhenExpressions
+		evaluatedCEL    map[string]bool
 		expected        bool
 	}{{
 		name: "in expression",
@@ -77,10 +78,44 @@ func TestAllowsExecution(t *testing.T) {
 			},
 		},
 		expected: true,
+	}, {
+		name: "cel expression - true",
+		whenExpressions: WhenExpressions{
+			{
+				CEL: "'main' == 'main'",
+			},
+		},
+		evaluatedCEL: map[string]bool{
+			"'main' == 'main'": true,
+		},
+		expected: true,
+	}, {
+		name: "cel and in expressions - false",
+		whenExpressions: WhenExpressions{
+			{
+				Input:    "foo",
+				Operator: selection.In,
+				Values:   []string{"foo"},
+			}, {
+				CEL: "'1.2.0' >= '1.3.0'",
+			},
+		},
+		evaluatedCEL: map[string]bool{
+			"'1.2.0' >= '1.3.0'": false,
+		},
+		expected: false,
+	}, {
+		name: "cel expression not evaluated",
+		whenExpressions: WhenExpressions{
+			{
+				CEL: "'main' == 'main'",
+			},
+		},
+		expected: false,
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := tc.whenExpressions.AllowsExecution()
+			got := tc.whenExpressions.AllowsExecution(tc.evaluatedCEL)
 			if d := cmp.Diff(tc.expected, got); d != "" {
 				t.Errorf("Error evaluating AllowsExecution() for When Expressions in test case %s", diff.PrintWantGot(d))
 			}
@@ -90,10 +125,11 @@ func TestAllowsExecution(t *testing.T) {
 
 func TestReplaceWhenExpressionsVariables(t *testing.T) {
 	tests := []struct {
-		name            string
-		whenExpressions WhenExpressions
-		replacements    map[string]string
-		expected        WhenExpressions
+		name              string
+		whenExpressions   WhenExpressions
+		replacements      map[string]string
+		arrayReplacements map[string][]string
+		expected          WhenExpressions
 	}{{
 		name: "params replacement in input",
 		whenExpressions: WhenExpressions{
@@ -198,10 +234,64 @@ func TestReplaceWhenExpressionsVariables(t *testing.T) {
 				Values:   []string{"bar"},
 			},
 		},
+	}, {
+		name: "array results replacement in values",
+		whenExpressions: WhenExpressions{
+			{
+				Input:    "$(tasks.aTask.results.foo)",
+				Operator: selection.In,
+				Values:   []string{"$(tasks.aTask.results.platforms[*])", "windows"},
+			},
+		},
+		replacements: map[string]string{
+			"tasks.aTask.results.foo": "linux",
+		},
+		arrayReplacements: map[string][]string{
+			"tasks.aTask.results.platforms[*]": {"linux", "mac"},
+		},
+		expected: WhenExpressions{
+			{
+				Input:    "linux",
+				Operator: selection.In,
+				Values:   []string{"linux", "mac", "windows"},
+			},
+		},
+	}, {
+		name: "params and results replacement in cel",
+		whenExpressions: WhenExpressions{
+			{
+				CEL: "$(params.branch).matches('release-.*') && $(tasks.aTask.results.foo) == 'linux'",
+			},
+		},
+		replacements: map[string]string{
+			"params.branch":           "release-1.2",
+			"tasks.aTask.results.foo": "linux",
+		},
+		expected: WhenExpressions{
+			{
+				CEL: `"release-1.2".matches('release-.*') && "linux" == 'linux'`,
+			},
+		},
+	}, {
+		name: "values are escaped in cel",
+		whenExpressions: WhenExpressions{
+			{
+				CEL: "$(params.branch) == 'main' && $(tasks.aTask.results.foo) == 'linux'",
+			},
+		},
+		replacements: map[string]string{
+			"params.branch":           `main" || "a" == "a`,
+			"tasks.aTask.results.foo": "$(params.branch)",
+		},
+		expected: WhenExpressions{
+			{
+				CEL: `"main\" || \"a\" == \"a" == 'main' && "\x24(params.branch)" == 'linux'`,
+			},
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := tc.whenExpressions.ReplaceWhenExpressionsVariables(tc.replacements, nil)
+			got := tc.whenExpressions.ReplaceWhenExpressionsVariables(tc.replacements, tc.arrayReplacements)
 			if d := cmp.Diff(tc.expected, got); d != "" {
 				t.Errorf("Error evaluating When Expressions in test case %s", diff.PrintWantGot(d))
 			}
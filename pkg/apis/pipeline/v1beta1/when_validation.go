@@ -17,9 +17,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -31,22 +35,25 @@ var validWhenOperators = []string{
 	string(selection.NotIn),
 }
 
-func (wes WhenExpressions) validate() *apis.FieldError {
-	errs := wes.validateWhenExpressionsFields().ViaField("when")
+func (wes WhenExpressions) validate(ctx context.Context) *apis.FieldError {
+	errs := wes.validateWhenExpressionsFields(ctx).ViaField("when")
 	return errs.Also(wes.validateTaskResultsVariables().ViaField("when"))
 }
 
-func (wes WhenExpressions) validateWhenExpressionsFields() (errs *apis.FieldError) {
+func (wes WhenExpressions) validateWhenExpressionsFields(ctx context.Context) (errs *apis.FieldError) {
 	for idx, we := range wes {
-		errs = errs.Also(we.validateWhenExpressionFields().ViaIndex(idx))
+		errs = errs.Also(we.validateWhenExpressionFields(ctx).ViaIndex(idx))
 	}
 	return errs
 }
 
-func (we *WhenExpression) validateWhenExpressionFields() *apis.FieldError {
+func (we *WhenExpression) validateWhenExpressionFields(ctx context.Context) *apis.FieldError {
 	if equality.Semantic.DeepEqual(we, &WhenExpression{}) || we == nil {
 		return apis.ErrMissingField(apis.CurrentField)
 	}
+	if we.CEL != "" {
+		return we.validateCEL(ctx)
+	}
 	if !sets.NewString(validWhenOperators...).Has(string(we.Operator)) {
 		message := fmt.Sprintf("operator %q is not recognized. valid operators: %s", we.Operator, strings.Join(validWhenOperators, ","))
 		return apis.ErrInvalidValue(message, apis.CurrentField)
@@ -57,6 +64,43 @@ func (we *WhenExpression) validateWhenExpressionFields() *apis.FieldError {
 	return nil
 }
 
+// validateCEL ensures that the CEL expression is not combined with Input, Operator and Values, and that it
+// compiles; the variables are not substituted at this point, so they are compiled as empty strings
+func (we *WhenExpression) validateCEL(ctx context.Context) *apis.FieldError {
+	errs := ValidateEnabledAPIFields(ctx, "when expressions cel", config.AlphaAPIFields)
+	if we.Input != "" || we.Operator != "" || len(we.Values) != 0 {
+		errs = errs.Also(apis.ErrMultipleOneOf("cel", "input", "operator", "values"))
+	}
+	if _, err := CompileCEL(we.CEL); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(err.Error(), "cel"))
+	}
+	return errs
+}
+
+// celEnv is the environment CEL expressions are compiled in. It declares no variables, given that
+// the variables are substituted in the expressions as string literals.
+var celEnv = func() *cel.Env {
+	env, err := cel.NewEnv()
+	if err != nil {
+		panic(fmt.Sprintf("failed to create the CEL environment: %v", err))
+	}
+	return env
+}()
+
+// CompileCEL parses and checks the CEL expression, and returns the program evaluating it. It returns an
+// error if the expression is invalid or if it doesn't evaluate to a boolean. The variables which are
+// not substituted yet are compiled as empty strings.
+func CompileCEL(expression string) (cel.Program, error) {
+	ast, iss := celEnv.Compile(variableSubstitutionRegex.ReplaceAllString(expression, `""`))
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("invalid cel expression %q: %w", expression, iss.Err())
+	}
+	if ast.ResultType().GetPrimitive() != decls.Bool.GetPrimitive() {
+		return nil, fmt.Errorf("cel expression %q must evaluate to a boolean", expression)
+	}
+	return celEnv.Program(ast)
+}
+
 func (wes WhenExpressions) validateTaskResultsVariables() *apis.FieldError {
 	for idx, we := range wes {
 		expressions, ok := we.GetVarSubstitutionExpressions()
@@ -77,6 +121,7 @@ func (wes WhenExpressions) validateTaskResultsVariables() *apis.FieldError {
 func (wes WhenExpressions) validatePipelineParametersVariables(prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
 	for idx, we := range wes {
 		errs = errs.Also(validateStringVariable(we.Input, prefix, paramNames, arrayParamNames).ViaField("input").ViaFieldIndex("when", idx))
+		errs = errs.Also(validateStringVariable(we.CEL, prefix, paramNames, arrayParamNames).ViaField("cel").ViaFieldIndex("when", idx))
 		for _, val := range we.Values {
 			// one of the values could be a reference to an array param, such as, $(params.foo[*])
 			// extract the variable name from the pattern $(params.foo[*]), if the variable name matches with one of the array params
@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/selection"
@@ -54,7 +56,7 @@ func TestWhenExpressions_Valid(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.wes.validate(); err != nil {
+			if err := tt.wes.validate(context.Background()); err != nil {
 				t.Errorf("WhenExpressions.validate() returned an error for valid when expressions: %s", tt.wes)
 			}
 		})
@@ -104,9 +106,75 @@ func TestWhenExpressions_Invalid(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.wes.validate(); err == nil {
+			if err := tt.wes.validate(context.Background()); err == nil {
 				t.Errorf("WhenExpressions.validate() did not return error for invalid when expressions: %s, %s", tt.wes, err)
 			}
 		})
 	}
 }
+
+func TestWhenExpressions_CEL(t *testing.T) {
+	tests := []struct {
+		name    string
+		wes     WhenExpressions
+		wc      func(context.Context) context.Context
+		wantErr string
+	}{{
+		name: "valid cel expression",
+		wes: []WhenExpression{{
+			CEL: "$(params.branch).matches('release-.*') || $(tasks.a-task.results.version) >= '1.2'",
+		}},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "cel expression without alpha api fields",
+		wes: []WhenExpression{{
+			CEL: "$(params.branch) == 'main'",
+		}},
+		wantErr: `when expressions cel requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+	}, {
+		name: "cel expression combined with input, operator and values",
+		wes: []WhenExpression{{
+			Input:    "foo",
+			Operator: selection.In,
+			Values:   []string{"foo"},
+			CEL:      "$(params.branch) == 'main'",
+		}},
+		wc:      enableAlphaAPIFields,
+		wantErr: "expected exactly one, got both: when[0].cel, when[0].input, when[0].operator, when[0].values",
+	}, {
+		name: "cel expression which doesn't compile",
+		wes: []WhenExpression{{
+			CEL: "$(params.branch) == 'main' &&",
+		}},
+		wc:      enableAlphaAPIFields,
+		wantErr: "invalid value: invalid cel expression",
+	}, {
+		name: "cel expression which doesn't evaluate to a boolean",
+		wes: []WhenExpression{{
+			CEL: "$(params.branch) + '-suffix'",
+		}},
+		wc:      enableAlphaAPIFields,
+		wantErr: `invalid value: cel expression "$(params.branch) + '-suffix'" must evaluate to a boolean: when[0].cel`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			err := tt.wes.validate(ctx)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("WhenExpressions.validate() returned an error for valid when expressions: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("WhenExpressions.validate() did not return error for invalid when expressions: %s", tt.wes)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("WhenExpressions.validate() returned %q, expected it to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
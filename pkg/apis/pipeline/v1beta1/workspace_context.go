@@ -0,0 +1,180 @@
+// Copyright 2021 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// workspaceCtxKey is the unique identifier for referencing workspace information
+// from a context.Context. It is a distinct type, so that it doesn't collide with
+// paramCtxKey. See [context.Context.Value](https://pkg.go.dev/context#Context)
+// for more details.
+type workspaceCtxKey struct{}
+
+// workspaceCtxVal is the data type stored in the workspace context.
+// This maps workspace names -> PipelineWorkspaceDeclaration.
+type workspaceCtxVal map[string]PipelineWorkspaceDeclaration
+
+// workspaceRefRegex matches the workspace variables, e.g. $(workspaces.<name>.path)
+var workspaceRefRegex = regexp.MustCompile(`\$\(workspaces\.([^.)]+)\.[^)]+\)`)
+
+// addContextWorkspaceBindings adds the given WorkspaceBindings to the workspace
+// context, as required workspaces.
+func addContextWorkspaceBindings(ctx context.Context, in []WorkspaceBinding) context.Context {
+	declarations := make([]PipelineWorkspaceDeclaration, 0, len(in))
+	for _, wb := range in {
+		declarations = append(declarations, PipelineWorkspaceDeclaration{Name: wb.Name})
+	}
+	return addContextWorkspaces(ctx, declarations)
+}
+
+// addContextWorkspaces adds the given PipelineWorkspaceDeclarations to the
+// workspace context.
+func addContextWorkspaces(ctx context.Context, in []PipelineWorkspaceDeclaration) context.Context {
+	if in == nil {
+		return ctx
+	}
+
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields != "alpha" {
+		return ctx
+	}
+
+	out := workspaceCtxVal{}
+	// Copy map to ensure that contexts are unique.
+	v := ctx.Value(workspaceCtxKey{})
+	if v != nil {
+		for n, ws := range v.(workspaceCtxVal) {
+			out[n] = ws
+		}
+	}
+	for _, ws := range in {
+		out[ws.Name] = ws
+	}
+	return context.WithValue(ctx, workspaceCtxKey{}, out)
+}
+
+// getContextWorkspace returns the workspace of the given name from the workspace
+// context, if any.
+func getContextWorkspace(ctx context.Context, name string) (PipelineWorkspaceDeclaration, bool) {
+	v := ctx.Value(workspaceCtxKey{})
+	if v == nil {
+		return PipelineWorkspaceDeclaration{}, false
+	}
+	ws, ok := v.(workspaceCtxVal)[name]
+	return ws, ok
+}
+
+// referencedWorkspaces returns the sorted names of the workspaces used by the
+// Steps and Sidecars of the TaskSpec, either through their workspaces or
+// through $(workspaces.<name>.*) variables.
+func (ts *TaskSpec) referencedWorkspaces() []string {
+	names := sets.NewString()
+	addRefs := func(values ...string) {
+		for _, value := range values {
+			for _, match := range workspaceRefRegex.FindAllStringSubmatch(value, -1) {
+				names.Insert(match[1])
+			}
+		}
+	}
+	addStep := func(s Step) {
+		addRefs(s.Image, s.WorkingDir, s.Script)
+		addRefs(s.Command...)
+		addRefs(s.Args...)
+		for _, env := range s.Env {
+			addRefs(env.Value)
+		}
+		for _, w := range s.Workspaces {
+			names.Insert(w.Name)
+		}
+	}
+	for _, s := range ts.Steps {
+		addStep(s)
+	}
+	for _, s := range ts.Sidecars {
+		addStep(Step{Container: s.Container, Script: s.Script, Workspaces: s.Workspaces})
+	}
+	return names.List()
+}
+
+// boundWorkspaces returns the sorted names of the Pipeline workspaces bound by
+// the Tasks and Finally of the PipelineSpec.
+func (ps *PipelineSpec) boundWorkspaces() []string {
+	names := sets.NewString()
+	for _, pt := range append(append([]PipelineTask{}, ps.Tasks...), ps.Finally...) {
+		for _, w := range pt.Workspaces {
+			names.Insert(w.Workspace)
+		}
+	}
+	return names.List()
+}
+
+// propagateWorkspaces declares the workspaces of the context which are used by
+// the TaskSpec but not declared by it.
+func (ts *TaskSpec) propagateWorkspaces(ctx context.Context) {
+	declared := sets.NewString()
+	for _, w := range ts.Workspaces {
+		declared.Insert(w.Name)
+	}
+	for _, name := range ts.referencedWorkspaces() {
+		if ws, ok := getContextWorkspace(ctx, name); ok && !declared.Has(name) {
+			ts.Workspaces = append(ts.Workspaces, WorkspaceDeclaration{Name: ws.Name, Optional: ws.Optional})
+		}
+	}
+}
+
+// propagateWorkspaces declares the workspaces of the context which are bound by
+// the PipelineTasks of the PipelineSpec but not declared by it.
+func (ps *PipelineSpec) propagateWorkspaces(ctx context.Context) {
+	declared := sets.NewString()
+	for _, w := range ps.Workspaces {
+		declared.Insert(w.Name)
+	}
+	for _, name := range ps.boundWorkspaces() {
+		if ws, ok := getContextWorkspace(ctx, name); ok && !declared.Has(name) {
+			ps.Workspaces = append(ps.Workspaces, ws)
+		}
+	}
+}
+
+// propagateWorkspaces binds the workspaces declared by the embedded TaskSpec or
+// PipelineSpec of the PipelineTask to the workspaces of the same name in the
+// context, unless the PipelineTask already binds them.
+func (pt *PipelineTask) propagateWorkspaces(ctx context.Context) {
+	var declared []string
+	switch {
+	case pt.TaskSpec != nil:
+		for _, w := range pt.TaskSpec.Workspaces {
+			declared = append(declared, w.Name)
+		}
+	case pt.PipelineSpec != nil:
+		for _, w := range pt.PipelineSpec.Workspaces {
+			declared = append(declared, w.Name)
+		}
+	}
+	bound := sets.NewString()
+	for _, w := range pt.Workspaces {
+		bound.Insert(w.Name)
+	}
+	for _, name := range declared {
+		if _, ok := getContextWorkspace(ctx, name); ok && !bound.Has(name) {
+			pt.Workspaces = append(pt.Workspaces, WorkspacePipelineTaskBinding{Name: name, Workspace: name})
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAddContextWorkspaces(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no-alpha", func(t *testing.T) {
+		ctx := addContextWorkspaces(ctx, []PipelineWorkspaceDeclaration{{Name: "a"}})
+		if v := ctx.Value(workspaceCtxKey{}); v != nil {
+			t.Errorf("expected no workspace context values, got %v", v)
+		}
+	})
+
+	// Enable Alpha features.
+	cfg := config.FromContextOrDefaults(ctx)
+	cfg.FeatureFlags = &config.FeatureFlags{EnableAPIFields: "alpha"}
+	ctx = config.ToContext(ctx, cfg)
+
+	ctx = addContextWorkspaceBindings(ctx, []WorkspaceBinding{{Name: "a"}, {Name: "b"}})
+	ctx = addContextWorkspaces(ctx, []PipelineWorkspaceDeclaration{{Name: "b", Optional: true}})
+	want := workspaceCtxVal{
+		"a": {Name: "a"},
+		"b": {Name: "b", Optional: true},
+	}
+	if d := cmp.Diff(want, ctx.Value(workspaceCtxKey{})); d != "" {
+		t.Error(d)
+	}
+	if v := ctx.Value(paramCtxKey); v != nil {
+		t.Errorf("expected no param context values, got %v", v)
+	}
+}
+
+func TestTaskSpec_ReferencedWorkspaces(t *testing.T) {
+	ts := &TaskSpec{
+		Steps: []Step{{
+			Container: corev1.Container{
+				Args: []string{"--output=$(workspaces.output.path)"},
+				Env:  []corev1.EnvVar{{Name: "CACHE_BOUND", Value: "$(workspaces.cache.bound)"}},
+			},
+			Script:     "cat $(workspaces.source.path)/README.md",
+			Workspaces: []WorkspaceUsage{{Name: "creds", MountPath: "/creds"}},
+		}},
+		Sidecars: []Sidecar{{
+			Container: corev1.Container{WorkingDir: "$(workspaces.source.path)"},
+		}},
+	}
+	want := []string{"cache", "creds", "output", "source"}
+	if d := cmp.Diff(want, ts.referencedWorkspaces()); d != "" {
+		t.Error(d)
+	}
+}
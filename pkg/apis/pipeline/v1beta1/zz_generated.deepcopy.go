@@ -24,11 +24,75 @@ import (
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	apis "knative.dev/pkg/apis"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Approval) DeepCopyInto(out *Approval) {
+	*out = *in
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]Approver, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Approval.
+func (in *Approval) DeepCopy() *Approval {
+	if in == nil {
+		return nil
+	}
+	out := new(Approval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalDecision) DeepCopyInto(out *ApprovalDecision) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalDecision.
+func (in *ApprovalDecision) DeepCopy() *ApprovalDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Approver) DeepCopyInto(out *Approver) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Approver.
+func (in *Approver) DeepCopy() *Approver {
+	if in == nil {
+		return nil
+	}
+	out := new(Approver)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArrayOrString) DeepCopyInto(out *ArrayOrString) {
 	*out = *in
@@ -37,6 +101,13 @@ func (in *ArrayOrString) DeepCopyInto(out *ArrayOrString) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ObjectVal != nil {
+		in, out := &in.ObjectVal, &out.ObjectVal
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -50,6 +121,32 @@ func (in *ArrayOrString) DeepCopy() *ArrayOrString {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cache) DeepCopyInto(out *Cache) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cache.
+func (in *Cache) DeepCopy() *Cache {
+	if in == nil {
+		return nil
+	}
+	out := new(Cache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudEventDelivery) DeepCopyInto(out *CloudEventDelivery) {
 	*out = *in
@@ -249,7 +346,7 @@ func (in *InternalTaskModifier) DeepCopyInto(out *InternalTaskModifier) {
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -267,6 +364,34 @@ func (in *InternalTaskModifier) DeepCopy() *InternalTaskModifier {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Iterate) DeepCopyInto(out *Iterate) {
+	*out = *in
+	if in.Until != nil {
+		in, out := &in.Until, &out.Until
+		*out = make([]WhenExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iterate.
+func (in *Iterate) DeepCopy() *Iterate {
+	if in == nil {
+		return nil
+	}
+	out := new(Iterate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Param) DeepCopyInto(out *Param) {
 	*out = *in
@@ -287,11 +412,33 @@ func (in *Param) DeepCopy() *Param {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParamSpec) DeepCopyInto(out *ParamSpec) {
 	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]PropertySpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Default != nil {
 		in, out := &in.Default, &out.Default
 		*out = new(ArrayOrString)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(float64)
+		**out = **in
+	}
 	return
 }
 
@@ -504,6 +651,34 @@ func (in *PipelineRun) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunChildPipelineRunStatus) DeepCopyInto(out *PipelineRunChildPipelineRunStatus) {
+	*out = *in
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(apis.Condition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WhenExpressions != nil {
+		in, out := &in.WhenExpressions, &out.WhenExpressions
+		*out = make([]WhenExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunChildPipelineRunStatus.
+func (in *PipelineRunChildPipelineRunStatus) DeepCopy() *PipelineRunChildPipelineRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunChildPipelineRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunConditionCheckStatus) DeepCopyInto(out *PipelineRunConditionCheckStatus) {
 	*out = *in
@@ -558,6 +733,27 @@ func (in *PipelineRunList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunRerun) DeepCopyInto(out *PipelineRunRerun) {
+	*out = *in
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunRerun.
+func (in *PipelineRunRerun) DeepCopy() *PipelineRunRerun {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunRerun)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunResult) DeepCopyInto(out *PipelineRunResult) {
 	*out = *in
@@ -582,6 +778,13 @@ func (in *PipelineRunRunStatus) DeepCopyInto(out *PipelineRunRunStatus) {
 		*out = new(runv1alpha1.RunStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MatrixParams != nil {
+		in, out := &in.MatrixParams, &out.MatrixParams
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.WhenExpressions != nil {
 		in, out := &in.WhenExpressions, &out.WhenExpressions
 		*out = make([]WhenExpression, len(*in))
@@ -641,7 +844,7 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.PodTemplate != nil {
@@ -663,6 +866,28 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rerun != nil {
+		in, out := &in.Rerun, &out.Rerun
+		*out = new(PipelineRunRerun)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Approvals != nil {
+		in, out := &in.Approvals, &out.Approvals
+		*out = make([]ApprovalDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TaskStatuses != nil {
+		in, out := &in.TaskStatuses, &out.TaskStatuses
+		*out = make([]PipelineRunTaskSpecStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -751,6 +976,21 @@ func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.ChildPipelineRuns != nil {
+		in, out := &in.ChildPipelineRuns, &out.ChildPipelineRuns
+		*out = make(map[string]*PipelineRunChildPipelineRunStatus, len(*in))
+		for key, val := range *in {
+			var outVal *PipelineRunChildPipelineRunStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PipelineRunChildPipelineRunStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.PipelineResults != nil {
 		in, out := &in.PipelineResults, &out.PipelineResults
 		*out = make([]PipelineRunResult, len(*in))
@@ -768,6 +1008,18 @@ func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReusedTasks != nil {
+		in, out := &in.ReusedTasks, &out.ReusedTasks
+		*out = make([]ReusedTask, len(*in))
+		copy(*out, *in)
+	}
+	if in.Approvals != nil {
+		in, out := &in.Approvals, &out.Approvals
+		*out = make([]PipelineTaskApprovalStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -804,6 +1056,13 @@ func (in *PipelineRunTaskRunStatus) DeepCopyInto(out *PipelineRunTaskRunStatus)
 			(*out)[key] = outVal
 		}
 	}
+	if in.MatrixParams != nil {
+		in, out := &in.MatrixParams, &out.MatrixParams
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.WhenExpressions != nil {
 		in, out := &in.WhenExpressions, &out.WhenExpressions
 		*out = make([]WhenExpression, len(*in))
@@ -824,6 +1083,22 @@ func (in *PipelineRunTaskRunStatus) DeepCopy() *PipelineRunTaskRunStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunTaskSpecStatus) DeepCopyInto(out *PipelineRunTaskSpecStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunTaskSpecStatus.
+func (in *PipelineRunTaskSpecStatus) DeepCopy() *PipelineRunTaskSpecStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunTaskSpecStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 	*out = *in
@@ -889,6 +1164,16 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 		*out = new(EmbeddedTask)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PipelineRef != nil {
+		in, out := &in.PipelineRef, &out.PipelineRef
+		*out = new(PipelineRef)
+		**out = **in
+	}
+	if in.PipelineSpec != nil {
+		in, out := &in.PipelineSpec, &out.PipelineSpec
+		*out = new(PipelineSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]PipelineTaskCondition, len(*in))
@@ -898,11 +1183,16 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 	}
 	if in.WhenExpressions != nil {
 		in, out := &in.WhenExpressions, &out.WhenExpressions
-		*out = make(WhenExpressions, len(*in))
+		*out = make([]WhenExpression, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RunAfter != nil {
 		in, out := &in.RunAfter, &out.RunAfter
 		*out = make([]string, len(*in))
@@ -920,6 +1210,13 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Workspaces != nil {
 		in, out := &in.Workspaces, &out.Workspaces
 		*out = make([]WorkspacePipelineTaskBinding, len(*in))
@@ -927,9 +1224,24 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(Cache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(Approval)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Iterate != nil {
+		in, out := &in.Iterate, &out.Iterate
+		*out = new(Iterate)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -943,6 +1255,30 @@ func (in *PipelineTask) DeepCopy() *PipelineTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskApprovalStatus) DeepCopyInto(out *PipelineTaskApprovalStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskApprovalStatus.
+func (in *PipelineTaskApprovalStatus) DeepCopy() *PipelineTaskApprovalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskApprovalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineTaskCondition) DeepCopyInto(out *PipelineTaskCondition) {
 	*out = *in
@@ -1159,6 +1495,22 @@ func (in *PipelineWorkspaceDeclaration) DeepCopy() *PipelineWorkspaceDeclaration
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropertySpec) DeepCopyInto(out *PropertySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertySpec.
+func (in *PropertySpec) DeepCopy() *PropertySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PropertySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResultRef) DeepCopyInto(out *ResultRef) {
 	*out = *in
@@ -1175,6 +1527,53 @@ func (in *ResultRef) DeepCopy() *ResultRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReusedTask) DeepCopyInto(out *ReusedTask) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReusedTask.
+func (in *ReusedTask) DeepCopy() *ReusedTask {
+	if in == nil {
+		return nil
+	}
+	out := new(ReusedTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Sidecar) DeepCopyInto(out *Sidecar) {
 	*out = *in
@@ -1243,7 +1642,7 @@ func (in *Step) DeepCopyInto(out *Step) {
 	in.Container.DeepCopyInto(&out.Container)
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.Workspaces != nil {
@@ -1251,6 +1650,18 @@ func (in *Step) DeepCopyInto(out *Step) {
 		*out = make([]WorkspaceUsage, len(*in))
 		copy(*out, *in)
 	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]StepResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.When != nil {
+		in, out := &in.When, &out.When
+		*out = make([]WhenExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1264,10 +1675,47 @@ func (in *Step) DeepCopy() *Step {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepResult) DeepCopyInto(out *StepResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepResult.
+func (in *StepResult) DeepCopy() *StepResult {
+	if in == nil {
+		return nil
+	}
+	out := new(StepResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepResultRef) DeepCopyInto(out *StepResultRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepResultRef.
+func (in *StepResultRef) DeepCopy() *StepResultRef {
+	if in == nil {
+		return nil
+	}
+	out := new(StepResultRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepState) DeepCopyInto(out *StepState) {
 	*out = *in
 	in.ContainerState.DeepCopyInto(&out.ContainerState)
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]TaskRunResult, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1651,7 +2099,7 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.PodTemplate != nil {
@@ -1666,6 +2114,16 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -1790,14 +2248,14 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.StepTemplate != nil {
 		in, out := &in.StepTemplate, &out.StepTemplate
-		*out = new(v1.Container)
+		*out = new(corev1.Container)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Sidecars != nil {
@@ -1835,17 +2293,17 @@ func (in *TimeoutFields) DeepCopyInto(out *TimeoutFields) {
 	*out = *in
 	if in.Pipeline != nil {
 		in, out := &in.Pipeline, &out.Pipeline
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.Tasks != nil {
 		in, out := &in.Tasks, &out.Tasks
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.Finally != nil {
 		in, out := &in.Finally, &out.Finally
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	return
@@ -1886,7 +2344,7 @@ func (in *WhenExpression) DeepCopy() *WhenExpression {
 func (in WhenExpressions) DeepCopyInto(out *WhenExpressions) {
 	{
 		in := &in
-		*out = make(WhenExpressions, len(*in))
+		*out = make([]WhenExpression, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1909,27 +2367,27 @@ func (in *WorkspaceBinding) DeepCopyInto(out *WorkspaceBinding) {
 	*out = *in
 	if in.VolumeClaimTemplate != nil {
 		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
-		*out = new(v1.PersistentVolumeClaim)
+		*out = new(corev1.PersistentVolumeClaim)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PersistentVolumeClaim != nil {
 		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
-		*out = new(v1.PersistentVolumeClaimVolumeSource)
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
 		**out = **in
 	}
 	if in.EmptyDir != nil {
 		in, out := &in.EmptyDir, &out.EmptyDir
-		*out = new(v1.EmptyDirVolumeSource)
+		*out = new(corev1.EmptyDirVolumeSource)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(v1.ConfigMapVolumeSource)
+		*out = new(corev1.ConfigMapVolumeSource)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Secret != nil {
 		in, out := &in.Secret, &out.Secret
-		*out = new(v1.SecretVolumeSource)
+		*out = new(corev1.SecretVolumeSource)
 		(*in).DeepCopyInto(*out)
 	}
 	return
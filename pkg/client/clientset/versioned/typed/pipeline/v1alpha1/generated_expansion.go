@@ -26,6 +26,8 @@ type PipelineExpansion interface{}
 
 type PipelineRunExpansion interface{}
 
+type PipelineRunScheduleExpansion interface{}
+
 type RunExpansion interface{}
 
 type TaskExpansion interface{}
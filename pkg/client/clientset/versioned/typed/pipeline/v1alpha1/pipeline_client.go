@@ -30,6 +30,7 @@ type TektonV1alpha1Interface interface {
 	ConditionsGetter
 	PipelinesGetter
 	PipelineRunsGetter
+	PipelineRunSchedulesGetter
 	RunsGetter
 	TasksGetter
 	TaskRunsGetter
@@ -56,6 +57,10 @@ func (c *TektonV1alpha1Client) PipelineRuns(namespace string) PipelineRunInterfa
 	return newPipelineRuns(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) PipelineRunSchedules(namespace string) PipelineRunScheduleInterface {
+	return newPipelineRunSchedules(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Runs(namespace string) RunInterface {
 	return newRuns(c, namespace)
 }
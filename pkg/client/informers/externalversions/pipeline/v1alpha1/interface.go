@@ -32,6 +32,8 @@ type Interface interface {
 	Pipelines() PipelineInformer
 	// PipelineRuns returns a PipelineRunInformer.
 	PipelineRuns() PipelineRunInformer
+	// PipelineRunSchedules returns a PipelineRunScheduleInformer.
+	PipelineRunSchedules() PipelineRunScheduleInformer
 	// Runs returns a RunInformer.
 	Runs() RunInformer
 	// Tasks returns a TaskInformer.
@@ -71,6 +73,11 @@ func (v *version) PipelineRuns() PipelineRunInformer {
 	return &pipelineRunInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// PipelineRunSchedules returns a PipelineRunScheduleInformer.
+func (v *version) PipelineRunSchedules() PipelineRunScheduleInformer {
+	return &pipelineRunScheduleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Runs returns a RunInformer.
 func (v *version) Runs() RunInformer {
 	return &runInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
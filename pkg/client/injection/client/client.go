@@ -633,6 +633,137 @@ func (w *wrapTektonV1alpha1PipelineRunImpl) Watch(ctx context.Context, opts v1.L
 	return nil, errors.New("NYI: Watch")
 }
 
+func (w *wrapTektonV1alpha1) PipelineRunSchedules(namespace string) typedtektonv1alpha1.PipelineRunScheduleInterface {
+	return &wrapTektonV1alpha1PipelineRunScheduleImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "tekton.dev",
+			Version:  "v1alpha1",
+			Resource: "pipelinerunschedules",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapTektonV1alpha1PipelineRunScheduleImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedtektonv1alpha1.PipelineRunScheduleInterface = (*wrapTektonV1alpha1PipelineRunScheduleImpl)(nil)
+
+func (w *wrapTektonV1alpha1PipelineRunScheduleImpl) Create(ctx context.Context, in *v1alpha1.PipelineRunSchedule, opts v1.CreateOptions) (*v1alpha1.PipelineRunSchedule, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "PipelineRunSchedule",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunSchedule{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunScheduleImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapTektonV1alpha1PipelineRunScheduleImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTektonV1alpha1PipelineRunScheduleImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.PipelineRunSchedule, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunSchedule{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunScheduleImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.PipelineRunScheduleList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunScheduleList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunScheduleImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PipelineRunSchedule, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunSchedule{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunScheduleImpl) Update(ctx context.Context, in *v1alpha1.PipelineRunSchedule, opts v1.UpdateOptions) (*v1alpha1.PipelineRunSchedule, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "PipelineRunSchedule",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunSchedule{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunScheduleImpl) UpdateStatus(ctx context.Context, in *v1alpha1.PipelineRunSchedule, opts v1.UpdateOptions) (*v1alpha1.PipelineRunSchedule, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "PipelineRunSchedule",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunSchedule{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunScheduleImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapTektonV1alpha1) Runs(namespace string) typedtektonv1alpha1.RunInterface {
 	return &wrapTektonV1alpha1RunImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
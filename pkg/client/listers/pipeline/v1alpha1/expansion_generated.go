@@ -46,6 +46,14 @@ type PipelineRunListerExpansion interface{}
 // PipelineRunNamespaceLister.
 type PipelineRunNamespaceListerExpansion interface{}
 
+// PipelineRunScheduleListerExpansion allows custom methods to be added to
+// PipelineRunScheduleLister.
+type PipelineRunScheduleListerExpansion interface{}
+
+// PipelineRunScheduleNamespaceListerExpansion allows custom methods to be added to
+// PipelineRunScheduleNamespaceLister.
+type PipelineRunScheduleNamespaceListerExpansion interface{}
+
 // RunListerExpansion allows custom methods to be added to
 // RunLister.
 type RunListerExpansion interface{}
@@ -0,0 +1,47 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"fmt"
+	"strings"
+
+	robfigcron "github.com/robfig/cron/v3"
+)
+
+// Schedule is a parsed cron expression. Its Next method returns the first time matching the
+// Schedule strictly after the given time, in the location of the given time, or the zero time
+// if the Schedule never matches.
+type Schedule = robfigcron.Schedule
+
+// parser parses standard cron expressions with five fields and the "@" macros.
+var parser = robfigcron.NewParser(robfigcron.Minute | robfigcron.Hour | robfigcron.Dom | robfigcron.Month | robfigcron.Dow | robfigcron.Descriptor)
+
+// Parse parses a standard cron expression with five fields: minute, hour, day of month, month
+// and day of week, or one of the macros "@yearly", "@annually", "@monthly", "@weekly", "@daily",
+// "@midnight" and "@hourly". Intervals ("@every") and time zones ("TZ=") can't be specified in
+// the expression: the time zone of a Schedule is the location of the times passed to Next.
+func Parse(expression string) (Schedule, error) {
+	expression = strings.TrimSpace(expression)
+	switch {
+	case strings.HasPrefix(expression, "@every"):
+		return nil, fmt.Errorf("intervals aren't supported in cron expression %q", expression)
+	case strings.HasPrefix(expression, "TZ=") || strings.HasPrefix(expression, "CRON_TZ="):
+		return nil, fmt.Errorf("time zones aren't supported in cron expression %q", expression)
+	}
+	return parser.Parse(expression)
+}
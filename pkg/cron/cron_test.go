@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+	from := time.Date(2021, time.March, 13, 10, 30, 15, 0, time.UTC) // a Saturday
+	tests := []struct {
+		name       string
+		expression string
+		from       time.Time
+		want       time.Time
+	}{{
+		name:       "every minute",
+		expression: "* * * * *",
+		from:       from,
+		want:       time.Date(2021, time.March, 13, 10, 31, 0, 0, time.UTC),
+	}, {
+		name:       "strictly after the given time",
+		expression: "31 10 * * *",
+		from:       time.Date(2021, time.March, 13, 10, 31, 0, 0, time.UTC),
+		want:       time.Date(2021, time.March, 14, 10, 31, 0, 0, time.UTC),
+	}, {
+		name:       "hourly macro",
+		expression: "@hourly",
+		from:       from,
+		want:       time.Date(2021, time.March, 13, 11, 0, 0, 0, time.UTC),
+	}, {
+		name:       "steps",
+		expression: "*/20 */6 * * *",
+		from:       from,
+		want:       time.Date(2021, time.March, 13, 12, 0, 0, 0, time.UTC),
+	}, {
+		name:       "ranges and lists",
+		expression: "0 9-17/4,22 * * *",
+		from:       from,
+		want:       time.Date(2021, time.March, 13, 13, 0, 0, 0, time.UTC),
+	}, {
+		name:       "named day of week",
+		expression: "0 0 * * mon-fri",
+		from:       from,
+		want:       time.Date(2021, time.March, 15, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:       "day of month or day of week",
+		expression: "0 0 20 * sun",
+		from:       from,
+		want:       time.Date(2021, time.March, 14, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:       "named month across years",
+		expression: "0 0 1 jan *",
+		from:       from,
+		want:       time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:       "leap day",
+		expression: "0 0 29 2 *",
+		from:       from,
+		want:       time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:       "never",
+		expression: "0 0 30 2 *",
+		from:       from,
+		want:       time.Time{},
+	}, {
+		name:       "in the location of the given time",
+		expression: "0 8 * * *",
+		from:       from.In(newYork),
+		want:       time.Date(2021, time.March, 13, 8, 0, 0, 0, newYork),
+	}, {
+		name:       "skips times in a daylight saving time gap",
+		expression: "30 2 * * *",
+		from:       from.In(newYork),
+		want:       time.Date(2021, time.March, 15, 2, 30, 0, 0, newYork),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.expression, err)
+			}
+			if got := s.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"* * * foo *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"@every 5m",
+		"TZ=Europe/Paris 0 * * * *",
+	} {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := Parse(expression); err == nil {
+				t.Errorf("Expected Parse(%q) to fail", expression)
+			}
+		})
+	}
+}
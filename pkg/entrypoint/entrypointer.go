@@ -71,6 +71,11 @@ type Entrypointer struct {
 
 	// Results is the set of files that might contain task results
 	Results []string
+	// ResultTypes holds the type of the results which are not strings, keyed by result name.
+	// The contents of these results must be valid JSON of the given type.
+	ResultTypes map[string]v1beta1.ResultsType
+	// StepResults is the set of files, within the results directory of the step, that might contain step results
+	StepResults []string
 	// Timeout is an optional user-specified duration within which the Step must complete
 	Timeout *time.Duration
 	// BreakpointOnFailure helps determine if entrypoint execution needs to adapt debugging requirements
@@ -85,6 +90,12 @@ type Entrypointer struct {
 	// the symlink is mainly created for providing easier access to the step metadata
 	// i.e. use `/tekton/steps/0/exitCode` instead of `/tekton/steps/my-awesome-step/exitCode`
 	StepMetadataDirLink string
+	// Skip indicates that the step is skipped, because its when expressions evaluated to false when
+	// the pod was built
+	Skip bool
+	// When holds the when expressions of the step which refer to the results of previous steps, they
+	// are evaluated once the previous steps are done
+	When v1beta1.WhenExpressions
 }
 
 // Waiter encapsulates waiting for files to exist.
@@ -123,6 +134,9 @@ func (e Entrypointer) Go() error {
 	// Create the directory where we will store the exit codes (and eventually other metadata) of Steps.
 	// Create a symlink to the directory for easier access by the index instead of a step name.
 	e.PostWriter.CreateDirWithSymlink(e.StepMetadataDir, e.StepMetadataDirLink)
+	if e.hasStepResults() {
+		e.PostWriter.CreateDirWithSymlink(filepath.Join(e.StepMetadataDir, pipeline.StepResultsDir), "")
+	}
 
 	for _, f := range e.WaitFiles {
 		if err := e.Waiter.Wait(f, e.WaitFileContent, e.BreakpointOnFailure); err != nil {
@@ -141,6 +155,43 @@ func (e Entrypointer) Go() error {
 		}
 	}
 
+	allowed, err := e.allowsExecution()
+	if err != nil {
+		e.WritePostFile(e.PostFile, err)
+		output = append(output, v1beta1.PipelineResourceResult{
+			Key:        "StartedAt",
+			Value:      time.Now().Format(timeFormat),
+			ResultType: v1beta1.InternalTektonResultType,
+		})
+		return err
+	}
+	if !allowed {
+		// The step is skipped, the next steps run as if it succeeded.
+		output = append(output, v1beta1.PipelineResourceResult{
+			Key:        "StartedAt",
+			Value:      time.Now().Format(timeFormat),
+			ResultType: v1beta1.InternalTektonResultType,
+		}, v1beta1.PipelineResourceResult{
+			Key:        "Reason",
+			Value:      v1beta1.StepReasonSkipped,
+			ResultType: v1beta1.InternalTektonResultType,
+		})
+		e.WritePostFile(e.PostFile, nil)
+		e.WriteExitCodeFile(e.StepMetadataDirLink, "0")
+		return nil
+	}
+
+	// The results of the previous steps are only known now that they ran.
+	if err := e.applyStepResultSubstitutions(); err != nil {
+		e.WritePostFile(e.PostFile, err)
+		output = append(output, v1beta1.PipelineResourceResult{
+			Key:        "StartedAt",
+			Value:      time.Now().Format(timeFormat),
+			ResultType: v1beta1.InternalTektonResultType,
+		})
+		return err
+	}
+
 	if e.Entrypoint != "" {
 		e.Args = append([]string{e.Entrypoint}, e.Args...)
 	}
@@ -151,7 +202,6 @@ func (e Entrypointer) Go() error {
 		ResultType: v1beta1.InternalTektonResultType,
 	})
 
-	var err error
 	if e.Timeout != nil && *e.Timeout < time.Duration(0) {
 		err = fmt.Errorf("negative timeout specified")
 	}
@@ -198,7 +248,7 @@ func (e Entrypointer) Go() error {
 
 	// strings.Split(..) with an empty string returns an array that contains one element, an empty string.
 	// This creates an error when trying to open the result folder as a file.
-	if len(e.Results) >= 1 && e.Results[0] != "" {
+	if (len(e.Results) >= 1 && e.Results[0] != "") || e.hasStepResults() {
 		if err := e.readResultsFromDisk(); err != nil {
 			logger.Fatalf("Error while handling results: %s", err)
 		}
@@ -219,6 +269,9 @@ func (e Entrypointer) readResultsFromDisk() error {
 		} else if err != nil {
 			return err
 		}
+		if err := v1beta1.ValidateResultValue(e.ResultTypes[resultFile], string(fileContents)); err != nil {
+			return fmt.Errorf("invalid %s result %q: %w", e.ResultTypes[resultFile], resultFile, err)
+		}
 		// if the file doesn't exist, ignore it
 		output = append(output, v1beta1.PipelineResourceResult{
 			Key:        resultFile,
@@ -226,6 +279,22 @@ func (e Entrypointer) readResultsFromDisk() error {
 			ResultType: v1beta1.TaskRunResultType,
 		})
 	}
+	for _, resultFile := range e.StepResults {
+		if resultFile == "" {
+			continue
+		}
+		fileContents, err := ioutil.ReadFile(filepath.Join(e.StepMetadataDir, pipeline.StepResultsDir, resultFile))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		output = append(output, v1beta1.PipelineResourceResult{
+			Key:        resultFile,
+			Value:      string(fileContents),
+			ResultType: v1beta1.StepResultType,
+		})
+	}
 	// push output to termination path
 	if len(output) != 0 {
 		if err := termination.WriteMessage(e.TerminationPath, output); err != nil {
@@ -235,6 +304,107 @@ func (e Entrypointer) readResultsFromDisk() error {
 	return nil
 }
 
+func (e Entrypointer) hasStepResults() bool {
+	return len(e.StepResults) >= 1 && e.StepResults[0] != ""
+}
+
+// applyStepResultSubstitutions replaces the references to the results of previous steps,
+// $(steps.<step-name>.results.<result-name>), in the entrypoint, the args, the script and the
+// environment of the step with the values the previous steps wrote.
+func (e *Entrypointer) applyStepResultSubstitutions() error {
+	var err error
+	if e.Entrypoint, err = v1beta1.ReplaceStepResultRefs(e.Entrypoint, e.readStepResult); err != nil {
+		return err
+	}
+	for i, arg := range e.Args {
+		if e.Args[i], err = v1beta1.ReplaceStepResultRefs(arg, e.readStepResult); err != nil {
+			return err
+		}
+	}
+	for _, env := range os.Environ() {
+		pair := strings.SplitN(env, "=", 2)
+		if len(pair) != 2 || !strings.Contains(pair[1], "$(steps.") {
+			continue
+		}
+		v, err := v1beta1.ReplaceStepResultRefs(pair[1], e.readStepResult)
+		if err != nil {
+			return err
+		}
+		if err := os.Setenv(pair[0], v); err != nil {
+			return err
+		}
+	}
+	// The scripts are mounted read-only, so a script referring to step results is
+	// copied into the metadata directory of the step with the values substituted.
+	if strings.HasPrefix(e.Entrypoint, pipeline.ScriptsDir) && e.StepMetadataDir != "" {
+		script, err := ioutil.ReadFile(e.Entrypoint)
+		if err != nil {
+			return err
+		}
+		replaced, err := v1beta1.ReplaceStepResultRefs(string(script), e.readStepResult)
+		if err != nil {
+			return err
+		}
+		if replaced != string(script) {
+			scriptFile := filepath.Join(e.StepMetadataDir, "script")
+			if err := ioutil.WriteFile(scriptFile, []byte(replaced), 0755); err != nil {
+				return err
+			}
+			e.Entrypoint = scriptFile
+		}
+	}
+	return nil
+}
+
+// allowsExecution evaluates the when expressions of the step, after replacing the references to the
+// results of the previous steps in them. When expressions referring to a result which wasn't written,
+// e.g. because the step producing it was skipped, evaluate to false and the step is skipped too.
+func (e Entrypointer) allowsExecution() (bool, error) {
+	if e.Skip {
+		return false, nil
+	}
+	missingResults := false
+	readStepResult := func(ref v1beta1.StepResultRef) (string, error) {
+		v, err := e.readStepResult(ref)
+		if errors.Is(err, os.ErrNotExist) {
+			missingResults = true
+			return "", nil
+		}
+		return v, err
+	}
+	wes := make(v1beta1.WhenExpressions, 0, len(e.When))
+	for _, we := range e.When {
+		input, err := v1beta1.ReplaceStepResultRefs(we.Input, readStepResult)
+		if err != nil {
+			return false, err
+		}
+		var values []string
+		for _, v := range we.Values {
+			value, err := v1beta1.ReplaceStepResultRefs(v, readStepResult)
+			if err != nil {
+				return false, err
+			}
+			values = append(values, value)
+		}
+		wes = append(wes, v1beta1.WhenExpression{Input: input, Operator: we.Operator, Values: values})
+	}
+	if missingResults {
+		return false, nil
+	}
+	return wes.AllowsExecution(nil), nil
+}
+
+// readStepResult reads the value of the result written by a previous step. The metadata directories
+// of the steps are siblings, named after the containers of the steps, i.e. step-<step-name>.
+func (e Entrypointer) readStepResult(ref v1beta1.StepResultRef) (string, error) {
+	stepsDir := filepath.Dir(e.StepMetadataDir)
+	v, err := ioutil.ReadFile(filepath.Join(stepsDir, "step-"+ref.Step, pipeline.StepResultsDir, ref.Result))
+	if err != nil {
+		return "", fmt.Errorf("reading result %q of step %q: %w", ref.Result, ref.Step, err)
+	}
+	return string(v), nil
+}
+
 // BreakpointExitCode reads the post file and returns the exit code it contains
 func (e Entrypointer) BreakpointExitCode(breakpointExitPostFile string) (int, error) {
 	exitCode, err := ioutil.ReadFile(breakpointExitPostFile)
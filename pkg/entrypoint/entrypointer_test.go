@@ -24,13 +24,16 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/selection"
 )
 
 func TestEntrypointerFailures(t *testing.T) {
@@ -373,6 +376,192 @@ func (f *fakeWaiter) Wait(file string, _ bool, _ bool) error {
 	return nil
 }
 
+func TestEntrypointer_StepResults(t *testing.T) {
+	stepsDir, err := ioutil.TempDir("", "steps")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary steps directory: %v", err)
+	}
+	defer os.RemoveAll(stepsDir)
+	for step, results := range map[string]map[string]string{
+		"step-build": {"digest": "sha256:1234"},
+		"step-push":  {"url": "registry.io/image@sha256:1234"},
+	} {
+		if err := os.MkdirAll(filepath.Join(stepsDir, step, "results"), 0755); err != nil {
+			t.Fatalf("unexpected error creating results directory: %v", err)
+		}
+		for name, value := range results {
+			if err := ioutil.WriteFile(filepath.Join(stepsDir, step, "results", name), []byte(value), 0644); err != nil {
+				t.Fatalf("unexpected error writing result: %v", err)
+			}
+		}
+	}
+	terminationFile, err := ioutil.TempFile("", "termination")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary termination file: %v", err)
+	}
+	defer os.Remove(terminationFile.Name())
+
+	fr := &fakeRunner{}
+	if err := (Entrypointer{
+		Entrypoint:      "push",
+		Args:            []string{"--digest=$(steps.build.results.digest)"},
+		Waiter:          &fakeWaiter{},
+		Runner:          fr,
+		PostWriter:      &fakePostWriter{},
+		TerminationPath: terminationFile.Name(),
+		StepResults:     []string{"url"},
+		StepMetadataDir: filepath.Join(stepsDir, "step-push"),
+	}).Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	if d := cmp.Diff([]string{"push", "--digest=sha256:1234"}, *fr.args); d != "" {
+		t.Errorf("Step result references not substituted %s", diff.PrintWantGot(d))
+	}
+	fileContents, err := ioutil.ReadFile(terminationFile.Name())
+	if err != nil {
+		t.Fatalf("unexpected error reading termination file: %v", err)
+	}
+	var entries []v1beta1.PipelineResourceResult
+	if err := json.Unmarshal(fileContents, &entries); err != nil {
+		t.Fatalf("unexpected error parsing termination message: %v", err)
+	}
+	var stepResults []v1beta1.PipelineResourceResult
+	for _, entry := range entries {
+		if entry.ResultType == v1beta1.StepResultType {
+			stepResults = append(stepResults, entry)
+		}
+	}
+	want := []v1beta1.PipelineResourceResult{{
+		Key:        "url",
+		Value:      "registry.io/image@sha256:1234",
+		ResultType: v1beta1.StepResultType,
+	}}
+	if d := cmp.Diff(want, stepResults); d != "" {
+		t.Errorf("Step results in termination message %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEntrypointer_StepResultsOfMissingStep(t *testing.T) {
+	stepsDir, err := ioutil.TempDir("", "steps")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary steps directory: %v", err)
+	}
+	defer os.RemoveAll(stepsDir)
+	terminationFile, err := ioutil.TempFile("", "termination")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary termination file: %v", err)
+	}
+	defer os.Remove(terminationFile.Name())
+
+	fr, fpw := &fakeRunner{}, &fakePostWriter{}
+	err = Entrypointer{
+		Entrypoint:      "push",
+		Args:            []string{"--digest=$(steps.build.results.digest)"},
+		PostFile:        "writeme",
+		Waiter:          &fakeWaiter{},
+		Runner:          fr,
+		PostWriter:      fpw,
+		TerminationPath: terminationFile.Name(),
+		StepMetadataDir: filepath.Join(stepsDir, "step-push"),
+	}.Go()
+	if err == nil {
+		t.Fatalf("Entrypointer should fail when a referenced step result doesn't exist")
+	}
+	if fr.args != nil {
+		t.Errorf("Ran %v when the step results couldn't be resolved", *fr.args)
+	}
+	if fpw.wrote == nil || *fpw.wrote != "writeme.err" {
+		t.Errorf("Wanted the error post file to be written, got %v", fpw.wrote)
+	}
+}
+
+func TestEntrypointer_WhenExpressions(t *testing.T) {
+	stepsDir, err := ioutil.TempDir("", "steps")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary steps directory: %v", err)
+	}
+	defer os.RemoveAll(stepsDir)
+	if err := os.MkdirAll(filepath.Join(stepsDir, "step-build", "results"), 0755); err != nil {
+		t.Fatalf("unexpected error creating results directory: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(stepsDir, "step-build", "results", "digest"), []byte("sha256:1234"), 0644); err != nil {
+		t.Fatalf("unexpected error writing result: %v", err)
+	}
+
+	for _, c := range []struct {
+		desc        string
+		skip        bool
+		when        v1beta1.WhenExpressions
+		wantSkipped bool
+	}{{
+		desc:        "skipped when the pod was built",
+		skip:        true,
+		wantSkipped: true,
+	}, {
+		desc: "when expressions referring to a step result evaluating to true",
+		when: v1beta1.WhenExpressions{{Input: "$(steps.build.results.digest)", Operator: selection.In, Values: []string{"sha256:1234"}}},
+	}, {
+		desc:        "when expressions referring to a step result evaluating to false",
+		when:        v1beta1.WhenExpressions{{Input: "sha256:5678", Operator: selection.In, Values: []string{"$(steps.build.results.digest)"}}},
+		wantSkipped: true,
+	}, {
+		desc:        "when expressions referring to the result of a skipped step",
+		when:        v1beta1.WhenExpressions{{Input: "$(steps.scan.results.report)", Operator: selection.NotIn, Values: []string{"failed"}}},
+		wantSkipped: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			terminationFile, err := ioutil.TempFile("", "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary termination file: %v", err)
+			}
+			defer os.Remove(terminationFile.Name())
+
+			fr, fpw := &fakeRunner{}, &fakePostWriter{}
+			if err := (Entrypointer{
+				Entrypoint:      "push",
+				PostFile:        "writeme",
+				Waiter:          &fakeWaiter{},
+				Runner:          fr,
+				PostWriter:      fpw,
+				TerminationPath: terminationFile.Name(),
+				StepMetadataDir: filepath.Join(stepsDir, "step-push"),
+				Skip:            c.skip,
+				When:            c.when,
+			}).Go(); err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+
+			if c.wantSkipped && fr.args != nil {
+				t.Errorf("Ran %v when the step should have been skipped", *fr.args)
+			}
+			if !c.wantSkipped && fr.args == nil {
+				t.Error("Didn't run the step")
+			}
+			if fpw.wrote == nil || *fpw.wrote != "writeme" {
+				t.Errorf("Wanted the post file to be written, got %v", fpw.wrote)
+			}
+			fileContents, err := ioutil.ReadFile(terminationFile.Name())
+			if err != nil {
+				t.Fatalf("unexpected error reading termination file: %v", err)
+			}
+			var entries []v1beta1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("unexpected error parsing termination message: %v", err)
+			}
+			skipped := false
+			for _, entry := range entries {
+				if entry.ResultType == v1beta1.InternalTektonResultType && entry.Key == "Reason" && entry.Value == v1beta1.StepReasonSkipped {
+					skipped = true
+				}
+			}
+			if skipped != c.wantSkipped {
+				t.Errorf("Wanted the step to be reported as skipped: %t, got %t", c.wantSkipped, skipped)
+			}
+		})
+	}
+}
+
 type fakeRunner struct{ args *[]string }
 
 func (f *fakeRunner) Run(ctx context.Context, args ...string) error {
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package matrix
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// Combination is a set of Params, with one value for each Param in a Matrix.
+type Combination []v1beta1.Param
+
+// FanOut produces all the Combinations of the array Params in a Matrix. Each
+// Combination holds one string Param for every Param in the Matrix, and the
+// Combinations are returned in a stable order: the values of the last Param
+// vary fastest.
+func FanOut(matrix []v1beta1.Param) []Combination {
+	if len(matrix) == 0 {
+		return nil
+	}
+	combinations := []Combination{{}}
+	for _, param := range matrix {
+		var next []Combination
+		for _, combination := range combinations {
+			for _, value := range param.Value.ArrayVal {
+				c := make(Combination, len(combination), len(combination)+1)
+				copy(c, combination)
+				next = append(next, append(c, v1beta1.Param{
+					Name:  param.Name,
+					Value: *v1beta1.NewArrayOrString(value),
+				}))
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// Count returns the number of Combinations produced by fanning out the Matrix.
+func Count(matrix []v1beta1.Param) int {
+	if len(matrix) == 0 {
+		return 0
+	}
+	count := 1
+	for _, param := range matrix {
+		count *= len(param.Value.ArrayVal)
+	}
+	return count
+}
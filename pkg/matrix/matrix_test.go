@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package matrix
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestFanOut(t *testing.T) {
+	tcs := []struct {
+		name   string
+		matrix []v1beta1.Param
+		want   []Combination
+	}{{
+		name: "no matrix",
+	}, {
+		name: "single param",
+		matrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}},
+		want: []Combination{{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("mac"),
+		}}},
+	}, {
+		name: "multiple params",
+		matrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}, {
+			Name: "browser", Value: *v1beta1.NewArrayOrString("chrome", "firefox", "safari"),
+		}},
+		want: []Combination{{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+		}, {
+			Name: "browser", Value: *v1beta1.NewArrayOrString("chrome"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+		}, {
+			Name: "browser", Value: *v1beta1.NewArrayOrString("firefox"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+		}, {
+			Name: "browser", Value: *v1beta1.NewArrayOrString("safari"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("mac"),
+		}, {
+			Name: "browser", Value: *v1beta1.NewArrayOrString("chrome"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("mac"),
+		}, {
+			Name: "browser", Value: *v1beta1.NewArrayOrString("firefox"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("mac"),
+		}, {
+			Name: "browser", Value: *v1beta1.NewArrayOrString("safari"),
+		}}},
+	}, {
+		name: "empty array",
+		matrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}, {
+			Name: "browser", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{}},
+		}},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FanOut(tc.matrix)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("FanOut() %s", diff.PrintWantGot(d))
+			}
+			if c := Count(tc.matrix); c != len(tc.want) {
+				t.Errorf("Count() = %d, want %d", c, len(tc.want))
+			}
+		})
+	}
+}
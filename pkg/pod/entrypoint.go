@@ -143,6 +143,16 @@ func orderContainers(commonExtraEntrypointArgs []string, steps []corev1.Containe
 				if taskSpec.Steps[i].OnError != "" {
 					argsForEntrypoint = append(argsForEntrypoint, "-on_error", taskSpec.Steps[i].OnError)
 				}
+				if len(taskSpec.Steps[i].Results) > 0 {
+					argsForEntrypoint = append(argsForEntrypoint, "-step_results", collectStepResultsName(taskSpec.Steps[i].Results))
+				}
+				if len(taskSpec.Steps[i].When) > 0 {
+					whenArgs, err := whenArguments(taskSpec.Steps[i].When)
+					if err != nil {
+						return nil, err
+					}
+					argsForEntrypoint = append(argsForEntrypoint, whenArgs...)
+				}
 			}
 			argsForEntrypoint = append(argsForEntrypoint, resultArgument(steps, taskSpec.Results)...)
 		}
@@ -183,7 +193,23 @@ func resultArgument(steps []corev1.Container, results []v1beta1.TaskResult) []st
 	if len(results) == 0 {
 		return nil
 	}
-	return []string{"-results", collectResultsName(results)}
+	args := []string{"-results", collectResultsName(results)}
+	if resultTypes := collectResultTypes(results); resultTypes != "" {
+		args = append(args, "-result_types", resultTypes)
+	}
+	return args
+}
+
+// collectResultTypes returns the <name>:<type> pairs of the results which are not strings,
+// so that the entrypoint can validate the values written for them
+func collectResultTypes(results []v1beta1.TaskResult) string {
+	var resultTypes []string
+	for _, r := range results {
+		if r.Type == v1beta1.ResultsTypeArray || r.Type == v1beta1.ResultsTypeObject {
+			resultTypes = append(resultTypes, fmt.Sprintf("%s:%s", r.Name, r.Type))
+		}
+	}
+	return strings.Join(resultTypes, ",")
 }
 
 func collectResultsName(results []v1beta1.TaskResult) string {
@@ -194,6 +220,46 @@ func collectResultsName(results []v1beta1.TaskResult) string {
 	return strings.Join(resultNames, ",")
 }
 
+func collectStepResultsName(results []v1beta1.StepResult) string {
+	var resultNames []string
+	for _, r := range results {
+		resultNames = append(resultNames, r.Name)
+	}
+	return strings.Join(resultNames, ",")
+}
+
+// whenArguments returns the entrypoint flags for the when expressions of a step. The params are
+// substituted when the pod is built, so the when expressions which don't refer to the results of
+// previous steps are evaluated right away, and the step is skipped if they evaluate to false. The
+// other ones are evaluated by the entrypoint once the previous steps are done.
+func whenArguments(wes v1beta1.WhenExpressions) ([]string, error) {
+	if !refersToStepResults(wes) {
+		if wes.AllowsExecution(nil) {
+			return nil, nil
+		}
+		return []string{"-skip"}, nil
+	}
+	b, err := json.Marshal(wes)
+	if err != nil {
+		return nil, err
+	}
+	return []string{"-when", string(b)}, nil
+}
+
+func refersToStepResults(wes v1beta1.WhenExpressions) bool {
+	for _, we := range wes {
+		if v1beta1.ContainsStepResultRefs(we.Input) {
+			return true
+		}
+		for _, v := range we.Values {
+			if v1beta1.ContainsStepResultRefs(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 var replaceReadyPatchBytes []byte
 
 func init() {
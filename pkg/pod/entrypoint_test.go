@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 )
 
@@ -299,6 +300,51 @@ func TestEntryPointSingleResultsSingleStep(t *testing.T) {
 	}
 }
 
+func TestEntryPointTypedResultsSingleStep(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Results: []v1beta1.TaskResult{{
+			Name: "sum",
+		}, {
+			Name: "images",
+			Type: v1beta1.ResultsTypeArray,
+		}, {
+			Name: "digest",
+			Type: v1beta1.ResultsTypeObject,
+		}},
+	}
+
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+		Args:    []string{"arg1", "arg2"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-unnamed-0",
+			"-step_metadata_dir_link", "/tekton/steps/0",
+			"-results", "sum,images,digest",
+			"-result_types", "images:array,digest:object",
+			"-entrypoint", "cmd", "--",
+			"arg1", "arg2",
+		},
+		VolumeMounts:           []corev1.VolumeMount{downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	got, err := orderContainers([]string{}, steps, &taskSpec, nil)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestEntryPointOnError(t *testing.T) {
 	taskSpec := v1beta1.TaskSpec{
 		Steps: []v1beta1.Step{{
@@ -358,6 +404,140 @@ func TestEntryPointOnError(t *testing.T) {
 	}
 }
 
+func TestEntryPointStepResults(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Results: []v1beta1.StepResult{{Name: "digest"}, {Name: "url"}},
+		}, {}},
+	}
+
+	steps := []corev1.Container{{
+		Name:    "build",
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Name:    "push",
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}}
+
+	want := []corev1.Container{{
+		Name:    "build",
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-build",
+			"-step_metadata_dir_link", "/tekton/steps/0",
+			"-step_results", "digest,url",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Name:    "push",
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/run/0/out",
+			"-post_file", "/tekton/run/1/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-push",
+			"-step_metadata_dir_link", "/tekton/steps/1",
+			"-entrypoint", "cmd", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	got, err := orderContainers([]string{}, steps, &taskSpec, nil)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEntryPointStepWhenExpressions(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			When: v1beta1.WhenExpressions{{Input: "foo", Operator: selection.In, Values: []string{"foo"}}},
+		}, {
+			When: v1beta1.WhenExpressions{{Input: "foo", Operator: selection.In, Values: []string{"bar"}}},
+		}, {
+			When: v1beta1.WhenExpressions{{Input: "$(steps.build.results.digest)", Operator: selection.NotIn, Values: []string{""}}},
+		}},
+	}
+
+	steps := []corev1.Container{{
+		Name:    "build",
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Name:    "test",
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}, {
+		Name:    "push",
+		Image:   "step-3",
+		Command: []string{"cmd"},
+	}}
+
+	want := []corev1.Container{{
+		Name:    "build",
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-build",
+			"-step_metadata_dir_link", "/tekton/steps/0",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Name:    "test",
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/run/0/out",
+			"-post_file", "/tekton/run/1/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-test",
+			"-step_metadata_dir_link", "/tekton/steps/1",
+			"-skip",
+			"-entrypoint", "cmd", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Name:    "push",
+		Image:   "step-3",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/run/1/out",
+			"-post_file", "/tekton/run/2/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-push",
+			"-step_metadata_dir_link", "/tekton/steps/2",
+			"-when", `[{"input":"$(steps.build.results.digest)","operator":"notin","values":[""]}]`,
+			"-entrypoint", "cmd", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	got, err := orderContainers([]string{}, steps, &taskSpec, nil)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestUpdateReady(t *testing.T) {
 	for _, c := range []struct {
 		desc            string
@@ -109,7 +109,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	)
 	volumeMounts := []corev1.VolumeMount{binROMount}
 	implicitEnvVars := []corev1.EnvVar{}
-	alphaAPIEnabled := config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields
+	featureFlags := config.FromContextOrDefaults(ctx).FeatureFlags
+	alphaAPIEnabled := featureFlags.EnableAPIFields == config.AlphaAPIFields
+	resultsFromSidecarLogs := featureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs && len(taskSpec.Results) > 0
 
 	// Add our implicit volumes first, so they can be overridden by the user if they prefer.
 	volumes = append(volumes, implicitVolumes...)
@@ -175,14 +177,23 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		VolumeMounts: []corev1.VolumeMount{binMount},
 	}
 
+	// When the results are read from the logs of the results sidecar, the steps
+	// don't write them to their termination messages.
+	orderedSpec := taskSpec
+	if resultsFromSidecarLogs {
+		orderedSpec.Results = nil
+	}
 	if alphaAPIEnabled {
-		stepContainers, err = orderContainers(credEntrypointArgs, stepContainers, &taskSpec, taskRun.Spec.Debug)
+		stepContainers, err = orderContainers(credEntrypointArgs, stepContainers, &orderedSpec, taskRun.Spec.Debug)
 	} else {
-		stepContainers, err = orderContainers(credEntrypointArgs, stepContainers, &taskSpec, nil)
+		stepContainers, err = orderContainers(credEntrypointArgs, stepContainers, &orderedSpec, nil)
 	}
 	if err != nil {
 		return nil, err
 	}
+	if resultsFromSidecarLogs {
+		sidecarContainers = append(sidecarContainers, resultsSidecar(b.Images.EntrypointImage, len(stepContainers), taskSpec.Results))
+	}
 	// place the entrypoint first in case other init containers rely on its
 	// features (e.g. decode-script).
 	initContainers = append([]corev1.Container{entrypointInit}, initContainers...)
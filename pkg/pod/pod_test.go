@@ -1423,6 +1423,58 @@ _EOF_
 				}),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
+		}, {
+			desc: "results from sidecar logs",
+			featureFlags: map[string]string{
+				"disable-creds-init": "true",
+				"results-from":       "sidecar-logs",
+			},
+			ts: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}}},
+				Results: []v1beta1.TaskResult{{Name: "foo"}, {Name: "bar"}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{placeToolsInit},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/steps/step-name",
+						"-step_metadata_dir_link",
+						"/tekton/steps/0",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}, {
+					Name:    "sidecar-tekton-log-results",
+					Image:   images.EntrypointImage,
+					Command: []string{"/ko-app/entrypoint", "log-results", "/tekton/results", "/tekton/run/0/out", "foo,bar"},
+					VolumeMounts: []corev1.VolumeMount{{
+						Name:      "tekton-internal-results",
+						MountPath: "/tekton/results",
+						ReadOnly:  true,
+					}, runMount(0, true)},
+				}},
+				Volumes:               append(implicitVolumes, binVolume, runVolume(0), downwardVolume),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
 		}} {
 		t.Run(c.desc, func(t *testing.T) {
 			names.TestingSeed()
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ReasonResultsTooLarge indicates that the results of a TaskRun are larger than the
+	// "max-result-size" configured in the feature flags.
+	ReasonResultsTooLarge = "TaskRunResultLargerThanAllowedLimit"
+
+	// ReasonFailedResultsExtraction indicates that the results of a TaskRun couldn't be read
+	// from the logs of the results sidecar.
+	ReasonFailedResultsExtraction = "TaskRunResultsExtractionFailed"
+
+	resultsSidecarName = "tekton-log-results"
+)
+
+// ErrResultsTooLarge is returned when the results logged by the results sidecar are larger
+// than the "max-result-size" configured in the feature flags.
+var ErrResultsTooLarge = errors.New("results are larger than the maximum result size")
+
+var resultsSidecarContainerName = sidecarPrefix + resultsSidecarName
+
+// resultsSidecar returns the sidecar which waits for the last of stepCount steps to be done,
+// then logs the results of the Task so that the controller can read them from its logs
+// instead of from the termination messages of the steps.
+func resultsSidecar(image string, stepCount int, results []v1beta1.TaskResult) corev1.Container {
+	return corev1.Container{
+		Name:  resultsSidecarName,
+		Image: image,
+		Command: []string{
+			"/ko-app/entrypoint", "log-results",
+			pipeline.DefaultResultPath,
+			filepath.Join(runDir, strconv.Itoa(stepCount-1), "out"),
+			collectResultsName(results),
+		},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      "tekton-internal-results",
+			MountPath: pipeline.DefaultResultPath,
+			ReadOnly:  true,
+		}, runMount(stepCount-1, true)},
+	}
+}
+
+// HasResultsSidecar returns true if the results of the Pod's steps are logged by the
+// results sidecar.
+func HasResultsSidecar(pod *corev1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == resultsSidecarContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+// IsResultsSidecarPending returns true if the steps of the Pod are done but its results
+// sidecar hasn't logged their results yet.
+func IsResultsSidecarPending(pod *corev1.Pod) bool {
+	if !HasResultsSidecar(pod) || !areStepsComplete(pod) {
+		return false
+	}
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Name == resultsSidecarContainerName {
+			return s.State.Terminated == nil
+		}
+	}
+	return true
+}
+
+// AddResultsFromSidecarLogs reads the results logged by the results sidecar of the Pod and
+// adds them to the status of the TaskRun. ErrResultsTooLarge is returned if the results are
+// larger than maxResultSize bytes.
+func AddResultsFromSidecarLogs(ctx context.Context, kubeclient kubernetes.Interface, tr *v1beta1.TaskRun, pod *corev1.Pod, maxResultSize int) error {
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Name == resultsSidecarContainerName && s.State.Terminated != nil && s.State.Terminated.ExitCode != 0 {
+			return fmt.Errorf("%q exited with code %d: %s", resultsSidecarContainerName, s.State.Terminated.ExitCode, s.State.Terminated.Message)
+		}
+	}
+
+	logs, err := kubeclient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: resultsSidecarContainerName}).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting the logs of %q in Pod %q: %w", resultsSidecarContainerName, pod.Name, err)
+	}
+	defer logs.Close()
+
+	results, err := parseResultsFromSidecarLogs(logs, maxResultSize)
+	if err != nil {
+		return err
+	}
+	taskResults, _, _ := filterResultsAndResources(results)
+	taskResults, err = validateTaskRunResults(tr, taskResults)
+	tr.Status.TaskRunResults = removeDuplicateResults(append(tr.Status.TaskRunResults, taskResults...))
+	return err
+}
+
+// parseResultsFromSidecarLogs reads the results logged by the results sidecar, one JSON
+// encoded result per line. Lines which aren't results, such as the messages of the
+// entrypoint binary, are ignored.
+func parseResultsFromSidecarLogs(logs io.Reader, maxResultSize int) ([]v1beta1.PipelineResourceResult, error) {
+	tooLarge := fmt.Errorf("%w of %d bytes configured by %q", ErrResultsTooLarge, maxResultSize, "max-result-size")
+
+	scanner := bufio.NewScanner(logs)
+	// A JSON encoded value can be up to 6 times as long as the value itself, when each of
+	// its characters has to be escaped, e.g. as \u0000.
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 6*maxResultSize+bufio.MaxScanTokenSize)
+
+	var results []v1beta1.PipelineResourceResult
+	size := 0
+	for scanner.Scan() {
+		var r v1beta1.PipelineResourceResult
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil || r.Key == "" {
+			continue
+		}
+		size += len(r.Value)
+		if size > maxResultSize {
+			return nil, tooLarge
+		}
+		results = append(results, r)
+	}
+	if err := scanner.Err(); errors.Is(err, bufio.ErrTooLong) {
+		return nil, tooLarge
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading the logs of %q: %w", resultsSidecarContainerName, err)
+	}
+	return results, nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseResultsFromSidecarLogs(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		logs string
+		want []v1beta1.PipelineResourceResult
+	}{{
+		desc: "results",
+		logs: `{"key":"foo","value":"hello","type":1}
+{"key":"bar","value":"[\"a\",\"b\"]","type":1}
+`,
+		want: []v1beta1.PipelineResourceResult{{
+			Key:        "foo",
+			Value:      "hello",
+			ResultType: v1beta1.TaskRunResultType,
+		}, {
+			Key:        "bar",
+			Value:      `["a","b"]`,
+			ResultType: v1beta1.TaskRunResultType,
+		}},
+	}, {
+		desc: "lines which aren't results are ignored",
+		logs: `{"key":"foo","value":"hello","type":1}
+2021/11/02 10:00:00 Logged results from /tekton/results
+`,
+		want: []v1beta1.PipelineResourceResult{{
+			Key:        "foo",
+			Value:      "hello",
+			ResultType: v1beta1.TaskRunResultType,
+		}},
+	}, {
+		desc: "no results",
+		logs: "",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parseResultsFromSidecarLogs(strings.NewReader(tc.logs), 4096)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParseResultsFromSidecarLogs_TooLarge(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		logs string
+	}{{
+		desc: "result larger than the maximum size",
+		logs: `{"key":"foo","value":"` + strings.Repeat("a", 20) + `","type":1}`,
+	}, {
+		desc: "results larger than the maximum size in total",
+		logs: `{"key":"foo","value":"` + strings.Repeat("a", 10) + `","type":1}
+{"key":"bar","value":"` + strings.Repeat("b", 10) + `","type":1}
+`,
+	}, {
+		desc: "line longer than any result within the maximum size",
+		logs: strings.Repeat("a", 10*bufio.MaxScanTokenSize),
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := parseResultsFromSidecarLogs(strings.NewReader(tc.logs), 16)
+			if !errors.Is(err, ErrResultsTooLarge) {
+				t.Fatalf("expected ErrResultsTooLarge but got %v", err)
+			}
+			if want := `results are larger than the maximum result size of 16 bytes configured by "max-result-size"`; err.Error() != want {
+				t.Errorf("expected error %q but got %q", want, err)
+			}
+		})
+	}
+}
+
+func TestIsResultsSidecarPending(t *testing.T) {
+	resultsSidecar := corev1.Container{Name: "sidecar-tekton-log-results"}
+	for _, tc := range []struct {
+		desc       string
+		containers []corev1.Container
+		statuses   []corev1.ContainerStatus
+		want       bool
+	}{{
+		desc:       "no results sidecar",
+		containers: []corev1.Container{{Name: "step-foo"}},
+		statuses: []corev1.ContainerStatus{{
+			Name:  "step-foo",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+		}},
+		want: false,
+	}, {
+		desc:       "steps running",
+		containers: []corev1.Container{{Name: "step-foo"}, resultsSidecar},
+		statuses: []corev1.ContainerStatus{{
+			Name:  "step-foo",
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		}, {
+			Name:  "sidecar-tekton-log-results",
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		}},
+		want: false,
+	}, {
+		desc:       "steps done and results sidecar running",
+		containers: []corev1.Container{{Name: "step-foo"}, resultsSidecar},
+		statuses: []corev1.ContainerStatus{{
+			Name:  "step-foo",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+		}, {
+			Name:  "sidecar-tekton-log-results",
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		}},
+		want: true,
+	}, {
+		desc:       "steps done and results sidecar done",
+		containers: []corev1.Container{{Name: "step-foo"}, resultsSidecar},
+		statuses: []corev1.ContainerStatus{{
+			Name:  "step-foo",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+		}, {
+			Name:  "sidecar-tekton-log-results",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+		}},
+		want: false,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{Containers: tc.containers},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodRunning,
+					ContainerStatuses: tc.statuses,
+				},
+			}
+			if got := IsResultsSidecarPending(pod); got != tc.want {
+				t.Errorf("expected IsResultsSidecarPending() to be %t but got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAddResultsFromSidecarLogs(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "step-foo"}, {Name: "sidecar-tekton-log-results"}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "sidecar-tekton-log-results",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+			}},
+		},
+	}
+	tr := &v1beta1.TaskRun{}
+	tr.Status.TaskRunResults = []v1beta1.TaskRunResult{{Name: "foo", Value: "bar"}}
+
+	// The logs of the fake clientset contain no results.
+	if err := AddResultsFromSidecarLogs(context.Background(), fakek8s.NewSimpleClientset(pod), tr, pod, 4096); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff([]v1beta1.TaskRunResult{{Name: "foo", Value: "bar"}}, tr.Status.TaskRunResults); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+
+	pod.Status.ContainerStatuses[0].State.Terminated = &corev1.ContainerStateTerminated{ExitCode: 1, Message: "oops"}
+	err := AddResultsFromSidecarLogs(context.Background(), fakek8s.NewSimpleClientset(pod), tr, pod, 4096)
+	if want := `"sidecar-tekton-log-results" exited with code 1: oops`; err == nil || err.Error() != want {
+		t.Errorf("expected error %q but got %v", want, err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
@@ -32,7 +33,7 @@ const (
 	scriptsVolumeName      = "tekton-internal-scripts"
 	debugScriptsVolumeName = "tekton-internal-debug-scripts"
 	debugInfoVolumeName    = "tekton-internal-debug-info"
-	scriptsDir             = "/tekton/scripts"
+	scriptsDir             = pipeline.ScriptsDir
 	debugScriptsDir        = "/tekton/debug/scripts"
 	defaultScriptPreamble  = "#!/bin/sh\nset -xe\n"
 	debugInfoDir           = "/tekton/debug/info"
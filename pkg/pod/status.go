@@ -65,12 +65,19 @@ const (
 	// ReasonExceededNodeResources or isPodHitConfigError
 	ReasonPending = "Pending"
 
+	// ReasonPodEvicted indicates that the TaskRun failed because its pod was evicted
+	// from its node, e.g. because the node ran out of resources
+	ReasonPodEvicted = "PodEvicted"
+
 	// timeFormat is RFC3339 with millisecond
 	timeFormat = "2006-01-02T15:04:05.000Z07:00"
 )
 
 const oomKilled = "OOMKilled"
 
+// podReasonEvicted is the reason of the status of a pod evicted by the kubelet
+const podReasonEvicted = "Evicted"
+
 // SidecarsReady returns true if all of the Pod's sidecars are Ready or
 // Terminated.
 func SidecarsReady(podStatus corev1.PodStatus) bool {
@@ -145,6 +152,7 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 	var merr *multierror.Error
 
 	for _, s := range stepStatuses {
+		var stepResults []v1beta1.TaskRunResult
 		if s.State.Terminated != nil && len(s.State.Terminated.Message) != 0 {
 			msg := s.State.Terminated.Message
 
@@ -163,7 +171,13 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 					logger.Errorf("error extracting the exit code of step %q in taskrun %q: %v", s.Name, tr.Name, err)
 					merr = multierror.Append(merr, err)
 				}
+				stepResults = extractStepResultsFromResults(results)
 				taskResults, pipelineResourceResults, filteredResults := filterResultsAndResources(results)
+				taskResults, err = validateTaskRunResults(tr, taskResults)
+				if err != nil {
+					logger.Errorf("error validating the results of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
 				if tr.IsSuccessful() {
 					trs.TaskRunResults = append(trs.TaskRunResults, taskResults...)
 					trs.ResourcesResult = append(trs.ResourcesResult, pipelineResourceResults...)
@@ -181,6 +195,9 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 				if exitCode != nil {
 					s.State.Terminated.ExitCode = *exitCode
 				}
+				if isStepSkipped(results) {
+					s.State.Terminated.Reason = v1beta1.StepReasonSkipped
+				}
 			}
 		}
 		trs.Steps = append(trs.Steps, v1beta1.StepState{
@@ -188,6 +205,7 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 			Name:           trimStepPrefix(s.Name),
 			ContainerName:  s.Name,
 			ImageID:        s.ImageID,
+			Results:        stepResults,
 		})
 	}
 
@@ -230,8 +248,9 @@ func filterResultsAndResources(results []v1beta1.PipelineResourceResult) ([]v1be
 			}
 			taskResults = append(taskResults, taskRunResult)
 			filteredResults = append(filteredResults, r)
-		case v1beta1.InternalTektonResultType:
+		case v1beta1.InternalTektonResultType, v1beta1.StepResultType:
 			// Internal messages are ignored because they're not used as external result
+			// and step results are recorded in the state of their step
 			continue
 		case v1beta1.PipelineResourceResultType:
 			fallthrough
@@ -244,6 +263,29 @@ func filterResultsAndResources(results []v1beta1.PipelineResourceResult) ([]v1be
 	return taskResults, pipelineResourceResults, filteredResults
 }
 
+// validateTaskRunResults checks that the values of array and object results, as declared by the
+// Task of the TaskRun, are valid JSON of their type. Invalid results are dropped and reported in
+// the returned error.
+func validateTaskRunResults(tr *v1beta1.TaskRun, taskResults []v1beta1.TaskRunResult) ([]v1beta1.TaskRunResult, error) {
+	if tr.Status.TaskSpec == nil {
+		return taskResults, nil
+	}
+	resultTypes := map[string]v1beta1.ResultsType{}
+	for _, r := range tr.Status.TaskSpec.Results {
+		resultTypes[r.Name] = r.Type
+	}
+	var merr *multierror.Error
+	var validResults []v1beta1.TaskRunResult
+	for _, r := range taskResults {
+		if err := v1beta1.ValidateResultValue(resultTypes[r.Name], r.Value); err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("invalid %s result %q: %w", resultTypes[r.Name], r.Name, err))
+			continue
+		}
+		validResults = append(validResults, r)
+	}
+	return validResults, merr.ErrorOrNil()
+}
+
 func removeDuplicateResults(taskRunResult []v1beta1.TaskRunResult) []v1beta1.TaskRunResult {
 	if len(taskRunResult) == 0 {
 		return nil
@@ -263,6 +305,21 @@ func removeDuplicateResults(taskRunResult []v1beta1.TaskRunResult) []v1beta1.Tas
 	return uniq
 }
 
+// extractStepResultsFromResults returns the results emitted by the step itself, as opposed to the
+// results of the Task
+func extractStepResultsFromResults(results []v1beta1.PipelineResourceResult) []v1beta1.TaskRunResult {
+	var stepResults []v1beta1.TaskRunResult
+	for _, r := range results {
+		if r.ResultType == v1beta1.StepResultType {
+			stepResults = append(stepResults, v1beta1.TaskRunResult{
+				Name:  r.Key,
+				Value: r.Value,
+			})
+		}
+	}
+	return stepResults
+}
+
 func extractStartedAtTimeFromResults(results []v1beta1.PipelineResourceResult) (*metav1.Time, error) {
 	for _, result := range results {
 		if result.Key == "StartedAt" {
@@ -292,10 +349,24 @@ func extractExitCodeFromResults(results []v1beta1.PipelineResourceResult) (*int3
 	return nil, nil
 }
 
+// isStepSkipped returns true if the entrypoint skipped the step because of its when expressions
+func isStepSkipped(results []v1beta1.PipelineResourceResult) bool {
+	for _, result := range results {
+		if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "Reason" && result.Value == v1beta1.StepReasonSkipped {
+			return true
+		}
+	}
+	return false
+}
+
 func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	if DidTaskRunFail(pod) {
 		msg := getFailureMessage(logger, pod)
-		markStatusFailure(trs, v1beta1.TaskRunReasonFailed.String(), msg)
+		reason := v1beta1.TaskRunReasonFailed.String()
+		if pod.Status.Reason == podReasonEvicted {
+			reason = ReasonPodEvicted
+		}
+		markStatusFailure(trs, reason, msg)
 	} else {
 		markStatusSuccess(trs)
 	}
@@ -222,6 +222,22 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "evicted",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusFailure(ReasonPodEvicted, "The node was low on resource: memory."),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps:    []v1beta1.StepState{},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc: "failed with OOM",
 		podStatus: corev1.PodStatus{
@@ -579,6 +595,43 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "step results",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"digest","value":"sha256:1234","type":4},{"key":"resultName","value":"resultValue","type":1}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `[{"key":"resultName","value":"resultValue","type":1}]`,
+						}},
+					Name:          "build",
+					ContainerName: "step-build",
+					Results: []v1beta1.TaskRunResult{{
+						Name:  "digest",
+						Value: "sha256:1234",
+					}},
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				TaskRunResults: []v1beta1.TaskRunResult{{
+					Name:  "resultName",
+					Value: "resultValue",
+				}},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc: "test result with pipeline result - no result type",
 		podStatus: corev1.PodStatus{
@@ -932,6 +985,46 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "report a step skipped by the entrypoint because of its when expressions",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "step-first",
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "step-first",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `[{"key":"Reason","value":"Skipped","type":"InternalTektonResult"}]`,
+						},
+					},
+				}},
+			},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason: v1beta1.StepReasonSkipped,
+						},
+					},
+					Name:          "first",
+					ContainerName: "step-first",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			now := metav1.Now()
@@ -1097,6 +1190,85 @@ func TestMakeRunStatusJSONError(t *testing.T) {
 
 }
 
+func TestValidateTaskRunResults(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "task-run",
+			Namespace: "foo",
+		},
+		Status: v1beta1.TaskRunStatus{
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				TaskSpec: &v1beta1.TaskSpec{
+					Results: []v1beta1.TaskResult{{
+						Name: "string",
+					}, {
+						Name: "array",
+						Type: v1beta1.ResultsTypeArray,
+					}, {
+						Name: "object",
+						Type: v1beta1.ResultsTypeObject,
+					}},
+				},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc    string
+		results []v1beta1.TaskRunResult
+		want    []v1beta1.TaskRunResult
+		wantErr bool
+	}{{
+		desc: "valid results",
+		results: []v1beta1.TaskRunResult{{
+			Name:  "string",
+			Value: "[not json",
+		}, {
+			Name:  "array",
+			Value: `["a","b"]`,
+		}, {
+			Name:  "object",
+			Value: `{"a":"b"}`,
+		}},
+		want: []v1beta1.TaskRunResult{{
+			Name:  "string",
+			Value: "[not json",
+		}, {
+			Name:  "array",
+			Value: `["a","b"]`,
+		}, {
+			Name:  "object",
+			Value: `{"a":"b"}`,
+		}},
+	}, {
+		desc: "invalid results are dropped",
+		results: []v1beta1.TaskRunResult{{
+			Name:  "string",
+			Value: "foo",
+		}, {
+			Name:  "array",
+			Value: `{"a":"b"}`,
+		}, {
+			Name:  "object",
+			Value: `["a","b"]`,
+		}},
+		want: []v1beta1.TaskRunResult{{
+			Name:  "string",
+			Value: "foo",
+		}},
+		wantErr: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := validateTaskRunResults(tr, tc.results)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("validateTaskRunResults() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestSidecarsReady(t *testing.T) {
 	for _, c := range []struct {
 		desc     string
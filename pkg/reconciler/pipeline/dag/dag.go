@@ -121,6 +121,30 @@ func GetSchedulable(g *Graph, doneTasks ...string) (sets.String, error) {
 	return d, nil
 }
 
+// GetDescendants returns the names of the specified tasks and of all the tasks which depend
+// on them, directly or not. If one of the specified tasks isn't in the Graph, an error is returned.
+func GetDescendants(g *Graph, tasks ...string) (sets.String, error) {
+	d := sets.NewString()
+	nodes := []*Node{}
+	for _, task := range tasks {
+		n, ok := g.Nodes[task]
+		if !ok {
+			return nil, fmt.Errorf("task %q is not present in the graph", task)
+		}
+		nodes = append(nodes, n)
+	}
+	for len(nodes) > 0 {
+		n := nodes[0]
+		nodes = nodes[1:]
+		if d.Has(n.Task.HashKey()) {
+			continue
+		}
+		d.Insert(n.Task.HashKey())
+		nodes = append(nodes, n.Next...)
+	}
+	return d, nil
+}
+
 func linkPipelineTasks(prev *Node, next *Node) error {
 	// Check for self cycle
 	if prev.Task.HashKey() == next.Task.HashKey() {
@@ -123,6 +123,49 @@ func TestGetSchedulable_Invalid(t *testing.T) {
 	}
 }
 
+func TestGetDescendants(t *testing.T) {
+	g := testGraph(t)
+	tcs := []struct {
+		name          string
+		tasks         []string
+		expectedTasks sets.String
+	}{{
+		name:          "root",
+		tasks:         []string{"a"},
+		expectedTasks: sets.NewString("a", "x", "y", "z", "w"),
+	}, {
+		name:          "middle",
+		tasks:         []string{"x"},
+		expectedTasks: sets.NewString("x", "y", "z", "w"),
+	}, {
+		name:          "leaf",
+		tasks:         []string{"z"},
+		expectedTasks: sets.NewString("z"),
+	}, {
+		name:          "multiple",
+		tasks:         []string{"b", "z"},
+		expectedTasks: sets.NewString("b", "w", "z"),
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tasks, err := dag.GetDescendants(g, tc.tasks...)
+			if err != nil {
+				t.Fatalf("Didn't expect error when getting descendants of %v but got %v", tc.tasks, err)
+			}
+			if d := cmp.Diff(tc.expectedTasks, tasks); d != "" {
+				t.Errorf("expected descendants of %v to be %v but was different: %s", tc.tasks, tc.expectedTasks, diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetDescendants_Invalid(t *testing.T) {
+	g := testGraph(t)
+	if _, err := dag.GetDescendants(g, "a", "missing"); err == nil {
+		t.Fatal("Expected error for a task which isn't in the graph but got none")
+	}
+}
+
 func TestBuild_Parallel(t *testing.T) {
 	a := v1beta1.PipelineTask{Name: "a"}
 	b := v1beta1.PipelineTask{Name: "b"}
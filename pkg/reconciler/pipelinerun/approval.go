@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// startApproval makes a PipelineTask wait for an approval, which is reported in the status
+// of the PipelineRun. A decision made before the PipelineTask started waiting applies at once.
+func (c *Reconciler) startApproval(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) {
+	logging.FromContext(ctx).Infof("PipelineTask %s of PipelineRun %s is waiting for an approval", rprt.PipelineTask.Name, pr.Name)
+	now := metav1.Now()
+	rprt.Approval = &v1beta1.PipelineTaskApprovalStatus{
+		Name:      rprt.PipelineTask.Name,
+		State:     v1beta1.ApprovalStatePending,
+		StartTime: &now,
+	}
+	c.checkApproval(ctx, pr, rprt)
+}
+
+// processApprovals applies the decisions made on the PipelineTasks waiting for an approval,
+// and times out the ones nobody approved or rejected in time.
+func (c *Reconciler) processApprovals(ctx context.Context, pr *v1beta1.PipelineRun, pipelineState resources.PipelineRunState) {
+	for _, rprt := range pipelineState {
+		if rprt.IsApproval() && rprt.Approval != nil && !rprt.Approval.IsDone() {
+			c.checkApproval(ctx, pr, rprt)
+		}
+	}
+}
+
+// checkApproval approves or rejects a PipelineTask waiting for an approval if one of its
+// approvers made a decision, or times it out. Otherwise the PipelineRun is requeued for
+// when the PipelineTask times out. Decisions made by other users are ignored.
+func (c *Reconciler) checkApproval(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) {
+	logger := logging.FromContext(ctx)
+	approval := rprt.PipelineTask.Approval
+	now := metav1.Now()
+
+	if d := pr.Spec.GetApprovalDecision(rprt.PipelineTask.Name); d != nil {
+		if approval.IsApprover(*d) {
+			logger.Infof("PipelineTask %s of PipelineRun %s was %sd by %q", rprt.PipelineTask.Name, pr.Name, d.Decision, d.User)
+			rprt.Approval.State = v1beta1.ApprovalStateRejected
+			if d.Decision == v1beta1.ApprovalDecisionApprove {
+				rprt.Approval.State = v1beta1.ApprovalStateApproved
+			}
+			rprt.Approval.Approver = d.User
+			rprt.Approval.Message = d.Message
+			rprt.Approval.CompletionTime = &now
+			return
+		}
+		logger.Warnf("Ignoring decision of %q on PipelineTask %s of PipelineRun %s: not an approver", d.User, rprt.PipelineTask.Name, pr.Name)
+		controller.GetEventRecorder(ctx).Eventf(pr, corev1.EventTypeWarning, "ApprovalIgnored",
+			"Decision of %q on PipelineTask %q was ignored: %q is not one of its approvers", d.User, rprt.PipelineTask.Name, d.User)
+	}
+
+	if approval.TimedOut(rprt.Approval.StartTime.Time, now.Time) {
+		logger.Infof("PipelineTask %s of PipelineRun %s timed out waiting for an approval", rprt.PipelineTask.Name, pr.Name)
+		rprt.Approval.State = v1beta1.ApprovalStateTimedOut
+		rprt.Approval.Message = fmt.Sprintf("PipelineTask %q wasn't approved or rejected within %s", rprt.PipelineTask.Name, approval.Timeout.Duration)
+		rprt.Approval.CompletionTime = &now
+		return
+	}
+	if approval.Timeout != nil {
+		c.enqueueAfter(pr, rprt.Approval.StartTime.Add(approval.Timeout.Duration).Sub(now.Time))
+	}
+}
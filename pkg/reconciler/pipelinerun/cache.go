@@ -0,0 +1,242 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// cacheKeyInputs are the inputs of a TaskRun which are hashed into its cache key.
+type cacheKeyInputs struct {
+	TaskSpec   *v1beta1.TaskSpec `json:"taskSpec"`
+	Params     []v1beta1.Param   `json:"params,omitempty"`
+	Workspaces map[string]string `json:"workspaces,omitempty"`
+}
+
+// workspaceContent is the content of a workspace which is hashed into its digest.
+type workspaceContent struct {
+	SubPath    string             `json:"subPath,omitempty"`
+	Items      []corev1.KeyToPath `json:"items,omitempty"`
+	Data       map[string]string  `json:"data,omitempty"`
+	BinaryData map[string][]byte  `json:"binaryData,omitempty"`
+}
+
+// reuseCachedResults looks for a successful TaskRun with the same inputs as the TaskRun of a
+// PipelineTask with a Cache, in the namespace of the PipelineRun or, for a cluster scoped Cache
+// allowed by the "enable-cluster-scoped-cache" feature flag, in any namespace. If there is one,
+// its results are reused by a TaskRun which is never created, and true is returned. Otherwise,
+// the cache key is set on the PipelineTask so that its TaskRun can be found by the next PipelineRuns.
+func (c *Reconciler) reuseCachedResults(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) (bool, error) {
+	logger := logging.FromContext(ctx)
+	cache := rprt.PipelineTask.Cache
+
+	digests, err := c.getWorkspaceDigests(ctx, pr, rprt.PipelineTask)
+	if err != nil || digests == nil {
+		return false, err
+	}
+	params := resources.ApplyPipelineTaskContexts(rprt.PipelineTask).Params
+	key, err := getCacheKey(rprt.ResolvedTaskResources.TaskSpec, params, digests)
+	if err != nil {
+		return false, err
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{pipeline.CacheKeyLabelKey: key})
+	var trs []*v1beta1.TaskRun
+	if cache.IsClusterScoped() && config.FromContextOrDefaults(ctx).FeatureFlags.EnableClusterScopedCache {
+		trs, err = c.taskRunLister.List(selector)
+	} else {
+		trs, err = c.taskRunLister.TaskRuns(pr.Namespace).List(selector)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to list TaskRuns with cache key %s: %w", key, err)
+	}
+
+	now := time.Now()
+	var cached *v1beta1.TaskRun
+	for _, tr := range trs {
+		if !tr.IsSuccessful() || tr.Status.CompletionTime == nil || cache.Expired(tr.Status.CompletionTime.Time, now) {
+			continue
+		}
+		if cached != nil && !cached.Status.CompletionTime.Before(tr.Status.CompletionTime) {
+			continue
+		}
+		// The cache key label can be set by anyone creating a TaskRun, so it is only trusted
+		// for the TaskRuns of a PipelineRun whose inputs hash to the same key.
+		if !c.isCreatedByPipelineRun(tr) {
+			continue
+		}
+		trKey, err := getCacheKey(tr.Status.TaskSpec, tr.Spec.Params, digests)
+		if err != nil {
+			return false, err
+		}
+		if trKey != key {
+			logger.Warnf("Not reusing the results of TaskRun %s/%s: its inputs don't match its cache key", tr.Namespace, tr.Name)
+			continue
+		}
+		cached = tr
+	}
+	if cached == nil {
+		rprt.CacheKey = key
+		return false, nil
+	}
+
+	logger.Infof("Reusing the results of TaskRun %s/%s for pipeline task %s of PipelineRun %s", cached.Namespace, cached.Name, rprt.PipelineTask.Name, pr.Name)
+	completionTime := metav1.NewTime(now)
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rprt.TaskRunName,
+			Namespace: pr.Namespace,
+			// The TaskRun doesn't make the PipelineRun start any earlier
+			CreationTimestamp: pr.CreationTimestamp,
+		},
+	}
+	tr.Status.SetCondition(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionTrue,
+		Reason:  v1beta1.TaskRunReasonCacheHit.String(),
+		Message: fmt.Sprintf("TaskRun results reused from TaskRun %s/%s", cached.Namespace, cached.Name),
+	})
+	tr.Status.StartTime = &completionTime
+	tr.Status.CompletionTime = &completionTime
+	tr.Status.TaskRunResults = append([]v1beta1.TaskRunResult{}, cached.Status.TaskRunResults...)
+	rprt.TaskRun = tr
+	return true, nil
+}
+
+// isCreatedByPipelineRun returns true if a TaskRun is controlled by an existing PipelineRun.
+func (c *Reconciler) isCreatedByPipelineRun(tr *v1beta1.TaskRun) bool {
+	owner := metav1.GetControllerOf(tr)
+	if owner == nil || owner.Kind != pipeline.PipelineRunControllerName || owner.APIVersion != v1beta1.SchemeGroupVersion.String() {
+		return false
+	}
+	pr, err := c.pipelineRunLister.PipelineRuns(tr.Namespace).Get(owner.Name)
+	return err == nil && pr.UID == owner.UID
+}
+
+// getCacheKey returns the hash of a resolved TaskSpec, the values of the params and the digests
+// of the content of the selected workspaces of a TaskRun.
+func getCacheKey(taskSpec *v1beta1.TaskSpec, params []v1beta1.Param, digests map[string]string) (string, error) {
+	inputs := cacheKeyInputs{
+		Params: append([]v1beta1.Param{}, params...),
+	}
+	sort.Slice(inputs.Params, func(i, j int) bool {
+		return inputs.Params[i].Name < inputs.Params[j].Name
+	})
+	if taskSpec != nil {
+		// The order of the implicit params declared by the defaulting of a TaskSpec isn't stable
+		inputs.TaskSpec = taskSpec.DeepCopy()
+		sort.Slice(inputs.TaskSpec.Params, func(i, j int) bool {
+			return inputs.TaskSpec.Params[i].Name < inputs.TaskSpec.Params[j].Name
+		})
+	}
+	if len(digests) > 0 {
+		inputs.Workspaces = digests
+	}
+
+	b, err := json.Marshal(inputs)
+	if err != nil {
+		return "", err
+	}
+	// The key is used as a label value, which can't be longer than 63 characters
+	sum := sha256.Sum224(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// getWorkspaceDigests returns the digests of the content of the selected workspaces of a
+// PipelineTask with a Cache. It returns nil if the content of a workspace can't be hashed,
+// in which case the cache is bypassed and a CacheSkipped event is emitted on the PipelineRun.
+func (c *Reconciler) getWorkspaceDigests(ctx context.Context, pr *v1beta1.PipelineRun, pt *v1beta1.PipelineTask) (map[string]string, error) {
+	digests := make(map[string]string, len(pt.Cache.Workspaces))
+	for _, name := range pt.Cache.Workspaces {
+		digest, err := c.getWorkspaceDigest(ctx, pr, pt, name)
+		if err != nil {
+			return nil, err
+		}
+		if digest == "" {
+			logging.FromContext(ctx).Infof("Not using the cache for pipeline task %s of PipelineRun %s: the content of workspace %s can't be hashed", pt.Name, pr.Name, name)
+			controller.GetEventRecorder(ctx).Eventf(pr, corev1.EventTypeWarning, "CacheSkipped",
+				"Cache of PipelineTask %q was skipped: workspace %q isn't bound to a ConfigMap or a Secret", pt.Name, name)
+			return nil, nil
+		}
+		digests[name] = digest
+	}
+	return digests, nil
+}
+
+// getWorkspaceDigest returns the hash of the content of a workspace of a PipelineTask. Only the
+// content of ConfigMaps and Secrets can be hashed: an empty digest is returned for other workspaces.
+func (c *Reconciler) getWorkspaceDigest(ctx context.Context, pr *v1beta1.PipelineRun, pt *v1beta1.PipelineTask, name string) (string, error) {
+	var pipelineWorkspace, subPath string
+	for _, ws := range pt.Workspaces {
+		if ws.Name == name {
+			pipelineWorkspace, subPath = ws.Workspace, ws.SubPath
+			break
+		}
+	}
+	var binding *v1beta1.WorkspaceBinding
+	for i := range pr.Spec.Workspaces {
+		if pr.Spec.Workspaces[i].Name == pipelineWorkspace {
+			binding = &pr.Spec.Workspaces[i]
+			break
+		}
+	}
+	if binding == nil {
+		return "", nil
+	}
+
+	content := workspaceContent{SubPath: combinedSubPath(binding.SubPath, subPath)}
+	switch {
+	case binding.ConfigMap != nil:
+		cm, err := c.KubeClientSet.CoreV1().ConfigMaps(pr.Namespace).Get(ctx, binding.ConfigMap.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get ConfigMap %s of workspace %s: %w", binding.ConfigMap.Name, name, err)
+		}
+		content.Items, content.Data, content.BinaryData = binding.ConfigMap.Items, cm.Data, cm.BinaryData
+	case binding.Secret != nil:
+		secret, err := c.KubeClientSet.CoreV1().Secrets(pr.Namespace).Get(ctx, binding.Secret.SecretName, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get Secret %s of workspace %s: %w", binding.Secret.SecretName, name, err)
+		}
+		content.Items, content.BinaryData = binding.Secret.Items, secret.Data
+	default:
+		return "", nil
+	}
+
+	b, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -27,15 +27,17 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	"go.uber.org/zap"
 	jsonpatch "gomodules.xyz/jsonpatch/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
-var cancelTaskRunPatchBytes, cancelRunPatchBytes []byte
+var cancelTaskRunPatchBytes, cancelRunPatchBytes, cancelPipelineRunPatchBytes []byte
 
 func init() {
 	var err error
@@ -55,6 +57,14 @@ func init() {
 	if err != nil {
 		log.Fatalf("failed to marshal Run cancel patch bytes: %v", err)
 	}
+	cancelPipelineRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{{
+		Operation: "add",
+		Path:      "/spec/status",
+		Value:     v1beta1.PipelineRunSpecStatusCancelled,
+	}})
+	if err != nil {
+		log.Fatalf("failed to marshal PipelineRun cancel patch bytes: %v", err)
+	}
 }
 
 func cancelRun(ctx context.Context, runName string, namespace string, clientSet clientset.Interface) error {
@@ -95,13 +105,29 @@ func cancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1bet
 	return nil
 }
 
-// cancelPipelineTaskRuns patches `TaskRun` and `Run` with canceled status
+// cancelPipelineTaskRuns patches `TaskRun`, `Run` and child `PipelineRun` with canceled status
 func cancelPipelineTaskRuns(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface) []string {
 	errs := []string{}
 
 	// Loop over the TaskRuns in the PipelineRun status.
 	// If a TaskRun is not in the status yet we should not cancel it anyways.
-	for taskRunName := range pr.Status.TaskRuns {
+	reused := sets.NewString()
+	for _, rt := range pr.Status.ReusedTasks {
+		reused.Insert(rt.TaskRunName)
+	}
+	for taskRunName, prtrs := range pr.Status.TaskRuns {
+		if prtrs.WaitingForMutex != "" {
+			// The TaskRun waits for its mutex and wasn't created
+			continue
+		}
+		if reused.Has(taskRunName) {
+			// The TaskRun belongs to the PipelineRun being rerun
+			continue
+		}
+		if prtrs.Status != nil && prtrs.Status.GetCondition(apis.ConditionSucceeded).GetReason() == v1beta1.TaskRunReasonCacheHit.String() {
+			// The results of a previous TaskRun were reused and the TaskRun wasn't created
+			continue
+		}
 		logger.Infof("cancelling TaskRun %s", taskRunName)
 
 		if _, err := clientSet.TektonV1beta1().TaskRuns(pr.Namespace).Patch(ctx, taskRunName, types.JSONPatchType, cancelTaskRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
@@ -118,6 +144,15 @@ func cancelPipelineTaskRuns(ctx context.Context, logger *zap.SugaredLogger, pr *
 			continue
 		}
 	}
+	// Loop over the child PipelineRuns in the PipelineRun status.
+	for childName := range pr.Status.ChildPipelineRuns {
+		logger.Infof("cancelling child PipelineRun %s", childName)
+
+		if _, err := clientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Patch(ctx, childName, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to patch child PipelineRun `%s` with cancellation: %s", childName, err).Error())
+			continue
+		}
+	}
 
 	return errs
 }
@@ -140,3 +175,46 @@ func gracefullyCancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger,
 	}
 	return nil
 }
+
+// cancelPipelineTasks patches the running `TaskRuns`, `Runs` and child `PipelineRuns` of the
+// PipelineTasks cancelled by the user with canceled status
+func cancelPipelineTasks(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, state resources.PipelineRunState, clientSet clientset.Interface) error {
+	errs := []string{}
+	for _, rprt := range state {
+		if rprt.SpecStatus != v1beta1.PipelineTaskSpecStatusCancelled || !rprt.IsRunning() {
+			continue
+		}
+		taskRuns, runs := rprt.TaskRuns, rprt.Runs
+		if !rprt.IsMatrixed() {
+			taskRuns, runs = []*v1beta1.TaskRun{rprt.TaskRun}, []*v1alpha1.Run{rprt.Run}
+		}
+		for _, tr := range taskRuns {
+			if tr == nil || tr.IsDone() || tr.IsCancelled() {
+				continue
+			}
+			logger.Infof("cancelling TaskRun %s of pipeline task %s", tr.Name, rprt.PipelineTask.Name)
+			if _, err := clientSet.TektonV1beta1().TaskRuns(pr.Namespace).Patch(ctx, tr.Name, types.JSONPatchType, cancelTaskRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to patch TaskRun `%s` with cancellation: %s", tr.Name, err).Error())
+			}
+		}
+		for _, run := range runs {
+			if run == nil || run.IsDone() || run.IsCancelled() {
+				continue
+			}
+			logger.Infof("cancelling Run %s of pipeline task %s", run.Name, rprt.PipelineTask.Name)
+			if err := cancelRun(ctx, run.Name, pr.Namespace, clientSet); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to patch Run `%s` with cancellation: %s", run.Name, err).Error())
+			}
+		}
+		if child := rprt.ChildPipelineRun; child != nil && !child.IsDone() && !child.IsCancelled() {
+			logger.Infof("cancelling child PipelineRun %s of pipeline task %s", child.Name, rprt.PipelineTask.Name)
+			if _, err := clientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Patch(ctx, child.Name, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to patch child PipelineRun `%s` with cancellation: %s", child.Name, err).Error())
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error(s) from cancelling pipeline tasks of PipelineRun %s: %s", pr.Name, strings.Join(errs, "\n"))
+	}
+	return nil
+}
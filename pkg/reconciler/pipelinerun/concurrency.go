@@ -0,0 +1,317 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+)
+
+// queuedPipelineRunRequeueInterval is how often a queued PipelineRun is reconciled,
+// in case the PipelineRuns it waits for go away without completing, e.g. when deleted.
+const queuedPipelineRunRequeueInterval = 30 * time.Second
+
+// admissionRequeueInterval is how often a PipelineRun waiting for the admission of another
+// PipelineRun by the same concurrency policy is reconciled.
+const admissionRequeueInterval = time.Second
+
+// admissionLeaseDuration is how long the admission Lease of a concurrency policy is considered
+// held when the admission of its holder isn't reflected in the informer cache, e.g. because
+// recording it failed.
+const admissionLeaseDuration = 30 * time.Second
+
+// getAdmissionLeaseName returns the name of the Lease serializing the admissions of a concurrency policy.
+func getAdmissionLeaseName(policy string) string {
+	return "tekton-concurrency-" + policy
+}
+
+// admitPipelineRun enforces the concurrency policy applying to a PipelineRun which hasn't
+// started yet. It returns false if the PipelineRun can't start: it is then either queued,
+// with its position in the queue reported in its status, or cancelled.
+// The admissions of a policy are serialized through a Lease, which the next admission only takes
+// over once the informer cache reflects the previous one, so that the PipelineRuns it counts
+// include all of the PipelineRuns admitted before. The admission is recorded in an annotation
+// of the PipelineRun before it starts.
+func (c *Reconciler) admitPipelineRun(ctx context.Context, pr *v1beta1.PipelineRun) (bool, error) {
+	policy := concurrencyPolicyFor(ctx, pr)
+	if policy == nil || isAdmitted(pr) {
+		return true, nil
+	}
+	lease, err := c.acquireAdmissionLease(ctx, pr, policy)
+	if err != nil || lease == nil {
+		return false, err
+	}
+	admitted, err := c.admitPipelineRunWithLease(ctx, pr, policy)
+	if err != nil || !admitted {
+		// The concurrent PipelineRuns didn't change, so the next admission doesn't have to wait
+		c.releaseAdmissionLease(ctx, lease)
+	}
+	return admitted, err
+}
+
+// admitPipelineRunWithLease enforces a concurrency policy on a PipelineRun once the admission
+// Lease of the policy is held for it.
+func (c *Reconciler) admitPipelineRunWithLease(ctx context.Context, pr *v1beta1.PipelineRun, policy *config.ConcurrencyPolicy) (bool, error) {
+	logger := logging.FromContext(ctx)
+	running, queued, err := c.getConcurrentPipelineRuns(ctx, pr.Namespace, policy)
+	if err != nil {
+		return false, err
+	}
+
+	switch policy.Strategy {
+	case config.ConcurrencyStrategyCancelNewest:
+		if len(running) >= policy.MaxRuns {
+			logger.Infof("Cancelling PipelineRun %s: %d PipelineRuns are already running for concurrency policy %s", pr.Name, len(running), policy.Name)
+			pr.Status.InitializeConditions()
+			pr.Status.MarkFailed(ReasonCancelled,
+				"PipelineRun %q was cancelled because %d PipelineRuns are already running for concurrency policy %q",
+				pr.Name, len(running), policy.Name)
+			return false, nil
+		}
+	case config.ConcurrencyStrategyCancelOldest:
+		for i := 0; i <= len(running)-policy.MaxRuns; i++ {
+			logger.Infof("Cancelling PipelineRun %s to start PipelineRun %s for concurrency policy %s", running[i].Name, pr.Name, policy.Name)
+			if _, err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Patch(ctx, running[i].Name, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+				return false, fmt.Errorf("failed to cancel PipelineRun %s for concurrency policy %s: %w", running[i].Name, policy.Name, err)
+			}
+		}
+	default:
+		free := policy.MaxRuns - len(running)
+		position := len(queued)
+		for i, q := range queued {
+			if q.Name == pr.Name {
+				position = i
+				break
+			}
+		}
+		if position >= free {
+			if free < 0 {
+				free = 0
+			}
+			pr.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: ReasonQueued,
+				Message: fmt.Sprintf("PipelineRun %q is queued at position %d for concurrency policy %q",
+					pr.Name, position-free+1, policy.Name),
+			})
+			c.enqueueAfter(pr, queuedPipelineRunRequeueInterval)
+			return false, nil
+		}
+	}
+
+	if err := c.recordAdmission(ctx, pr, policy); err != nil {
+		return false, err
+	}
+	if cond := pr.Status.GetCondition(apis.ConditionSucceeded); cond != nil && cond.Reason == ReasonQueued {
+		pr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		})
+	}
+	return true, nil
+}
+
+// acquireAdmissionLease acquires the admission Lease of a concurrency policy for a PipelineRun.
+// It returns nil if the admission of another PipelineRun is in progress: the PipelineRun is then
+// reconciled again shortly.
+func (c *Reconciler) acquireAdmissionLease(ctx context.Context, pr *v1beta1.PipelineRun, policy *config.ConcurrencyPolicy) (*coordinationv1.Lease, error) {
+	leases := c.KubeClientSet.CoordinationV1().Leases(pr.Namespace)
+	now := metav1.NowMicro()
+
+	lease, err := leases.Get(ctx, getAdmissionLeaseName(policy.Name), metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            getAdmissionLeaseName(policy.Name),
+				Namespace:       pr.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity: &pr.Name,
+				AcquireTime:    &now,
+			},
+		}
+		created, err := leases.Create(ctx, lease, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			return c.waitForAdmission(ctx, pr, policy, ""), nil
+		}
+		return created, err
+	case err != nil:
+		return nil, err
+	case lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == pr.Name:
+		// The PipelineRun already holds the Lease, e.g. recording its admission failed
+		return lease, nil
+	}
+	holder := ""
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+	}
+	if c.admissionInProgress(pr.Namespace, holder, lease, now.Time) {
+		return c.waitForAdmission(ctx, pr, policy, holder), nil
+	}
+	lease = lease.DeepCopy()
+	lease.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(pr)}
+	lease.Spec.HolderIdentity = &pr.Name
+	lease.Spec.AcquireTime = &now
+	updated, err := leases.Update(ctx, lease, metav1.UpdateOptions{})
+	if errors.IsConflict(err) {
+		return c.waitForAdmission(ctx, pr, policy, holder), nil
+	}
+	return updated, err
+}
+
+// admissionInProgress returns true if the admission of the PipelineRun holding the admission
+// Lease of a concurrency policy isn't reflected in the informer cache yet. The Lease is
+// considered released once it expires, in case the admission never completes.
+func (c *Reconciler) admissionInProgress(namespace, holder string, lease *coordinationv1.Lease, now time.Time) bool {
+	if holder == "" || lease.Spec.AcquireTime == nil || now.Sub(lease.Spec.AcquireTime.Time) >= admissionLeaseDuration {
+		return false
+	}
+	pr, err := c.pipelineRunLister.PipelineRuns(namespace).Get(holder)
+	if err != nil {
+		// The holder was deleted
+		return false
+	}
+	return !isAdmitted(pr) && !pr.IsDone() && !pr.IsCancelled()
+}
+
+// waitForAdmission requeues a PipelineRun waiting for the admission of another PipelineRun by
+// the same concurrency policy.
+func (c *Reconciler) waitForAdmission(ctx context.Context, pr *v1beta1.PipelineRun, policy *config.ConcurrencyPolicy, holder string) *coordinationv1.Lease {
+	logging.FromContext(ctx).Infof("PipelineRun %s is waiting for the admission of PipelineRun %q for concurrency policy %s", pr.Name, holder, policy.Name)
+	c.enqueueAfter(pr, admissionRequeueInterval)
+	return nil
+}
+
+// releaseAdmissionLease releases the admission Lease of a concurrency policy held by a PipelineRun
+// which wasn't admitted. A Lease which can't be released expires.
+func (c *Reconciler) releaseAdmissionLease(ctx context.Context, lease *coordinationv1.Lease) {
+	precondition := metav1.Preconditions{UID: &lease.UID, ResourceVersion: &lease.ResourceVersion}
+	if err := c.KubeClientSet.CoordinationV1().Leases(lease.Namespace).Delete(ctx, lease.Name, metav1.DeleteOptions{Preconditions: &precondition}); err != nil && !errors.IsNotFound(err) {
+		logging.FromContext(ctx).Warnf("Failed to release admission Lease %s: %v", lease.Name, err)
+	}
+}
+
+// recordAdmission records in an annotation that the PipelineRun was admitted by the concurrency
+// policy, so that it is counted as running by the next admissions even before its start is
+// reflected in its status. The update fails with a conflict if the PipelineRun changed since it
+// was read, in which case it is reconciled again.
+func (c *Reconciler) recordAdmission(ctx context.Context, pr *v1beta1.PipelineRun, policy *config.ConcurrencyPolicy) error {
+	admitted := pr.DeepCopy()
+	if admitted.Annotations == nil {
+		admitted.Annotations = map[string]string{}
+	}
+	admitted.Annotations[pipeline.ConcurrencyAdmittedAnnotationKey] = policy.Name
+	updated, err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Update(ctx, admitted, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to record the admission of PipelineRun %s for concurrency policy %s: %w", pr.Name, policy.Name, err)
+	}
+	pr.Annotations = updated.Annotations
+	return nil
+}
+
+// isAdmitted returns true if the admission of the PipelineRun by its concurrency policy was recorded.
+func isAdmitted(pr *v1beta1.PipelineRun) bool {
+	return pr.Annotations[pipeline.ConcurrencyAdmittedAnnotationKey] != ""
+}
+
+// dequeuePipelineRuns triggers the reconciliation of the PipelineRuns queued behind a
+// PipelineRun which is done, so that they can start.
+func (c *Reconciler) dequeuePipelineRuns(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	policy := concurrencyPolicyFor(ctx, pr)
+	if policy == nil {
+		return nil
+	}
+	_, queued, err := c.getConcurrentPipelineRuns(ctx, pr.Namespace, policy)
+	if err != nil {
+		return err
+	}
+	for _, q := range queued {
+		c.enqueueAfter(q, 0)
+	}
+	return nil
+}
+
+// getConcurrentPipelineRuns returns the PipelineRuns of a namespace to which a concurrency
+// policy applies which are running, sorted by start time, and which are queued, sorted by creation
+// time. A PipelineRun matching several policies only counts against the one applying to it.
+// The PipelineRuns admitted but not started yet are running, after the started ones. Cancelled
+// PipelineRuns are about to complete and are neither running nor queued.
+func (c *Reconciler) getConcurrentPipelineRuns(ctx context.Context, namespace string, policy *config.ConcurrencyPolicy) ([]*v1beta1.PipelineRun, []*v1beta1.PipelineRun, error) {
+	prs, err := c.pipelineRunLister.PipelineRuns(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list PipelineRuns for concurrency policy %s: %w", policy.Name, err)
+	}
+	var running, queued []*v1beta1.PipelineRun
+	for _, pr := range prs {
+		if pr.IsDone() || pr.IsPending() || pr.IsCancelled() {
+			continue
+		}
+		if applying := concurrencyPolicyFor(ctx, pr); applying == nil || applying.Name != policy.Name {
+			continue
+		}
+		if pr.HasStarted() || isAdmitted(pr) {
+			running = append(running, pr)
+		} else {
+			queued = append(queued, pr)
+		}
+	}
+	sort.SliceStable(running, func(i, j int) bool {
+		if running[j].Status.StartTime == nil {
+			return running[i].Status.StartTime != nil
+		}
+		return running[i].Status.StartTime.Before(running[j].Status.StartTime)
+	})
+	sort.Slice(queued, func(i, j int) bool {
+		if queued[i].CreationTimestamp.Equal(&queued[j].CreationTimestamp) {
+			return queued[i].Name < queued[j].Name
+		}
+		return queued[i].CreationTimestamp.Before(&queued[j].CreationTimestamp)
+	})
+	return running, queued, nil
+}
+
+// concurrencyPolicyFor returns the concurrency policy applying to a PipelineRun, if any.
+func concurrencyPolicyFor(ctx context.Context, pr *v1beta1.PipelineRun) *config.ConcurrencyPolicy {
+	return config.FromContextOrDefaults(ctx).Concurrency.PolicyFor(pipelineNameOf(pr), pr.Labels)
+}
+
+// pipelineNameOf returns the name of the Pipeline of a PipelineRun, which is only
+// known from its label once the PipelineRun started if its Pipeline is embedded.
+func pipelineNameOf(pr *v1beta1.PipelineRun) string {
+	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Name != "" {
+		return pr.Spec.PipelineRef.Name
+	}
+	return pr.Labels[pipeline.PipelineLabelKey]
+}
@@ -83,7 +83,14 @@ func NewController(opts *pipeline.Options) func(context.Context, configmap.Watch
 			}
 		})
 
+		c.enqueueAfter = impl.EnqueueAfter
+
 		pipelineRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+		// Child PipelineRuns created for PipelineTasks running a Pipeline enqueue their parent PipelineRun
+		pipelineRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		})
 
 		taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
@@ -0,0 +1,241 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+)
+
+// mutexRequeueInterval is how often a PipelineRun with a PipelineTask waiting for its mutex
+// is reconciled, in case the mutex is released without the PipelineRun being notified.
+const mutexRequeueInterval = 10 * time.Second
+
+// getMutexLeaseName returns the name of the Lease backing a mutex.
+func getMutexLeaseName(mutex string) string {
+	return "tekton-mutex-" + mutex
+}
+
+// acquireMutex tries to acquire the mutex of a PipelineTask for its TaskRun, through a Lease held
+// by the TaskRun. It returns false if the mutex is held by another TaskRun: the PipelineTask then
+// waits for it, which is reported in the status of the PipelineRun.
+func (c *Reconciler) acquireMutex(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) (bool, error) {
+	logger := logging.FromContext(ctx)
+	mutex := rprt.PipelineTask.Mutex
+	leases := c.KubeClientSet.CoordinationV1().Leases(pr.Namespace)
+	now := metav1.NowMicro()
+
+	lease, err := leases.Get(ctx, getMutexLeaseName(mutex), metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            getMutexLeaseName(mutex),
+				Namespace:       pr.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+				Labels:          map[string]string{pipeline.MutexLabelKey: mutex},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity: &rprt.TaskRunName,
+				AcquireTime:    &now,
+			},
+		}
+		if _, err := leases.Create(ctx, lease, metav1.CreateOptions{}); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return c.waitForMutex(ctx, pr, rprt, ""), nil
+			}
+			return false, err
+		}
+	case err != nil:
+		return false, err
+	case lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == rprt.TaskRunName:
+		// The TaskRun already holds the mutex
+	default:
+		holder := ""
+		if lease.Spec.HolderIdentity != nil {
+			holder = *lease.Spec.HolderIdentity
+		}
+		if holder != "" {
+			// A TaskRun created right after acquiring the mutex may not be in the lister yet,
+			// which the grace period of the mutex accounts for.
+			tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(holder)
+			switch {
+			case errors.IsNotFound(err):
+				tr = nil
+			case err != nil:
+				return false, err
+			}
+			if holdsMutex(tr, lease, now.Time, mutexGracePeriod(ctx)) {
+				return c.waitForMutex(ctx, pr, rprt, holder), nil
+			}
+		}
+		// The TaskRun holding the mutex is done or gone without releasing it
+		lease = lease.DeepCopy()
+		lease.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(pr)}
+		lease.Spec.HolderIdentity = &rprt.TaskRunName
+		lease.Spec.AcquireTime = &now
+		if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+			if errors.IsConflict(err) {
+				return c.waitForMutex(ctx, pr, rprt, holder), nil
+			}
+			return false, err
+		}
+	}
+	logger.Infof("TaskRun %s of PipelineRun %s acquired mutex %s", rprt.TaskRunName, pr.Name, mutex)
+	rprt.WaitingForMutex = ""
+	return true, nil
+}
+
+// waitForMutex marks a PipelineTask as waiting for its mutex, and requeues its PipelineRun.
+func (c *Reconciler) waitForMutex(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask, holder string) bool {
+	logging.FromContext(ctx).Infof("PipelineTask %s of PipelineRun %s is waiting for mutex %s held by %q", rprt.PipelineTask.Name, pr.Name, rprt.PipelineTask.Mutex, holder)
+	rprt.WaitingForMutex = rprt.PipelineTask.Mutex
+	c.enqueueAfter(pr, mutexRequeueInterval)
+	return false
+}
+
+// releaseMutexes releases the mutexes held by the TaskRuns of a PipelineRun which are done,
+// and triggers the reconciliation of the PipelineRuns waiting for them. Once its mutex is
+// released, a TaskRun is marked with its completion time so that it isn't released again,
+// unless it completes again after being retried.
+func (c *Reconciler) releaseMutexes(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	logger := logging.FromContext(ctx)
+	hasMutex, err := labels.NewRequirement(pipeline.MutexLabelKey, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	selector := labels.SelectorFromSet(getTaskrunLabels(pr, "", false)).Add(*hasMutex)
+	trs, err := c.taskRunLister.TaskRuns(pr.Namespace).List(selector)
+	if err != nil {
+		return fmt.Errorf("failed to list TaskRuns holding a mutex: %w", err)
+	}
+	leases := c.KubeClientSet.CoordinationV1().Leases(pr.Namespace)
+	for _, tr := range trs {
+		if !tr.IsDone() {
+			continue
+		}
+		completionTime := ""
+		if tr.Status.CompletionTime != nil {
+			completionTime = tr.Status.CompletionTime.UTC().Format(time.RFC3339)
+		}
+		if released, ok := tr.Annotations[pipeline.MutexReleasedAnnotationKey]; ok && released == completionTime {
+			continue
+		}
+		mutex := tr.Labels[pipeline.MutexLabelKey]
+		lease, err := leases.Get(ctx, getMutexLeaseName(mutex), metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+		case err != nil:
+			return err
+		case lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != tr.Name:
+		case holdsMutex(tr, lease, time.Now(), mutexGracePeriod(ctx)):
+			continue
+		default:
+			precondition := metav1.Preconditions{UID: &lease.UID, ResourceVersion: &lease.ResourceVersion}
+			if err := leases.Delete(ctx, lease.Name, metav1.DeleteOptions{Preconditions: &precondition}); err != nil {
+				if errors.IsConflict(err) {
+					continue
+				}
+				if !errors.IsNotFound(err) {
+					return err
+				}
+			}
+			logger.Infof("TaskRun %s of PipelineRun %s released mutex %s", tr.Name, pr.Name, mutex)
+			if err := c.notifyMutexWaiters(pr.Namespace, mutex); err != nil {
+				return err
+			}
+		}
+		if err := c.markMutexReleased(ctx, tr, completionTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markMutexReleased records in an annotation of a TaskRun that its mutex was released after it
+// completed at the given time.
+func (c *Reconciler) markMutexReleased(ctx context.Context, tr *v1beta1.TaskRun, completionTime string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{pipeline.MutexReleasedAnnotationKey: completionTime},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.PipelineClientSet.TektonV1beta1().TaskRuns(tr.Namespace).Patch(ctx, tr.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to mark the mutex of TaskRun %s as released: %w", tr.Name, err)
+	}
+	return nil
+}
+
+// notifyMutexWaiters triggers the reconciliation of the PipelineRuns of a namespace
+// with a PipelineTask waiting for a mutex.
+func (c *Reconciler) notifyMutexWaiters(namespace, mutex string) error {
+	prs, err := c.pipelineRunLister.PipelineRuns(namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list PipelineRuns waiting for mutex %s: %w", mutex, err)
+	}
+	for _, pr := range prs {
+		if pr.IsDone() {
+			continue
+		}
+		for _, prtrs := range pr.Status.TaskRuns {
+			if prtrs.WaitingForMutex == mutex {
+				c.enqueueAfter(pr, 0)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// holdsMutex returns true if a TaskRun still holds the mutex of a Lease it acquired. A TaskRun
+// which is done may have been retried since, acquiring the mutex again after completing. A TaskRun
+// which doesn't exist holds the mutex during the grace period following its acquisition: it may be
+// about to be created, or its creation may have failed and be retried.
+func holdsMutex(tr *v1beta1.TaskRun, lease *coordinationv1.Lease, now time.Time, gracePeriod time.Duration) bool {
+	if tr == nil {
+		return lease.Spec.AcquireTime != nil && now.Sub(lease.Spec.AcquireTime.Time) < gracePeriod
+	}
+	if !tr.IsDone() {
+		return true
+	}
+	return lease.Spec.AcquireTime != nil && tr.Status.CompletionTime != nil &&
+		lease.Spec.AcquireTime.Time.After(tr.Status.CompletionTime.Time)
+}
+
+// mutexGracePeriod returns how long a mutex is considered held after being acquired for a TaskRun
+// which can't be found.
+func mutexGracePeriod(ctx context.Context) time.Duration {
+	return time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultMutexGracePeriodSeconds) * time.Second
+}
@@ -38,6 +38,7 @@ import (
 	listersv1alpha1 "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
 	resourcelisters "github.com/tektoncd/pipeline/pkg/client/resource/listers/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/matrix"
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events"
@@ -54,6 +55,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/controller"
@@ -78,6 +80,15 @@ const (
 	// parameter(s) declared in the PipelineRun do not have the some declared type as the
 	// parameters(s) declared in the Pipeline that they are supposed to override.
 	ReasonParameterTypeMismatch = "ParameterTypeMismatch"
+	// ReasonObjectParameterMissKeys indicates that the object param value provided from PipelineRun spec
+	// misses some keys required for the object param declared in Pipeline spec.
+	ReasonObjectParameterMissKeys = "ObjectParameterMissKeys"
+	// ReasonParameterValueInvalid indicates that the value of parameter(s) declared in the PipelineRun
+	// do not satisfy the constraints of the parameter(s) declared in the Pipeline.
+	ReasonParameterValueInvalid = "ParameterValueInvalid"
+	// ReasonInvalidRerun indicates that the PipelineRun to rerun or the PipelineTasks
+	// to rerun it from are invalid.
+	ReasonInvalidRerun = "InvalidRerun"
 	// ReasonCouldntGetTask indicates that the reason for the failure status is that the
 	// associated Pipeline's Tasks couldn't all be retrieved
 	ReasonCouldntGetTask = "CouldntGetTask"
@@ -96,12 +107,21 @@ const (
 	// ReasonInvalidGraph indicates that the reason for the failure status is that the
 	// associated Pipeline is an invalid graph (a.k.a wrong order, cycle, …)
 	ReasonInvalidGraph = "PipelineInvalidGraph"
+	// ReasonInvalidChildPipeline indicates that the reason for the failure status is that a
+	// PipelineTask runs a Pipeline the PipelineRun is nested in, or nests PipelineRuns too deeply
+	ReasonInvalidChildPipeline = "InvalidChildPipeline"
+	// ReasonInvalidMatrixCombinationsCount indicates that the reason for the failure status is that a
+	// matrixed PipelineTask fans out into more combinations than allowed once its parameters are substituted
+	ReasonInvalidMatrixCombinationsCount = "InvalidMatrixCombinationsCount"
 	// ReasonCancelled indicates that a PipelineRun was cancelled.
 	ReasonCancelled = pipelinerunmetrics.ReasonCancelled
 	// ReasonCancelledDeprecated Deprecated: "PipelineRunCancelled" indicates that a PipelineRun was cancelled.
 	ReasonCancelledDeprecated = pipelinerunmetrics.ReasonCancelledDeprecated
 	// ReasonPending indicates that a PipelineRun is pending.
 	ReasonPending = "PipelineRunPending"
+	// ReasonQueued indicates that a PipelineRun is waiting for other PipelineRuns
+	// matching the same concurrency policy to complete.
+	ReasonQueued = "PipelineRunQueued"
 	// ReasonCouldntCancel indicates that a PipelineRun was cancelled but attempting to update
 	// all of the running TaskRuns as cancelled failed.
 	ReasonCouldntCancel = "PipelineRunCouldntCancel"
@@ -111,6 +131,9 @@ const (
 	// ReasonRequiredWorkspaceMarkedOptional indicates an optional workspace
 	// has been passed to a Task that is expecting a non-optional workspace
 	ReasonRequiredWorkspaceMarkedOptional = "RequiredWorkspaceMarkedOptional"
+	// ReasonCELEvaluationFailed indicates a CEL expression in the when expressions
+	// of a pipeline task couldn't be evaluated
+	ReasonCELEvaluationFailed = "CELEvaluationFailed"
 )
 
 // Reconciler implements controller.Reconciler for Configuration resources.
@@ -132,6 +155,10 @@ type Reconciler struct {
 	metrics           *pipelinerunmetrics.Recorder
 	pvcHandler        volumeclaim.PvcHandler
 
+	// enqueueAfter requeues a PipelineRun after a delay, e.g. to retry
+	// a failed PipelineTask once the backoff of its RetryPolicy elapsed
+	enqueueAfter func(interface{}, time.Duration)
+
 	// disableResolution is a flag to the reconciler that it should
 	// not be performing resolution of pipelineRefs.
 	// TODO(sbwsg): Once we've agreed on a way forward for TEP-0060
@@ -158,6 +185,15 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 	// Read the initial condition
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
 
+	if !pr.HasStarted() && !pr.IsPending() && !pr.IsCancelled() {
+		if admitted, err := c.admitPipelineRun(ctx, pr); err != nil || !admitted {
+			if err != nil {
+				logger.Errorf("Failed to enforce the concurrency policy of pipelinerun %s: %v", pr.Name, err)
+			}
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+	}
+
 	if !pr.HasStarted() && !pr.IsPending() {
 		pr.Status.InitializeConditions()
 		// In case node time was not synchronized, when controller has been scheduled to other nodes.
@@ -186,6 +222,11 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
 	}
 
+	// A PipelineRun which failed runs again when one of its failed tasks is requested to be retried
+	if reopenForRequestedRetry(pr) {
+		logger.Infof("PipelineRun %s is running again to retry the tasks requested to be retried", pr.Name)
+	}
+
 	if pr.IsDone() {
 		pr.SetDefaults(ctx)
 
@@ -205,6 +246,18 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 			logger.Errorf("Failed to update Run status for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 		}
+		if err := c.updateChildPipelineRunsStatusDirectly(pr); err != nil {
+			logger.Errorf("Failed to update child PipelineRun status for PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+		if err := c.dequeuePipelineRuns(ctx, pr); err != nil {
+			logger.Errorf("Failed to dequeue PipelineRuns after PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+		if err := c.releaseMutexes(ctx, pr); err != nil {
+			logger.Errorf("Failed to release mutexes of PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
 		go func(metrics *pipelinerunmetrics.Recorder) {
 			err := metrics.DurationAndCount(pr)
 			if err != nil {
@@ -233,6 +286,12 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 	}
 
+	// Release the mutexes held by the TaskRuns which are done, before scheduling the next ones
+	if err := c.releaseMutexes(ctx, pr); err != nil {
+		logger.Errorf("Failed to release mutexes of PipelineRun %s: %v", pr.Name, err)
+		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+	}
+
 	// Reconcile this copy of the pipelinerun and then write back any status or label
 	// updates regardless of whether the reconciliation errored out.
 	if err = c.reconcile(ctx, pr, getPipelineFunc); err != nil {
@@ -305,6 +364,9 @@ func (c *Reconciler) resolvePipelineState(
 			func(name string) (*v1alpha1.Run, error) {
 				return c.runLister.Runs(pr.Namespace).Get(name)
 			},
+			func(name string) (*v1beta1.PipelineRun, error) {
+				return c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(name)
+			},
 			func(name string) (*v1alpha1.Condition, error) {
 				return c.conditionLister.Conditions(pr.Namespace).Get(name)
 			},
@@ -393,10 +455,10 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	}
 
 	// build DAG with a list of final tasks, this DAG is used later to identify
-	// if a task in PipelineRunState is final task or not
+	// if a task in PipelineRunState is final task or not and to order the final tasks
 	// the finally section is optional and might not exist
 	// dfinally holds an empty Graph in the absence of finally clause
-	dfinally, err := dag.Build(v1beta1.PipelineTaskList(pipelineSpec.Finally), map[string][]string{})
+	dfinally, err := dag.Build(v1beta1.PipelineTaskList(pipelineSpec.Finally), v1beta1.PipelineTaskList(pipelineSpec.Finally).FinallyDeps())
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.MarkFailed(ReasonInvalidGraph,
@@ -457,6 +519,24 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	}
 
+	// Ensure that the keys of the object parameters required by the Pipeline are provided by the PipelineRun.
+	if err := resources.ValidateObjectParamRequiredKeys(pipelineSpec.Params, pr.Spec.Params); err != nil {
+		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		pr.Status.MarkFailed(ReasonObjectParameterMissKeys,
+			"PipelineRun %s/%s parameters is missing object keys required by Pipeline %s/%s's parameters: %s",
+			pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
+	// Ensure that the values of the parameters satisfy the constraints declared by the Pipeline.
+	if err := v1beta1.ValidateParamValues(pipelineSpec.Params, pr.Spec.Params); err != nil {
+		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		pr.Status.MarkFailed(ReasonParameterValueInvalid,
+			"PipelineRun %s/%s parameters have invalid values for Pipeline %s/%s's parameters: %s",
+			pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	// Ensure that the workspaces expected by the Pipeline are provided by the PipelineRun.
 	if err := resources.ValidateWorkspaceBindings(pipelineSpec, pr); err != nil {
 		pr.Status.MarkFailed(ReasonInvalidWorkspaceBinding,
@@ -482,11 +562,28 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	}
 
+	// Ensure that the PipelineTasks running a Pipeline don't nest PipelineRuns endlessly.
+	if err := resources.ValidateChildPipelines(pipelineSpec, pr); err != nil {
+		pr.Status.MarkFailed(ReasonInvalidChildPipeline,
+			"PipelineRun %s/%s can't run the child Pipelines of Pipeline %s/%s: %s",
+			pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	// Apply parameter substitution from the PipelineRun
 	pipelineSpec = resources.ApplyParameters(pipelineSpec, pr)
 	pipelineSpec = resources.ApplyContexts(pipelineSpec, pipelineMeta.Name, pr)
 	pipelineSpec = resources.ApplyWorkspaces(pipelineSpec, pr)
 
+	// Ensure that the matrixed PipelineTasks don't fan out into too many combinations once the
+	// array parameters referenced in their matrix are substituted.
+	if err := resources.ValidateMatrixCombinationsCount(ctx, pipelineSpec); err != nil {
+		pr.Status.MarkFailed(ReasonInvalidMatrixCombinationsCount,
+			"PipelineRun %s/%s can't fan out the matrixed PipelineTasks of Pipeline %s/%s: %s",
+			pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	// pipelineState holds a list of pipeline tasks after resolving conditions and pipeline resources
 	// pipelineState also holds a taskRun for each pipeline task after the taskRun is created
 	// pipelineState is instantiated and updated on every reconcile cycle
@@ -511,8 +608,8 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	}
 
 	for _, rprt := range pipelineRunFacts.State {
-		if !rprt.IsCustomTask() {
-			err := taskrun.ValidateResolvedTaskResources(ctx, rprt.PipelineTask.Params, rprt.ResolvedTaskResources)
+		if !rprt.IsCustomTask() && !rprt.RunsPipeline() && !rprt.IsApproval() {
+			err := taskrun.ValidateResolvedTaskResources(ctx, getParamsToValidate(rprt.PipelineTask), rprt.ResolvedTaskResources)
 			if err != nil {
 				logger.Errorf("Failed to validate pipelinerun %q with error %v", pr.Name, err)
 				pr.Status.MarkFailed(ReasonFailedValidation, err.Error())
@@ -574,6 +671,23 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		}
 	}
 
+	if pr.Spec.Rerun != nil {
+		// Reuse the TaskRuns of the PipelineRun being rerun, once, before creating any TaskRun
+		if pipelineRunFacts.State.IsBeforeFirstTaskRun() && pr.Status.ReusedTasks == nil {
+			if err := c.reuseTasks(ctx, pr, pipelineSpec, d); err != nil {
+				return err
+			}
+		}
+		pipelineRunFacts.State.ApplyReusedTasks(pr)
+	}
+	pipelineRunFacts.State.ApplyCacheHits(pr)
+	c.processApprovals(ctx, pr, pipelineRunFacts.State)
+
+	if err := cancelPipelineTasks(ctx, logger, pr, pipelineRunFacts.State, c.PipelineClientSet); err != nil {
+		// failed to cancel tasks, maybe retry would help (don't return permanent error)
+		return err
+	}
+
 	as, err := artifacts.InitializeArtifactStorage(ctx, c.Images, pr, pipelineSpec, c.KubeClientSet)
 	if err != nil {
 		logger.Infof("PipelineRun failed to initialize artifact storage %s", pr.Name)
@@ -605,9 +719,11 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	pr.Status.StartTime = pipelineRunFacts.State.AdjustStartTime(pr.Status.StartTime)
 	pr.Status.TaskRuns = pipelineRunFacts.State.GetTaskRunsStatus(pr)
 	pr.Status.Runs = pipelineRunFacts.State.GetRunsStatus(pr)
+	pr.Status.ChildPipelineRuns = pipelineRunFacts.State.GetChildPipelineRunsStatus(pr)
 	pr.Status.SkippedTasks = pipelineRunFacts.GetSkippedTasks()
+	pr.Status.Approvals = pipelineRunFacts.State.GetApprovalsStatus()
 	if after.Status == corev1.ConditionTrue {
-		pr.Status.PipelineResults = resources.ApplyTaskResultsToPipelineResults(pipelineSpec.Results, pr.Status.TaskRuns, pr.Status.Runs)
+		pr.Status.PipelineResults = resources.ApplyTaskResultsToPipelineResults(pipelineSpec.Results, pr.Status.TaskRuns, pr.Status.Runs, pipelineRunFacts.State.GetChildPipelineRunResults())
 	}
 
 	logger.Infof("PipelineRun %s status is being set to %s", pr.Name, after)
@@ -623,13 +739,20 @@ func (c *Reconciler) processRunTimeouts(ctx context.Context, pr *v1beta1.Pipelin
 		return nil
 	}
 	for _, rprt := range pipelineState {
-		if rprt.IsCustomTask() {
-			if rprt.Run != nil && !rprt.Run.IsCancelled() && (pr.IsTimedOut() || (rprt.Run.HasTimedOut() && !rprt.Run.IsDone())) {
-				logger.Infof("Cancelling run task: %s due to timeout.", rprt.RunName)
-				err := cancelRun(ctx, rprt.RunName, pr.Namespace, c.PipelineClientSet)
+		if !rprt.IsCustomTask() {
+			continue
+		}
+		runs := rprt.Runs
+		if !rprt.IsMatrixed() {
+			runs = []*v1alpha1.Run{rprt.Run}
+		}
+		for _, run := range runs {
+			if run != nil && !run.IsCancelled() && (pr.IsTimedOut() || (run.HasTimedOut() && !run.IsDone())) {
+				logger.Infof("Cancelling run task: %s due to timeout.", run.Name)
+				err := cancelRun(ctx, run.Name, pr.Namespace, c.PipelineClientSet)
 				if err != nil {
 					errs = append(errs,
-						fmt.Errorf("failed to patch Run `%s` with cancellation: %s", rprt.RunName, err).Error())
+						fmt.Errorf("failed to patch Run `%s` with cancellation: %s", run.Name, err).Error())
 				}
 			}
 		}
@@ -691,23 +814,78 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 		if rprt == nil || rprt.Skip(pipelineRunFacts).IsSkipped || rprt.IsFinallySkipped(pipelineRunFacts).IsSkipped {
 			continue
 		}
+		if _, err := rprt.EvaluateCEL(pipelineRunFacts); err != nil {
+			logger.Infof("Failed to evaluate the when expressions of pipeline task %q for %q with error %v", rprt.PipelineTask.Name, pr.Name, err)
+			pr.Status.MarkFailed(ReasonCELEvaluationFailed, err.Error())
+			return controller.NewPermanentError(err)
+		}
+		if wait := rprt.RetryBackoff(time.Now()); wait > 0 {
+			logger.Infof("Retrying pipeline task %s of PipelineRun %s in %s", rprt.PipelineTask.Name, pr.Name, wait)
+			c.enqueueAfter(pr, wait)
+			continue
+		}
+		if rprt.NeedsNextIteration() {
+			if wait := rprt.IterationDelay(time.Now()); wait > 0 {
+				logger.Infof("Starting the next iteration of pipeline task %s of PipelineRun %s in %s", rprt.PipelineTask.Name, pr.Name, wait)
+				c.enqueueAfter(pr, wait)
+				continue
+			}
+			rprt.StartNextIteration(pr.Name)
+		}
 		if rprt.ResolvedConditionChecks == nil || rprt.ResolvedConditionChecks.IsSuccess() {
-			if rprt.IsCustomTask() {
-				if rprt.IsFinalTask(pipelineRunFacts) {
-					rprt.Run, err = c.createRun(ctx, rprt, pr, getFinallyTaskRunTimeout)
-				} else {
-					rprt.Run, err = c.createRun(ctx, rprt, pr, getTaskRunTimeout)
+			if rprt.IsApproval() {
+				c.startApproval(ctx, pr, rprt)
+				continue
+			}
+			if rprt.PipelineTask.Cache != nil && rprt.TaskRun == nil && !rprt.IsCustomTask() && !rprt.IsMatrixed() && !rprt.RunsPipeline() {
+				reused, err := c.reuseCachedResults(ctx, pr, rprt)
+				if err != nil {
+					return fmt.Errorf("error looking up cached results for PipelineTask %s from PipelineRun %s: %w", rprt.PipelineTask.Name, pr.Name, err)
 				}
+				if reused {
+					continue
+				}
+			}
+			if rprt.PipelineTask.Mutex != "" {
+				acquired, err := c.acquireMutex(ctx, pr, rprt)
+				if err != nil {
+					return fmt.Errorf("error acquiring mutex %s for PipelineTask %s from PipelineRun %s: %w", rprt.PipelineTask.Mutex, rprt.PipelineTask.Name, pr.Name, err)
+				}
+				if !acquired {
+					continue
+				}
+			}
+			getTimeoutFunc := getTaskRunTimeout
+			if rprt.IsFinalTask(pipelineRunFacts) {
+				getTimeoutFunc = getFinallyTaskRunTimeout
+			}
+			switch {
+			case rprt.RunsPipeline():
+				rprt.ChildPipelineRun, err = c.createChildPipelineRun(ctx, rprt, pr, getTimeoutFunc)
+				if err != nil {
+					recorder.Eventf(pr, corev1.EventTypeWarning, "ChildPipelineRunCreationFailed", "Failed to create child PipelineRun %q: %v", rprt.ChildPipelineRunName, err)
+					return fmt.Errorf("error creating child PipelineRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.ChildPipelineRunName, rprt.PipelineTask.Name, pr.Name, err)
+				}
+			case rprt.IsCustomTask() && rprt.IsMatrixed():
+				rprt.Runs, err = c.createRuns(ctx, rprt, pr, getTimeoutFunc)
+				if err != nil {
+					recorder.Eventf(pr, corev1.EventTypeWarning, "RunsCreationFailed", "Failed to create Runs %q: %v", rprt.RunNames, err)
+					return fmt.Errorf("error creating Runs called %s for PipelineTask %s from PipelineRun %s: %w", rprt.RunNames, rprt.PipelineTask.Name, pr.Name, err)
+				}
+			case rprt.IsMatrixed():
+				rprt.TaskRuns, err = c.createTaskRuns(ctx, rprt, pr, as.StorageBasePath(pr), getTimeoutFunc)
+				if err != nil {
+					recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunsCreationFailed", "Failed to create TaskRuns %q: %v", rprt.TaskRunNames, err)
+					return fmt.Errorf("error creating TaskRuns called %s for PipelineTask %s from PipelineRun %s: %w", rprt.TaskRunNames, rprt.PipelineTask.Name, pr.Name, err)
+				}
+			case rprt.IsCustomTask():
+				rprt.Run, err = c.createRun(ctx, rprt.RunName, nil, rprt, pr, getTimeoutFunc)
 				if err != nil {
 					recorder.Eventf(pr, corev1.EventTypeWarning, "RunCreationFailed", "Failed to create Run %q: %v", rprt.RunName, err)
 					return fmt.Errorf("error creating Run called %s for PipelineTask %s from PipelineRun %s: %w", rprt.RunName, rprt.PipelineTask.Name, pr.Name, err)
 				}
-			} else {
-				if rprt.IsFinalTask(pipelineRunFacts) {
-					rprt.TaskRun, err = c.createTaskRun(ctx, rprt, pr, as.StorageBasePath(pr), getFinallyTaskRunTimeout)
-				} else {
-					rprt.TaskRun, err = c.createTaskRun(ctx, rprt, pr, as.StorageBasePath(pr), getTaskRunTimeout)
-				}
+			default:
+				rprt.TaskRun, err = c.createTaskRun(ctx, rprt.TaskRunName, nil, rprt, pr, as.StorageBasePath(pr), getTimeoutFunc)
 				if err != nil {
 					recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create TaskRun %q: %v", rprt.TaskRunName, err)
 					return fmt.Errorf("error creating TaskRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.TaskRunName, rprt.PipelineTask.Name, pr.Name, err)
@@ -758,12 +936,89 @@ func (c *Reconciler) updateRunsStatusDirectly(pr *v1beta1.PipelineRun) error {
 	return nil
 }
 
+func (c *Reconciler) updateChildPipelineRunsStatusDirectly(pr *v1beta1.PipelineRun) error {
+	for childName := range pr.Status.ChildPipelineRuns {
+		prcprs := pr.Status.ChildPipelineRuns[childName]
+		child, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(childName)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving child PipelineRun %s: %w", childName, err)
+			}
+		} else {
+			prcprs.Condition = child.Status.GetCondition(apis.ConditionSucceeded).DeepCopy()
+		}
+	}
+	return nil
+}
+
+// reopenForRequestedRetry marks a PipelineRun which failed as running again if one of its PipelineTasks,
+// whose failed TaskRun or Run exhausted its retries, is requested to be retried but wasn't retried on
+// request yet. It returns whether the PipelineRun was marked as running again.
+func reopenForRequestedRetry(pr *v1beta1.PipelineRun) bool {
+	c := pr.Status.GetCondition(apis.ConditionSucceeded)
+	if !c.IsFalse() || c.Reason != v1beta1.PipelineRunReasonFailed.String() || pr.Status.PipelineSpec == nil {
+		return false
+	}
+	retries := map[string]int{}
+	for _, pt := range append(append([]v1beta1.PipelineTask{}, pr.Status.PipelineSpec.Tasks...), pr.Status.PipelineSpec.Finally...) {
+		retries[pt.Name] = pt.Retries
+	}
+	retryable := func(c *apis.Condition, retriesDone int, pipelineTaskName string) bool {
+		r, ok := retries[pipelineTaskName]
+		return ok && c.IsFalse() && c.Reason != v1beta1.TaskRunReasonCancelled.String() && c.Reason != v1alpha1.RunReasonCancelled && retriesDone == r
+	}
+	for _, ts := range pr.Spec.TaskStatuses {
+		if ts.Status != v1beta1.PipelineTaskSpecStatusRetry {
+			continue
+		}
+		for _, trStatus := range pr.Status.TaskRuns {
+			if trStatus.PipelineTaskName == ts.PipelineTaskName && trStatus.Status != nil &&
+				retryable(trStatus.Status.GetCondition(apis.ConditionSucceeded), len(trStatus.Status.RetriesStatus), ts.PipelineTaskName) {
+				pr.Status.MarkRunning(v1beta1.PipelineRunReasonRunning.String(), "Retrying pipeline task %q", ts.PipelineTaskName)
+				pr.Status.CompletionTime = nil
+				return true
+			}
+		}
+		for _, runStatus := range pr.Status.Runs {
+			if runStatus.PipelineTaskName == ts.PipelineTaskName && runStatus.Status != nil &&
+				retryable(runStatus.Status.GetCondition(apis.ConditionSucceeded), len(runStatus.Status.RetriesStatus), ts.PipelineTaskName) {
+				pr.Status.MarkRunning(v1beta1.PipelineRunReasonRunning.String(), "Retrying pipeline task %q", ts.PipelineTaskName)
+				pr.Status.CompletionTime = nil
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type getTimeoutFunc func(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) *metav1.Duration
 
-func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string, getTimeoutFunc getTimeoutFunc) (*v1beta1.TaskRun, error) {
+// createTaskRuns creates the TaskRuns of a matrixed PipelineTask which are yet to be created, and
+// retries the ones which have failed and haven't exhausted their retries, one for each combination
+// of the Matrix. It returns all the TaskRuns of the PipelineTask.
+func (c *Reconciler) createTaskRuns(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string, getTimeoutFunc getTimeoutFunc) ([]*v1beta1.TaskRun, error) {
+	rprt.PipelineTask = resources.ApplyPipelineTaskContexts(rprt.PipelineTask)
+	combinations := matrix.FanOut(rprt.PipelineTask.Matrix)
+	pending := sets.NewString(rprt.GetPendingTaskRunNames()...)
+	taskRuns := make([]*v1beta1.TaskRun, len(rprt.TaskRunNames))
+	copy(taskRuns, rprt.TaskRuns)
+	for i, taskRunName := range rprt.TaskRunNames {
+		if !pending.Has(taskRunName) || i >= len(combinations) {
+			continue
+		}
+		taskRun, err := c.createTaskRun(ctx, taskRunName, combinations[i], rprt, pr, storageBasePath, getTimeoutFunc)
+		if err != nil {
+			return nil, err
+		}
+		taskRuns[i] = taskRun
+	}
+	return taskRuns, nil
+}
+
+func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, matrixParams []v1beta1.Param, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string, getTimeoutFunc getTimeoutFunc) (*v1beta1.TaskRun, error) {
 	logger := logging.FromContext(ctx)
 
-	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(rprt.TaskRunName)
+	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(taskRunName)
 	if tr != nil {
 		// Don't modify the lister cache's copy.
 		tr = tr.DeepCopy()
@@ -779,17 +1034,20 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 	}
 
 	rprt.PipelineTask = resources.ApplyPipelineTaskContexts(rprt.PipelineTask)
+	if rprt.Iterates() {
+		rprt.PipelineTask = resources.ApplyIterationContext(rprt.PipelineTask, rprt.Iteration)
+	}
 	taskRunSpec := pr.GetTaskRunSpec(rprt.PipelineTask.Name)
 	tr = &v1beta1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            rprt.TaskRunName,
+			Name:            taskRunName,
 			Namespace:       pr.Namespace,
 			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
 			Labels:          combineTaskRunAndTaskSpecLabels(pr, rprt.PipelineTask),
 			Annotations:     combineTaskRunAndTaskSpecAnnotations(pr, rprt.PipelineTask),
 		},
 		Spec: v1beta1.TaskRunSpec{
-			Params:             rprt.PipelineTask.Params,
+			Params:             combineParams(rprt.PipelineTask.Params, matrixParams),
 			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
 			Timeout:            getTimeoutFunc(ctx, pr, rprt),
 			PodTemplate:        taskRunSpec.TaskPodTemplate,
@@ -813,17 +1071,57 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 		tr.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
 	}
 
+	if rprt.CacheKey != "" {
+		tr.Labels[pipeline.CacheKeyLabelKey] = rprt.CacheKey
+	}
+
 	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, storageBasePath)
-	logger.Infof("Creating a new TaskRun object %s for pipeline task %s", rprt.TaskRunName, rprt.PipelineTask.Name)
+	logger.Infof("Creating a new TaskRun object %s for pipeline task %s", taskRunName, rprt.PipelineTask.Name)
 	return c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
 }
 
-func (c *Reconciler) createRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, getTimeoutFunc getTimeoutFunc) (*v1alpha1.Run, error) {
+// createRuns creates the Runs of a matrixed custom PipelineTask which are yet to be created, one
+// for each combination of the Matrix. It returns all the Runs of the PipelineTask.
+func (c *Reconciler) createRuns(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, getTimeoutFunc getTimeoutFunc) ([]*v1alpha1.Run, error) {
+	combinations := matrix.FanOut(rprt.PipelineTask.Matrix)
+	pending := sets.NewString(rprt.GetPendingRunNames()...)
+	runs := make([]*v1alpha1.Run, len(rprt.RunNames))
+	copy(runs, rprt.Runs)
+	for i, runName := range rprt.RunNames {
+		if !pending.Has(runName) || i >= len(combinations) {
+			continue
+		}
+		run, err := c.createRun(ctx, runName, combinations[i], rprt, pr, getTimeoutFunc)
+		if err != nil {
+			return nil, err
+		}
+		runs[i] = run
+	}
+	return runs, nil
+}
+
+func (c *Reconciler) createRun(ctx context.Context, runName string, matrixParams []v1beta1.Param, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, getTimeoutFunc getTimeoutFunc) (*v1alpha1.Run, error) {
 	logger := logging.FromContext(ctx)
+
+	r, _ := c.runLister.Runs(pr.Namespace).Get(runName)
+	if r != nil {
+		// Don't modify the lister cache's copy.
+		r = r.DeepCopy()
+		// is a retry
+		addRunRetryHistory(r)
+		clearRunStatus(r)
+		r.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		})
+		logger.Infof("Updating run %s with cleared status and retry history (length: %d).", r.GetName(), len(r.Status.RetriesStatus))
+		return c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).UpdateStatus(ctx, r, metav1.UpdateOptions{})
+	}
+
 	taskRunSpec := pr.GetTaskRunSpec(rprt.PipelineTask.Name)
-	r := &v1alpha1.Run{
+	r = &v1alpha1.Run{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            rprt.RunName,
+			Name:            runName,
 			Namespace:       pr.Namespace,
 			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
 			Labels:          getTaskrunLabels(pr, rprt.PipelineTask.Name, true),
@@ -831,7 +1129,7 @@ func (c *Reconciler) createRun(ctx context.Context, rprt *resources.ResolvedPipe
 		},
 		Spec: v1alpha1.RunSpec{
 			Ref:                rprt.PipelineTask.TaskRef,
-			Params:             rprt.PipelineTask.Params,
+			Params:             combineParams(rprt.PipelineTask.Params, matrixParams),
 			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
 			Timeout:            getTimeoutFunc(ctx, pr, rprt),
 			PodTemplate:        taskRunSpec.TaskPodTemplate,
@@ -867,10 +1165,67 @@ func (c *Reconciler) createRun(ctx context.Context, rprt *resources.ResolvedPipe
 		r.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
 	}
 
-	logger.Infof("Creating a new Run object %s", rprt.RunName)
+	logger.Infof("Creating a new Run object %s", runName)
 	return c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Create(ctx, r, metav1.CreateOptions{})
 }
 
+// createChildPipelineRun creates the child PipelineRun of a PipelineTask which runs a Pipeline,
+// passing down the params and workspaces of the PipelineTask.
+func (c *Reconciler) createChildPipelineRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, getTimeoutFunc getTimeoutFunc) (*v1beta1.PipelineRun, error) {
+	logger := logging.FromContext(ctx)
+	rprt.PipelineTask = resources.ApplyPipelineTaskContexts(rprt.PipelineTask)
+	taskRunSpec := pr.GetTaskRunSpec(rprt.PipelineTask.Name)
+	child := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.ChildPipelineRunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+			Labels:          getTaskrunLabels(pr, rprt.PipelineTask.Name, true),
+			Annotations:     getTaskrunAnnotations(pr),
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:        rprt.PipelineTask.PipelineRef,
+			PipelineSpec:       rprt.PipelineTask.PipelineSpec,
+			Params:             rprt.PipelineTask.Params,
+			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
+			Timeout:            getTimeoutFunc(ctx, pr, rprt),
+			PodTemplate:        taskRunSpec.TaskPodTemplate,
+		},
+	}
+
+	child.Annotations[pipeline.PipelineAncestryAnnotationKey] = strings.Join(resources.ChildPipelineAncestry(pr), ",")
+
+	var err error
+	child.Spec.Workspaces, _, err = getTaskrunWorkspaces(pr, rprt)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Creating a new child PipelineRun object %s for pipeline task %s", rprt.ChildPipelineRunName, rprt.PipelineTask.Name)
+	return c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Create(ctx, child, metav1.CreateOptions{})
+}
+
+// combineParams returns the params of a PipelineTask followed by the params of the combination
+// of its Matrix that a TaskRun or Run is created for, if any
+func combineParams(params []v1beta1.Param, matrixParams []v1beta1.Param) []v1beta1.Param {
+	if len(matrixParams) == 0 {
+		return params
+	}
+	combined := make([]v1beta1.Param, 0, len(params)+len(matrixParams))
+	combined = append(combined, params...)
+	return append(combined, matrixParams...)
+}
+
+// getParamsToValidate returns the params that the TaskRuns of a PipelineTask are created with,
+// which for a matrixed PipelineTask includes a string param for each param in its Matrix
+func getParamsToValidate(pt *v1beta1.PipelineTask) []v1beta1.Param {
+	var matrixParams []v1beta1.Param
+	for _, p := range pt.Matrix {
+		matrixParams = append(matrixParams, v1beta1.Param{Name: p.Name, Value: *v1beta1.NewArrayOrString("")})
+	}
+	return combineParams(pt.Params, matrixParams)
+}
+
 func getTaskrunWorkspaces(pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) ([]v1beta1.WorkspaceBinding, string, error) {
 	var workspaces []v1beta1.WorkspaceBinding
 	var pipelinePVCWorkspaceName string
@@ -947,6 +1302,19 @@ func clearStatus(tr *v1beta1.TaskRun) {
 	tr.Status.PodName = ""
 }
 
+func addRunRetryHistory(r *v1alpha1.Run) {
+	newStatus := *r.Status.DeepCopy()
+	newStatus.RetriesStatus = nil
+	r.Status.RetriesStatus = append(r.Status.RetriesStatus, newStatus)
+}
+
+func clearRunStatus(r *v1alpha1.Run) {
+	r.Status.StartTime = nil
+	r.Status.CompletionTime = nil
+	r.Status.Results = nil
+	r.Status.ExtraFields = runtime.RawExtension{}
+}
+
 func getTaskrunAnnotations(pr *v1beta1.PipelineRun) map[string]string {
 	// Propagate annotations from PipelineRun to TaskRun.
 	annotations := make(map[string]string, len(pr.ObjectMeta.Annotations)+1)
@@ -1021,6 +1389,9 @@ func combineTaskRunAndTaskSpecLabels(pr *v1beta1.PipelineRun, pipelineTask *v1be
 			labels[key] = value
 		}
 	}
+	if pipelineTask.Mutex != "" {
+		labels[pipeline.MutexLabelKey] = pipelineTask.Mutex
+	}
 	return labels
 }
 
@@ -1245,6 +1616,13 @@ func (c *Reconciler) updatePipelineRunStatusFromInformer(ctx context.Context, pr
 	}
 	updatePipelineRunStatusFromRuns(logger, pr, runs)
 
+	childPipelineRuns, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(k8slabels.SelectorFromSet(pipelineRunLabels))
+	if err != nil {
+		logger.Errorf("could not list child PipelineRuns %#v", err)
+		return err
+	}
+	updatePipelineRunStatusFromChildPipelineRuns(logger, pr, childPipelineRuns)
+
 	return nil
 }
 
@@ -1365,3 +1743,30 @@ func updatePipelineRunStatusFromRuns(logger *zap.SugaredLogger, pr *v1beta1.Pipe
 		}
 	}
 }
+
+func updatePipelineRunStatusFromChildPipelineRuns(logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, childPipelineRuns []*v1beta1.PipelineRun) {
+	// If no child PipelineRun was found, nothing to be done. We never remove child PipelineRuns from the status
+	if len(childPipelineRuns) == 0 {
+		return
+	}
+	if pr.Status.ChildPipelineRuns == nil {
+		pr.Status.ChildPipelineRuns = make(map[string]*v1beta1.PipelineRunChildPipelineRunStatus)
+	}
+	// Loop over all the child PipelineRuns associated to PipelineTasks
+	for _, child := range childPipelineRuns {
+		// Only process child PipelineRuns that are owned by this PipelineRun.
+		if !metav1.IsControlledBy(child, pr) {
+			logger.Debugf("Found a PipelineRun %s that is not owned by this PipelineRun", child.Name)
+			continue
+		}
+		lbls := child.GetLabels()
+		pipelineTaskName := lbls[pipeline.PipelineTaskLabelKey]
+		if _, ok := pr.Status.ChildPipelineRuns[child.Name]; !ok {
+			// This child PipelineRun was missing from the status.
+			pr.Status.ChildPipelineRuns[child.Name] = &v1beta1.PipelineRunChildPipelineRunStatus{
+				PipelineTaskName: pipelineTaskName,
+				Condition:        child.Status.GetCondition(apis.ConditionSucceeded).DeepCopy(),
+			}
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -46,7 +47,9 @@ import (
 	"github.com/tektoncd/pipeline/test/names"
 	"gomodules.xyz/jsonpatch/v2"
 	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
@@ -109,7 +112,7 @@ type PipelineRunTest struct {
 }
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists, concurrencyExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -126,6 +129,9 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetMetricsConfigName() {
 			metricsExists = true
 		}
+		if cm.Name == config.GetConcurrencyConfigName() {
+			concurrencyExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -157,6 +163,12 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !concurrencyExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetConcurrencyConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
 
 // getPipelineRunController returns an instance of the PipelineRun controller/reconciler that has been seeded with
@@ -1007,6 +1019,179 @@ func TestReconcile_CustomTask(t *testing.T) {
 	}
 }
 
+func TestReconcile_ChildPipelineRun(t *testing.T) {
+	names.TestingSeed()
+	const pipelineRunName = "test-pipelinerun"
+	const pipelineTaskName = "child"
+	const namespace = "namespace"
+	childSpec := &v1beta1.PipelineSpec{
+		Params:     []v1beta1.ParamSpec{{Name: "param1", Type: v1beta1.ParamTypeString}},
+		Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "childws"}},
+		Tasks: []v1beta1.PipelineTask{{
+			Name:    "unit-test",
+			TaskRef: &v1beta1.TaskRef{Name: "unit-test-task"},
+		}},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pipelineRunName,
+			Namespace: namespace,
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "pipelinews"}},
+				Tasks: []v1beta1.PipelineTask{{
+					Name: pipelineTaskName,
+					Params: []v1beta1.Param{{
+						Name:  "param1",
+						Value: *v1beta1.NewArrayOrString("value1"),
+					}},
+					PipelineSpec: childSpec,
+					Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+						Name:      "childws",
+						Workspace: "pipelinews",
+						SubPath:   "bar",
+					}},
+				}},
+			},
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name:     "pipelinews",
+				SubPath:  "foo",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+	}
+	// The embedded spec is defaulted, with the params of the PipelineTask passed through.
+	wantChildSpec := childSpec.DeepCopy()
+	wantChildSpec.Tasks[0].TaskRef.Kind = v1beta1.NamespacedTaskKind
+	wantChildSpec.Tasks[0].Params = []v1beta1.Param{{
+		Name:  "param1",
+		Value: *v1beta1.NewArrayOrString("$(params.param1)"),
+	}}
+	wantChild := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pipelinerun-child-9l9zj",
+			Namespace: namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         "tekton.dev/v1beta1",
+				Kind:               "PipelineRun",
+				Name:               pipelineRunName,
+				Controller:         &trueb,
+				BlockOwnerDeletion: &trueb,
+			}},
+			Labels: map[string]string{
+				"tekton.dev/pipeline":     pipelineRunName,
+				"tekton.dev/pipelineRun":  pipelineRunName,
+				"tekton.dev/pipelineTask": pipelineTaskName,
+				pipeline.MemberOfLabelKey: v1beta1.PipelineTasks,
+			},
+			Annotations: map[string]string{
+				pipeline.PipelineAncestryAnnotationKey: pipelineRunName,
+			},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: wantChildSpec,
+			Params: []v1beta1.Param{{
+				Name:  "param1",
+				Value: *v1beta1.NewArrayOrString("value1"),
+			}},
+			ServiceAccountName: "default",
+			Timeout:            &metav1.Duration{Duration: time.Hour},
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name:     "childws",
+				SubPath:  "foo/bar",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+	}
+
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr},
+		ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun(namespace, pipelineRunName, wantEvents, false)
+
+	var actual *v1beta1.PipelineRun
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "pipelineruns" {
+			actual = a.(ktesting.CreateAction).GetObject().(*v1beta1.PipelineRun)
+		}
+	}
+	if actual == nil {
+		t.Fatalf("Expected a child PipelineRun to be created")
+	}
+	if d := cmp.Diff(wantChild, actual); d != "" {
+		t.Errorf("expected to see child PipelineRun created: %s", diff.PrintWantGot(d))
+	}
+
+	if len(reconciledRun.Status.ChildPipelineRuns) != 1 {
+		t.Errorf("Expected PipelineRun status to include one child PipelineRun status, got %d", len(reconciledRun.Status.ChildPipelineRuns))
+	}
+	if status, exists := reconciledRun.Status.ChildPipelineRuns[wantChild.Name]; !exists || status.PipelineTaskName != pipelineTaskName {
+		t.Errorf("Expected PipelineRun status to include child PipelineRun status but was %v", reconciledRun.Status.ChildPipelineRuns)
+	}
+}
+
+func TestReconcile_ChildPipelineRunNesting(t *testing.T) {
+	const namespace = "foo"
+	ps := []*v1beta1.Pipeline{{
+		ObjectMeta: baseObjectMeta("outer", namespace),
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:        "inner",
+				PipelineRef: &v1beta1.PipelineRef{Name: "inner"},
+			}},
+		},
+	}}
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		wantEvent   string
+	}{{
+		name:        "pipeline task runs a pipeline it is nested in",
+		annotations: map[string]string{pipeline.PipelineAncestryAnnotationKey: "inner"},
+		wantEvent:   `Warning Failed PipelineRun foo/pr can't run the child Pipelines of Pipeline foo/outer: pipeline task "inner" can't run Pipeline "inner" which it is already nested in`,
+	}, {
+		name:        "pipeline task nests pipelineruns too deeply",
+		annotations: map[string]string{pipeline.PipelineAncestryAnnotationKey: "a,b,c,d,e"},
+		wantEvent:   `Warning Failed PipelineRun foo/pr can't run the child Pipelines of Pipeline foo/outer: pipeline task "inner" can't run a Pipeline nested more than 5 PipelineRuns deep`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: baseObjectMeta("pr", namespace),
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{Name: "outer"},
+				},
+			}
+			pr.Annotations = tc.annotations
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{pr},
+				Pipelines:    ps,
+				ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun(namespace, "pr", []string{"Normal Started", tc.wantEvent, "Warning InternalError 1 error occurred"}, true)
+			if c := reconciledRun.Status.GetCondition(apis.ConditionSucceeded); !c.IsFalse() || c.Reason != ReasonInvalidChildPipeline {
+				t.Errorf("Expected the PipelineRun to fail with reason %s but its condition was %v", ReasonInvalidChildPipeline, c)
+			}
+			for _, a := range clients.Pipeline.Actions() {
+				if a.GetVerb() == "create" && a.GetResource().Resource == "pipelineruns" {
+					t.Errorf("Expected no child PipelineRun to be created but got %v", a)
+				}
+			}
+		})
+	}
+}
+
 func TestReconcile_PipelineSpecTaskSpec(t *testing.T) {
 	// TestReconcile_PipelineSpecTaskSpec runs "Reconcile" on a PipelineRun that has an embedded PipelineSpec that has an embedded TaskSpec.
 	// It verifies that a TaskRun is created, it checks the resulting API actions, status and events.
@@ -3714,6 +3899,1465 @@ func TestReconcileWithTimeoutAndRetry(t *testing.T) {
 	}
 }
 
+// TestReconcileWithRetryPolicy runs "Reconcile" against a PipelineRun with a failed TaskRun
+// whose PipelineTask has a retry policy, and verifies that the TaskRun is retried only once the
+// backoff has elapsed and if the reason of its failure is retried.
+func TestReconcileWithRetryPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		completedAgo    time.Duration
+		reason          string
+		wantRetries     int
+		wantPRCondition corev1.ConditionStatus
+	}{{
+		name:            "backoff not elapsed",
+		completedAgo:    time.Minute,
+		reason:          v1beta1.TaskRunReasonTimedOut.String(),
+		wantRetries:     0,
+		wantPRCondition: corev1.ConditionUnknown,
+	}, {
+		name:            "backoff elapsed",
+		completedAgo:    time.Hour,
+		reason:          v1beta1.TaskRunReasonTimedOut.String(),
+		wantRetries:     1,
+		wantPRCondition: corev1.ConditionUnknown,
+	}, {
+		name:            "reason not retried",
+		completedAgo:    time.Hour,
+		reason:          v1beta1.TaskRunReasonFailed.String(),
+		wantRetries:     0,
+		wantPRCondition: corev1.ConditionFalse,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1beta1.Pipeline{{
+				ObjectMeta: baseObjectMeta("test-pipeline-retry", "foo"),
+				Spec: v1beta1.PipelineSpec{
+					Tasks: []v1beta1.PipelineTask{{
+						Name:    "hello-world-1",
+						TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+						Retries: 2,
+						RetryPolicy: &v1beta1.RetryPolicy{
+							Backoff: &metav1.Duration{Duration: 30 * time.Minute},
+							Reasons: []string{v1beta1.TaskRunReasonTimedOut.String()},
+						},
+					}},
+				},
+			}}
+			prs := []*v1beta1.PipelineRun{{
+				ObjectMeta: baseObjectMeta("test-pipeline-retry-run", "foo"),
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef:        &v1beta1.PipelineRef{Name: "test-pipeline-retry"},
+					ServiceAccountName: "test-sa",
+					Timeout:            &metav1.Duration{Duration: 12 * time.Hour},
+				},
+				Status: v1beta1.PipelineRunStatus{
+					PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+						StartTime: &metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+					},
+				},
+			}}
+			trs := []*v1beta1.TaskRun{{
+				ObjectMeta: taskRunObjectMeta("hello-world-1", "foo", "test-pipeline-retry-run", "test-pipeline-retry", "hello-world-1", false),
+				Status: v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: duckv1beta1.Conditions{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+							Reason: tc.reason,
+						}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						PodName:        "my-pod-name",
+						CompletionTime: &metav1.Time{Time: time.Now().Add(-tc.completedAgo)},
+					},
+				},
+			}}
+			prs[0].Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+				"hello-world-1": {PipelineTaskName: "hello-world-1", Status: &trs[0].Status},
+			}
+			cms := getConfigMapsWithEnabledAlphaAPIFields()
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				TaskRuns:     trs,
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-retry-run", []string{}, false)
+
+			if got := len(reconciledRun.Status.TaskRuns["hello-world-1"].Status.RetriesStatus); got != tc.wantRetries {
+				t.Errorf("expected %d retries but got %d", tc.wantRetries, got)
+			}
+			if got := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Status; got != tc.wantPRCondition {
+				t.Errorf("expected PipelineRun condition %s but got %s", tc.wantPRCondition, got)
+			}
+		})
+	}
+}
+
+// TestReconcileWithRetryRequestedAfterFailure runs "Reconcile" against a PipelineRun which failed because
+// a task exhausted its retries, and verifies that requesting to retry the task runs the PipelineRun again.
+func TestReconcileWithRetryRequestedAfterFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		reason          string
+		taskStatuses    []v1beta1.PipelineRunTaskSpecStatus
+		retriesDone     int
+		wantRetries     int
+		wantPRCondition corev1.ConditionStatus
+	}{{
+		name:            "retry requested",
+		reason:          v1beta1.PipelineRunReasonFailed.String(),
+		taskStatuses:    []v1beta1.PipelineRunTaskSpecStatus{{PipelineTaskName: "hello-world-1", Status: v1beta1.PipelineTaskSpecStatusRetry}},
+		retriesDone:     1,
+		wantRetries:     2,
+		wantPRCondition: corev1.ConditionUnknown,
+	}, {
+		name:            "no retry requested",
+		reason:          v1beta1.PipelineRunReasonFailed.String(),
+		retriesDone:     1,
+		wantRetries:     1,
+		wantPRCondition: corev1.ConditionFalse,
+	}, {
+		name:            "task already retried on request",
+		reason:          v1beta1.PipelineRunReasonFailed.String(),
+		taskStatuses:    []v1beta1.PipelineRunTaskSpecStatus{{PipelineTaskName: "hello-world-1", Status: v1beta1.PipelineTaskSpecStatusRetry}},
+		retriesDone:     2,
+		wantRetries:     2,
+		wantPRCondition: corev1.ConditionFalse,
+	}, {
+		name:            "pipelinerun timed out",
+		reason:          v1beta1.PipelineRunReasonTimedOut.String(),
+		taskStatuses:    []v1beta1.PipelineRunTaskSpecStatus{{PipelineTaskName: "hello-world-1", Status: v1beta1.PipelineTaskSpecStatusRetry}},
+		retriesDone:     1,
+		wantRetries:     1,
+		wantPRCondition: corev1.ConditionFalse,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1beta1.Pipeline{{
+				ObjectMeta: baseObjectMeta("test-pipeline-retry", "foo"),
+				Spec: v1beta1.PipelineSpec{
+					Tasks: []v1beta1.PipelineTask{{
+						Name:    "hello-world-1",
+						TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+						Retries: 1,
+					}},
+				},
+			}}
+			prs := []*v1beta1.PipelineRun{{
+				ObjectMeta: baseObjectMeta("test-pipeline-retry-run", "foo"),
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef:        &v1beta1.PipelineRef{Name: "test-pipeline-retry"},
+					ServiceAccountName: "test-sa",
+					Timeout:            &metav1.Duration{Duration: 12 * time.Hour},
+					TaskStatuses:       tc.taskStatuses,
+				},
+				Status: v1beta1.PipelineRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: duckv1beta1.Conditions{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+							Reason: tc.reason,
+						}},
+					},
+					PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+						StartTime:      &metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+						CompletionTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+						PipelineSpec:   ps[0].Spec.DeepCopy(),
+					},
+				},
+			}}
+			trs := []*v1beta1.TaskRun{{
+				ObjectMeta: taskRunObjectMeta("hello-world-1", "foo", "test-pipeline-retry-run", "test-pipeline-retry", "hello-world-1", false),
+				Status: v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: duckv1beta1.Conditions{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+							Reason: v1beta1.TaskRunReasonFailed.String(),
+						}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						PodName:        "my-pod-name",
+						CompletionTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+						RetriesStatus:  make([]v1beta1.TaskRunStatus, tc.retriesDone),
+					},
+				},
+			}}
+			prs[0].Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+				"hello-world-1": {PipelineTaskName: "hello-world-1", Status: trs[0].Status.DeepCopy()},
+			}
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				TaskRuns:     trs,
+				ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-retry-run", []string{}, false)
+
+			tr, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, "hello-world-1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get the TaskRun: %v", err)
+			}
+			if got := len(tr.Status.RetriesStatus); got != tc.wantRetries {
+				t.Errorf("expected %d retries but got %d", tc.wantRetries, got)
+			}
+			if got := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Status; got != tc.wantPRCondition {
+				t.Errorf("expected PipelineRun condition %s but got %s", tc.wantPRCondition, got)
+			}
+			if tc.wantPRCondition == corev1.ConditionUnknown && reconciledRun.Status.CompletionTime != nil {
+				t.Errorf("expected the PipelineRun running again not to have a completion time but got %v", reconciledRun.Status.CompletionTime)
+			}
+		})
+	}
+}
+
+// TestReconcileWithConcurrencyPolicy runs "Reconcile" against a PipelineRun of a Pipeline whose
+// PipelineRuns are limited to one at a time, and verifies the strategies of concurrency policies.
+func TestReconcileWithConcurrencyPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		strategy        string
+		runningDone     bool
+		runningAdmitted bool // admitted but its start not recorded yet
+		runningOther    bool // another policy applies to the running pipelinerun
+		admitting       bool // the admission of another pipelinerun is in progress
+		wantTaskRuns    bool
+		wantReason      string
+		wantMessage     string
+		wantCancelled   bool
+		wantPRCondition corev1.ConditionStatus
+	}{{
+		name:            "queued behind the running pipelinerun",
+		strategy:        config.ConcurrencyStrategyQueue,
+		wantReason:      ReasonQueued,
+		wantMessage:     `PipelineRun "test-pipeline-run-new" is queued at position 1 for concurrency policy "deploy"`,
+		wantPRCondition: corev1.ConditionUnknown,
+	}, {
+		name:            "queued behind the pipelinerun admitted but not started yet",
+		strategy:        config.ConcurrencyStrategyQueue,
+		runningAdmitted: true,
+		wantReason:      ReasonQueued,
+		wantMessage:     `PipelineRun "test-pipeline-run-new" is queued at position 1 for concurrency policy "deploy"`,
+		wantPRCondition: corev1.ConditionUnknown,
+	}, {
+		name:            "started when another policy applies to the running pipelinerun",
+		strategy:        config.ConcurrencyStrategyQueue,
+		runningOther:    true,
+		wantTaskRuns:    true,
+		wantPRCondition: corev1.ConditionUnknown,
+	}, {
+		name:      "waits for the admission of another pipelinerun",
+		strategy:  config.ConcurrencyStrategyCancelOldest,
+		admitting: true,
+	}, {
+		name:            "started once the running pipelinerun is done",
+		strategy:        config.ConcurrencyStrategyQueue,
+		runningDone:     true,
+		wantTaskRuns:    true,
+		wantPRCondition: corev1.ConditionUnknown,
+	}, {
+		name:            "cancels the running pipelinerun",
+		strategy:        config.ConcurrencyStrategyCancelOldest,
+		wantTaskRuns:    true,
+		wantCancelled:   true,
+		wantPRCondition: corev1.ConditionUnknown,
+	}, {
+		name:            "cancelled because of the running pipelinerun",
+		strategy:        config.ConcurrencyStrategyCancelNewest,
+		wantReason:      ReasonCancelled,
+		wantMessage:     `PipelineRun "test-pipeline-run-new" was cancelled because 1 PipelineRuns are already running for concurrency policy "deploy"`,
+		wantPRCondition: corev1.ConditionFalse,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1beta1.Pipeline{{
+				ObjectMeta: baseObjectMeta("test-pipeline", "foo"),
+				Spec: v1beta1.PipelineSpec{
+					Tasks: []v1beta1.PipelineTask{{
+						Name:    "hello-world-1",
+						TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+					}},
+				},
+			}}
+			runningCondition := apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: v1beta1.PipelineRunReasonRunning.String(),
+			}
+			if tc.runningDone {
+				runningCondition.Status = corev1.ConditionTrue
+				runningCondition.Reason = v1beta1.PipelineRunReasonSuccessful.String()
+			}
+			prs := []*v1beta1.PipelineRun{{
+				ObjectMeta: baseObjectMeta("test-pipeline-run-running", "foo"),
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{Name: "test-pipeline"},
+				},
+				Status: v1beta1.PipelineRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: duckv1beta1.Conditions{runningCondition},
+					},
+					PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+						StartTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+					},
+				},
+			}, {
+				ObjectMeta: baseObjectMeta("test-pipeline-run-new", "foo"),
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{Name: "test-pipeline"},
+				},
+			}}
+			if tc.runningAdmitted {
+				prs[0].Annotations = map[string]string{pipeline.ConcurrencyAdmittedAnnotationKey: "deploy"}
+				prs[0].Status = v1beta1.PipelineRunStatus{}
+			}
+			if tc.runningOther {
+				prs[0].Labels = map[string]string{"team": "build"}
+			}
+			if tc.admitting {
+				prs = append(prs, &v1beta1.PipelineRun{
+					ObjectMeta: baseObjectMeta("test-pipeline-run-admitting", "foo"),
+					Spec: v1beta1.PipelineRunSpec{
+						PipelineRef: &v1beta1.PipelineRef{Name: "test-pipeline"},
+					},
+				})
+			}
+			cms := []*corev1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetConcurrencyConfigName(), Namespace: system.Namespace()},
+				Data: map[string]string{
+					"build":  "selector:\n  matchLabels:\n    team: build\nmaxRuns: 1\n",
+					"deploy": fmt.Sprintf("pipelineName: test-pipeline\nmaxRuns: 1\nstrategy: %s\n", tc.strategy),
+				},
+			}}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			leases := prt.TestAssets.Clients.Kube.CoordinationV1().Leases("foo")
+			if tc.admitting {
+				holder := "test-pipeline-run-admitting"
+				if _, err := leases.Create(prt.TestAssets.Ctx, &coordinationv1.Lease{
+					ObjectMeta: metav1.ObjectMeta{Name: "tekton-concurrency-deploy", Namespace: "foo"},
+					Spec: coordinationv1.LeaseSpec{
+						HolderIdentity: &holder,
+						AcquireTime:    &metav1.MicroTime{Time: time.Now()},
+					},
+				}, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("Failed to create Lease: %v", err)
+				}
+			}
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-new", []string{}, false)
+
+			lease, err := leases.Get(prt.TestAssets.Ctx, "tekton-concurrency-deploy", metav1.GetOptions{})
+			switch {
+			case tc.wantTaskRuns || tc.admitting:
+				wantHolder := "test-pipeline-run-new"
+				if tc.admitting {
+					wantHolder = "test-pipeline-run-admitting"
+				}
+				if err != nil {
+					t.Fatalf("Failed to get Lease: %v", err)
+				}
+				if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != wantHolder {
+					t.Errorf("expected admission Lease to be held by %s but got %v", wantHolder, lease.Spec.HolderIdentity)
+				}
+			case !k8serrors.IsNotFound(err):
+				t.Errorf("expected admission Lease to be released but got %v, %v", lease, err)
+			}
+
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if tc.admitting {
+				if condition != nil || len(reconciledRun.Status.TaskRuns) > 0 || isAdmitted(reconciledRun) {
+					t.Errorf("expected PipelineRun to wait for its admission but got condition %v", condition)
+				}
+				return
+			}
+			if condition == nil || condition.Status != tc.wantPRCondition {
+				t.Fatalf("expected PipelineRun condition %s but got %v", tc.wantPRCondition, condition)
+			}
+			if tc.wantReason != "" {
+				if condition.Reason != tc.wantReason || condition.Message != tc.wantMessage {
+					t.Errorf("expected PipelineRun reason %q and message %q but got %q and %q", tc.wantReason, tc.wantMessage, condition.Reason, condition.Message)
+				}
+			}
+			if created := len(reconciledRun.Status.TaskRuns) > 0; created != tc.wantTaskRuns {
+				t.Errorf("expected TaskRuns created to be %t but got %t", tc.wantTaskRuns, created)
+			}
+			if admitted := reconciledRun.Annotations[pipeline.ConcurrencyAdmittedAnnotationKey] == "deploy"; admitted != tc.wantTaskRuns {
+				t.Errorf("expected PipelineRun admission recorded to be %t but got %t", tc.wantTaskRuns, admitted)
+			}
+
+			running, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-running", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get PipelineRun: %v", err)
+			}
+			if cancelled := running.IsCancelled(); cancelled != tc.wantCancelled {
+				t.Errorf("expected running PipelineRun cancelled to be %t but got %t", tc.wantCancelled, cancelled)
+			}
+		})
+	}
+}
+
+// TestReconcileWithMutex runs "Reconcile" against a PipelineRun with a PipelineTask using a mutex,
+// and verifies that its TaskRun is only created once the mutex is acquired.
+func TestReconcileWithMutex(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		holder       *v1beta1.TaskRun
+		acquiredAgo  time.Duration // defaults to 2 minutes
+		gracePeriod  string        // defaults to 1 minute
+		wantTaskRun  bool
+		wantWaitFor  string
+		wantHolderOf string // defaults to the TaskRun of the PipelineRun
+	}{{
+		name:        "acquires the free mutex",
+		wantTaskRun: true,
+	}, {
+		name: "waits for the mutex held by a running taskrun",
+		holder: &v1beta1.TaskRun{
+			ObjectMeta: taskRunObjectMeta("other-pipeline-run-hello-world-1", "foo", "other-pipeline-run", "test-pipeline", "hello-world-1", false),
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}},
+				},
+			},
+		},
+		wantWaitFor:  "deploy",
+		wantHolderOf: "other-pipeline-run-hello-world-1",
+	}, {
+		name: "takes over the mutex held by a done taskrun",
+		holder: &v1beta1.TaskRun{
+			ObjectMeta: taskRunObjectMeta("other-pipeline-run-hello-world-1", "foo", "other-pipeline-run", "test-pipeline", "hello-world-1", false),
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+				},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					CompletionTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+				},
+			},
+		},
+		wantTaskRun: true,
+	}, {
+		name:        "takes over the mutex held by a deleted taskrun",
+		holder:      &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "deleted-taskrun"}},
+		wantTaskRun: true,
+	}, {
+		name:         "waits for the mutex just acquired for a taskrun not created yet",
+		holder:       &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "deleted-taskrun"}},
+		acquiredAgo:  10 * time.Second,
+		wantWaitFor:  "deploy",
+		wantHolderOf: "deleted-taskrun",
+	}, {
+		name:         "waits for the mutex acquired within the configured grace period",
+		holder:       &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "deleted-taskrun"}},
+		gracePeriod:  "300",
+		wantWaitFor:  "deploy",
+		wantHolderOf: "deleted-taskrun",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1beta1.PipelineRun{{
+				ObjectMeta: baseObjectMeta("test-pipeline-run-mutex", "foo"),
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineSpec: &v1beta1.PipelineSpec{
+						Tasks: []v1beta1.PipelineTask{{
+							Name:    "hello-world-1",
+							TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+							Mutex:   "deploy",
+						}},
+					},
+				},
+			}}
+			var trs []*v1beta1.TaskRun
+			if tc.holder != nil && tc.holder.Name != "deleted-taskrun" {
+				trs = append(trs, tc.holder)
+			}
+			cms := getConfigMapsWithEnabledAlphaAPIFields()
+			if tc.gracePeriod != "" {
+				cms = append(cms, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+					Data:       map[string]string{"default-mutex-grace-period-seconds": tc.gracePeriod},
+				})
+			}
+			d := test.Data{
+				PipelineRuns: prs,
+				TaskRuns:     trs,
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			leases := prt.TestAssets.Clients.Kube.CoordinationV1().Leases("foo")
+			if tc.holder != nil {
+				acquiredAgo := tc.acquiredAgo
+				if acquiredAgo == 0 {
+					acquiredAgo = 2 * time.Minute
+				}
+				if _, err := leases.Create(prt.TestAssets.Ctx, &coordinationv1.Lease{
+					ObjectMeta: metav1.ObjectMeta{Name: "tekton-mutex-deploy", Namespace: "foo"},
+					Spec: coordinationv1.LeaseSpec{
+						HolderIdentity: &tc.holder.Name,
+						AcquireTime:    &metav1.MicroTime{Time: time.Now().Add(-acquiredAgo)},
+					},
+				}, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("Failed to create Lease: %v", err)
+				}
+			}
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-mutex", []string{}, false)
+
+			taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+				LabelSelector: pipeline.PipelineRunLabelKey + "=test-pipeline-run-mutex",
+			})
+			if err != nil {
+				t.Fatalf("Failed to list TaskRuns: %v", err)
+			}
+			if created := len(taskRuns.Items) > 0; created != tc.wantTaskRun {
+				t.Errorf("expected TaskRun created to be %t but got %t", tc.wantTaskRun, created)
+			}
+			if tc.wantTaskRun && taskRuns.Items[0].Labels[pipeline.MutexLabelKey] != "deploy" {
+				t.Errorf("expected TaskRun to have label %s=deploy but got labels %v", pipeline.MutexLabelKey, taskRuns.Items[0].Labels)
+			}
+
+			if len(reconciledRun.Status.TaskRuns) != 1 {
+				t.Fatalf("expected PipelineRun status to have 1 TaskRun but got %v", reconciledRun.Status.TaskRuns)
+			}
+			wantHolder := tc.wantHolderOf
+			for taskRunName, status := range reconciledRun.Status.TaskRuns {
+				if status.WaitingForMutex != tc.wantWaitFor {
+					t.Errorf("expected PipelineTask waiting for mutex %q but got %q", tc.wantWaitFor, status.WaitingForMutex)
+				}
+				if wantHolder == "" {
+					wantHolder = taskRunName
+				}
+			}
+
+			lease, err := leases.Get(prt.TestAssets.Ctx, "tekton-mutex-deploy", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get Lease: %v", err)
+			}
+			if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != wantHolder {
+				t.Errorf("expected mutex to be held by %s but got %v", wantHolder, lease.Spec.HolderIdentity)
+			}
+		})
+	}
+}
+
+// TestReconcileReleasesMutex runs "Reconcile" against a PipelineRun whose TaskRun holding a mutex
+// is done, and verifies that the mutex is released once.
+func TestReconcileReleasesMutex(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		released     bool // the TaskRun is marked as having released its mutex
+		wantReleased bool
+	}{{
+		name:         "releases the mutex of the done taskrun",
+		wantReleased: true,
+	}, {
+		name:     "doesn't release the mutex of the taskrun again",
+		released: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			testReconcileReleasesMutex(t, tc.released, tc.wantReleased)
+		})
+	}
+}
+
+func testReconcileReleasesMutex(t *testing.T, released, wantReleased bool) {
+	t.Helper()
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: baseObjectMeta("test-pipeline-run-mutex", "foo"),
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name:    "hello-world-1",
+					TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+					Mutex:   "deploy",
+				}, {
+					Name:     "hello-world-2",
+					TaskRef:  &v1beta1.TaskRef{Name: "hello-world"},
+					RunAfter: []string{"hello-world-1"},
+				}},
+			},
+		},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				StartTime: &metav1.Time{Time: time.Now().Add(-5 * time.Minute)},
+			},
+		},
+	}}
+	trMeta := taskRunObjectMeta("test-pipeline-run-mutex-hello-world-1", "foo", "test-pipeline-run-mutex", "test-pipeline-run-mutex", "hello-world-1", false)
+	trMeta.Labels[pipeline.MutexLabelKey] = "deploy"
+	completionTime := metav1.Time{Time: time.Now().Add(-time.Minute).Truncate(time.Second)}
+	if released {
+		trMeta.Annotations = map[string]string{pipeline.MutexReleasedAnnotationKey: completionTime.UTC().Format(time.RFC3339)}
+	}
+	trs := []*v1beta1.TaskRun{{
+		ObjectMeta: trMeta,
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+		},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				CompletionTime: &completionTime,
+			},
+		},
+	}}
+	prs[0].Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+		"test-pipeline-run-mutex-hello-world-1": {PipelineTaskName: "hello-world-1", Status: &trs[0].Status},
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		TaskRuns:     trs,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	leases := prt.TestAssets.Clients.Kube.CoordinationV1().Leases("foo")
+	holder := "test-pipeline-run-mutex-hello-world-1"
+	if _, err := leases.Create(prt.TestAssets.Ctx, &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: "tekton-mutex-deploy", Namespace: "foo"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity: &holder,
+			AcquireTime:    &metav1.MicroTime{Time: time.Now().Add(-2 * time.Minute)},
+		},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create Lease: %v", err)
+	}
+
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run-mutex", []string{}, false)
+
+	_, err := leases.Get(prt.TestAssets.Ctx, "tekton-mutex-deploy", metav1.GetOptions{})
+	if gotReleased := k8serrors.IsNotFound(err); gotReleased != wantReleased {
+		t.Errorf("expected mutex released to be %t but got %v", wantReleased, err)
+	}
+	tr, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, holder, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get TaskRun: %v", err)
+	}
+	if got, want := tr.Annotations[pipeline.MutexReleasedAnnotationKey], completionTime.UTC().Format(time.RFC3339); got != want {
+		t.Errorf("expected TaskRun annotation %s to be %q but got %q", pipeline.MutexReleasedAnnotationKey, want, got)
+	}
+}
+
+// TestReconcileWithRerun runs "Reconcile" against a PipelineRun rerunning a PipelineTask of a
+// previous PipelineRun, and verifies that the PipelineTasks which don't depend on it are reused.
+func TestReconcileWithRerun(t *testing.T) {
+	pipelineSpec := &v1beta1.PipelineSpec{
+		Tasks: []v1beta1.PipelineTask{{
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+		}, {
+			Name:     "test",
+			TaskRef:  &v1beta1.TaskRef{Name: "hello-world"},
+			RunAfter: []string{"build"},
+		}, {
+			Name:     "deploy",
+			TaskRef:  &v1beta1.TaskRef{Name: "hello-world"},
+			RunAfter: []string{"test"},
+		}, {
+			Name:    "lint",
+			TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+		}},
+	}
+	taskRunStatus := func(status corev1.ConditionStatus) *v1beta1.TaskRunStatus {
+		return &v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: status}},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				TaskRunResults: []v1beta1.TaskRunResult{{Name: "result", Value: "value"}},
+			},
+		}
+	}
+	resolvedSpec := pipelineSpec.DeepCopy()
+	resolvedSpec.SetDefaults(context.Background())
+	previous := &v1beta1.PipelineRun{
+		ObjectMeta: baseObjectMeta("test-pipeline-run-previous", "foo"),
+		Spec:       v1beta1.PipelineRunSpec{PipelineSpec: pipelineSpec},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse}},
+			},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineSpec: resolvedSpec,
+				TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+					"test-pipeline-run-previous-build":  {PipelineTaskName: "build", Status: taskRunStatus(corev1.ConditionTrue)},
+					"test-pipeline-run-previous-test":   {PipelineTaskName: "test", Status: taskRunStatus(corev1.ConditionTrue)},
+					"test-pipeline-run-previous-deploy": {PipelineTaskName: "deploy", Status: taskRunStatus(corev1.ConditionFalse)},
+					"test-pipeline-run-previous-lint":   {PipelineTaskName: "lint", Status: taskRunStatus(corev1.ConditionTrue)},
+				},
+			},
+		},
+	}
+	// Done PipelineRuns running another Pipeline
+	other := previous.DeepCopy()
+	other.Name = "test-pipeline-run-other"
+	other.Spec.PipelineSpec.Tasks = other.Spec.PipelineSpec.Tasks[:3]
+	other.Status.PipelineSpec.Tasks = other.Status.PipelineSpec.Tasks[:3]
+	ref := previous.DeepCopy()
+	ref.Name = "test-pipeline-run-ref"
+	ref.Spec.PipelineSpec = nil
+	ref.Spec.PipelineRef = &v1beta1.PipelineRef{Name: "test-pipeline"}
+
+	for _, tc := range []struct {
+		name            string
+		rerun           *v1beta1.PipelineRunRerun
+		wantReused      []v1beta1.ReusedTask
+		wantTaskRunsFor []string
+		wantReason      string
+	}{{
+		name:  "reuses the tasks which don't depend on the rerun tasks",
+		rerun: &v1beta1.PipelineRunRerun{PipelineRunName: "test-pipeline-run-previous", Tasks: []string{"test"}},
+		wantReused: []v1beta1.ReusedTask{{
+			Name:            "build",
+			PipelineRunName: "test-pipeline-run-previous",
+			TaskRunName:     "test-pipeline-run-previous-build",
+		}, {
+			Name:            "lint",
+			PipelineRunName: "test-pipeline-run-previous",
+			TaskRunName:     "test-pipeline-run-previous-lint",
+		}},
+		wantTaskRunsFor: []string{"test"},
+	}, {
+		name:            "reruns everything from the root task",
+		rerun:           &v1beta1.PipelineRunRerun{PipelineRunName: "test-pipeline-run-previous", Tasks: []string{"build"}},
+		wantReused:      []v1beta1.ReusedTask{{Name: "lint", PipelineRunName: "test-pipeline-run-previous", TaskRunName: "test-pipeline-run-previous-lint"}},
+		wantTaskRunsFor: []string{"build"},
+	}, {
+		name:       "previous pipelinerun doesn't exist",
+		rerun:      &v1beta1.PipelineRunRerun{PipelineRunName: "missing", Tasks: []string{"test"}},
+		wantReason: ReasonInvalidRerun,
+	}, {
+		name:       "rerun task doesn't exist",
+		rerun:      &v1beta1.PipelineRunRerun{PipelineRunName: "test-pipeline-run-previous", Tasks: []string{"missing"}},
+		wantReason: ReasonInvalidRerun,
+	}, {
+		name:       "previous pipelinerun runs another pipeline",
+		rerun:      &v1beta1.PipelineRunRerun{PipelineRunName: "test-pipeline-run-other", Tasks: []string{"test"}},
+		wantReason: ReasonInvalidRerun,
+	}, {
+		name:       "previous pipelinerun references a pipeline",
+		rerun:      &v1beta1.PipelineRunRerun{PipelineRunName: "test-pipeline-run-ref", Tasks: []string{"test"}},
+		wantReason: ReasonInvalidRerun,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1beta1.PipelineRun{previous, other, ref, {
+				ObjectMeta: baseObjectMeta("test-pipeline-run-rerun", "foo"),
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineSpec: pipelineSpec,
+					Rerun:        tc.rerun,
+				},
+			}}
+			d := test.Data{
+				PipelineRuns: prs,
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-rerun", []string{}, tc.wantReason != "")
+
+			if tc.wantReason != "" {
+				condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+				if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != tc.wantReason {
+					t.Errorf("expected PipelineRun to fail with reason %s but got %v", tc.wantReason, condition)
+				}
+				return
+			}
+
+			if d := cmp.Diff(tc.wantReused, reconciledRun.Status.ReusedTasks); d != "" {
+				t.Errorf("Unexpected reused tasks %s", diff.PrintWantGot(d))
+			}
+			for _, rt := range tc.wantReused {
+				prtrs := reconciledRun.Status.TaskRuns[rt.TaskRunName]
+				if d := cmp.Diff(previous.Status.TaskRuns[rt.TaskRunName], prtrs); d != "" {
+					t.Errorf("Unexpected status for reused TaskRun %s %s", rt.TaskRunName, diff.PrintWantGot(d))
+				}
+			}
+
+			taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+				LabelSelector: pipeline.PipelineRunLabelKey + "=test-pipeline-run-rerun",
+			})
+			if err != nil {
+				t.Fatalf("Failed to list TaskRuns: %v", err)
+			}
+			var gotTaskRunsFor []string
+			for _, tr := range taskRuns.Items {
+				gotTaskRunsFor = append(gotTaskRunsFor, tr.Labels[pipeline.PipelineTaskLabelKey])
+			}
+			if d := cmp.Diff(tc.wantTaskRunsFor, gotTaskRunsFor); d != "" {
+				t.Errorf("Unexpected TaskRuns created %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// TestReconcileWithParamConstraints runs "Reconcile" against a PipelineRun whose PipelineTasks pass
+// a matrix and a result to a param with an enum, and verifies that their TaskRuns are created.
+func TestReconcileWithParamConstraints(t *testing.T) {
+	enumTask := &v1beta1.Task{
+		ObjectMeta: baseObjectMeta("platform-task", "foo"),
+		Spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "platform", Type: v1beta1.ParamTypeString, Enum: []string{"linux", "mac"}}},
+		},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: baseObjectMeta("test-pipeline-run-constraints", "foo"),
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name:    "detect",
+					TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+				}, {
+					Name:    "build",
+					TaskRef: &v1beta1.TaskRef{Name: "platform-task"},
+					Matrix:  []v1beta1.Param{{Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac")}},
+				}, {
+					Name:    "deploy",
+					TaskRef: &v1beta1.TaskRef{Name: "platform-task"},
+					Params:  []v1beta1.Param{{Name: "platform", Value: *v1beta1.NewArrayOrString("$(tasks.detect.results.platform)")}},
+				}},
+			},
+		},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				StartTime: &metav1.Time{Time: time.Now()},
+				TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+					"test-pipeline-run-constraints-detect": {PipelineTaskName: "detect"},
+				},
+			},
+		},
+	}
+	detect := &v1beta1.TaskRun{
+		ObjectMeta: taskRunObjectMeta("test-pipeline-run-constraints-detect", "foo", "test-pipeline-run-constraints", "test-pipeline-run-constraints", "detect", false),
+		Spec:       v1beta1.TaskRunSpec{TaskRef: &v1beta1.TaskRef{Name: "hello-world"}},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				TaskRunResults: []v1beta1.TaskRunResult{{Name: "platform", Value: "mac"}},
+			},
+		},
+	}
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr},
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask, enumTask},
+		TaskRuns:     []*v1beta1.TaskRun{detect},
+		ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-constraints", []string{}, false)
+	if condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded); condition.IsFalse() {
+		t.Fatalf("Expected PipelineRun to be running but it failed: %v", condition)
+	}
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: pipeline.PipelineRunLabelKey + "=test-pipeline-run-constraints",
+	})
+	if err != nil {
+		t.Fatalf("Failed to list TaskRuns: %v", err)
+	}
+	var platforms []string
+	for _, tr := range taskRuns.Items {
+		if tr.Name == detect.Name {
+			continue
+		}
+		for _, p := range tr.Spec.Params {
+			platforms = append(platforms, tr.Labels[pipeline.PipelineTaskLabelKey]+"="+p.Value.StringVal)
+		}
+	}
+	sort.Strings(platforms)
+	if d := cmp.Diff([]string{"build=linux", "build=mac", "deploy=mac"}, platforms); d != "" {
+		t.Errorf("Unexpected params of the TaskRuns created %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithCache(t *testing.T) {
+	newPipelineRun := func(cache *v1beta1.Cache) *v1beta1.PipelineRun {
+		return &v1beta1.PipelineRun{
+			ObjectMeta: baseObjectMeta("test-pipeline-run-cache", "foo"),
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineSpec: &v1beta1.PipelineSpec{
+					Tasks: []v1beta1.PipelineTask{{
+						Name:    "build",
+						TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+						Cache:   cache,
+					}},
+				},
+			},
+		}
+	}
+	listTaskRuns := func(t *testing.T, prt *PipelineRunTest) []v1beta1.TaskRun {
+		t.Helper()
+		taskRuns, err := prt.TestAssets.Clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+			LabelSelector: pipeline.PipelineRunLabelKey + "=test-pipeline-run-cache",
+		})
+		if err != nil {
+			t.Fatalf("Failed to list TaskRuns: %v", err)
+		}
+		return taskRuns.Items
+	}
+
+	// Without any previous TaskRun, the TaskRun is created with its cache key
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{newPipelineRun(&v1beta1.Cache{})},
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	prt.reconcileRun("foo", "test-pipeline-run-cache", []string{}, false)
+	taskRuns := listTaskRuns(t, prt)
+	prt.Cancel()
+	if len(taskRuns) != 1 {
+		t.Fatalf("Expected 1 TaskRun to be created but got %d", len(taskRuns))
+	}
+	cacheKey := taskRuns[0].Labels[pipeline.CacheKeyLabelKey]
+	if cacheKey == "" {
+		t.Fatalf("Expected TaskRun to have the %s label but got labels %v", pipeline.CacheKeyLabelKey, taskRuns[0].Labels)
+	}
+
+	// The PipelineRun which created the previous TaskRun
+	previousRun := func(namespace string) *v1beta1.PipelineRun {
+		pr := newPipelineRun(&v1beta1.Cache{})
+		pr.Name = "previous-pipeline-run-cache"
+		pr.Namespace = namespace
+		pr.UID = "previous-pipeline-run-uid"
+		return pr
+	}
+	results := []v1beta1.TaskRunResult{{Name: "image", Value: "registry/image:tag"}}
+	for _, tc := range []struct {
+		name      string
+		cache     *v1beta1.Cache
+		namespace string
+		age       time.Duration
+		noOwner   bool
+		taskSpec  *v1beta1.TaskSpec
+		// clusterScoped enables the "enable-cluster-scoped-cache" feature flag
+		clusterScoped bool
+		wantHit       bool
+	}{{
+		name:      "successful TaskRun in the namespace",
+		cache:     &v1beta1.Cache{},
+		namespace: "foo",
+		age:       time.Hour,
+		wantHit:   true,
+	}, {
+		name:      "successful TaskRun past its ttl",
+		cache:     &v1beta1.Cache{TTL: &metav1.Duration{Duration: time.Minute}},
+		namespace: "foo",
+		age:       time.Hour,
+	}, {
+		name:      "successful TaskRun in another namespace",
+		cache:     &v1beta1.Cache{},
+		namespace: "bar",
+		age:       time.Hour,
+	}, {
+		name:          "successful TaskRun in another namespace with cluster scope",
+		cache:         &v1beta1.Cache{Scope: v1beta1.CacheScopeCluster},
+		namespace:     "bar",
+		age:           time.Hour,
+		clusterScoped: true,
+		wantHit:       true,
+	}, {
+		name:      "successful TaskRun not created by a PipelineRun",
+		cache:     &v1beta1.Cache{},
+		namespace: "foo",
+		age:       time.Hour,
+		noOwner:   true,
+	}, {
+		name:      "successful TaskRun with a forged cache key",
+		cache:     &v1beta1.Cache{},
+		namespace: "foo",
+		age:       time.Hour,
+		taskSpec: &v1beta1.TaskSpec{Steps: []v1beta1.Step{{Container: corev1.Container{
+			Name:  "forged",
+			Image: "attacker/image",
+		}}}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := previousRun(tc.namespace)
+			taskSpec := tc.taskSpec
+			if taskSpec == nil {
+				taskSpec = simpleHelloWorldTask.Spec.DeepCopy()
+			}
+			cached := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "previous-build",
+					Namespace:       tc.namespace,
+					Labels:          map[string]string{pipeline.CacheKeyLabelKey: cacheKey},
+					OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(owner)},
+				},
+				Status: v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						CompletionTime: &metav1.Time{Time: time.Now().Add(-tc.age)},
+						TaskRunResults: results,
+						TaskSpec:       taskSpec,
+					},
+				},
+			}
+			if tc.noOwner {
+				cached.OwnerReferences = nil
+			}
+			cms := getConfigMapsWithEnabledAlphaAPIFields()
+			if tc.clusterScoped {
+				cms[0].Data["enable-cluster-scoped-cache"] = "true"
+			}
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{newPipelineRun(tc.cache), owner},
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				TaskRuns:     []*v1beta1.TaskRun{cached},
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-cache", []string{}, false)
+
+			taskRuns := listTaskRuns(t, prt)
+			if !tc.wantHit {
+				if len(taskRuns) != 1 {
+					t.Errorf("Expected 1 TaskRun to be created but got %d", len(taskRuns))
+				}
+				return
+			}
+			if len(taskRuns) != 0 {
+				t.Errorf("Expected no TaskRun to be created but got %d", len(taskRuns))
+			}
+			if len(reconciledRun.Status.TaskRuns) != 1 {
+				t.Fatalf("Expected 1 TaskRun in the status but got %v", reconciledRun.Status.TaskRuns)
+			}
+			for _, prtrs := range reconciledRun.Status.TaskRuns {
+				condition := prtrs.Status.GetCondition(apis.ConditionSucceeded)
+				if prtrs.PipelineTaskName != "build" || !condition.IsTrue() || condition.Reason != v1beta1.TaskRunReasonCacheHit.String() {
+					t.Errorf("Expected build to succeed with reason %s but got %v", v1beta1.TaskRunReasonCacheHit, condition)
+				}
+				if d := cmp.Diff(results, prtrs.Status.TaskRunResults); d != "" {
+					t.Errorf("Unexpected results of cached TaskRun %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+// TestReconcileWithCacheSkipped runs "Reconcile" against a PipelineRun with a cached PipelineTask
+// whose workspace can't be hashed, and verifies that its TaskRun is created and the skip is reported.
+func TestReconcileWithCacheSkipped(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: baseObjectMeta("test-pipeline-run-cache-skipped", "foo"),
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "shared"}},
+				Tasks: []v1beta1.PipelineTask{{
+					Name: "build",
+					TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+						Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+						Steps: []v1beta1.Step{{Container: corev1.Container{
+							Name:  "build",
+							Image: "foo",
+						}}},
+					}},
+					Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "source", Workspace: "shared"}},
+					Cache:      &v1beta1.Cache{Workspaces: []string{"source"}},
+				}},
+			},
+			Workspaces: []v1beta1.WorkspaceBinding{{Name: "shared", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		`Warning CacheSkipped Cache of PipelineTask "build" was skipped: workspace "source" isn't bound to a ConfigMap or a Secret`,
+		"Normal Running Tasks Completed: 0",
+	}
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run-cache-skipped", wantEvents, false)
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list TaskRuns: %v", err)
+	}
+	if len(taskRuns.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRun to be created but got %d", len(taskRuns.Items))
+	}
+	if key, ok := taskRuns.Items[0].Labels[pipeline.CacheKeyLabelKey]; ok {
+		t.Errorf("Expected the TaskRun to have no cache key but got %s", key)
+	}
+}
+
+// TestReconcileWithRunRetries runs "Reconcile" against a PipelineRun with a failed Run whose
+// custom PipelineTask has retries left, and verifies that the Run is retried.
+func TestReconcileWithApproval(t *testing.T) {
+	newPipelineSpec := func(onTimeout string) *v1beta1.PipelineSpec {
+		return &v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name: "approve",
+				Approval: &v1beta1.Approval{
+					Approvers: []v1beta1.Approver{
+						{Kind: v1beta1.ApproverKindUser, Name: "alice"},
+						{Kind: v1beta1.ApproverKindGroup, Name: "release-managers"},
+					},
+					Timeout:   &metav1.Duration{Duration: time.Hour},
+					OnTimeout: onTimeout,
+				},
+			}, {
+				Name:     "deploy",
+				TaskRef:  &v1beta1.TaskRef{Name: "hello-world"},
+				RunAfter: []string{"approve"},
+			}},
+		}
+	}
+	pending := func(startedAgo time.Duration) []v1beta1.PipelineTaskApprovalStatus {
+		return []v1beta1.PipelineTaskApprovalStatus{{
+			Name:      "approve",
+			State:     v1beta1.ApprovalStatePending,
+			StartTime: &metav1.Time{Time: time.Now().Add(-startedAgo)},
+		}}
+	}
+
+	for _, tc := range []struct {
+		name         string
+		onTimeout    string
+		approvals    []v1beta1.PipelineTaskApprovalStatus
+		decisions    []v1beta1.ApprovalDecision
+		wantEvents   []string
+		wantState    v1beta1.ApprovalState
+		wantApprover string
+		wantTaskRun  bool
+		wantStatus   corev1.ConditionStatus
+		wantSkipped  []string
+	}{{
+		name:       "waits for an approval",
+		wantState:  v1beta1.ApprovalStatePending,
+		wantStatus: corev1.ConditionUnknown,
+	}, {
+		name:         "approved by a user",
+		approvals:    pending(time.Minute),
+		decisions:    []v1beta1.ApprovalDecision{{PipelineTask: "approve", Decision: v1beta1.ApprovalDecisionApprove, User: "alice"}},
+		wantState:    v1beta1.ApprovalStateApproved,
+		wantApprover: "alice",
+		wantTaskRun:  true,
+		wantStatus:   corev1.ConditionUnknown,
+	}, {
+		name:      "approved before waiting for an approval",
+		decisions: []v1beta1.ApprovalDecision{{PipelineTask: "approve", Decision: v1beta1.ApprovalDecisionApprove, User: "alice"}},
+		wantState: v1beta1.ApprovalStateApproved,
+		// The TaskRun of the PipelineTask depending on the approval is created at the next reconcile
+		wantApprover: "alice",
+		wantStatus:   corev1.ConditionUnknown,
+	}, {
+		name:      "rejected by a member of a group",
+		approvals: pending(time.Minute),
+		decisions: []v1beta1.ApprovalDecision{{
+			PipelineTask: "approve",
+			Decision:     v1beta1.ApprovalDecisionReject,
+			User:         "bob",
+			Groups:       []string{"release-managers"},
+		}},
+		wantState:    v1beta1.ApprovalStateRejected,
+		wantApprover: "bob",
+		wantStatus:   corev1.ConditionFalse,
+		wantSkipped:  []string{"deploy"},
+	}, {
+		name:      "ignores the decision of somebody else",
+		approvals: pending(time.Minute),
+		decisions: []v1beta1.ApprovalDecision{{PipelineTask: "approve", Decision: v1beta1.ApprovalDecisionApprove, User: "mallory"}},
+		wantEvents: []string{
+			`Warning ApprovalIgnored Decision of "mallory" on PipelineTask "approve" was ignored`,
+			"Normal Running Tasks Completed: 0",
+		},
+		wantState:  v1beta1.ApprovalStatePending,
+		wantStatus: corev1.ConditionUnknown,
+	}, {
+		name:        "fails on timeout",
+		approvals:   pending(2 * time.Hour),
+		wantState:   v1beta1.ApprovalStateTimedOut,
+		wantStatus:  corev1.ConditionFalse,
+		wantSkipped: []string{"deploy"},
+	}, {
+		name:        "skips on timeout",
+		onTimeout:   v1beta1.ApprovalOnTimeoutSkip,
+		approvals:   pending(2 * time.Hour),
+		wantState:   v1beta1.ApprovalStateTimedOut,
+		wantStatus:  corev1.ConditionTrue,
+		wantSkipped: []string{"approve", "deploy"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: baseObjectMeta("test-pipeline-run-approval", "foo"),
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineSpec: newPipelineSpec(tc.onTimeout),
+					Approvals:    tc.decisions,
+				},
+			}
+			if tc.approvals != nil {
+				pr.Status.InitializeConditions()
+				pr.Status.Approvals = tc.approvals
+			}
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{pr},
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-approval", tc.wantEvents, false)
+
+			if len(reconciledRun.Status.Approvals) != 1 {
+				t.Fatalf("Expected the status of one approval but got %v", reconciledRun.Status.Approvals)
+			}
+			approval := reconciledRun.Status.Approvals[0]
+			if approval.State != tc.wantState || approval.Approver != tc.wantApprover {
+				t.Errorf("Expected approval %s by %q but got %s by %q", tc.wantState, tc.wantApprover, approval.State, approval.Approver)
+			}
+			if approval.StartTime == nil || approval.IsDone() != (approval.CompletionTime != nil) {
+				t.Errorf("Unexpected times of the approval: started at %v, completed at %v", approval.StartTime, approval.CompletionTime)
+			}
+			if got := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Status; got != tc.wantStatus {
+				t.Errorf("Expected PipelineRun status %s but got %s", tc.wantStatus, got)
+			}
+			var skipped []string
+			for _, st := range reconciledRun.Status.SkippedTasks {
+				skipped = append(skipped, st.Name)
+			}
+			if d := cmp.Diff(tc.wantSkipped, skipped); d != "" {
+				t.Errorf("Unexpected skipped tasks %s", diff.PrintWantGot(d))
+			}
+
+			taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list TaskRuns: %v", err)
+			}
+			if got := len(taskRuns.Items) == 1; got != tc.wantTaskRun {
+				t.Errorf("Expected a TaskRun to be created: %t but got %d TaskRuns", tc.wantTaskRun, len(taskRuns.Items))
+			}
+		})
+	}
+}
+
+func TestReconcileWithTaskSpecStatus(t *testing.T) {
+	prName := "test-pipeline-run-task-status"
+	buildTaskRunName := prName + "-build"
+	pipelineSpec := &v1beta1.PipelineSpec{
+		Tasks: []v1beta1.PipelineTask{{
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+		}, {
+			Name:     "deploy",
+			TaskRef:  &v1beta1.TaskRef{Name: "hello-world"},
+			RunAfter: []string{"build"},
+		}, {
+			Name:    "lint",
+			TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+		}},
+	}
+	buildTaskRun := func(status corev1.ConditionStatus) *v1beta1.TaskRun {
+		return &v1beta1.TaskRun{
+			ObjectMeta: taskRunObjectMeta(buildTaskRunName, "foo", prName, prName, "build", false),
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+			},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: status}},
+				},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name            string
+		specStatus      v1beta1.PipelineTaskSpecStatus
+		buildTaskRun    *v1beta1.TaskRun
+		wantBuildStatus v1beta1.TaskRunSpecStatus
+		wantRetries     int
+		wantTaskRuns    []string
+		wantSkipped     []string
+	}{{
+		name:            "cancels a running task",
+		specStatus:      v1beta1.PipelineTaskSpecStatusCancelled,
+		buildTaskRun:    buildTaskRun(corev1.ConditionUnknown),
+		wantBuildStatus: v1beta1.TaskRunSpecStatusCancelled,
+		wantTaskRuns:    []string{"build", "lint"},
+	}, {
+		name:         "cancels a task before it started",
+		specStatus:   v1beta1.PipelineTaskSpecStatusCancelled,
+		wantTaskRuns: []string{"lint"},
+		wantSkipped:  []string{"build", "deploy"},
+	}, {
+		name:         "skips a task before it started",
+		specStatus:   v1beta1.PipelineTaskSpecStatusSkipped,
+		wantTaskRuns: []string{"deploy", "lint"},
+		wantSkipped:  []string{"build"},
+	}, {
+		name:         "retries a failed task",
+		specStatus:   v1beta1.PipelineTaskSpecStatusRetry,
+		buildTaskRun: buildTaskRun(corev1.ConditionFalse),
+		wantRetries:  1,
+		wantTaskRuns: []string{"build", "lint"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: baseObjectMeta(prName, "foo"),
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineSpec: pipelineSpec,
+					TaskStatuses: []v1beta1.PipelineRunTaskSpecStatus{{PipelineTaskName: "build", Status: tc.specStatus}},
+				},
+			}
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{pr},
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+			}
+			if tc.buildTaskRun != nil {
+				pr.Status.InitializeConditions()
+				pr.Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+					buildTaskRunName: {PipelineTaskName: "build", Status: &tc.buildTaskRun.Status},
+				}
+				d.TaskRuns = []*v1beta1.TaskRun{tc.buildTaskRun}
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", prName, nil, false)
+
+			if got := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Status; got != corev1.ConditionUnknown {
+				t.Errorf("Expected PipelineRun to keep running but its status is %s", got)
+			}
+			var skipped []string
+			for _, st := range reconciledRun.Status.SkippedTasks {
+				skipped = append(skipped, st.Name)
+			}
+			if d := cmp.Diff(tc.wantSkipped, skipped); d != "" {
+				t.Errorf("Unexpected skipped tasks %s", diff.PrintWantGot(d))
+			}
+
+			taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list TaskRuns: %v", err)
+			}
+			var pipelineTasks []string
+			for _, tr := range taskRuns.Items {
+				pipelineTasks = append(pipelineTasks, tr.Labels[pipeline.PipelineTaskLabelKey])
+				if tr.Name != buildTaskRunName {
+					continue
+				}
+				if tr.Spec.Status != tc.wantBuildStatus {
+					t.Errorf("Expected the TaskRun of the build task to have status %q but got %q", tc.wantBuildStatus, tr.Spec.Status)
+				}
+				if len(tr.Status.RetriesStatus) != tc.wantRetries {
+					t.Errorf("Expected the TaskRun of the build task to be retried %d times but got %d", tc.wantRetries, len(tr.Status.RetriesStatus))
+				}
+			}
+			if d := cmp.Diff(tc.wantTaskRuns, pipelineTasks, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+				t.Errorf("Unexpected TaskRuns %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileWithRunRetries(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: baseObjectMeta("test-pipeline-run-retry", "foo"),
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name:    "custom-task",
+					TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+					Retries: 1,
+				}},
+			},
+			ServiceAccountName: "test-sa",
+		},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				StartTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}}
+	runs := []*v1alpha1.Run{{
+		ObjectMeta: taskRunObjectMeta("test-pipeline-run-retry-custom-task", "foo", "test-pipeline-run-retry", "test-pipeline-run-retry", "custom-task", true),
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+		},
+		Status: v1alpha1.RunStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+					Reason: "Failed",
+				}},
+			},
+		},
+	}}
+	prs[0].Status.Runs = map[string]*v1beta1.PipelineRunRunStatus{
+		"test-pipeline-run-retry-custom-task": {PipelineTaskName: "custom-task", Status: &runs[0].Status},
+	}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"enable-custom-tasks": "true",
+		},
+	}}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Runs:         runs,
+		ConfigMaps:   cms,
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-retry", []string{}, false)
+
+	run, err := clients.Pipeline.TektonV1alpha1().Runs("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-retry-custom-task", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get Run: %v", err)
+	}
+	if len(run.Status.RetriesStatus) != 1 {
+		t.Errorf("expected the Run to be retried once but got %d retries", len(run.Status.RetriesStatus))
+	}
+	if !run.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("expected the retried Run to be running but got %v", run.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("expected the PipelineRun to be running but got %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
 func TestReconcilePropagateAnnotations(t *testing.T) {
 	names.TestingSeed()
 
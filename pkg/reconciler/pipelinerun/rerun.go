@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// reuseTasks copies the status of the PipelineTasks which succeeded in the PipelineRun being
+// rerun into the status of the PipelineRun, except for the PipelineTasks to rerun and the ones
+// depending on them. These PipelineTasks are reported as reused and aren't run again.
+func (c *Reconciler) reuseTasks(ctx context.Context, pr *v1beta1.PipelineRun, pipelineSpec *v1beta1.PipelineSpec, d *dag.Graph) error {
+	logger := logging.FromContext(ctx)
+	rerun := pr.Spec.Rerun
+
+	previous, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(rerun.PipelineRunName)
+	switch {
+	case errors.IsNotFound(err):
+		pr.Status.MarkFailed(ReasonInvalidRerun,
+			"PipelineRun %s/%s can't rerun PipelineRun %s: it doesn't exist",
+			pr.Namespace, pr.Name, rerun.PipelineRunName)
+		return controller.NewPermanentError(err)
+	case err != nil:
+		return fmt.Errorf("failed to get PipelineRun %s to rerun: %w", rerun.PipelineRunName, err)
+	case !previous.IsDone():
+		pr.Status.MarkFailed(ReasonInvalidRerun,
+			"PipelineRun %s/%s can't rerun PipelineRun %s: it isn't done",
+			pr.Namespace, pr.Name, rerun.PipelineRunName)
+		return controller.NewPermanentError(fmt.Errorf("PipelineRun %s to rerun isn't done", rerun.PipelineRunName))
+	}
+	if err := checkSamePipeline(pr, previous); err != nil {
+		pr.Status.MarkFailed(ReasonInvalidRerun,
+			"PipelineRun %s/%s can't rerun PipelineRun %s: %s",
+			pr.Namespace, pr.Name, rerun.PipelineRunName, err)
+		return controller.NewPermanentError(err)
+	}
+
+	// The PipelineTasks to rerun must be run again along with all the PipelineTasks depending on them
+	rerunTasks, err := dag.GetDescendants(d, rerun.Tasks...)
+	if err != nil {
+		pr.Status.MarkFailed(ReasonInvalidRerun,
+			"PipelineRun %s/%s can't rerun PipelineRun %s: %s",
+			pr.Namespace, pr.Name, rerun.PipelineRunName, err)
+		return controller.NewPermanentError(err)
+	}
+
+	if pr.Status.TaskRuns == nil {
+		pr.Status.TaskRuns = make(map[string]*v1beta1.PipelineRunTaskRunStatus)
+	}
+	reused := []v1beta1.ReusedTask{}
+	for _, pt := range pipelineSpec.Tasks {
+		if rerunTasks.Has(pt.Name) || pt.IsMatrixed() {
+			continue
+		}
+		for taskRunName, prtrs := range previous.Status.TaskRuns {
+			if prtrs.PipelineTaskName != pt.Name || prtrs.Status == nil || !prtrs.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+				continue
+			}
+			logger.Infof("Reusing TaskRun %s of PipelineRun %s for pipeline task %s of PipelineRun %s", taskRunName, previous.Name, pt.Name, pr.Name)
+			pr.Status.TaskRuns[taskRunName] = prtrs.DeepCopy()
+			reused = append(reused, v1beta1.ReusedTask{
+				Name:            pt.Name,
+				PipelineRunName: previous.Name,
+				TaskRunName:     taskRunName,
+			})
+			break
+		}
+	}
+	pr.Status.ReusedTasks = reused
+	return nil
+}
+
+// checkSamePipeline returns an error if the PipelineRun being rerun doesn't run the same
+// Pipeline as the PipelineRun, either because it references another Pipeline or because
+// its resolved PipelineSpec is different.
+func checkSamePipeline(pr, previous *v1beta1.PipelineRun) error {
+	ref, previousRef := pr.Spec.PipelineRef, previous.Spec.PipelineRef
+	switch {
+	case ref != nil && previousRef == nil:
+		return fmt.Errorf("it doesn't reference the Pipeline %s", ref.Name)
+	case ref == nil && previousRef != nil:
+		return fmt.Errorf("it references the Pipeline %s instead of embedding a PipelineSpec", previousRef.Name)
+	case ref != nil && (ref.Name != previousRef.Name || ref.Bundle != previousRef.Bundle):
+		return fmt.Errorf("it references the Pipeline %s instead of %s", previousRef.Name, ref.Name)
+	}
+	previousSpec := previous.Status.PipelineSpec
+	if previousSpec == nil {
+		previousSpec = previous.Spec.PipelineSpec
+	}
+	if previousSpec != nil && pr.Status.PipelineSpec != nil && !equality.Semantic.DeepEqual(previousSpec, pr.Status.PipelineSpec) {
+		return fmt.Errorf("its resolved PipelineSpec is different")
+	}
+	return nil
+}
@@ -45,11 +45,18 @@ func ApplyParameters(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.
 	// Set all the default stringReplacements
 	for _, p := range p.Params {
 		if p.Default != nil {
-			if p.Default.Type == v1beta1.ParamTypeString {
+			switch p.Default.Type {
+			case v1beta1.ParamTypeString:
 				for _, pattern := range patterns {
 					stringReplacements[fmt.Sprintf(pattern, p.Name)] = p.Default.StringVal
 				}
-			} else {
+			case v1beta1.ParamTypeObject:
+				for _, pattern := range patterns {
+					for k, v := range p.Default.ObjectVal {
+						stringReplacements[fmt.Sprintf(pattern+".%s", p.Name, k)] = v
+					}
+				}
+			default:
 				for _, pattern := range patterns {
 					arrayReplacements[fmt.Sprintf(pattern, p.Name)] = p.Default.ArrayVal
 				}
@@ -58,11 +65,20 @@ func ApplyParameters(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.
 	}
 	// Set and overwrite params with the ones from the PipelineRun
 	for _, p := range pr.Spec.Params {
-		if p.Value.Type == v1beta1.ParamTypeString {
+		switch p.Value.Type {
+		case v1beta1.ParamTypeString:
 			for _, pattern := range patterns {
 				stringReplacements[fmt.Sprintf(pattern, p.Name)] = p.Value.StringVal
 			}
-		} else {
+		case v1beta1.ParamTypeObject:
+			// the keys of an object are replaced one by one, so that the keys
+			// which are not provided keep the value of the default
+			for _, pattern := range patterns {
+				for k, v := range p.Value.ObjectVal {
+					stringReplacements[fmt.Sprintf(pattern+".%s", p.Name, k)] = v
+				}
+			}
+		default:
 			for _, pattern := range patterns {
 				arrayReplacements[fmt.Sprintf(pattern, p.Name)] = p.Value.ArrayVal
 			}
@@ -92,12 +108,25 @@ func ApplyPipelineTaskContexts(pt *v1beta1.PipelineTask) *v1beta1.PipelineTask {
 		"context.pipelineTask.retries": strconv.Itoa(pt.Retries),
 	}
 	pt.Params = replaceParamValues(pt.Params, replacements, map[string][]string{})
+	pt.Matrix = replaceParamValues(pt.Matrix, replacements, map[string][]string{})
+	return pt
+}
+
+// ApplyIterationContext applies the substitution from $(context.iteration) with the given iteration
+// of a PipelineTask with Iterate.
+func ApplyIterationContext(pt *v1beta1.PipelineTask, iteration int) *v1beta1.PipelineTask {
+	pt = pt.DeepCopy()
+	replacements := map[string]string{
+		"context.iteration": strconv.Itoa(iteration),
+	}
+	pt.Params = replaceParamValues(pt.Params, replacements, map[string][]string{})
 	return pt
 }
 
 // ApplyTaskResults applies the ResolvedResultRef to each PipelineTask.Params and Pipeline.WhenExpressions in targets
 func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResultRefs) {
 	stringReplacements := resolvedResultRefs.getStringReplacements()
+	arrayReplacements := resolvedResultRefs.getArrayReplacements()
 	for _, resolvedPipelineRunTask := range targets {
 		// also make substitution for resolved condition checks
 		for _, resolvedConditionCheck := range resolvedPipelineRunTask.ResolvedConditionChecks {
@@ -107,8 +136,8 @@ func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResul
 		}
 		if resolvedPipelineRunTask.PipelineTask != nil {
 			pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
-			pipelineTask.Params = replaceParamValues(pipelineTask.Params, stringReplacements, nil)
-			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(stringReplacements, nil)
+			pipelineTask.Params = replaceParamValues(pipelineTask.Params, stringReplacements, arrayReplacements)
+			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(stringReplacements, arrayReplacements)
 			resolvedPipelineRunTask.PipelineTask = pipelineTask
 		}
 	}
@@ -120,6 +149,7 @@ func ApplyPipelineTaskStateContext(state PipelineRunState, replacements map[stri
 		if resolvedPipelineRunTask.PipelineTask != nil {
 			pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
 			pipelineTask.Params = replaceParamValues(pipelineTask.Params, replacements, nil)
+			pipelineTask.Matrix = replaceParamValues(pipelineTask.Matrix, replacements, nil)
 			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(replacements, nil)
 			resolvedPipelineRunTask.PipelineTask = pipelineTask
 		}
@@ -148,6 +178,7 @@ func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string,
 
 	for i := range p.Tasks {
 		p.Tasks[i].Params = replaceParamValues(p.Tasks[i].Params, replacements, arrayReplacements)
+		p.Tasks[i].Matrix = replaceParamValues(p.Tasks[i].Matrix, replacements, arrayReplacements)
 		for j := range p.Tasks[i].Workspaces {
 			p.Tasks[i].Workspaces[j].SubPath = substitution.ApplyReplacements(p.Tasks[i].Workspaces[j].SubPath, replacements)
 		}
@@ -156,11 +187,18 @@ func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string,
 			c.Params = replaceParamValues(c.Params, replacements, arrayReplacements)
 		}
 		p.Tasks[i].WhenExpressions = p.Tasks[i].WhenExpressions.ReplaceWhenExpressionsVariables(replacements, arrayReplacements)
+		if p.Tasks[i].Iterate != nil {
+			p.Tasks[i].Iterate.Until = p.Tasks[i].Iterate.Until.ReplaceWhenExpressionsVariables(replacements, arrayReplacements)
+		}
 	}
 
 	for i := range p.Finally {
 		p.Finally[i].Params = replaceParamValues(p.Finally[i].Params, replacements, arrayReplacements)
+		p.Finally[i].Matrix = replaceParamValues(p.Finally[i].Matrix, replacements, arrayReplacements)
 		p.Finally[i].WhenExpressions = p.Finally[i].WhenExpressions.ReplaceWhenExpressionsVariables(replacements, arrayReplacements)
+		if p.Finally[i].Iterate != nil {
+			p.Finally[i].Iterate.Until = p.Finally[i].Iterate.Until.ReplaceWhenExpressionsVariables(replacements, arrayReplacements)
+		}
 	}
 
 	return p
@@ -177,14 +215,20 @@ func replaceParamValues(params []v1beta1.Param, stringReplacements map[string]st
 // list of PipelineResults, returning the computed set of PipelineRunResults. References to
 // non-existent TaskResults or failed TaskRuns or Runs result in a PipelineResult being considered invalid
 // and omitted from the returned slice. A nil slice is returned if no results are passed in or all
-// results are invalid.
+// results are invalid. The results of final tasks, referenced using $(finally.<taskName>.results.<resultName>),
+// are resolved the same way since the PipelineResults are only computed once the final tasks are done.
 func ApplyTaskResultsToPipelineResults(
 	results []v1beta1.PipelineResult,
 	taskRunStatuses map[string]*v1beta1.PipelineRunTaskRunStatus,
-	runStatuses map[string]*v1beta1.PipelineRunRunStatus) []v1beta1.PipelineRunResult {
+	runStatuses map[string]*v1beta1.PipelineRunRunStatus,
+	childPipelineRunResults map[string][]v1beta1.PipelineRunResult) []v1beta1.PipelineRunResult {
 
 	taskStatuses := map[string]*v1beta1.PipelineRunTaskRunStatus{}
 	for _, trStatus := range taskRunStatuses {
+		// the results of a PipelineTask with Iterate are the ones of its last iteration
+		if previous, ok := taskStatuses[trStatus.PipelineTaskName]; ok && previous.Iteration > trStatus.Iteration {
+			continue
+		}
 		taskStatuses[trStatus.PipelineTaskName] = trStatus
 	}
 	customTaskStatuses := map[string]*v1beta1.PipelineRunRunStatus{}
@@ -201,12 +245,19 @@ func ApplyTaskResultsToPipelineResults(
 			if _, isMemoized := stringReplacements[variable]; isMemoized {
 				continue
 			}
-			variableParts := strings.Split(variable, ".")
-			if len(variableParts) == 4 && variableParts[0] == "tasks" && variableParts[2] == "results" {
-				taskName, resultName := variableParts[1], variableParts[3]
-				if resultValue := taskResultValue(taskName, resultName, taskStatuses); resultValue != nil {
-					stringReplacements[variable] = *resultValue
-				} else if resultValue := runResultValue(taskName, resultName, customTaskStatuses); resultValue != nil {
+			if refs := v1beta1.NewPipelineResultRefs([]string{variable}); len(refs) == 1 {
+				taskName, resultName := refs[0].PipelineTask, refs[0].Result
+				resultValue := taskResultValue(taskName, resultName, taskStatuses)
+				if resultValue == nil {
+					resultValue = runResultValue(taskName, resultName, customTaskStatuses)
+				}
+				if resultValue == nil {
+					resultValue = childPipelineRunResultValue(taskName, resultName, childPipelineRunResults)
+				}
+				if resultValue != nil && refs[0].Property != "" {
+					resultValue = objectResultPropertyValue(*resultValue, refs[0].Property)
+				}
+				if resultValue != nil {
 					stringReplacements[variable] = *resultValue
 				} else {
 					validPipelineResult = false
@@ -253,6 +304,19 @@ func taskResultValue(taskName string, resultName string, taskStatuses map[string
 	return nil
 }
 
+// objectResultPropertyValue returns the value of the given key of an object result.
+// A nil pointer is returned if the result is not a valid object or does not contain the key.
+func objectResultPropertyValue(resultValue string, property string) *string {
+	objectVal, err := v1beta1.ParseObjectResult(resultValue)
+	if err != nil {
+		return nil
+	}
+	if propertyVal, ok := objectVal[property]; ok {
+		return &propertyVal
+	}
+	return nil
+}
+
 // runResultValue checks if a Run result exists for a given pipeline task and result name.
 // A nil pointer is returned if the variable is invalid for any reason.
 func runResultValue(taskName string, resultName string, runStatuses map[string]*v1beta1.PipelineRunRunStatus) *string {
@@ -274,3 +338,14 @@ func runResultValue(taskName string, resultName string, runStatuses map[string]*
 	}
 	return nil
 }
+
+// childPipelineRunResultValue checks if a child PipelineRun result exists for a given pipeline task and result name.
+// A nil pointer is returned if the variable is invalid for any reason.
+func childPipelineRunResultValue(taskName string, resultName string, childPipelineRunResults map[string][]v1beta1.PipelineRunResult) *string {
+	for _, prResult := range childPipelineRunResults[taskName] {
+		if prResult.Name == resultName {
+			return &prResult.Value
+		}
+	}
+	return nil
+}
@@ -352,6 +352,39 @@ func TestApplyParameters(t *testing.T) {
 				},
 			}},
 		},
+	}, {
+		name: "object parameter keys",
+		original: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "gitrepo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}, "revision": {}},
+				Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/catalog", "revision": "main"}),
+			}},
+			Tasks: []v1beta1.PipelineTask{{
+				Params: []v1beta1.Param{
+					{Name: "url", Value: *v1beta1.NewArrayOrString("$(params.gitrepo.url)")},
+					{Name: "args", Value: *v1beta1.NewArrayOrString("--branch", "$(params.gitrepo.revision)")},
+					{Name: "repo", Value: *v1beta1.NewObject(map[string]string{"url": "$(params.gitrepo.url)", "revision": "$(params.gitrepo.revision)"})},
+				},
+			}},
+		},
+		params: []v1beta1.Param{{Name: "gitrepo", Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"})}},
+		expected: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "gitrepo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}, "revision": {}},
+				Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/catalog", "revision": "main"}),
+			}},
+			Tasks: []v1beta1.PipelineTask{{
+				Params: []v1beta1.Param{
+					{Name: "url", Value: *v1beta1.NewArrayOrString("https://github.com/tektoncd/pipeline")},
+					{Name: "args", Value: *v1beta1.NewArrayOrString("--branch", "main")},
+					{Name: "repo", Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "main"})},
+				},
+			}},
+		},
 	},
 	} {
 		tt := tt // capture range variable
@@ -438,6 +471,73 @@ func TestApplyTaskResults_MinimalExpression(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		name: "Test array result substitution on minimal variable substitution expression - params",
+		resolvedResultRefs: ResolvedResultRefs{{
+			Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"a", "b"}},
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "aResult",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+		targets: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Params: []v1beta1.Param{{
+					Name:  "bParam",
+					Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.aResult[*])", "c"),
+				}, {
+					Name:  "cParam",
+					Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.aResult)"),
+				}},
+			},
+		}},
+		want: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Params: []v1beta1.Param{{
+					Name:  "bParam",
+					Value: *v1beta1.NewArrayOrString("a", "b", "c"),
+				}, {
+					Name:  "cParam",
+					Value: *v1beta1.NewArrayOrString(`["a","b"]`),
+				}},
+			},
+		}},
+	}, {
+		name: "Test object result substitution on minimal variable substitution expression - params",
+		resolvedResultRefs: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("sha256:abc"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "aResult",
+				Property:     "digest",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+		targets: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Params: []v1beta1.Param{{
+					Name:  "bParam",
+					Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.aResult.digest)"),
+				}},
+			},
+		}},
+		want: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Params: []v1beta1.Param{{
+					Name:  "bParam",
+					Value: *v1beta1.NewArrayOrString("sha256:abc"),
+				}},
+			},
+		}},
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			ApplyTaskResults(tt.targets, tt.resolvedResultRefs)
@@ -769,7 +869,9 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		results     []v1beta1.PipelineResult
 		statuses    map[string]*v1beta1.PipelineRunTaskRunStatus
 		runStatuses map[string]*v1beta1.PipelineRunRunStatus
-		expected    []v1beta1.PipelineRunResult
+		// childPipelineRunResults are the results of successful child PipelineRuns
+		childPipelineRunResults map[string][]v1beta1.PipelineRunResult
+		expected                []v1beta1.PipelineRunResult
 	}{{
 		description: "no-pipeline-results-no-returned-results",
 		results:     []v1beta1.PipelineResult{},
@@ -1024,6 +1126,72 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 			Name:  "pipeline-result-2",
 			Value: "do, rae, mi, rae, do",
 		}},
+	}, {
+		description: "finally-task-results",
+		results: []v1beta1.PipelineResult{{
+			Name:  "pipeline-result-1",
+			Value: "$(finally.final1.results.report)",
+		}, {
+			Name:  "pipeline-result-2",
+			Value: "$(tasks.pt1.results.foo), $(finally.final1.results.report)",
+		}, {
+			Name:  "pipeline-result-3",
+			Value: "$(finally.final2.results.report)",
+		}},
+		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
+			"task1": {
+				PipelineTaskName: "pt1",
+				Status: &v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						TaskRunResults: []v1beta1.TaskRunResult{{
+							Name:  "foo",
+							Value: "do",
+						}},
+					},
+				},
+			},
+			"final-task1": {
+				PipelineTaskName: "final1",
+				Status: &v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						TaskRunResults: []v1beta1.TaskRunResult{{
+							Name:  "report",
+							Value: "https://reports/1",
+						}},
+					},
+				},
+			},
+			"final-task2": {
+				PipelineTaskName: "final2",
+				Status: &v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+						}},
+					},
+				},
+			},
+		},
+		expected: []v1beta1.PipelineRunResult{{
+			Name:  "pipeline-result-1",
+			Value: "https://reports/1",
+		}, {
+			Name:  "pipeline-result-2",
+			Value: "do, https://reports/1",
+		}},
 	}, {
 		description: "no-run-results-no-returned-results",
 		results: []v1beta1.PipelineResult{{
@@ -1182,9 +1350,92 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 			Name:  "pipeline-result-2",
 			Value: "do, rae, mi, rae, do",
 		}},
+	}, {
+		description: "child-pipelinerun-results",
+		results: []v1beta1.PipelineResult{{
+			Name:  "pipeline-result-1",
+			Value: "$(tasks.childpipeline.results.foo)",
+		}, {
+			Name:  "pipeline-result-2",
+			Value: "$(tasks.childpipeline.results.foo), $(tasks.normaltask.results.baz)",
+		}},
+		childPipelineRunResults: map[string][]v1beta1.PipelineRunResult{
+			"childpipeline": {{
+				Name:  "foo",
+				Value: "do",
+			}},
+		},
+		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
+			"task2": {
+				PipelineTaskName: "normaltask",
+				Status: &v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						TaskRunResults: []v1beta1.TaskRunResult{{
+							Name:  "baz",
+							Value: "rae",
+						}},
+					},
+				},
+			},
+		},
+		expected: []v1beta1.PipelineRunResult{{
+			Name:  "pipeline-result-1",
+			Value: "do",
+		}, {
+			Name:  "pipeline-result-2",
+			Value: "do, rae",
+		}},
+	}, {
+		description: "array-and-object-results",
+		results: []v1beta1.PipelineResult{{
+			Name:  "pipeline-result-1",
+			Type:  v1beta1.ResultsTypeArray,
+			Value: "$(tasks.pt1.results.foo[*])",
+		}, {
+			Name:  "pipeline-result-2",
+			Value: "$(tasks.pt1.results.bar.digest)",
+		}, {
+			Name:  "pipeline-result-3",
+			Value: "$(tasks.pt1.results.bar.missing)",
+		}},
+		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
+			"task1": {
+				PipelineTaskName: "pt1",
+				Status: &v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						TaskRunResults: []v1beta1.TaskRunResult{{
+							Name:  "foo",
+							Value: `["a","b"]`,
+						}, {
+							Name:  "bar",
+							Value: `{"digest":"sha256:abc"}`,
+						}},
+					},
+				},
+			},
+		},
+		expected: []v1beta1.PipelineRunResult{{
+			Name:  "pipeline-result-1",
+			Value: `["a","b"]`,
+		}, {
+			Name:  "pipeline-result-2",
+			Value: "sha256:abc",
+		}},
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
-			received := ApplyTaskResultsToPipelineResults(tc.results, tc.statuses, tc.runStatuses)
+			received := ApplyTaskResultsToPipelineResults(tc.results, tc.statuses, tc.runStatuses, tc.childPipelineRunResults)
 			if d := cmp.Diff(tc.expected, received); d != "" {
 				t.Errorf(diff.PrintWantGot(d))
 			}
@@ -20,22 +20,30 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/list"
+	"github.com/tektoncd/pipeline/pkg/matrix"
 	"github.com/tektoncd/pipeline/pkg/names"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
 )
 
 const (
 	// ReasonConditionCheckFailed indicates that the reason for the failure status is that the
 	// condition check associated to the pipeline task evaluated to false
 	ReasonConditionCheckFailed = "ConditionCheckFailed"
+
+	// MaxPipelineNestingDepth is the maximum number of PipelineRuns a child PipelineRun can be nested in
+	MaxPipelineNestingDepth = 5
 )
 
 // SkippingReason explains why a task was skipped
@@ -56,6 +64,20 @@ const (
 	IsGracefullyStoppedSkip SkippingReason = "IsGracefullyStoppedSkip"
 	// MissingResultsSkip means the task was skipped because it's missing necessary results
 	MissingResultsSkip SkippingReason = "MissingResultsSkip"
+	// EmptyArrayInMatrixParamsSkip means the task was skipped because its Matrix contains an empty array,
+	// so there are no combinations to fan out to
+	EmptyArrayInMatrixParamsSkip SkippingReason = "EmptyArrayInMatrixParamsSkip"
+	// ApprovalTimedOutSkip means the task was skipped because nobody approved or rejected it in time
+	ApprovalTimedOutSkip SkippingReason = "ApprovalTimedOutSkip"
+	// CancelledSkip means the task was skipped because the user cancelled it before it started
+	CancelledSkip SkippingReason = "CancelledSkip"
+	// RequestedSkip means the task was skipped because the user requested it
+	RequestedSkip SkippingReason = "RequestedSkip"
+	// ParentTasksCancelledSkip means the task was skipped because the user cancelled one of its parents
+	ParentTasksCancelledSkip SkippingReason = "ParentTasksCancelledSkip"
+	// ParentTasksFailedSkip means the task was skipped because one of its parents failed, the failure
+	// didn't stop the pipeline run since other tasks depend on the execution status of that parent
+	ParentTasksFailedSkip SkippingReason = "ParentTasksFailedSkip"
 	// None means the task was not skipped
 	None SkippingReason = "None"
 )
@@ -86,19 +108,53 @@ func (e *ConditionNotFoundError) Error() string {
 	return fmt.Sprintf("Couldn't retrieve Condition %q: %s", e.Name, e.Msg)
 }
 
+// CELEvaluationError indicates that a CEL expression in the when expressions of a PipelineTask couldn't
+// be evaluated to a boolean once its variables were substituted
+type CELEvaluationError struct {
+	Name       string
+	Expression string
+	Msg        string
+}
+
+func (e *CELEvaluationError) Error() string {
+	return fmt.Sprintf("Couldn't evaluate CEL expression %q of PipelineTask %q: %s", e.Expression, e.Name, e.Msg)
+}
+
 // ResolvedPipelineRunTask contains a Task and its associated TaskRun, if it
 // exists. TaskRun can be nil to represent there being no TaskRun.
 type ResolvedPipelineRunTask struct {
 	TaskRunName string
 	TaskRun     *v1beta1.TaskRun
 	// If the PipelineTask is a Custom Task, RunName and Run will be set.
-	CustomTask            bool
-	RunName               string
-	Run                   *v1alpha1.Run
+	CustomTask bool
+	RunName    string
+	Run        *v1alpha1.Run
+	// If the PipelineTask is matrixed, TaskRunNames and TaskRuns (or RunNames and Runs
+	// for a Custom Task) will be set instead, with one entry for each combination of the
+	// Matrix. An entry in TaskRuns or Runs is nil until the corresponding instance is created.
+	TaskRunNames []string
+	TaskRuns     []*v1beta1.TaskRun
+	RunNames     []string
+	Runs         []*v1alpha1.Run
+	// If the PipelineTask runs a Pipeline, ChildPipelineRunName and ChildPipelineRun will be set.
+	ChildPipelineRunName  string
+	ChildPipelineRun      *v1beta1.PipelineRun
 	PipelineTask          *v1beta1.PipelineTask
 	ResolvedTaskResources *resources.ResolvedTaskResources
 	// ConditionChecks ~~TaskRuns but for evaling conditions
 	ResolvedConditionChecks TaskConditionCheckState // Could also be a TaskRun or maybe just a Pod?
+	// WaitingForMutex is set to the Mutex of the PipelineTask while it is held by another TaskRun
+	WaitingForMutex string
+	// CacheKey is the hash of the inputs of the TaskRun of a PipelineTask with a Cache,
+	// set when no previous TaskRun with the same inputs could be reused
+	CacheKey string
+	// If the PipelineTask waits for an approval, Approval is set once it started waiting
+	Approval *v1beta1.PipelineTaskApprovalStatus
+	// SpecStatus is the status the user provided for the PipelineTask in the PipelineRun, if any
+	SpecStatus v1beta1.PipelineTaskSpecStatus
+	// If the PipelineTask iterates, Iteration is the current iteration, starting at 1, and
+	// TaskRunName and TaskRun are the ones of the current iteration
+	Iteration int
 }
 
 // IsDone returns true only if the task is skipped, succeeded or failed
@@ -108,11 +164,22 @@ func (t ResolvedPipelineRunTask) IsDone(facts *PipelineRunFacts) bool {
 
 // IsRunning returns true only if the task is neither succeeded, cancelled nor failed
 func (t ResolvedPipelineRunTask) IsRunning() bool {
-	if t.IsCustomTask() {
+	switch {
+	case t.IsApproval():
+		return t.Approval != nil && !t.Approval.IsDone()
+	case t.RunsPipeline():
+		if t.ChildPipelineRun == nil {
+			return false
+		}
+	case t.IsMatrixed():
+		if !t.hasCreatedInstance() {
+			return false
+		}
+	case t.IsCustomTask():
 		if t.Run == nil {
 			return false
 		}
-	} else {
+	default:
 		if t.TaskRun == nil {
 			return false
 		}
@@ -125,47 +192,249 @@ func (t ResolvedPipelineRunTask) IsCustomTask() bool {
 	return t.CustomTask
 }
 
-// IsSuccessful returns true only if the run has completed successfully
+// IsApproval returns true if the PipelineTask waits for an approval.
+func (t ResolvedPipelineRunTask) IsApproval() bool {
+	return t.PipelineTask != nil && t.PipelineTask.IsApproval()
+}
+
+// isApprovalTimedOutSkip returns true if nobody approved or rejected the PipelineTask
+// in time, and it is skipped rather than failed.
+func (t ResolvedPipelineRunTask) isApprovalTimedOutSkip() bool {
+	return t.IsApproval() && t.Approval != nil && t.Approval.State == v1beta1.ApprovalStateTimedOut &&
+		t.PipelineTask.Approval.SkipsOnTimeout()
+}
+
+// RunsPipeline returns true if the PipelineTask runs a Pipeline in a child PipelineRun.
+func (t ResolvedPipelineRunTask) RunsPipeline() bool {
+	return t.PipelineTask != nil && t.PipelineTask.RunsPipeline()
+}
+
+// Iterates returns true if the PipelineTask is rerun until the results of its last iteration
+// satisfy the Until when expressions of its Iterate.
+func (t ResolvedPipelineRunTask) Iterates() bool {
+	return t.PipelineTask != nil && t.PipelineTask.Iterate != nil
+}
+
+// isIterationDone returns true if the results of the current iteration satisfy the Until when
+// expressions of the PipelineTask.
+func (t ResolvedPipelineRunTask) isIterationDone() bool {
+	return t.PipelineTask.Iterate.IsDone(t.PipelineTask.Name, t.TaskRun.Status.TaskRunResults)
+}
+
+// IsMatrixed returns true if the PipelineTask fans out over a Matrix.
+func (t ResolvedPipelineRunTask) IsMatrixed() bool {
+	return t.PipelineTask != nil && t.PipelineTask.IsMatrixed()
+}
+
+// IsSuccessful returns true only if the run has completed successfully.
+// A matrixed task is successful only when all of its TaskRuns or Runs have completed successfully.
 func (t ResolvedPipelineRunTask) IsSuccessful() bool {
+	if t.IsApproval() {
+		return t.Approval != nil && t.Approval.State == v1beta1.ApprovalStateApproved
+	}
+	if t.RunsPipeline() {
+		return t.ChildPipelineRun != nil && t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+	}
+	if t.IsMatrixed() {
+		if t.IsCustomTask() {
+			if len(t.Runs) == 0 {
+				return false
+			}
+			for _, run := range t.Runs {
+				if run == nil || !run.IsSuccessful() {
+					return false
+				}
+			}
+			return true
+		}
+		if len(t.TaskRuns) == 0 {
+			return false
+		}
+		for _, taskRun := range t.TaskRuns {
+			if taskRun == nil || !taskRun.IsSuccessful() {
+				return false
+			}
+		}
+		return true
+	}
 	if t.IsCustomTask() {
 		return t.Run != nil && t.Run.IsSuccessful()
 	}
+	if t.Iterates() {
+		return t.TaskRun != nil && t.TaskRun.IsSuccessful() && t.isIterationDone()
+	}
 	return t.TaskRun != nil && t.TaskRun.IsSuccessful()
 }
 
 // IsFailure returns true only if the run has failed and will not be retried.
+// A matrixed task has failed only when all of its TaskRuns or Runs are done and
+// at least one of them has failed and will not be retried.
 func (t ResolvedPipelineRunTask) IsFailure() bool {
+	if t.IsApproval() {
+		return t.Approval != nil && (t.Approval.State == v1beta1.ApprovalStateRejected ||
+			(t.Approval.State == v1beta1.ApprovalStateTimedOut && !t.isApprovalTimedOutSkip()))
+	}
+	if t.RunsPipeline() {
+		return t.ChildPipelineRun != nil && t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+	}
+	if t.IsMatrixed() {
+		if t.IsCustomTask() {
+			if len(t.Runs) == 0 {
+				return false
+			}
+			failed := false
+			for _, run := range t.Runs {
+				switch {
+				case run == nil:
+					return false
+				case t.isRunFailure(run):
+					failed = true
+				case !run.IsSuccessful():
+					return false
+				}
+			}
+			return failed
+		}
+		if len(t.TaskRuns) == 0 {
+			return false
+		}
+		failed := false
+		for _, taskRun := range t.TaskRuns {
+			switch {
+			case taskRun == nil:
+				return false
+			case t.isTaskRunFailure(taskRun):
+				failed = true
+			case !taskRun.IsSuccessful():
+				return false
+			}
+		}
+		return failed
+	}
 	if t.IsCustomTask() {
-		return t.Run != nil && t.Run.IsDone() && !t.Run.IsSuccessful()
+		return t.Run != nil && t.isRunFailure(t.Run)
 	}
 	if t.TaskRun == nil {
 		return false
 	}
-	c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-	retriesDone := len(t.TaskRun.Status.RetriesStatus)
+	if t.Iterates() && t.TaskRun.IsSuccessful() {
+		// the last iteration succeeded but its results don't satisfy Until
+		return !t.isIterationDone() && t.Iteration >= t.PipelineTask.Iterate.MaxIterations
+	}
+	return t.isTaskRunFailure(t.TaskRun)
+}
+
+// isFailureIgnored returns true only if the run has failed and will not be retried, but its
+// PipelineTask lets the PipelineRun continue on error. Cancelled runs still stop the PipelineRun.
+func (t ResolvedPipelineRunTask) isFailureIgnored() bool {
+	return t.PipelineTask.OnError == v1beta1.PipelineTaskContinue && t.IsFailure() && !t.IsCancelled()
+}
+
+// isTaskRunFailure returns true only if the given TaskRun has failed and will not be retried.
+func (t ResolvedPipelineRunTask) isTaskRunFailure(tr *v1beta1.TaskRun) bool {
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
+	retriesDone := len(tr.Status.RetriesStatus)
 	retries := t.PipelineTask.Retries
-	return c.IsFalse() && (retriesDone >= retries || c.Reason == v1beta1.TaskRunReasonCancelled.String())
+	return c.IsFalse() && (retriesDone >= retries || c.Reason == v1beta1.TaskRunReasonCancelled.String() || !t.PipelineTask.RetryPolicy.ShouldRetry(c.Reason)) &&
+		!(t.isRetryRequested(retriesDone) && t.isRetryable(tr))
+}
+
+// isRunFailure returns true only if the given Run has failed and will not be retried.
+func (t ResolvedPipelineRunTask) isRunFailure(run *v1alpha1.Run) bool {
+	return run.IsDone() && !run.IsSuccessful() && !t.isRunRetryable(run)
 }
 
-// IsCancelled returns true only if the run is cancelled
+// isCancelledOnRequest returns true only if the run was cancelled because the user cancelled
+// its PipelineTask. Unlike other cancelled runs, it doesn't stop the PipelineRun.
+func (t ResolvedPipelineRunTask) isCancelledOnRequest() bool {
+	return t.SpecStatus == v1beta1.PipelineTaskSpecStatusCancelled && t.IsCancelled()
+}
+
+// IsCancelled returns true only if the run is cancelled.
+// A matrixed task is cancelled only when all of its TaskRuns or Runs are done and
+// at least one of them was cancelled.
 func (t ResolvedPipelineRunTask) IsCancelled() bool {
+	if t.IsApproval() {
+		return false
+	}
+	if t.RunsPipeline() {
+		if t.ChildPipelineRun == nil {
+			return false
+		}
+		c := t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+		return c != nil && c.IsFalse() && c.Reason == v1beta1.PipelineRunReasonCancelled.String()
+	}
+	if t.IsMatrixed() {
+		if t.IsCustomTask() {
+			if len(t.Runs) == 0 {
+				return false
+			}
+			cancelled := false
+			for _, run := range t.Runs {
+				if run == nil || !(run.IsSuccessful() || t.isRunFailure(run)) {
+					return false
+				}
+				cancelled = cancelled || isRunCancelled(run)
+			}
+			return cancelled
+		}
+		if len(t.TaskRuns) == 0 {
+			return false
+		}
+		cancelled := false
+		for _, taskRun := range t.TaskRuns {
+			if taskRun == nil || !(taskRun.IsSuccessful() || t.isTaskRunFailure(taskRun)) {
+				return false
+			}
+			cancelled = cancelled || isTaskRunCancelled(taskRun)
+		}
+		return cancelled
+	}
 	if t.IsCustomTask() {
 		if t.Run == nil {
 			return false
 		}
-		c := t.Run.Status.GetCondition(apis.ConditionSucceeded)
-		return c != nil && c.IsFalse() && c.Reason == v1alpha1.RunReasonCancelled
+		return isRunCancelled(t.Run)
 	}
 	if t.TaskRun == nil {
 		return false
 	}
-	c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
+	return isTaskRunCancelled(t.TaskRun)
+}
+
+func isRunCancelled(run *v1alpha1.Run) bool {
+	c := run.Status.GetCondition(apis.ConditionSucceeded)
+	return c != nil && c.IsFalse() && c.Reason == v1alpha1.RunReasonCancelled
+}
+
+func isTaskRunCancelled(tr *v1beta1.TaskRun) bool {
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
 	return c != nil && c.IsFalse() && c.Reason == v1beta1.TaskRunReasonCancelled.String()
 }
 
-// IsStarted returns true only if the PipelineRunTask itself has a TaskRun or
-// Run associated that has a Succeeded-type condition.
+// IsStarted returns true only if the PipelineRunTask itself has a TaskRun,
+// Run or child PipelineRun associated that has a Succeeded-type condition.
+// A matrixed task is started as soon as one of its TaskRuns or Runs has started.
 func (t ResolvedPipelineRunTask) IsStarted() bool {
+	if t.IsApproval() {
+		return t.Approval != nil
+	}
+	if t.RunsPipeline() {
+		return t.ChildPipelineRun != nil && t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded) != nil
+	}
+	if t.IsMatrixed() {
+		for _, run := range t.Runs {
+			if run != nil && run.Status.GetCondition(apis.ConditionSucceeded) != nil {
+				return true
+			}
+		}
+		for _, taskRun := range t.TaskRuns {
+			if taskRun != nil && taskRun.Status.GetCondition(apis.ConditionSucceeded) != nil {
+				return true
+			}
+		}
+		return false
+	}
 	if t.IsCustomTask() {
 		return t.Run != nil && t.Run.Status.GetCondition(apis.ConditionSucceeded) != nil
 
@@ -175,8 +444,28 @@ func (t ResolvedPipelineRunTask) IsStarted() bool {
 
 // IsConditionStatusFalse returns true when a task has succeeded condition with status set to false
 // it includes task failed after retries are exhausted, cancelled tasks, and time outs
+// for a matrixed task, it returns true when any of its TaskRuns or Runs has the succeeded condition set to false
 func (t ResolvedPipelineRunTask) IsConditionStatusFalse() bool {
 	if t.IsStarted() {
+		if t.IsApproval() {
+			return t.IsFailure()
+		}
+		if t.RunsPipeline() {
+			return t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+		}
+		if t.IsMatrixed() {
+			for _, run := range t.Runs {
+				if run != nil && run.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+					return true
+				}
+			}
+			for _, taskRun := range t.TaskRuns {
+				if taskRun != nil && taskRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+					return true
+				}
+			}
+			return false
+		}
 		if t.IsCustomTask() {
 			return t.Run.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
 		}
@@ -185,9 +474,24 @@ func (t ResolvedPipelineRunTask) IsConditionStatusFalse() bool {
 	return false
 }
 
+// hasCreatedInstance returns true if at least one of the TaskRuns or Runs of a matrixed task exists.
+func (t ResolvedPipelineRunTask) hasCreatedInstance() bool {
+	for _, run := range t.Runs {
+		if run != nil {
+			return true
+		}
+	}
+	for _, taskRun := range t.TaskRuns {
+		if taskRun != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *ResolvedPipelineRunTask) checkParentsDone(facts *PipelineRunFacts) bool {
 	if facts.isFinalTask(t.PipelineTask.Name) {
-		return true
+		return t.checkFinalParentsDone(facts)
 	}
 	stateMap := facts.State.ToMap()
 	node := facts.TasksGraph.Nodes[t.PipelineTask.Name]
@@ -199,12 +503,32 @@ func (t *ResolvedPipelineRunTask) checkParentsDone(facts *PipelineRunFacts) bool
 	return true
 }
 
+// checkFinalParentsDone returns true if all the final tasks a final task depends on, through runAfter or
+// task results, are done, a final task is done once it has succeeded, failed or was skipped
+func (t *ResolvedPipelineRunTask) checkFinalParentsDone(facts *PipelineRunFacts) bool {
+	stateMap := facts.State.ToMap()
+	node := facts.FinalTasksGraph.Nodes[t.PipelineTask.Name]
+	for _, p := range node.Prev {
+		parentTask := stateMap[p.Task.HashKey()]
+		if !parentTask.IsDone(facts) && !parentTask.IsFinallySkipped(facts).IsSkipped {
+			return false
+		}
+	}
+	return true
+}
+
 func (t *ResolvedPipelineRunTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 	var skippingReason SkippingReason
 
 	switch {
+	case t.isApprovalTimedOutSkip():
+		skippingReason = ApprovalTimedOutSkip
 	case facts.isFinalTask(t.PipelineTask.Name) || t.IsStarted():
 		skippingReason = None
+	case t.SpecStatus == v1beta1.PipelineTaskSpecStatusCancelled:
+		skippingReason = CancelledSkip
+	case t.SpecStatus == v1beta1.PipelineTaskSpecStatusSkipped:
+		skippingReason = RequestedSkip
 	case facts.IsStopping():
 		skippingReason = IsStoppingSkip
 	case facts.IsGracefullyCancelled():
@@ -213,12 +537,18 @@ func (t *ResolvedPipelineRunTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 		skippingReason = IsGracefullyStoppedSkip
 	case t.skipBecauseParentTaskWasSkipped(facts):
 		skippingReason = ParentTasksSkip
+	case t.skipBecauseParentTaskWasCancelled(facts):
+		skippingReason = ParentTasksCancelledSkip
+	case t.skipBecauseParentTaskFailed(facts):
+		skippingReason = ParentTasksFailedSkip
 	case t.skipBecauseConditionsFailed():
 		skippingReason = ConditionsSkip
 	case t.skipBecauseResultReferencesAreMissing(facts):
 		skippingReason = MissingResultsSkip
 	case t.skipBecauseWhenExpressionsEvaluatedToFalse(facts):
 		skippingReason = WhenExpressionsSkip
+	case t.skipBecauseEmptyArrayInMatrixParams():
+		skippingReason = EmptyArrayInMatrixParamsSkip
 	default:
 		skippingReason = None
 	}
@@ -235,6 +565,9 @@ func (t *ResolvedPipelineRunTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 // (3) its parent task was skipped
 // (4) Pipeline is in stopping state (one of the PipelineTasks failed)
 // (5) Pipeline is gracefully cancelled or stopped
+// (6) its Matrix contains an empty array
+// (7) nobody approved or rejected it in time, and it is skipped on timeout
+// (8) the user skipped or cancelled it, or cancelled its parent task
 // Note that this means Skip returns false if a conditionCheck is in progress
 func (t *ResolvedPipelineRunTask) Skip(facts *PipelineRunFacts) TaskSkipStatus {
 	if facts.SkipCache == nil {
@@ -261,17 +594,89 @@ func (t *ResolvedPipelineRunTask) skipBecauseConditionsFailed() bool {
 // it returns true if any of the when expressions evaluate to false
 func (t *ResolvedPipelineRunTask) skipBecauseWhenExpressionsEvaluatedToFalse(facts *PipelineRunFacts) bool {
 	if t.checkParentsDone(facts) {
-		if !t.PipelineTask.WhenExpressions.AllowsExecution() {
+		evaluatedCEL, err := t.EvaluateCEL(facts)
+		if err != nil {
+			// the task is not skipped so that the reconciler fails the pipeline run with the evaluation error
+			return false
+		}
+		if !t.whenExpressions(facts).AllowsExecution(evaluatedCEL) {
 			return true
 		}
 	}
 	return false
 }
 
+// whenExpressions returns the when expressions of the pipeline task with its execution status references
+// replaced; they are replaced in a copy, the references are needed to schedule the pipeline task
+func (t *ResolvedPipelineRunTask) whenExpressions(facts *PipelineRunFacts) v1beta1.WhenExpressions {
+	if len(t.PipelineTask.ExecutionStatusDeps()) == 0 {
+		return t.PipelineTask.WhenExpressions
+	}
+	return append(v1beta1.WhenExpressions{}, t.PipelineTask.WhenExpressions...).ReplaceWhenExpressionsVariables(facts.getDAGTasksExecutionStatus(), nil)
+}
+
+// EvaluateCEL evaluates the CEL expressions in the when expressions of the pipeline task, once the params,
+// results and execution status references in them are substituted. It returns the result of each expression,
+// or a CELEvaluationError if one of them can't be evaluated to a boolean.
+func (t *ResolvedPipelineRunTask) EvaluateCEL(facts *PipelineRunFacts) (map[string]bool, error) {
+	evaluatedCEL := map[string]bool{}
+	for _, we := range t.whenExpressions(facts) {
+		if we.CEL == "" {
+			continue
+		}
+		if _, ok := evaluatedCEL[we.CEL]; ok {
+			continue
+		}
+		result, err := evaluateCEL(we.CEL)
+		if err != nil {
+			return nil, &CELEvaluationError{Name: t.PipelineTask.Name, Expression: we.CEL, Msg: err.Error()}
+		}
+		evaluatedCEL[we.CEL] = result
+	}
+	return evaluatedCEL, nil
+}
+
+// evaluatedCELCache holds the results of the CEL expressions evaluated by the reconciler, since an expression
+// is evaluated every time the pipeline task is checked to be skipped; the expressions don't declare variables,
+// so their result only depends on the expression itself.
+var evaluatedCELCache, _ = lru.New(evaluatedCELCacheSize)
+
+const evaluatedCELCacheSize = 1024
+
+func evaluateCEL(expression string) (bool, error) {
+	if result, ok := evaluatedCELCache.Get(expression); ok {
+		return result.(bool), nil
+	}
+	prg, err := v1beta1.CompileCEL(expression)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.Eval(map[string]interface{}{})
+	if err != nil {
+		return false, err
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expected a boolean but got %v", out.Value())
+	}
+	evaluatedCELCache.Add(expression, result)
+	return result, nil
+}
+
+// skipBecauseEmptyArrayInMatrixParams returns true if the task is matrixed and any of the params
+// in its Matrix is an empty array, given that there are no combinations to fan out to
+func (t *ResolvedPipelineRunTask) skipBecauseEmptyArrayInMatrixParams() bool {
+	return t.IsMatrixed() && matrix.Count(t.PipelineTask.Matrix) == 0
+}
+
 // skipBecauseParentTaskWasSkipped loops through the parent tasks and checks if the parent task skipped:
 //    if yes, is it because of when expressions and are when expressions?
 //        if yes, it ignores this parent skip and continue evaluating other parent tasks
-//        if no, it returns true to skip the current task because this parent task was skipped
+//    if yes, is it because the user requested it?
+//        if yes, it ignores this parent skip and continue evaluating other parent tasks
+//    if yes, does the current task depend on the execution status of this parent task?
+//        if yes, it ignores this parent skip and continue evaluating other parent tasks
+//    if yes, for any other reason, it returns true to skip the current task because this parent task was skipped
 //    if no, it continues checking the other parent tasks
 func (t *ResolvedPipelineRunTask) skipBecauseParentTaskWasSkipped(facts *PipelineRunFacts) bool {
 	stateMap := facts.State.ToMap()
@@ -284,6 +689,65 @@ func (t *ResolvedPipelineRunTask) skipBecauseParentTaskWasSkipped(facts *Pipelin
 			if parentSkipStatus.SkippingReason == WhenExpressionsSkip && facts.ScopeWhenExpressionsToTask {
 				continue
 			}
+			// a task skipped by the user doesn't block the tasks depending on it
+			if parentSkipStatus.SkippingReason == RequestedSkip {
+				continue
+			}
+			// a task depending on the execution status of its parent evaluates it instead
+			if t.dependsOnExecutionStatusOf(parentTask.PipelineTask.Name) {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// skipBecauseParentTaskWasCancelled returns true if the user cancelled one of the parent tasks while it was running
+func (t *ResolvedPipelineRunTask) skipBecauseParentTaskWasCancelled(facts *PipelineRunFacts) bool {
+	stateMap := facts.State.ToMap()
+	node := facts.TasksGraph.Nodes[t.PipelineTask.Name]
+	for _, p := range node.Prev {
+		if stateMap[p.Task.HashKey()].isCancelledOnRequest() {
+			return true
+		}
+	}
+	return false
+}
+
+// skipBecauseParentTaskFailed returns true if one of the parent tasks failed and the task doesn't depend on the
+// execution status of that parent, such a failure doesn't stop the pipeline run when other tasks depend on it
+func (t *ResolvedPipelineRunTask) skipBecauseParentTaskFailed(facts *PipelineRunFacts) bool {
+	stateMap := facts.State.ToMap()
+	node := facts.TasksGraph.Nodes[t.PipelineTask.Name]
+	for _, p := range node.Prev {
+		parentTask := stateMap[p.Task.HashKey()]
+		if parentTask.isFailureHandled(facts) && !t.dependsOnExecutionStatusOf(parentTask.PipelineTask.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// dependsOnExecutionStatusOf returns true if the when expressions of the task reference the execution
+// status of the given pipeline task using $(tasks.<pipelineTask>.status)
+func (t ResolvedPipelineRunTask) dependsOnExecutionStatusOf(pipelineTaskName string) bool {
+	for _, dep := range t.PipelineTask.ExecutionStatusDeps() {
+		if dep == pipelineTaskName {
+			return true
+		}
+	}
+	return false
+}
+
+// isFailureHandled returns true if the DAG task failed and other DAG tasks depend on its execution status,
+// such a failure doesn't stop the pipeline run so that the tasks depending on it can run
+func (t ResolvedPipelineRunTask) isFailureHandled(facts *PipelineRunFacts) bool {
+	if !t.IsFailure() || t.IsCancelled() || t.isFailureIgnored() || !facts.isDAGTask(t.PipelineTask.Name) {
+		return false
+	}
+	for _, rprt := range facts.State {
+		if facts.isDAGTask(rprt.PipelineTask.Name) && rprt.dependsOnExecutionStatusOf(t.PipelineTask.Name) {
 			return true
 		}
 	}
@@ -296,7 +760,8 @@ func (t *ResolvedPipelineRunTask) skipBecauseResultReferencesAreMissing(facts *P
 	if t.checkParentsDone(facts) && t.hasResultReferences() {
 		resolvedResultRefs, pt, err := ResolveResultRefs(facts.State, PipelineRunState{t})
 		rprt := facts.State.ToMap()[pt]
-		if err != nil && (t.IsFinalTask(facts) || rprt.Skip(facts).SkippingReason == WhenExpressionsSkip) {
+		if err != nil && (t.IsFinalTask(facts) || rprt.Skip(facts).SkippingReason == WhenExpressionsSkip ||
+			rprt.Skip(facts).SkippingReason == RequestedSkip || rprt.isFailureIgnored()) {
 			return true
 		}
 		ApplyTaskResults(PipelineRunState{t}, resolvedResultRefs)
@@ -319,10 +784,19 @@ func (t *ResolvedPipelineRunTask) IsFinallySkipped(facts *PipelineRunFacts) Task
 		skippingReason = None
 	case facts.checkDAGTasksDone() && facts.isFinalTask(t.PipelineTask.Name):
 		switch {
+		case t.SpecStatus == v1beta1.PipelineTaskSpecStatusCancelled:
+			skippingReason = CancelledSkip
+		case t.SpecStatus == v1beta1.PipelineTaskSpecStatusSkipped:
+			skippingReason = RequestedSkip
+		case !t.checkFinalParentsDone(facts):
+			// the final task is waiting for the final tasks it depends on
+			skippingReason = None
 		case t.skipBecauseResultReferencesAreMissing(facts):
 			skippingReason = MissingResultsSkip
 		case t.skipBecauseWhenExpressionsEvaluatedToFalse(facts):
 			skippingReason = WhenExpressionsSkip
+		case t.skipBecauseEmptyArrayInMatrixParams():
+			skippingReason = EmptyArrayInMatrixParamsSkip
 		default:
 			skippingReason = None
 		}
@@ -340,6 +814,9 @@ func (t *ResolvedPipelineRunTask) IsFinallySkipped(facts *PipelineRunFacts) Task
 // GetRun is a function that will retrieve a Run by name.
 type GetRun func(name string) (*v1alpha1.Run, error)
 
+// GetPipelineRun is a function that will retrieve a PipelineRun by name.
+type GetPipelineRun func(name string) (*v1beta1.PipelineRun, error)
+
 // GetResourcesFromBindings will retrieve all Resources bound in PipelineRun pr and return a map
 // from the declared name of the PipelineResource (which is how the PipelineResource will
 // be referred to in the PipelineRun) to the PipelineResource, obtained via getResource.
@@ -441,6 +918,40 @@ func ValidateServiceaccountMapping(p *v1beta1.PipelineSpec, pr *v1beta1.Pipeline
 	return nil
 }
 
+// ChildPipelineAncestry returns the names of the Pipelines run by the ancestors of the child PipelineRuns
+// of a PipelineRun, from the outermost one to the Pipeline run by the PipelineRun itself.
+func ChildPipelineAncestry(pr *v1beta1.PipelineRun) []string {
+	var ancestry []string
+	if a := pr.Annotations[pipeline.PipelineAncestryAnnotationKey]; a != "" {
+		ancestry = strings.Split(a, ",")
+	}
+	return append(ancestry, pr.Labels[pipeline.PipelineLabelKey])
+}
+
+// ValidateChildPipelines validates that the PipelineTasks running a Pipeline don't run one of the
+// Pipelines the PipelineRun is nested in, and that they don't nest PipelineRuns too deeply.
+func ValidateChildPipelines(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) error {
+	ancestry := ChildPipelineAncestry(pr)
+	for _, pts := range [][]v1beta1.PipelineTask{p.Tasks, p.Finally} {
+		for _, pt := range pts {
+			if !pt.RunsPipeline() {
+				continue
+			}
+			if len(ancestry) > MaxPipelineNestingDepth {
+				return fmt.Errorf("pipeline task %q can't run a Pipeline nested more than %d PipelineRuns deep", pt.Name, MaxPipelineNestingDepth)
+			}
+			if pt.PipelineRef != nil {
+				for _, name := range ancestry {
+					if pt.PipelineRef.Name == name {
+						return fmt.Errorf("pipeline task %q can't run Pipeline %q which it is already nested in", pt.Name, name)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func isCustomTask(ctx context.Context, rprt ResolvedPipelineRunTask) bool {
 	invalidSpec := rprt.PipelineTask.TaskRef != nil && rprt.PipelineTask.TaskSpec != nil
 	isTaskRefCustomTask := rprt.PipelineTask.TaskRef != nil && rprt.PipelineTask.TaskRef.APIVersion != "" &&
@@ -454,13 +965,15 @@ func isCustomTask(ctx context.Context, rprt ResolvedPipelineRunTask) bool {
 // ResolvePipelineRunTask retrieves a single Task's instance using the getTask to fetch
 // the spec. If it is unable to retrieve an instance of a referenced Task, it  will return
 // an error, otherwise it returns a list of all of the Tasks retrieved.  It will retrieve
-// the Resources needed for the TaskRun using the mapping of providedResources.
+// the Resources needed for the TaskRun using the mapping of providedResources. If the PipelineTask
+// runs a Pipeline, only its child PipelineRun is retrieved, using getPipelineRun.
 func ResolvePipelineRunTask(
 	ctx context.Context,
 	pipelineRun v1beta1.PipelineRun,
 	getTask resources.GetTask,
 	getTaskRun resources.GetTaskRun,
 	getRun GetRun,
+	getPipelineRun GetPipelineRun,
 	getCondition GetCondition,
 	task v1beta1.PipelineTask,
 	providedResources map[string]*resourcev1alpha1.PipelineResource,
@@ -468,18 +981,43 @@ func ResolvePipelineRunTask(
 
 	rprt := ResolvedPipelineRunTask{
 		PipelineTask: &task,
+		SpecStatus:   pipelineRun.GetTaskSpecStatus(task.Name),
+	}
+	if task.IsApproval() {
+		// There is nothing to run, the approval is only tracked in the status of the PipelineRun
+		rprt.Approval = getApprovalStatus(pipelineRun.Status.Approvals, task.Name)
+		return &rprt, nil
+	}
+	if task.RunsPipeline() {
+		// The Pipeline itself is resolved by the reconciler of the child PipelineRun
+		rprt.ChildPipelineRunName = getChildPipelineRunName(pipelineRun.Status.ChildPipelineRuns, task.Name, pipelineRun.Name)
+		childPipelineRun, err := getPipelineRun(rprt.ChildPipelineRunName)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("error retrieving child PipelineRun %s: %w", rprt.ChildPipelineRunName, err)
+		}
+		rprt.ChildPipelineRun = childPipelineRun
+		return &rprt, nil
 	}
 	rprt.CustomTask = isCustomTask(ctx, rprt)
 	if rprt.IsCustomTask() {
-		rprt.RunName = getRunName(pipelineRun.Status.Runs, task.Name, pipelineRun.Name)
-		run, err := getRun(rprt.RunName)
-		if err != nil && !errors.IsNotFound(err) {
-			return nil, fmt.Errorf("error retrieving Run %s: %w", rprt.RunName, err)
+		if task.IsMatrixed() {
+			rprt.RunNames = getNamesOfRuns(task.Name, pipelineRun.Name, matrix.Count(task.Matrix))
+			for _, runName := range rprt.RunNames {
+				run, err := getRun(runName)
+				if err != nil && !errors.IsNotFound(err) {
+					return nil, fmt.Errorf("error retrieving Run %s: %w", runName, err)
+				}
+				rprt.Runs = append(rprt.Runs, run)
+			}
+		} else {
+			rprt.RunName = getRunName(pipelineRun.Status.Runs, task.Name, pipelineRun.Name)
+			run, err := getRun(rprt.RunName)
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("error retrieving Run %s: %w", rprt.RunName, err)
+			}
+			rprt.Run = run
 		}
-		rprt.Run = run
 	} else {
-		rprt.TaskRunName = GetTaskRunName(pipelineRun.Status.TaskRuns, task.Name, pipelineRun.Name)
-
 		// Find the Task that this PipelineTask is using
 		var (
 			t        v1beta1.TaskObject
@@ -487,16 +1025,39 @@ func ResolvePipelineRunTask(
 			spec     v1beta1.TaskSpec
 			taskName string
 			kind     v1beta1.TaskKind
+			taskRun  *v1beta1.TaskRun
 		)
 
-		taskRun, err := getTaskRun(rprt.TaskRunName)
-		if err != nil {
-			if !errors.IsNotFound(err) {
-				return nil, fmt.Errorf("error retrieving TaskRun %s: %w", rprt.TaskRunName, err)
+		if task.IsMatrixed() {
+			rprt.TaskRunNames = GetNamesOfTaskRuns(task.Name, pipelineRun.Name, matrix.Count(task.Matrix))
+			for _, taskRunName := range rprt.TaskRunNames {
+				tr, err := getTaskRun(taskRunName)
+				if err != nil && !errors.IsNotFound(err) {
+					return nil, fmt.Errorf("error retrieving TaskRun %s: %w", taskRunName, err)
+				}
+				rprt.TaskRuns = append(rprt.TaskRuns, tr)
+				// Any of the TaskRuns that already stores a TaskSpec can be used as source of truth
+				if tr != nil && (taskRun == nil || taskRun.Status.TaskSpec == nil) {
+					taskRun = tr
+				}
+			}
+		} else if task.Iterate != nil {
+			rprt.Iteration, rprt.TaskRunName, rprt.TaskRun, err = resolveLastIteration(task, pipelineRun.Name, getTaskRun)
+			if err != nil {
+				return nil, err
+			}
+			taskRun = rprt.TaskRun
+		} else {
+			rprt.TaskRunName = GetTaskRunName(pipelineRun.Status.TaskRuns, task.Name, pipelineRun.Name)
+			taskRun, err = getTaskRun(rprt.TaskRunName)
+			if err != nil {
+				if !errors.IsNotFound(err) {
+					return nil, fmt.Errorf("error retrieving TaskRun %s: %w", rprt.TaskRunName, err)
+				}
+			}
+			if taskRun != nil {
+				rprt.TaskRun = taskRun
 			}
-		}
-		if taskRun != nil {
-			rprt.TaskRun = taskRun
 		}
 
 		if task.TaskRef != nil {
@@ -539,6 +1100,16 @@ func ResolvePipelineRunTask(
 	return &rprt, nil
 }
 
+// getApprovalStatus returns a copy of the status of the approval of a PipelineTask, if it started waiting for one.
+func getApprovalStatus(approvals []v1beta1.PipelineTaskApprovalStatus, ptName string) *v1beta1.PipelineTaskApprovalStatus {
+	for _, a := range approvals {
+		if a.Name == ptName {
+			return a.DeepCopy()
+		}
+	}
+	return nil
+}
+
 // getConditionCheckName should return a unique name for a `ConditionCheck` if one has not already been defined, and the existing one otherwise.
 func getConditionCheckName(taskRunStatus map[string]*v1beta1.PipelineRunTaskRunStatus, trName, conditionRegisterName string) string {
 	trStatus, ok := taskRunStatus[trName]
@@ -564,6 +1135,60 @@ func GetTaskRunName(taskRunsStatus map[string]*v1beta1.PipelineRunTaskRunStatus,
 	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
 }
 
+// GetNamesOfTaskRuns returns the names of the `TaskRuns` of a matrixed PipelineTask, one for
+// each of the combinations of its Matrix. The names are derived from the PipelineRun name, the
+// PipelineTask name and the index of the combination so that they are stable across reconciles.
+func GetNamesOfTaskRuns(ptName, prName string, combinationCount int) []string {
+	var taskRunNames []string
+	for i := 0; i < combinationCount; i++ {
+		taskRunNames = append(taskRunNames, kmeta.ChildName(prName, fmt.Sprintf("-%s-%d", ptName, i)))
+	}
+	return taskRunNames
+}
+
+// GetIterationTaskRunName returns the name of the `TaskRun` of an iteration of a PipelineTask with
+// Iterate. The name is derived from the PipelineRun name, the PipelineTask name and the iteration
+// so that it is stable across reconciles.
+func GetIterationTaskRunName(ptName, prName string, iteration int) string {
+	return kmeta.ChildName(prName, fmt.Sprintf("-%s-iteration-%d", ptName, iteration))
+}
+
+// resolveLastIteration returns the last iteration of a PipelineTask with Iterate, along with the
+// name of its TaskRun and the TaskRun, if it was created. The TaskRun of an iteration is only
+// created once the one of the previous iteration is done.
+func resolveLastIteration(pt v1beta1.PipelineTask, prName string, getTaskRun resources.GetTaskRun) (int, string, *v1beta1.TaskRun, error) {
+	var (
+		iteration int
+		name      string
+		taskRun   *v1beta1.TaskRun
+	)
+	for i := 1; i <= pt.Iterate.MaxIterations; i++ {
+		n := GetIterationTaskRunName(pt.Name, prName, i)
+		tr, err := getTaskRun(n)
+		if err != nil && !errors.IsNotFound(err) {
+			return 0, "", nil, fmt.Errorf("error retrieving TaskRun %s: %w", n, err)
+		}
+		if tr == nil && i > 1 {
+			break
+		}
+		iteration, name, taskRun = i, n, tr
+		if tr == nil {
+			break
+		}
+	}
+	return iteration, name, taskRun, nil
+}
+
+// getNamesOfRuns returns the names of the `Runs` of a matrixed PipelineTask, one for each of
+// the combinations of its Matrix.
+func getNamesOfRuns(ptName, prName string, combinationCount int) []string {
+	var runNames []string
+	for i := 0; i < combinationCount; i++ {
+		runNames = append(runNames, kmeta.ChildName(prName, fmt.Sprintf("-%s-%d", ptName, i)))
+	}
+	return runNames
+}
+
 // getRunName should return a unique name for a `Run` if one has not already
 // been defined, and the existing one otherwise.
 func getRunName(runsStatus map[string]*v1beta1.PipelineRunRunStatus, ptName, prName string) string {
@@ -576,6 +1201,18 @@ func getRunName(runsStatus map[string]*v1beta1.PipelineRunRunStatus, ptName, prN
 	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
 }
 
+// getChildPipelineRunName should return a unique name for a child `PipelineRun` if one has not
+// already been defined, and the existing one otherwise.
+func getChildPipelineRunName(childPipelineRunsStatus map[string]*v1beta1.PipelineRunChildPipelineRunStatus, ptName, prName string) string {
+	for k, v := range childPipelineRunsStatus {
+		if v.PipelineTaskName == ptName {
+			return k
+		}
+	}
+
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
+}
+
 func resolveConditionChecks(pt *v1beta1.PipelineTask, taskRunStatus map[string]*v1beta1.PipelineRunTaskRunStatus, taskRunName string, getTaskRun resources.GetTaskRun, getCondition GetCondition, providedResources map[string]*resourcev1alpha1.PipelineResource) ([]*ResolvedConditionCheck, error) {
 	rccs := []*ResolvedConditionCheck{}
 	for i := range pt.Conditions {
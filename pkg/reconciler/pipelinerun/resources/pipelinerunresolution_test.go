@@ -46,6 +46,9 @@ import (
 func nopGetRun(string) (*v1alpha1.Run, error) {
 	return nil, errors.New("GetRun should not be called")
 }
+func nopGetPipelineRun(string) (*v1beta1.PipelineRun, error) {
+	return nil, errors.New("GetPipelineRun should not be called")
+}
 func nopGetTask(context.Context, string) (v1beta1.TaskObject, error) {
 	return nil, errors.New("GetTask should not be called")
 }
@@ -1286,6 +1289,52 @@ func TestIsSkipped(t *testing.T) {
 			"mytask18": true,
 			"mytask19": false,
 		},
+	}, {
+		name: "tasks-parent-failed-and-continues-on-error",
+		state: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "lint",
+				TaskRef: &v1beta1.TaskRef{Name: "task"},
+				OnError: v1beta1.PipelineTaskContinue,
+			},
+			TaskRunName: "pipelinerun-lint",
+			TaskRun:     makeFailed(trs[0]),
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}, {
+			// not skipped because its parent task continues on error
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:     "build",
+				TaskRef:  &v1beta1.TaskRef{Name: "task"},
+				RunAfter: []string{"lint"},
+			},
+			TaskRunName: "pipelinerun-build",
+			TaskRun:     nil,
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}, {
+			// skipped because of missing result from parent task which failed
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "report",
+				TaskRef: &v1beta1.TaskRef{Name: "task"},
+				Params: []v1beta1.Param{{
+					Name:  "findings",
+					Value: *v1beta1.NewArrayOrString("$(tasks.lint.results.findings)"),
+				}},
+			},
+			TaskRunName: "pipelinerun-report",
+			TaskRun:     nil,
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}},
+		expected: map[string]bool{
+			"lint":   false,
+			"build":  false,
+			"report": true,
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			d, err := dagFromState(tc.state)
@@ -1800,7 +1849,7 @@ func TestResolvePipelineRun(t *testing.T) {
 
 	pipelineState := PipelineRunState{}
 	for _, task := range p.Spec.Tasks {
-		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, task, providedResources)
+		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, task, providedResources)
 		if err != nil {
 			t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 		}
@@ -1898,7 +1947,7 @@ func TestResolvePipelineRun_CustomTask(t *testing.T) {
 	})
 	ctx = cfg.ToContext(ctx)
 	for _, task := range pts {
-		ps, err := ResolvePipelineRunTask(ctx, pr, nopGetTask, nopGetTaskRun, getRun, nopGetCondition, task, nil)
+		ps, err := ResolvePipelineRunTask(ctx, pr, nopGetTask, nopGetTaskRun, getRun, nopGetPipelineRun, nopGetCondition, task, nil)
 		if err != nil {
 			t.Fatalf("ResolvePipelineRunTask: %v", err)
 		}
@@ -1926,6 +1975,409 @@ func TestResolvePipelineRun_CustomTask(t *testing.T) {
 	}
 }
 
+func TestResolvePipelineRun_Matrix(t *testing.T) {
+	pts := []v1beta1.PipelineTask{{
+		Name:    "matrixed",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Matrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}, {
+			Name: "browser", Value: *v1beta1.NewArrayOrString("chrome", "firefox"),
+		}},
+	}, {
+		Name:    "matrixed-customtask",
+		TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+		Matrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}},
+	}}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+	}
+	taskRun := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-matrixed-1"}}
+	getTask := func(ctx context.Context, name string) (v1beta1.TaskObject, error) { return task, nil }
+	getTaskRun := func(name string) (*v1beta1.TaskRun, error) {
+		if name == "pipelinerun-matrixed-1" {
+			return taskRun, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("taskrun"), name)
+	}
+	run := &v1alpha1.Run{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-matrixed-customtask-0"}}
+	getRun := func(name string) (*v1alpha1.Run, error) {
+		if name == "pipelinerun-matrixed-customtask-0" {
+			return run, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("run"), name)
+	}
+	nopGetCondition := func(string) (*v1alpha1.Condition, error) { return nil, errors.New("GetCondition should not be called") }
+	ctx := context.Background()
+	cfg := config.NewStore(logtesting.TestLogger(t))
+	cfg.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName()},
+		Data: map[string]string{
+			"enable-custom-tasks": "true",
+			"enable-api-fields":   "alpha",
+		},
+	})
+	ctx = cfg.ToContext(ctx)
+	pipelineState := PipelineRunState{}
+	for _, task := range pts {
+		ps, err := ResolvePipelineRunTask(ctx, pr, getTask, getTaskRun, getRun, nopGetPipelineRun, nopGetCondition, task, nil)
+		if err != nil {
+			t.Fatalf("ResolvePipelineRunTask: %v", err)
+		}
+		pipelineState = append(pipelineState, ps)
+	}
+
+	expectedState := PipelineRunState{{
+		PipelineTask: &pts[0],
+		TaskRunNames: []string{"pipelinerun-matrixed-0", "pipelinerun-matrixed-1", "pipelinerun-matrixed-2", "pipelinerun-matrixed-3"},
+		TaskRuns:     []*v1beta1.TaskRun{nil, taskRun, nil, nil},
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskName: task.Name,
+			TaskSpec: &task.Spec,
+			Inputs:   map[string]*resourcev1alpha1.PipelineResource{},
+			Outputs:  map[string]*resourcev1alpha1.PipelineResource{},
+		},
+	}, {
+		PipelineTask: &pts[1],
+		CustomTask:   true,
+		RunNames:     []string{"pipelinerun-matrixed-customtask-0", "pipelinerun-matrixed-customtask-1"},
+		Runs:         []*v1alpha1.Run{run, nil},
+	}}
+	if d := cmp.Diff(expectedState, pipelineState, cmpopts.IgnoreUnexported(v1beta1.TaskRunSpec{})); d != "" {
+		t.Errorf("Unexpected pipeline state: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvePipelineRun_Iterate(t *testing.T) {
+	pt := v1beta1.PipelineTask{
+		Name:    "poll",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Iterate: &v1beta1.Iterate{
+			Until: v1beta1.WhenExpressions{{
+				Input:    "$(tasks.poll.results.ready)",
+				Operator: selection.In,
+				Values:   []string{"true"},
+			}},
+			MaxIterations: 3,
+		},
+	}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+	}
+	getTask := func(ctx context.Context, name string) (v1beta1.TaskObject, error) { return task, nil }
+	nopGetCondition := func(string) (*v1alpha1.Condition, error) { return nil, errors.New("GetCondition should not be called") }
+	first := makeSucceeded(v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-poll-iteration-1"}})
+	second := makeStarted(v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-poll-iteration-2"}})
+
+	for _, tc := range []struct {
+		name              string
+		taskRuns          []*v1beta1.TaskRun
+		expectedIteration int
+		expectedName      string
+		expectedTaskRun   *v1beta1.TaskRun
+	}{{
+		name:              "first iteration not created yet",
+		expectedIteration: 1,
+		expectedName:      "pipelinerun-poll-iteration-1",
+	}, {
+		name:              "second iteration running",
+		taskRuns:          []*v1beta1.TaskRun{first, second},
+		expectedIteration: 2,
+		expectedName:      "pipelinerun-poll-iteration-2",
+		expectedTaskRun:   second,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			getTaskRun := func(name string) (*v1beta1.TaskRun, error) {
+				for _, tr := range tc.taskRuns {
+					if tr.Name == name {
+						return tr, nil
+					}
+				}
+				return nil, kerrors.NewNotFound(v1beta1.Resource("taskrun"), name)
+			}
+			rprt, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, nopGetCondition, pt, nil)
+			if err != nil {
+				t.Fatalf("ResolvePipelineRunTask: %v", err)
+			}
+			if rprt.Iteration != tc.expectedIteration {
+				t.Errorf("Expected iteration %d but got %d", tc.expectedIteration, rprt.Iteration)
+			}
+			if rprt.TaskRunName != tc.expectedName {
+				t.Errorf("Expected TaskRun name %s but got %s", tc.expectedName, rprt.TaskRunName)
+			}
+			if d := cmp.Diff(tc.expectedTaskRun, rprt.TaskRun); d != "" {
+				t.Errorf("Unexpected TaskRun: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolvedPipelineRunTask_Matrixed(t *testing.T) {
+	pt := v1beta1.PipelineTask{
+		Name:    "matrixed",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Matrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}},
+	}
+	ptWithRetries := *pt.DeepCopy()
+	ptWithRetries.Retries = 1
+	customPt := v1beta1.PipelineTask{
+		Name:    "matrixed-customtask",
+		TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+		Matrix:  pt.Matrix,
+	}
+
+	for _, tc := range []struct {
+		name          string
+		rprt          ResolvedPipelineRunTask
+		wantStarted   bool
+		wantRunning   bool
+		wantSucceeded bool
+		wantFailed    bool
+		wantCancelled bool
+	}{{
+		name: "taskruns not created",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRuns:     []*v1beta1.TaskRun{nil, nil},
+		},
+	}, {
+		name: "one taskrun running, one not created",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRuns:     []*v1beta1.TaskRun{makeStarted(trs[0]), nil},
+		},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name: "one taskrun succeeded, one running",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRuns:     []*v1beta1.TaskRun{makeSucceeded(trs[0]), makeStarted(trs[1])},
+		},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name: "all taskruns succeeded",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRuns:     []*v1beta1.TaskRun{makeSucceeded(trs[0]), makeSucceeded(trs[1])},
+		},
+		wantStarted:   true,
+		wantSucceeded: true,
+	}, {
+		name: "one taskrun failed, one running",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRuns:     []*v1beta1.TaskRun{makeFailed(trs[0]), makeStarted(trs[1])},
+		},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name: "one taskrun failed, one succeeded",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRuns:     []*v1beta1.TaskRun{makeFailed(trs[0]), makeSucceeded(trs[1])},
+		},
+		wantStarted: true,
+		wantFailed:  true,
+	}, {
+		name: "one taskrun failed with retries left, one succeeded",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &ptWithRetries,
+			TaskRuns:     []*v1beta1.TaskRun{makeFailed(trs[0]), makeSucceeded(trs[1])},
+		},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name: "one taskrun cancelled, one succeeded",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRuns:     []*v1beta1.TaskRun{withCancelled(makeFailed(trs[0])), makeSucceeded(trs[1])},
+		},
+		wantStarted:   true,
+		wantFailed:    true,
+		wantCancelled: true,
+	}, {
+		name: "runs not created",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &customPt,
+			CustomTask:   true,
+			Runs:         []*v1alpha1.Run{nil, nil},
+		},
+	}, {
+		name: "one run succeeded, one running",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &customPt,
+			CustomTask:   true,
+			Runs:         []*v1alpha1.Run{makeRunSucceeded(runs[0]), makeRunStarted(runs[1])},
+		},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name: "all runs succeeded",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &customPt,
+			CustomTask:   true,
+			Runs:         []*v1alpha1.Run{makeRunSucceeded(runs[0]), makeRunSucceeded(runs[1])},
+		},
+		wantStarted:   true,
+		wantSucceeded: true,
+	}, {
+		name: "one run failed, one succeeded",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &customPt,
+			CustomTask:   true,
+			Runs:         []*v1alpha1.Run{makeRunFailed(runs[0]), makeRunSucceeded(runs[1])},
+		},
+		wantStarted: true,
+		wantFailed:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rprt.IsStarted(); got != tc.wantStarted {
+				t.Errorf("expected IsStarted: %t but got %t", tc.wantStarted, got)
+			}
+			if got := tc.rprt.IsRunning(); got != tc.wantRunning {
+				t.Errorf("expected IsRunning: %t but got %t", tc.wantRunning, got)
+			}
+			if got := tc.rprt.IsSuccessful(); got != tc.wantSucceeded {
+				t.Errorf("expected IsSuccessful: %t but got %t", tc.wantSucceeded, got)
+			}
+			if got := tc.rprt.IsFailure(); got != tc.wantFailed {
+				t.Errorf("expected IsFailure: %t but got %t", tc.wantFailed, got)
+			}
+			if got := tc.rprt.IsCancelled(); got != tc.wantCancelled {
+				t.Errorf("expected IsCancelled: %t but got %t", tc.wantCancelled, got)
+			}
+		})
+	}
+}
+
+func TestResolvePipelineRun_ChildPipelineRun(t *testing.T) {
+	names.TestingSeed()
+	pts := []v1beta1.PipelineTask{{
+		Name:        "child-ref",
+		PipelineRef: &v1beta1.PipelineRef{Name: "child"},
+	}, {
+		Name: "child-spec",
+		PipelineSpec: &v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{Name: "unit", TaskRef: &v1beta1.TaskRef{Name: "task"}}},
+		},
+	}}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				ChildPipelineRuns: map[string]*v1beta1.PipelineRunChildPipelineRunStatus{
+					"pipelinerun-child-ref-abcde": {PipelineTaskName: "child-ref"},
+				},
+			},
+		},
+	}
+	child := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-child-ref-abcde"}}
+	getPipelineRun := func(name string) (*v1beta1.PipelineRun, error) {
+		if name == child.Name {
+			return child, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("pipelinerun"), name)
+	}
+	nopGetCondition := func(string) (*v1alpha1.Condition, error) { return nil, errors.New("GetCondition should not be called") }
+	pipelineState := PipelineRunState{}
+	for _, task := range pts {
+		ps, err := ResolvePipelineRunTask(context.Background(), pr, nopGetTask, nopGetTaskRun, nopGetRun, getPipelineRun, nopGetCondition, task, nil)
+		if err != nil {
+			t.Fatalf("ResolvePipelineRunTask: %v", err)
+		}
+		pipelineState = append(pipelineState, ps)
+	}
+
+	expectedState := PipelineRunState{{
+		PipelineTask:         &pts[0],
+		ChildPipelineRunName: "pipelinerun-child-ref-abcde",
+		ChildPipelineRun:     child,
+	}, {
+		PipelineTask:         &pts[1],
+		ChildPipelineRunName: "pipelinerun-child-spec-9l9zj",
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Unexpected pipeline state: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvedPipelineRunTask_ChildPipelineRunStatus(t *testing.T) {
+	pt := v1beta1.PipelineTask{
+		Name:        "child",
+		PipelineRef: &v1beta1.PipelineRef{Name: "child"},
+	}
+	withCondition := func(status corev1.ConditionStatus, reason string) *v1beta1.PipelineRun {
+		pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-child"}}
+		pr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: status,
+			Reason: reason,
+		})
+		return pr
+	}
+	for _, tc := range []struct {
+		name          string
+		child         *v1beta1.PipelineRun
+		wantStarted   bool
+		wantRunning   bool
+		wantSucceeded bool
+		wantFailed    bool
+		wantCancelled bool
+	}{{
+		name: "child pipelinerun not created",
+	}, {
+		name:        "child pipelinerun running",
+		child:       withCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String()),
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name:          "child pipelinerun succeeded",
+		child:         withCondition(corev1.ConditionTrue, v1beta1.PipelineRunReasonSuccessful.String()),
+		wantStarted:   true,
+		wantSucceeded: true,
+	}, {
+		name:        "child pipelinerun failed",
+		child:       withCondition(corev1.ConditionFalse, v1beta1.PipelineRunReasonFailed.String()),
+		wantStarted: true,
+		wantFailed:  true,
+	}, {
+		name:          "child pipelinerun cancelled",
+		child:         withCondition(corev1.ConditionFalse, v1beta1.PipelineRunReasonCancelled.String()),
+		wantStarted:   true,
+		wantFailed:    true,
+		wantCancelled: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rprt := ResolvedPipelineRunTask{
+				PipelineTask:         &pt,
+				ChildPipelineRunName: "pipelinerun-child",
+				ChildPipelineRun:     tc.child,
+			}
+			if got := rprt.IsStarted(); got != tc.wantStarted {
+				t.Errorf("expected IsStarted: %t but got %t", tc.wantStarted, got)
+			}
+			if got := rprt.IsRunning(); got != tc.wantRunning {
+				t.Errorf("expected IsRunning: %t but got %t", tc.wantRunning, got)
+			}
+			if got := rprt.IsSuccessful(); got != tc.wantSucceeded {
+				t.Errorf("expected IsSuccessful: %t but got %t", tc.wantSucceeded, got)
+			}
+			if got := rprt.IsFailure(); got != tc.wantFailed {
+				t.Errorf("expected IsFailure: %t but got %t", tc.wantFailed, got)
+			}
+			if got := rprt.IsCancelled(); got != tc.wantCancelled {
+				t.Errorf("expected IsCancelled: %t but got %t", tc.wantCancelled, got)
+			}
+		})
+	}
+}
+
 func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	pts := []v1beta1.PipelineTask{{
 		Name:    "mytask1",
@@ -1949,7 +2401,7 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	}
 	pipelineState := PipelineRunState{}
 	for _, task := range pts {
-		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, task, providedResources)
+		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, task, providedResources)
 		if err != nil {
 			t.Errorf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 		}
@@ -1994,7 +2446,7 @@ func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 			Name: "pipelinerun",
 		},
 	}
-	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 	switch err := err.(type) {
 	case nil:
 		t.Fatalf("Expected error getting non-existent Tasks for Pipeline %s but got none", p.Name)
@@ -2064,7 +2516,7 @@ func TestResolvePipelineRun_ResourceBindingsDontExist(t *testing.T) {
 				},
 			}
 			pipelineState := PipelineRunState{}
-			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, tt.p.Spec.Tasks[0], providedResources)
+			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, tt.p.Spec.Tasks[0], providedResources)
 			if err == nil {
 				t.Fatalf("Expected error when bindings are in incorrect state for Pipeline %s but got none: %s", p.ObjectMeta.Name, err)
 			}
@@ -2126,7 +2578,7 @@ func TestResolvePipelineRun_withExistingTaskRuns(t *testing.T) {
 	getTask := func(_ context.Context, name string) (v1beta1.TaskObject, error) { return task, nil }
 	getTaskRun := func(name string) (*v1beta1.TaskRun, error) { return nil, nil }
 	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
-	resolvedTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, p.Spec.Tasks[0], providedResources)
+	resolvedTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, p.Spec.Tasks[0], providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -2194,7 +2646,7 @@ func TestResolvedPipelineRun_PipelineTaskHasOptionalResources(t *testing.T) {
 	getTaskRun := func(name string) (*v1beta1.TaskRun, error) { return nil, nil }
 	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
 
-	actualTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, p.Spec.Tasks[0], providedResources)
+	actualTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, p.Spec.Tasks[0], providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -2291,7 +2743,7 @@ func TestResolveConditionChecks(t *testing.T) {
 		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, tc.getTaskRun, nopGetRun, getCondition, pt, providedResources)
+			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, tc.getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 			}
@@ -2381,7 +2833,7 @@ func TestResolveConditionChecks_MultipleConditions(t *testing.T) {
 		ResolvedResources:     providedResources,
 	}}
 
-	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 	}
@@ -2423,7 +2875,7 @@ func TestResolveConditionChecks_ConditionDoesNotExist(t *testing.T) {
 		},
 	}
 
-	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 
 	switch err := err.(type) {
 	case nil:
@@ -2491,7 +2943,7 @@ func TestResolveConditionCheck_UseExistingConditionCheckName(t *testing.T) {
 		},
 	}
 
-	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 	}
@@ -2578,7 +3030,7 @@ func TestResolvedConditionCheck_WithResources(t *testing.T) {
 		wantErr:           true,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, tc.providedResources)
+			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, tc.providedResources)
 
 			if tc.wantErr {
 				if err == nil {
@@ -3075,7 +3527,7 @@ func TestResolvePipeline_WhenExpressions(t *testing.T) {
 	}
 
 	t.Run("When Expressions exist", func(t *testing.T) {
-		_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+		_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 		if err != nil {
 			t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 		}
@@ -3197,7 +3649,7 @@ func TestIsCustomTask(t *testing.T) {
 				},
 			})
 			ctx = cfg.ToContext(ctx)
-			rprt, err := ResolvePipelineRunTask(ctx, pr, getTask, getTaskRun, getRun, getCondition, tc.pt, nil)
+			rprt, err := ResolvePipelineRunTask(ctx, pr, getTask, getTaskRun, getRun, nopGetPipelineRun, getCondition, tc.pt, nil)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 			}
@@ -3410,3 +3862,231 @@ func TestResolvedPipelineRunTask_IsFinalTask(t *testing.T) {
 	}
 
 }
+
+func TestResolvePipelineRun_Approval(t *testing.T) {
+	approval := &v1beta1.Approval{Approvers: []v1beta1.Approver{{Kind: v1beta1.ApproverKindUser, Name: "alice"}}}
+	pts := []v1beta1.PipelineTask{
+		{Name: "approve-staging", Approval: approval},
+		{Name: "approve-prod", Approval: approval},
+	}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				Approvals: []v1beta1.PipelineTaskApprovalStatus{{
+					Name:     "approve-staging",
+					State:    v1beta1.ApprovalStateApproved,
+					Approver: "alice",
+				}},
+			},
+		},
+	}
+	nopGetPipelineRun := func(string) (*v1beta1.PipelineRun, error) {
+		return nil, errors.New("GetPipelineRun should not be called")
+	}
+	nopGetCondition := func(string) (*v1alpha1.Condition, error) { return nil, errors.New("GetCondition should not be called") }
+	pipelineState := PipelineRunState{}
+	for _, task := range pts {
+		ps, err := ResolvePipelineRunTask(context.Background(), pr, nopGetTask, nopGetTaskRun, nopGetRun, nopGetPipelineRun, nopGetCondition, task, nil)
+		if err != nil {
+			t.Fatalf("ResolvePipelineRunTask: %v", err)
+		}
+		pipelineState = append(pipelineState, ps)
+	}
+
+	expectedState := PipelineRunState{{
+		PipelineTask: &pts[0],
+		Approval: &v1beta1.PipelineTaskApprovalStatus{
+			Name:     "approve-staging",
+			State:    v1beta1.ApprovalStateApproved,
+			Approver: "alice",
+		},
+	}, {
+		PipelineTask: &pts[1],
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Unexpected pipeline state: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvedPipelineRunTask_ApprovalStatus(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		onTimeout     string
+		approval      *v1beta1.PipelineTaskApprovalStatus
+		wantStarted   bool
+		wantRunning   bool
+		wantSucceeded bool
+		wantFailed    bool
+	}{{
+		name: "not waiting for an approval",
+	}, {
+		name:        "pending",
+		approval:    &v1beta1.PipelineTaskApprovalStatus{State: v1beta1.ApprovalStatePending},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name:          "approved",
+		approval:      &v1beta1.PipelineTaskApprovalStatus{State: v1beta1.ApprovalStateApproved},
+		wantStarted:   true,
+		wantSucceeded: true,
+	}, {
+		name:        "rejected",
+		approval:    &v1beta1.PipelineTaskApprovalStatus{State: v1beta1.ApprovalStateRejected},
+		wantStarted: true,
+		wantFailed:  true,
+	}, {
+		name:        "timed out and failed",
+		approval:    &v1beta1.PipelineTaskApprovalStatus{State: v1beta1.ApprovalStateTimedOut},
+		wantStarted: true,
+		wantFailed:  true,
+	}, {
+		name:        "timed out and skipped",
+		onTimeout:   v1beta1.ApprovalOnTimeoutSkip,
+		approval:    &v1beta1.PipelineTaskApprovalStatus{State: v1beta1.ApprovalStateTimedOut},
+		wantStarted: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rprt := ResolvedPipelineRunTask{
+				PipelineTask: &v1beta1.PipelineTask{
+					Name: "approve",
+					Approval: &v1beta1.Approval{
+						Approvers: []v1beta1.Approver{{Kind: v1beta1.ApproverKindUser, Name: "alice"}},
+						OnTimeout: tc.onTimeout,
+					},
+				},
+				Approval: tc.approval,
+			}
+			if got := rprt.IsStarted(); got != tc.wantStarted {
+				t.Errorf("expected IsStarted: %t but got %t", tc.wantStarted, got)
+			}
+			if got := rprt.IsRunning(); got != tc.wantRunning {
+				t.Errorf("expected IsRunning: %t but got %t", tc.wantRunning, got)
+			}
+			if got := rprt.IsSuccessful(); got != tc.wantSucceeded {
+				t.Errorf("expected IsSuccessful: %t but got %t", tc.wantSucceeded, got)
+			}
+			if got := rprt.IsFailure(); got != tc.wantFailed {
+				t.Errorf("expected IsFailure: %t but got %t", tc.wantFailed, got)
+			}
+			if got := rprt.IsCancelled(); got {
+				t.Errorf("expected IsCancelled: false but got %t", got)
+			}
+		})
+	}
+}
+
+func TestResolvedPipelineRunTask_EvaluateCEL(t *testing.T) {
+	buildTask := v1beta1.PipelineTask{
+		Name:    "build",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+	}
+	for _, tc := range []struct {
+		name                 string
+		cel                  string
+		expectedEvaluatedCEL map[string]bool
+		expectedErr          bool
+		expectedSkipReason   SkippingReason
+	}{{
+		name: "expression evaluates to true",
+		cel:  "$(tasks.build.status) == 'Succeeded' && 'release-1.2'.matches('release-.*')",
+		expectedEvaluatedCEL: map[string]bool{
+			`"Succeeded" == 'Succeeded' && 'release-1.2'.matches('release-.*')`: true,
+		},
+		expectedSkipReason: None,
+	}, {
+		name: "expression evaluates to false",
+		cel:  "$(tasks.build.status) != 'Succeeded' || '1.2' >= '1.3'",
+		expectedEvaluatedCEL: map[string]bool{
+			`"Succeeded" != 'Succeeded' || '1.2' >= '1.3'`: false,
+		},
+		expectedSkipReason: WhenExpressionsSkip,
+	}, {
+		name:               "expression doesn't evaluate to a boolean",
+		cel:                "$(tasks.build.status) + '-suffix'",
+		expectedErr:        true,
+		expectedSkipReason: None,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			deployTask := v1beta1.PipelineTask{
+				Name:            "deploy",
+				TaskRef:         &v1beta1.TaskRef{Name: "task"},
+				WhenExpressions: v1beta1.WhenExpressions{{CEL: tc.cel}},
+			}
+			state := PipelineRunState{{
+				PipelineTask: &buildTask,
+				TaskRunName:  "pipelinerun-build",
+				TaskRun:      makeSucceeded(trs[0]),
+				ResolvedTaskResources: &resources.ResolvedTaskResources{
+					TaskSpec: &task.Spec,
+				},
+			}, {
+				PipelineTask: &deployTask,
+				TaskRunName:  "pipelinerun-deploy",
+				ResolvedTaskResources: &resources.ResolvedTaskResources{
+					TaskSpec: &task.Spec,
+				},
+			}}
+			d, err := dagFromState(state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+			}
+			evaluatedCEL, err := state[1].EvaluateCEL(&facts)
+			if tc.expectedErr {
+				var celErr *CELEvaluationError
+				if !errors.As(err, &celErr) {
+					t.Errorf("Expected a CELEvaluationError but got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error evaluating CEL: %v", err)
+			}
+			if d := cmp.Diff(tc.expectedEvaluatedCEL, evaluatedCEL); d != "" {
+				t.Errorf("Unexpected evaluated CEL: %s", diff.PrintWantGot(d))
+			}
+			if got := state[1].Skip(&facts).SkippingReason; got != tc.expectedSkipReason {
+				t.Errorf("Expected skipping reason %s but got %s", tc.expectedSkipReason, got)
+			}
+			if d := cmp.Diff(v1beta1.WhenExpressions{{CEL: tc.cel}}, deployTask.WhenExpressions); d != "" {
+				t.Errorf("Expected the when expressions of the pipeline task to be unchanged: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestEvaluateCEL_SubstitutedValues(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		value string
+		want  bool
+	}{{
+		name:  "value is compared",
+		value: "main",
+		want:  true,
+	}, {
+		name:  "value can't change the expression",
+		value: `dev" == "dev" || "main`,
+		want:  false,
+	}, {
+		name:  "variables in the value are not substituted",
+		value: "$(params.branch)",
+		want:  false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			wes := v1beta1.WhenExpressions{{CEL: "$(tasks.build.results.branch) == 'main'"}}
+			wes = wes.ReplaceWhenExpressionsVariables(map[string]string{"tasks.build.results.branch": tc.value}, nil)
+			wes = wes.ReplaceWhenExpressionsVariables(map[string]string{"params.branch": "main"}, nil)
+			got, err := evaluateCEL(wes[0].CEL)
+			if err != nil {
+				t.Fatalf("Unexpected error evaluating %q: %v", wes[0].CEL, err)
+			}
+			if got != tc.want {
+				t.Errorf("Expected %q to evaluate to %t but got %t", wes[0].CEL, tc.want, got)
+			}
+		})
+	}
+}
@@ -18,7 +18,9 @@ package resources
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"go.uber.org/zap"
@@ -71,8 +73,12 @@ type pipelineRunStatusCount struct {
 	Succeeded int
 	// failed tasks count
 	Failed int
+	// failed tasks which continue on error count, included in the failed tasks count
+	IgnoredFailed int
 	// cancelled tasks count
 	Cancelled int
+	// tasks cancelled by the user count, included in the cancelled tasks count
+	CancelledOnRequest int
 	// number of tasks which are still pending, have not executed
 	Incomplete int
 }
@@ -94,7 +100,13 @@ func (state PipelineRunState) ToMap() map[string]*ResolvedPipelineRunTask {
 // IsBeforeFirstTaskRun returns true if the PipelineRun has not yet started its first TaskRun
 func (state PipelineRunState) IsBeforeFirstTaskRun() bool {
 	for _, t := range state {
-		if t.IsCustomTask() && t.Run != nil {
+		if t.IsApproval() && t.Approval != nil {
+			return false
+		} else if t.RunsPipeline() && t.ChildPipelineRun != nil {
+			return false
+		} else if t.IsMatrixed() && t.hasCreatedInstance() {
+			return false
+		} else if t.IsCustomTask() && t.Run != nil {
 			return false
 		} else if t.TaskRun != nil {
 			return false
@@ -114,6 +126,19 @@ func (state PipelineRunState) IsBeforeFirstTaskRun() bool {
 func (state PipelineRunState) AdjustStartTime(unadjustedStartTime *metav1.Time) *metav1.Time {
 	adjustedStartTime := unadjustedStartTime
 	for _, rprt := range state {
+		if rprt.ChildPipelineRun != nil && rprt.ChildPipelineRun.CreationTimestamp.Time.Before(adjustedStartTime.Time) {
+			adjustedStartTime = &rprt.ChildPipelineRun.CreationTimestamp
+		}
+		for _, run := range rprt.Runs {
+			if run != nil && run.CreationTimestamp.Time.Before(adjustedStartTime.Time) {
+				adjustedStartTime = &run.CreationTimestamp
+			}
+		}
+		for _, taskRun := range rprt.TaskRuns {
+			if taskRun != nil && taskRun.CreationTimestamp.Time.Before(adjustedStartTime.Time) {
+				adjustedStartTime = &taskRun.CreationTimestamp
+			}
+		}
 		if rprt.TaskRun == nil {
 			if rprt.Run != nil {
 				if rprt.Run.CreationTimestamp.Time.Before(adjustedStartTime.Time) {
@@ -129,6 +154,55 @@ func (state PipelineRunState) AdjustStartTime(unadjustedStartTime *metav1.Time)
 	return adjustedStartTime.DeepCopy()
 }
 
+// ApplyReusedTasks sets the TaskRuns of the PipelineTasks reused from the PipelineRun being
+// rerun. These TaskRuns only hold the status copied in the status of the PipelineRun, since the
+// actual TaskRuns belong to the PipelineRun being rerun, which may have been deleted since.
+func (state PipelineRunState) ApplyReusedTasks(pr *v1beta1.PipelineRun) {
+	for _, reused := range pr.Status.ReusedTasks {
+		prtrs := pr.Status.TaskRuns[reused.TaskRunName]
+		if prtrs == nil || prtrs.Status == nil {
+			continue
+		}
+		for _, rprt := range state {
+			if rprt.PipelineTask.Name != reused.Name {
+				continue
+			}
+			rprt.TaskRunName = reused.TaskRunName
+			rprt.TaskRun = newStatusOnlyTaskRun(pr, reused.TaskRunName, prtrs.Status)
+		}
+	}
+}
+
+// ApplyCacheHits sets the TaskRuns of the PipelineTasks whose results were reused from a previous
+// TaskRun with the same inputs. These TaskRuns were never created and only hold the status copied
+// in the status of the PipelineRun.
+func (state PipelineRunState) ApplyCacheHits(pr *v1beta1.PipelineRun) {
+	for _, rprt := range state {
+		if rprt.TaskRun != nil || rprt.TaskRunName == "" {
+			continue
+		}
+		prtrs := pr.Status.TaskRuns[rprt.TaskRunName]
+		if prtrs == nil || prtrs.Status == nil ||
+			prtrs.Status.GetCondition(apis.ConditionSucceeded).GetReason() != v1beta1.TaskRunReasonCacheHit.String() {
+			continue
+		}
+		rprt.TaskRun = newStatusOnlyTaskRun(pr, rprt.TaskRunName, prtrs.Status)
+	}
+}
+
+// newStatusOnlyTaskRun returns a TaskRun of a PipelineRun which only holds the given status.
+func newStatusOnlyTaskRun(pr *v1beta1.PipelineRun, name string, status *v1beta1.TaskRunStatus) *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: pr.Namespace,
+			// The TaskRun doesn't make the PipelineRun start any earlier
+			CreationTimestamp: pr.CreationTimestamp,
+		},
+		Status: *status.DeepCopy(),
+	}
+}
+
 // GetTaskRunsStatus returns a map of taskrun name and the taskrun
 // ignore a nil taskrun in pipelineRunState, otherwise, capture taskrun object from PipelineRun Status
 // update taskrun status based on the pipelineRunState before returning it in the map
@@ -138,7 +212,33 @@ func (state PipelineRunState) GetTaskRunsStatus(pr *v1beta1.PipelineRun) map[str
 		if rprt.IsCustomTask() {
 			continue
 		}
-		if rprt.TaskRun == nil && rprt.ResolvedConditionChecks == nil {
+		if rprt.IsMatrixed() {
+			for _, taskRun := range rprt.TaskRuns {
+				if taskRun == nil {
+					continue
+				}
+				prtrs := pr.Status.TaskRuns[taskRun.Name]
+				if prtrs == nil {
+					prtrs = &v1beta1.PipelineRunTaskRunStatus{
+						PipelineTaskName: rprt.PipelineTask.Name,
+						WhenExpressions:  rprt.PipelineTask.WhenExpressions,
+					}
+				}
+				prtrs.MatrixParams = getMatrixParams(rprt.PipelineTask.Matrix, taskRun.Spec.Params)
+				prtrs.Status = &taskRun.Status
+				status[taskRun.Name] = prtrs
+			}
+			continue
+		}
+		if rprt.Iterates() {
+			// the TaskRuns of the previous iterations are kept in the status
+			for name, prtrs := range pr.Status.TaskRuns {
+				if prtrs.PipelineTaskName == rprt.PipelineTask.Name && name != rprt.TaskRunName {
+					status[name] = prtrs
+				}
+			}
+		}
+		if rprt.TaskRun == nil && rprt.ResolvedConditionChecks == nil && rprt.WaitingForMutex == "" {
 			continue
 		}
 
@@ -156,6 +256,8 @@ func (state PipelineRunState) GetTaskRunsStatus(pr *v1beta1.PipelineRun) map[str
 		if rprt.TaskRun != nil {
 			prtrs.Status = &rprt.TaskRun.Status
 		}
+		prtrs.WaitingForMutex = rprt.WaitingForMutex
+		prtrs.Iteration = rprt.Iteration
 
 		if len(rprt.ResolvedConditionChecks) > 0 {
 			cStatus := make(map[string]*v1beta1.PipelineRunConditionCheckStatus)
@@ -194,6 +296,24 @@ func (state PipelineRunState) GetRunsStatus(pr *v1beta1.PipelineRun) map[string]
 		if !rprt.IsCustomTask() {
 			continue
 		}
+		if rprt.IsMatrixed() {
+			for _, run := range rprt.Runs {
+				if run == nil {
+					continue
+				}
+				prrs := pr.Status.Runs[run.Name]
+				if prrs == nil {
+					prrs = &v1beta1.PipelineRunRunStatus{
+						PipelineTaskName: rprt.PipelineTask.Name,
+						WhenExpressions:  rprt.PipelineTask.WhenExpressions,
+					}
+				}
+				prrs.MatrixParams = getMatrixParams(rprt.PipelineTask.Matrix, run.Spec.Params)
+				prrs.Status = &run.Status
+				status[run.Name] = prrs
+			}
+			continue
+		}
 		if rprt.Run == nil && rprt.ResolvedConditionChecks == nil {
 			continue
 		}
@@ -220,6 +340,69 @@ func (state PipelineRunState) GetRunsStatus(pr *v1beta1.PipelineRun) map[string]
 	return status
 }
 
+// GetChildPipelineRunsStatus returns a map of child PipelineRun name and the status of the child PipelineRun.
+// Ignore a nil child PipelineRun in pipelineRunState, otherwise, capture the child PipelineRun status
+// from PipelineRun Status. Update its condition based on the pipelineRunState before returning it in the map.
+func (state PipelineRunState) GetChildPipelineRunsStatus(pr *v1beta1.PipelineRun) map[string]*v1beta1.PipelineRunChildPipelineRunStatus {
+	status := map[string]*v1beta1.PipelineRunChildPipelineRunStatus{}
+	for _, rprt := range state {
+		if !rprt.RunsPipeline() || rprt.ChildPipelineRun == nil {
+			continue
+		}
+
+		prcprs := pr.Status.ChildPipelineRuns[rprt.ChildPipelineRunName]
+		if prcprs == nil {
+			prcprs = &v1beta1.PipelineRunChildPipelineRunStatus{
+				PipelineTaskName: rprt.PipelineTask.Name,
+				WhenExpressions:  rprt.PipelineTask.WhenExpressions,
+			}
+		}
+		prcprs.Condition = rprt.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded).DeepCopy()
+		status[rprt.ChildPipelineRunName] = prcprs
+	}
+	return status
+}
+
+// GetChildPipelineRunResults returns the results of the child PipelineRuns which have succeeded,
+// by the name of their PipelineTask.
+func (state PipelineRunState) GetChildPipelineRunResults() map[string][]v1beta1.PipelineRunResult {
+	results := map[string][]v1beta1.PipelineRunResult{}
+	for _, rprt := range state {
+		if rprt.RunsPipeline() && rprt.IsSuccessful() {
+			results[rprt.PipelineTask.Name] = rprt.ChildPipelineRun.Status.PipelineResults
+		}
+	}
+	return results
+}
+
+// GetApprovalsStatus returns the status of the approvals of the PipelineTasks which started
+// waiting for one, in the order of the PipelineTasks.
+func (state PipelineRunState) GetApprovalsStatus() []v1beta1.PipelineTaskApprovalStatus {
+	var approvals []v1beta1.PipelineTaskApprovalStatus
+	for _, rprt := range state {
+		if rprt.IsApproval() && rprt.Approval != nil {
+			approvals = append(approvals, *rprt.Approval)
+		}
+	}
+	return approvals
+}
+
+// getMatrixParams returns the params of a TaskRun or Run created for a matrixed PipelineTask
+// which hold the values of the combination of the Matrix it was created for
+func getMatrixParams(m []v1beta1.Param, params []v1beta1.Param) []v1beta1.Param {
+	names := sets.NewString()
+	for _, p := range m {
+		names.Insert(p.Name)
+	}
+	var matrixParams []v1beta1.Param
+	for _, p := range params {
+		if names.Has(p.Name) {
+			matrixParams = append(matrixParams, p)
+		}
+	}
+	return matrixParams
+}
+
 // getNextTasks returns a list of tasks which should be executed next i.e.
 // a list of tasks from candidateTasks which aren't yet indicated in state to be running and
 // a list of cancelled/failed tasks from candidateTasks which haven't exhausted their retries
@@ -227,32 +410,173 @@ func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*Resolv
 	tasks := []*ResolvedPipelineRunTask{}
 	for _, t := range state {
 		if _, ok := candidateTasks[t.PipelineTask.Name]; ok {
-			if t.TaskRun == nil && t.Run == nil {
+			if t.IsApproval() {
+				// a PipelineTask waits for an approval only once
+				if t.Approval == nil {
+					tasks = append(tasks, t)
+				}
+			} else if t.RunsPipeline() {
+				// a child PipelineRun is created only once, it doesn't support retries
+				if t.ChildPipelineRun == nil {
+					tasks = append(tasks, t)
+				}
+			} else if t.IsMatrixed() {
+				// a matrixed task is scheduled as long as some of its TaskRuns or Runs
+				// are yet to be created or some of its TaskRuns are yet to be retried
+				if len(t.GetPendingTaskRunNames()) > 0 || len(t.GetPendingRunNames()) > 0 {
+					tasks = append(tasks, t)
+				}
+			} else if t.TaskRun == nil && t.Run == nil {
 				tasks = append(tasks, t)
-			} else if t.TaskRun != nil { // only TaskRun currently supports retry
-				status := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-				if status != nil && status.IsFalse() {
-					if !(t.TaskRun.IsCancelled() || status.Reason == v1beta1.TaskRunReasonCancelled.String() || status.Reason == ReasonConditionCheckFailed) {
-						if len(t.TaskRun.Status.RetriesStatus) < t.PipelineTask.Retries {
-							tasks = append(tasks, t)
-						}
-					}
+			} else if t.TaskRun != nil {
+				if t.isRetryable(t.TaskRun) || t.NeedsNextIteration() {
+					tasks = append(tasks, t)
 				}
+			} else if t.isRunRetryable(t.Run) {
+				tasks = append(tasks, t)
 			}
 		}
 	}
 	return tasks
 }
 
+// GetPendingTaskRunNames returns the names of the TaskRuns of a matrixed task which are yet to be
+// created, or which have failed and haven't exhausted their retries
+func (t *ResolvedPipelineRunTask) GetPendingTaskRunNames() []string {
+	var names []string
+	for i, name := range t.TaskRunNames {
+		if i >= len(t.TaskRuns) || t.TaskRuns[i] == nil || t.isRetryable(t.TaskRuns[i]) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// GetPendingRunNames returns the names of the Runs of a matrixed custom task which are yet to be
+// created, or which have failed and haven't exhausted their retries
+func (t *ResolvedPipelineRunTask) GetPendingRunNames() []string {
+	var names []string
+	for i, name := range t.RunNames {
+		if i >= len(t.Runs) || t.Runs[i] == nil || t.isRunRetryable(t.Runs[i]) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isRetryable returns true if the TaskRun has failed, wasn't cancelled and hasn't exhausted its retries,
+// or the user requested to retry it
+func (t *ResolvedPipelineRunTask) isRetryable(tr *v1beta1.TaskRun) bool {
+	status := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if status == nil || !status.IsFalse() {
+		return false
+	}
+	if tr.IsCancelled() || status.Reason == v1beta1.TaskRunReasonCancelled.String() || status.Reason == ReasonConditionCheckFailed {
+		return false
+	}
+	if t.isRetryRequested(len(tr.Status.RetriesStatus)) {
+		return true
+	}
+	return len(tr.Status.RetriesStatus) < t.PipelineTask.Retries && t.PipelineTask.RetryPolicy.ShouldRetry(status.Reason)
+}
+
+// isRunRetryable returns true if the Run has failed, wasn't cancelled and hasn't exhausted its retries,
+// or the user requested to retry it
+func (t *ResolvedPipelineRunTask) isRunRetryable(run *v1alpha1.Run) bool {
+	status := run.Status.GetCondition(apis.ConditionSucceeded)
+	if status == nil || !status.IsFalse() {
+		return false
+	}
+	if run.IsCancelled() || status.Reason == v1alpha1.RunReasonCancelled {
+		return false
+	}
+	if t.isRetryRequested(len(run.Status.RetriesStatus)) {
+		return true
+	}
+	return len(run.Status.RetriesStatus) < t.PipelineTask.Retries && t.PipelineTask.RetryPolicy.ShouldRetry(status.Reason)
+}
+
+// isRetryRequested returns true if the user requested to retry the task, and its failed TaskRun or Run,
+// which was retried the given number of times, exhausted the retries of its PipelineTask but wasn't
+// retried on request yet. The user can only retry a task once, on top of the retries of its PipelineTask,
+// which are still subject to its RetryPolicy.
+func (t *ResolvedPipelineRunTask) isRetryRequested(retriesDone int) bool {
+	return t.SpecStatus == v1beta1.PipelineTaskSpecStatusRetry && retriesDone == t.PipelineTask.Retries
+}
+
+// RetryBackoff returns how long to wait from now before the failed TaskRuns or Runs of the task
+// can be retried, according to the backoff of its RetryPolicy. A matrixed task is retried once
+// the backoff of all of its failed TaskRuns or Runs has elapsed.
+func (t *ResolvedPipelineRunTask) RetryBackoff(now time.Time) time.Duration {
+	var wait time.Duration
+	backoff := func(retriesDone int, completionTime *metav1.Time) {
+		if completionTime == nil {
+			return
+		}
+		if w := completionTime.Add(t.PipelineTask.RetryPolicy.Delay(retriesDone)).Sub(now); w > wait {
+			wait = w
+		}
+	}
+	taskRuns, runs := t.TaskRuns, t.Runs
+	if !t.IsMatrixed() {
+		taskRuns, runs = []*v1beta1.TaskRun{t.TaskRun}, []*v1alpha1.Run{t.Run}
+	}
+	for _, tr := range taskRuns {
+		// a retry requested by the user starts right away
+		if tr != nil && t.isRetryable(tr) && !t.isRetryRequested(len(tr.Status.RetriesStatus)) {
+			backoff(len(tr.Status.RetriesStatus), tr.Status.CompletionTime)
+		}
+	}
+	for _, run := range runs {
+		if run != nil && t.isRunRetryable(run) && !t.isRetryRequested(len(run.Status.RetriesStatus)) {
+			backoff(len(run.Status.RetriesStatus), run.Status.CompletionTime)
+		}
+	}
+	return wait
+}
+
+// NeedsNextIteration returns true if the current iteration of a PipelineTask with Iterate succeeded
+// but its results don't satisfy Until, and the PipelineTask hasn't reached its maximum iterations
+func (t *ResolvedPipelineRunTask) NeedsNextIteration() bool {
+	if !t.Iterates() || t.TaskRun == nil || !t.TaskRun.IsSuccessful() {
+		return false
+	}
+	return !t.isIterationDone() && t.Iteration < t.PipelineTask.Iterate.MaxIterations
+}
+
+// IterationDelay returns how long to wait from now before starting the next iteration of the
+// task, according to the delay of its Iterate.
+func (t *ResolvedPipelineRunTask) IterationDelay(now time.Time) time.Duration {
+	if !t.NeedsNextIteration() || t.PipelineTask.Iterate.Delay == nil || t.TaskRun.Status.CompletionTime == nil {
+		return 0
+	}
+	if wait := t.TaskRun.Status.CompletionTime.Add(t.PipelineTask.Iterate.Delay.Duration).Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// StartNextIteration moves a PipelineTask with Iterate to its next iteration, whose TaskRun is
+// yet to be created.
+func (t *ResolvedPipelineRunTask) StartNextIteration(prName string) {
+	t.Iteration++
+	t.TaskRunName = GetIterationTaskRunName(t.PipelineTask.Name, prName, t.Iteration)
+	t.TaskRun = nil
+}
+
 // IsStopping returns true if the PipelineRun won't be scheduling any new Task because
 // at least one task already failed or was cancelled in the specified dag
+// a failed task which continues on error or a task cancelled by the user doesn't stop the PipelineRun
 func (facts *PipelineRunFacts) IsStopping() bool {
 	for _, t := range facts.State {
 		if facts.isDAGTask(t.PipelineTask.Name) {
+			if t.isCancelledOnRequest() {
+				continue
+			}
 			if t.IsCancelled() {
 				return true
 			}
-			if t.IsFailure() {
+			if t.IsFailure() && !t.isFailureIgnored() && !t.isFailureHandled(facts) {
 				return true
 			}
 		}
@@ -301,7 +625,7 @@ func (facts *PipelineRunFacts) DAGExecutionQueue() (PipelineRunState, error) {
 
 // GetFinalTasks returns a list of final tasks without any taskRun associated with it
 // GetFinalTasks returns final tasks only when all DAG tasks have finished executing successfully or skipped or
-// any one DAG task resulted in failure
+// any one DAG task resulted in failure, a final task is returned once the final tasks it depends on are done
 func (facts *PipelineRunFacts) GetFinalTasks() PipelineRunState {
 	tasks := PipelineRunState{}
 	finalCandidates := sets.NewString()
@@ -310,7 +634,7 @@ func (facts *PipelineRunFacts) GetFinalTasks() PipelineRunState {
 	if facts.checkDAGTasksDone() {
 		// return list of tasks with all final tasks
 		for _, t := range facts.State {
-			if facts.isFinalTask(t.PipelineTask.Name) && !t.IsSuccessful() {
+			if facts.isFinalTask(t.PipelineTask.Name) && !t.IsSuccessful() && t.checkFinalParentsDone(facts) {
 				finalCandidates.Insert(t.PipelineTask.Name)
 			}
 		}
@@ -324,7 +648,7 @@ func (facts *PipelineRunFacts) GetFinalTasks() PipelineRunState {
 func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRun, logger *zap.SugaredLogger) *apis.Condition {
 	// We have 4 different states here:
 	// 1. Timed out -> Failed
-	// 2. All tasks are done and at least one has failed or has been cancelled -> Failed
+	// 2. All tasks are done and at least one has failed or has been cancelled, not by the user -> Failed
 	// 3. All tasks are done or are skipped (i.e. condition check failed).-> Success
 	// 4. A Task or Condition is running right now or there are things left to run -> Running
 	if pr.IsTimedOut() {
@@ -352,14 +676,14 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRu
 		reason := v1beta1.PipelineRunReasonSuccessful.String()
 		message := fmt.Sprintf("Tasks Completed: %d (Failed: %d, Cancelled %d), Skipped: %d",
 			cmTasks, s.Failed, s.Cancelled, s.Skipped)
-		// Set reason to ReasonCompleted - At least one is skipped
-		if s.Skipped > 0 {
+		// Set reason to ReasonCompleted - At least one is skipped, failed but continues on error or was cancelled by the user
+		if s.Skipped > 0 || s.IgnoredFailed > 0 || s.CancelledOnRequest > 0 {
 			reason = v1beta1.PipelineRunReasonCompleted.String()
 		}
 
 		switch {
-		case s.Failed > 0:
-			// Set reason to ReasonFailed - At least one failed
+		case s.Failed > s.IgnoredFailed:
+			// Set reason to ReasonFailed - At least one failed and doesn't continue on error
 			reason = v1beta1.PipelineRunReasonFailed.String()
 			status = corev1.ConditionFalse
 		case pr.IsGracefullyCancelled() || pr.IsGracefullyStopped():
@@ -367,8 +691,8 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRu
 			reason = v1beta1.PipelineRunReasonCancelled.String()
 			status = corev1.ConditionFalse
 			message = fmt.Sprintf("PipelineRun %q was cancelled", pr.Name)
-		case s.Cancelled > 0:
-			// Set reason to ReasonCancelled - At least one is cancelled and no failure yet
+		case s.Cancelled > s.CancelledOnRequest:
+			// Set reason to ReasonCancelled - At least one is cancelled, not by the user, and no failure yet
 			reason = v1beta1.PipelineRunReasonCancelled.String()
 			status = corev1.ConditionFalse
 		}
@@ -389,7 +713,7 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRu
 	case pr.IsGracefullyStopped():
 		// Transition pipeline into running finally state, when graceful stop is in progress
 		reason = v1beta1.PipelineRunReasonStoppedRunningFinally.String()
-	case s.Cancelled > 0 || (s.Failed > 0 && facts.checkFinalTasksDone()):
+	case s.Cancelled > s.CancelledOnRequest || (s.Failed > s.IgnoredFailed && facts.checkFinalTasksDone()):
 		// Transition pipeline into stopping state when one of the tasks(dag/final) cancelled or one of the dag tasks failed
 		// for a pipeline with final tasks, single dag task failure does not transition to interim stopping state
 		// pipeline stays in running state until all final tasks are done before transitioning to failed state
@@ -435,6 +759,35 @@ func (facts *PipelineRunFacts) GetSkippedTasks() []v1beta1.SkippedTask {
 // GetPipelineTaskStatus returns the status of a PipelineTask depending on its taskRun
 // the checks are implemented such that the finally tasks are requesting status of the dag tasks
 func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
+	tStatus := facts.getDAGTasksExecutionStatus()
+	// initialize aggregate status of all dag tasks to None
+	aggregateStatus := PipelineTaskStateNone
+	if facts.checkDAGTasksDone() {
+		// all dag tasks are done, change the aggregate status to succeeded
+		// will reset it to failed/skipped if needed
+		aggregateStatus = v1beta1.PipelineRunReasonSuccessful.String()
+		for _, t := range facts.State {
+			if facts.isDAGTask(t.PipelineTask.Name) {
+				// if any of the dag task failed, change the aggregate status to failed and return
+				if t.IsConditionStatusFalse() && !t.isFailureIgnored() && !t.isCancelledOnRequest() {
+					aggregateStatus = v1beta1.PipelineRunReasonFailed.String()
+					break
+				}
+				// if any of the dag task skipped, failed but continues on error or was cancelled by the user,
+				// change the aggregate status to completed but continue checking for any other failure
+				if t.Skip(facts).IsSkipped || t.isFailureIgnored() || t.isCancelledOnRequest() {
+					aggregateStatus = v1beta1.PipelineRunReasonCompleted.String()
+				}
+			}
+		}
+	}
+	tStatus[v1beta1.PipelineTasksAggregateStatus] = aggregateStatus
+	return tStatus
+}
+
+// getDAGTasksExecutionStatus returns a map of tasks.<pipelineTask>.status and the execution status of each
+// DAG task, unlike GetPipelineTaskStatus it leaves out the aggregate status which depends on all DAG tasks
+func (facts *PipelineRunFacts) getDAGTasksExecutionStatus() map[string]string {
 	// construct a map of tasks.<pipelineTask>.status and its state
 	tStatus := make(map[string]string)
 	for _, t := range facts.State {
@@ -454,38 +807,17 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 			tStatus[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskStatusSuffix] = s
 		}
 	}
-	// initialize aggregate status of all dag tasks to None
-	aggregateStatus := PipelineTaskStateNone
-	if facts.checkDAGTasksDone() {
-		// all dag tasks are done, change the aggregate status to succeeded
-		// will reset it to failed/skipped if needed
-		aggregateStatus = v1beta1.PipelineRunReasonSuccessful.String()
-		for _, t := range facts.State {
-			if facts.isDAGTask(t.PipelineTask.Name) {
-				// if any of the dag task failed, change the aggregate status to failed and return
-				if t.IsConditionStatusFalse() {
-					aggregateStatus = v1beta1.PipelineRunReasonFailed.String()
-					break
-				}
-				// if any of the dag task skipped, change the aggregate status to completed
-				// but continue checking for any other failure
-				if t.Skip(facts).IsSkipped {
-					aggregateStatus = v1beta1.PipelineRunReasonCompleted.String()
-				}
-			}
-		}
-	}
-	tStatus[v1beta1.PipelineTasksAggregateStatus] = aggregateStatus
 	return tStatus
 }
 
 // successfulOrSkippedTasks returns a list of the names of all of the PipelineTasks in state
-// which have successfully completed or skipped
+// which have successfully completed or skipped, which failed but continue on error or have tasks
+// depending on their execution status, or which were cancelled by the user
 func (facts *PipelineRunFacts) successfulOrSkippedDAGTasks() []string {
 	tasks := []string{}
 	for _, t := range facts.State {
 		if facts.isDAGTask(t.PipelineTask.Name) {
-			if t.IsSuccessful() || t.Skip(facts).IsSkipped {
+			if t.IsSuccessful() || t.Skip(facts).IsSkipped || t.isFailureIgnored() || t.isCancelledOnRequest() || t.isFailureHandled(facts) {
 				tasks = append(tasks, t.PipelineTask.Name)
 			}
 		}
@@ -519,11 +851,12 @@ func (facts *PipelineRunFacts) checkFinalTasksDone() bool {
 // getPipelineTasksCount returns the count of successful tasks, failed tasks, cancelled tasks, skipped task, and incomplete tasks
 func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 	s := pipelineRunStatusCount{
-		Skipped:    0,
-		Succeeded:  0,
-		Failed:     0,
-		Cancelled:  0,
-		Incomplete: 0,
+		Skipped:       0,
+		Succeeded:     0,
+		Failed:        0,
+		Cancelled:     0,
+		IgnoredFailed: 0,
+		Incomplete:    0,
 	}
 	for _, t := range facts.State {
 		switch {
@@ -533,9 +866,16 @@ func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 		// increment cancelled counter since the task is cancelled
 		case t.IsCancelled():
 			s.Cancelled++
+			if t.isCancelledOnRequest() {
+				s.CancelledOnRequest++
+			}
 		// increment failure counter since the task has failed
+		// and the ignored failure counter if the task continues on error
 		case t.IsFailure():
 			s.Failed++
+			if t.isFailureIgnored() {
+				s.IgnoredFailed++
+			}
 		// increment skip counter since the task is skipped
 		case t.Skip(facts).IsSkipped:
 			s.Skipped++
@@ -30,6 +30,7 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -500,6 +501,224 @@ func TestGetNextTaskWithRetries(t *testing.T) {
 	}
 }
 
+func TestGetNextTaskWithRetryPolicy(t *testing.T) {
+	timedOutTaskRun := makeFailed(trs[0])
+	timedOutTaskRun.Status.Conditions[0].Reason = v1beta1.TaskRunReasonTimedOut.String()
+	failedTaskRun := makeFailed(trs[0])
+	failedTaskRun.Status.Conditions[0].Reason = v1beta1.TaskRunReasonFailed.String()
+	retriedRun := makeRunFailed(runs[0])
+	retriedRun.Status.RetriesStatus = []v1alpha1.RunStatus{retriedRun.Status}
+
+	retryOnTimeout := v1beta1.PipelineTask{
+		Name:        "mytask1",
+		TaskRef:     &v1beta1.TaskRef{Name: "task"},
+		Retries:     1,
+		RetryPolicy: &v1beta1.RetryPolicy{Reasons: []string{v1beta1.TaskRunReasonTimedOut.String()}},
+	}
+	customTaskWithRetries := v1beta1.PipelineTask{
+		Name:    "mytask13",
+		TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example", Name: "customtask"},
+		Retries: 1,
+	}
+
+	tcs := []struct {
+		name            string
+		state           PipelineRunState
+		expectedNext    int
+		expectedFailure bool
+	}{{
+		name: "reason retried",
+		state: PipelineRunState{{
+			PipelineTask: &retryOnTimeout,
+			TaskRunName:  "pipelinerun-mytask1",
+			TaskRun:      timedOutTaskRun,
+		}},
+		expectedNext: 1,
+	}, {
+		name: "reason not retried",
+		state: PipelineRunState{{
+			PipelineTask: &retryOnTimeout,
+			TaskRunName:  "pipelinerun-mytask1",
+			TaskRun:      failedTaskRun,
+		}},
+		expectedFailure: true,
+	}, {
+		name: "run retried",
+		state: PipelineRunState{{
+			PipelineTask: &customTaskWithRetries,
+			CustomTask:   true,
+			RunName:      "pipelinerun-mytask13",
+			Run:          makeRunFailed(runs[0]),
+		}},
+		expectedNext: 1,
+	}, {
+		name: "run cancelled",
+		state: PipelineRunState{{
+			PipelineTask: &customTaskWithRetries,
+			CustomTask:   true,
+			RunName:      "pipelinerun-mytask13",
+			Run:          withRunCancelled(*makeRunFailed(runs[0])),
+		}},
+		expectedFailure: true,
+	}, {
+		name: "run retries exhausted",
+		state: PipelineRunState{{
+			PipelineTask: &customTaskWithRetries,
+			CustomTask:   true,
+			RunName:      "pipelinerun-mytask13",
+			Run:          retriedRun,
+		}},
+		expectedFailure: true,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			next := tc.state.getNextTasks(sets.NewString(tc.state[0].PipelineTask.Name))
+			if len(next) != tc.expectedNext {
+				t.Errorf("expected %d next tasks but got %d", tc.expectedNext, len(next))
+			}
+			if got := tc.state[0].IsFailure(); got != tc.expectedFailure {
+				t.Errorf("expected IsFailure to be %t but got %t", tc.expectedFailure, got)
+			}
+		})
+	}
+}
+
+func TestResolvedPipelineRunTask_Iterate(t *testing.T) {
+	now := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	succeededWith := func(ready string) *v1beta1.TaskRun {
+		tr := makeSucceeded(trs[0])
+		tr.Status.CompletionTime = &metav1.Time{Time: now.Add(-4 * time.Second)}
+		tr.Status.TaskRunResults = []v1beta1.TaskRunResult{{Name: "ready", Value: ready}}
+		return tr
+	}
+	iterate := v1beta1.PipelineTask{
+		Name:    "mytask1",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Iterate: &v1beta1.Iterate{
+			Until: v1beta1.WhenExpressions{{
+				Input:    "$(tasks.mytask1.results.ready)",
+				Operator: selection.In,
+				Values:   []string{"true"},
+			}},
+			MaxIterations: 3,
+			Delay:         &metav1.Duration{Duration: 10 * time.Second},
+		},
+	}
+
+	tcs := []struct {
+		name              string
+		rprt              *ResolvedPipelineRunTask
+		wantSuccessful    bool
+		wantFailure       bool
+		wantNextIteration bool
+		wantDelay         time.Duration
+	}{{
+		name: "iteration running",
+		rprt: &ResolvedPipelineRunTask{PipelineTask: &iterate, TaskRun: makeStarted(trs[0]), Iteration: 1},
+	}, {
+		name:              "until doesn't hold yet",
+		rprt:              &ResolvedPipelineRunTask{PipelineTask: &iterate, TaskRun: succeededWith("false"), Iteration: 1},
+		wantNextIteration: true,
+		wantDelay:         6 * time.Second,
+	}, {
+		name:           "until holds",
+		rprt:           &ResolvedPipelineRunTask{PipelineTask: &iterate, TaskRun: succeededWith("true"), Iteration: 2},
+		wantSuccessful: true,
+	}, {
+		name:        "max iterations reached",
+		rprt:        &ResolvedPipelineRunTask{PipelineTask: &iterate, TaskRun: succeededWith("false"), Iteration: 3},
+		wantFailure: true,
+	}, {
+		name:        "iteration failed",
+		rprt:        &ResolvedPipelineRunTask{PipelineTask: &iterate, TaskRun: makeFailed(trs[0]), Iteration: 1},
+		wantFailure: true,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rprt.IsSuccessful(); got != tc.wantSuccessful {
+				t.Errorf("expected IsSuccessful to be %t but got %t", tc.wantSuccessful, got)
+			}
+			if got := tc.rprt.IsFailure(); got != tc.wantFailure {
+				t.Errorf("expected IsFailure to be %t but got %t", tc.wantFailure, got)
+			}
+			if got := tc.rprt.NeedsNextIteration(); got != tc.wantNextIteration {
+				t.Errorf("expected NeedsNextIteration to be %t but got %t", tc.wantNextIteration, got)
+			}
+			if got := tc.rprt.IterationDelay(now); got != tc.wantDelay {
+				t.Errorf("expected an iteration delay of %s but got %s", tc.wantDelay, got)
+			}
+		})
+	}
+
+	rprt := &ResolvedPipelineRunTask{PipelineTask: &iterate, TaskRun: succeededWith("false"), Iteration: 1}
+	rprt.StartNextIteration("pipelinerun")
+	if rprt.Iteration != 2 || rprt.TaskRun != nil || rprt.TaskRunName != "pipelinerun-mytask1-iteration-2" {
+		t.Errorf("unexpected next iteration: %d, %s, %v", rprt.Iteration, rprt.TaskRunName, rprt.TaskRun)
+	}
+}
+
+func TestResolvedPipelineRunTask_RetryBackoff(t *testing.T) {
+	now := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	failedAt := func(ago time.Duration, retriesDone int) *v1beta1.TaskRun {
+		tr := makeFailed(trs[0])
+		tr.Status.CompletionTime = &metav1.Time{Time: now.Add(-ago)}
+		for i := 0; i < retriesDone; i++ {
+			tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, v1beta1.TaskRunStatus{})
+		}
+		return tr
+	}
+	backoff := v1beta1.PipelineTask{
+		Name:    "mytask1",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Retries: 3,
+		RetryPolicy: &v1beta1.RetryPolicy{
+			Backoff:    &metav1.Duration{Duration: 10 * time.Second},
+			MaxBackoff: &metav1.Duration{Duration: 30 * time.Second},
+		},
+	}
+
+	tcs := []struct {
+		name     string
+		rprt     *ResolvedPipelineRunTask
+		expected time.Duration
+	}{{
+		name:     "no retry policy",
+		rprt:     &ResolvedPipelineRunTask{PipelineTask: &pts[4], TaskRun: failedAt(time.Second, 0)},
+		expected: 0,
+	}, {
+		name:     "first retry",
+		rprt:     &ResolvedPipelineRunTask{PipelineTask: &backoff, TaskRun: failedAt(4*time.Second, 0)},
+		expected: 6 * time.Second,
+	}, {
+		name:     "second retry",
+		rprt:     &ResolvedPipelineRunTask{PipelineTask: &backoff, TaskRun: failedAt(4*time.Second, 1)},
+		expected: 16 * time.Second,
+	}, {
+		name:     "capped by max backoff",
+		rprt:     &ResolvedPipelineRunTask{PipelineTask: &backoff, TaskRun: failedAt(4*time.Second, 2)},
+		expected: 26 * time.Second,
+	}, {
+		name:     "backoff elapsed",
+		rprt:     &ResolvedPipelineRunTask{PipelineTask: &backoff, TaskRun: failedAt(time.Minute, 0)},
+		expected: 0,
+	}, {
+		name:     "retries exhausted",
+		rprt:     &ResolvedPipelineRunTask{PipelineTask: &backoff, TaskRun: failedAt(time.Second, 3)},
+		expected: 0,
+	}, {
+		name:     "still running",
+		rprt:     &ResolvedPipelineRunTask{PipelineTask: &backoff, TaskRun: makeStarted(trs[0])},
+		expected: 0,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rprt.RetryBackoff(now); got != tc.expected {
+				t.Errorf("expected a backoff of %s but got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
 func TestPipelineRunState_SuccessfulOrSkippedDAGTasks(t *testing.T) {
 	largePipelineState := buildPipelineStateWithLargeDepencyGraph(t)
 	tcs := []struct {
@@ -830,6 +1049,83 @@ func TestPipelineRunState_GetFinalTasks(t *testing.T) {
 	}
 }
 
+func TestPipelineRunState_GetFinalTasks_Ordering(t *testing.T) {
+	// tasks: [ mytask1 ]
+	// finally: [ mytask2, mytask3 runAfter mytask2 ]
+	finalTask := v1beta1.PipelineTask{
+		Name:     "mytask3",
+		TaskRef:  &v1beta1.TaskRef{Name: "task"},
+		RunAfter: []string{"mytask2"},
+	}
+	finalTasks := []v1beta1.PipelineTask{pts[1], finalTask}
+	dagTask := &ResolvedPipelineRunTask{
+		PipelineTask: &pts[0],
+		TaskRunName:  "pipelinerun-mytask1",
+		TaskRun:      makeSucceeded(trs[0]),
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskSpec: &task.Spec,
+		},
+	}
+	dependentFinalTask := &ResolvedPipelineRunTask{
+		PipelineTask: &finalTask,
+		TaskRunName:  "pipelinerun-mytask3",
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskSpec: &task.Spec,
+		},
+	}
+	tcs := []struct {
+		name               string
+		finalTaskRun       *v1beta1.TaskRun
+		expectedFinalTasks []string
+	}{{
+		name:               "final task not started, schedule it only",
+		expectedFinalTasks: []string{"mytask2"},
+	}, {
+		name:               "final task running, do not schedule the final task depending on it",
+		finalTaskRun:       makeStarted(trs[1]),
+		expectedFinalTasks: []string{},
+	}, {
+		name:               "final task succeeded, schedule the final task depending on it",
+		finalTaskRun:       makeSucceeded(trs[1]),
+		expectedFinalTasks: []string{"mytask3"},
+	}, {
+		name:               "final task failed, schedule the final task depending on it",
+		finalTaskRun:       makeFailed(trs[1]),
+		expectedFinalTasks: []string{"mytask3"},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			dagGraph, err := dag.Build(v1beta1.PipelineTaskList{pts[0]}, map[string][]string{})
+			if err != nil {
+				t.Fatalf("Unexpected error while buildig DAG for pipelineTasks: %v", err)
+			}
+			finalGraph, err := dag.Build(v1beta1.PipelineTaskList(finalTasks), v1beta1.PipelineTaskList(finalTasks).FinallyDeps())
+			if err != nil {
+				t.Fatalf("Unexpected error while buildig DAG for final pipelineTasks: %v", err)
+			}
+			facts := PipelineRunFacts{
+				State: PipelineRunState{dagTask, {
+					PipelineTask: &pts[1],
+					TaskRunName:  "pipelinerun-mytask2",
+					TaskRun:      tc.finalTaskRun,
+					ResolvedTaskResources: &resources.ResolvedTaskResources{
+						TaskSpec: &task.Spec,
+					},
+				}, dependentFinalTask},
+				TasksGraph:      dagGraph,
+				FinalTasksGraph: finalGraph,
+			}
+			names := []string{}
+			for _, next := range facts.GetFinalTasks() {
+				names = append(names, next.PipelineTask.Name)
+			}
+			if d := cmp.Diff(tc.expectedFinalTasks, names); d != "" {
+				t.Errorf("Didn't get expected final Tasks: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestGetPipelineConditionStatus(t *testing.T) {
 
 	var taskRetriedState = PipelineRunState{{
@@ -1693,3 +1989,953 @@ func TestPipelineRunFacts_IsRunning(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRunFacts_Matrix(t *testing.T) {
+	matrixedTask := v1beta1.PipelineTask{
+		Name:    "matrixed",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Matrix: []v1beta1.Param{{
+			Name:  "platform",
+			Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}},
+	}
+	downstreamTask := v1beta1.PipelineTask{
+		Name:     "downstream",
+		TaskRef:  &v1beta1.TaskRef{Name: "task"},
+		RunAfter: []string{"matrixed"},
+	}
+	makeState := func(taskRuns ...*v1beta1.TaskRun) PipelineRunState {
+		var names []string
+		for i := range taskRuns {
+			names = append(names, fmt.Sprintf("pipelinerun-matrixed-%d", i))
+		}
+		return PipelineRunState{{
+			PipelineTask: &matrixedTask,
+			TaskRunNames: names,
+			TaskRuns:     taskRuns,
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}, {
+			PipelineTask: &downstreamTask,
+			TaskRunName:  "pipelinerun-downstream",
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}}
+	}
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"}}
+
+	tcs := []struct {
+		name               string
+		state              PipelineRunState
+		expectedQueue      []string
+		expectedStatus     corev1.ConditionStatus
+		expectedReason     string
+		expectedSucceeded  int
+		expectedFailed     int
+		expectedIncomplete int
+		expectedSkipped    int
+	}{{
+		name:               "no instances created",
+		state:              makeState(nil, nil),
+		expectedQueue:      []string{"matrixed"},
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1beta1.PipelineRunReasonRunning.String(),
+		expectedIncomplete: 2,
+	}, {
+		name:               "some instances created",
+		state:              makeState(makeStarted(trs[0]), nil),
+		expectedQueue:      []string{"matrixed"},
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1beta1.PipelineRunReasonRunning.String(),
+		expectedIncomplete: 2,
+	}, {
+		name:               "one instance still running",
+		state:              makeState(makeSucceeded(trs[0]), makeStarted(trs[1])),
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1beta1.PipelineRunReasonRunning.String(),
+		expectedIncomplete: 2,
+	}, {
+		name:               "all instances succeeded",
+		state:              makeState(makeSucceeded(trs[0]), makeSucceeded(trs[1])),
+		expectedQueue:      []string{"downstream"},
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1beta1.PipelineRunReasonRunning.String(),
+		expectedSucceeded:  1,
+		expectedIncomplete: 1,
+	}, {
+		name:            "one instance failed",
+		state:           makeState(makeSucceeded(trs[0]), makeFailed(trs[1])),
+		expectedStatus:  corev1.ConditionFalse,
+		expectedReason:  v1beta1.PipelineRunReasonFailed.String(),
+		expectedFailed:  1,
+		expectedSkipped: 1,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dagFromState(tc.state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", tc.state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+			}
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Fatalf("Unexpected error getting DAG execution queue: %v", err)
+			}
+			var queueNames []string
+			for _, rprt := range queue {
+				queueNames = append(queueNames, rprt.PipelineTask.Name)
+			}
+			if d := cmp.Diff(tc.expectedQueue, queueNames); d != "" {
+				t.Errorf("Unexpected DAG execution queue: %s", diff.PrintWantGot(d))
+			}
+
+			c := facts.GetPipelineConditionStatus(pr, zap.NewNop().Sugar())
+			wantCondition := &apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: tc.expectedStatus,
+				Reason: tc.expectedReason,
+				Message: getExpectedMessage(pr.Name, "", tc.expectedStatus,
+					tc.expectedSucceeded, tc.expectedIncomplete, tc.expectedSkipped, tc.expectedFailed, 0),
+			}
+			if d := cmp.Diff(wantCondition, c); d != "" {
+				t.Errorf("Unexpected pipeline condition: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunFacts_OnErrorContinue(t *testing.T) {
+	lintTask := v1beta1.PipelineTask{
+		Name:    "lint",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		OnError: v1beta1.PipelineTaskContinue,
+	}
+	buildTask := v1beta1.PipelineTask{
+		Name:     "build",
+		TaskRef:  &v1beta1.TaskRef{Name: "task"},
+		RunAfter: []string{"lint"},
+	}
+	makeState := func(lintTaskRun, buildTaskRun *v1beta1.TaskRun) PipelineRunState {
+		return PipelineRunState{{
+			PipelineTask: &lintTask,
+			TaskRunName:  "pipelinerun-lint",
+			TaskRun:      lintTaskRun,
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}, {
+			PipelineTask: &buildTask,
+			TaskRunName:  "pipelinerun-build",
+			TaskRun:      buildTaskRun,
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}}
+	}
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"}}
+
+	tcs := []struct {
+		name                    string
+		state                   PipelineRunState
+		expectedQueue           []string
+		expectedStatus          corev1.ConditionStatus
+		expectedReason          string
+		expectedSucceeded       int
+		expectedFailed          int
+		expectedIncomplete      int
+		expectedStopping        bool
+		expectedPipelineTaskSts map[string]string
+	}{{
+		name:               "failed task continues on error",
+		state:              makeState(makeFailed(trs[0]), nil),
+		expectedQueue:      []string{"build"},
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1beta1.PipelineRunReasonRunning.String(),
+		expectedFailed:     1,
+		expectedIncomplete: 1,
+		expectedPipelineTaskSts: map[string]string{
+			"tasks.lint.status":                  v1beta1.TaskRunReasonFailed.String(),
+			"tasks.build.status":                 PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus: PipelineTaskStateNone,
+		},
+	}, {
+		name:              "dependent of a failed task which continues on error succeeded",
+		state:             makeState(makeFailed(trs[0]), makeSucceeded(trs[1])),
+		expectedStatus:    corev1.ConditionTrue,
+		expectedReason:    v1beta1.PipelineRunReasonCompleted.String(),
+		expectedSucceeded: 1,
+		expectedFailed:    1,
+		expectedPipelineTaskSts: map[string]string{
+			"tasks.lint.status":                  v1beta1.TaskRunReasonFailed.String(),
+			"tasks.build.status":                 v1beta1.TaskRunReasonSuccessful.String(),
+			v1beta1.PipelineTasksAggregateStatus: v1beta1.PipelineRunReasonCompleted.String(),
+		},
+	}, {
+		name:             "dependent of a failed task which continues on error failed",
+		state:            makeState(makeFailed(trs[0]), makeFailed(trs[1])),
+		expectedStatus:   corev1.ConditionFalse,
+		expectedReason:   v1beta1.PipelineRunReasonFailed.String(),
+		expectedFailed:   2,
+		expectedStopping: true,
+		expectedPipelineTaskSts: map[string]string{
+			"tasks.lint.status":                  v1beta1.TaskRunReasonFailed.String(),
+			"tasks.build.status":                 v1beta1.TaskRunReasonFailed.String(),
+			v1beta1.PipelineTasksAggregateStatus: v1beta1.PipelineRunReasonFailed.String(),
+		},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dagFromState(tc.state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", tc.state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+			}
+			if got := facts.IsStopping(); got != tc.expectedStopping {
+				t.Errorf("Expected IsStopping() to be %t but got %t", tc.expectedStopping, got)
+			}
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Fatalf("Unexpected error getting DAG execution queue: %v", err)
+			}
+			var queueNames []string
+			for _, rprt := range queue {
+				queueNames = append(queueNames, rprt.PipelineTask.Name)
+			}
+			if d := cmp.Diff(tc.expectedQueue, queueNames); d != "" {
+				t.Errorf("Unexpected DAG execution queue: %s", diff.PrintWantGot(d))
+			}
+
+			c := facts.GetPipelineConditionStatus(pr, zap.NewNop().Sugar())
+			wantCondition := &apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: tc.expectedStatus,
+				Reason: tc.expectedReason,
+				Message: getExpectedMessage(pr.Name, "", tc.expectedStatus,
+					tc.expectedSucceeded, tc.expectedIncomplete, 0, tc.expectedFailed, 0),
+			}
+			if d := cmp.Diff(wantCondition, c); d != "" {
+				t.Errorf("Unexpected pipeline condition: %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.expectedPipelineTaskSts, facts.GetPipelineTaskStatus()); d != "" {
+				t.Errorf("Unexpected pipeline task status: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunFacts_ExecutionStatusDeps(t *testing.T) {
+	deployTask := v1beta1.PipelineTask{
+		Name:    "deploy",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+	}
+	rollbackTask := v1beta1.PipelineTask{
+		Name:    "rollback",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		WhenExpressions: v1beta1.WhenExpressions{{
+			Input:    "$(tasks.deploy.status)",
+			Operator: selection.In,
+			Values:   []string{v1beta1.TaskRunReasonFailed.String()},
+		}},
+	}
+	notifyTask := v1beta1.PipelineTask{
+		Name:     "notify",
+		TaskRef:  &v1beta1.TaskRef{Name: "task"},
+		RunAfter: []string{"deploy"},
+	}
+	taskRun := func(name string) v1beta1.TaskRun {
+		return v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "pipelinerun-" + name}}
+	}
+	makeState := func(deployTaskRun, rollbackTaskRun *v1beta1.TaskRun) PipelineRunState {
+		return PipelineRunState{{
+			PipelineTask: &deployTask,
+			TaskRunName:  "pipelinerun-deploy",
+			TaskRun:      deployTaskRun,
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}, {
+			PipelineTask: &rollbackTask,
+			TaskRunName:  "pipelinerun-rollback",
+			TaskRun:      rollbackTaskRun,
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}, {
+			PipelineTask: &notifyTask,
+			TaskRunName:  "pipelinerun-notify",
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}}
+	}
+
+	tcs := []struct {
+		name                string
+		state               PipelineRunState
+		expectedQueue       []string
+		expectedStopping    bool
+		expectedSkipReasons map[string]SkippingReason
+	}{{
+		name:  "task whose execution status is referenced is running",
+		state: makeState(makeStarted(taskRun("deploy")), nil),
+		expectedSkipReasons: map[string]SkippingReason{
+			"deploy":   None,
+			"rollback": None,
+			"notify":   None,
+		},
+	}, {
+		name:          "task whose execution status is referenced failed",
+		state:         makeState(makeFailed(taskRun("deploy")), nil),
+		expectedQueue: []string{"rollback"},
+		expectedSkipReasons: map[string]SkippingReason{
+			"deploy":   None,
+			"rollback": None,
+			"notify":   ParentTasksFailedSkip,
+		},
+	}, {
+		name:          "task whose execution status is referenced succeeded",
+		state:         makeState(makeSucceeded(taskRun("deploy")), nil),
+		expectedQueue: []string{"notify"},
+		expectedSkipReasons: map[string]SkippingReason{
+			"deploy":   None,
+			"rollback": WhenExpressionsSkip,
+			"notify":   None,
+		},
+	}, {
+		name:             "task depending on the execution status failed",
+		state:            makeState(makeFailed(taskRun("deploy")), makeFailed(taskRun("rollback"))),
+		expectedStopping: true,
+		expectedSkipReasons: map[string]SkippingReason{
+			"deploy":   None,
+			"rollback": None,
+			"notify":   IsStoppingSkip,
+		},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dagFromState(tc.state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", tc.state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+			}
+			if got := facts.IsStopping(); got != tc.expectedStopping {
+				t.Errorf("Expected IsStopping() to be %t but got %t", tc.expectedStopping, got)
+			}
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Fatalf("Unexpected error getting DAG execution queue: %v", err)
+			}
+			var queueNames []string
+			for _, rprt := range queue {
+				queueNames = append(queueNames, rprt.PipelineTask.Name)
+			}
+			if d := cmp.Diff(tc.expectedQueue, queueNames); d != "" {
+				t.Errorf("Unexpected DAG execution queue: %s", diff.PrintWantGot(d))
+			}
+			skipReasons := map[string]SkippingReason{}
+			for _, rprt := range tc.state {
+				skipReasons[rprt.PipelineTask.Name] = rprt.Skip(&facts).SkippingReason
+			}
+			if d := cmp.Diff(tc.expectedSkipReasons, skipReasons); d != "" {
+				t.Errorf("Unexpected skipping reasons: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunState_GetTaskRunsStatus_Matrix(t *testing.T) {
+	matrixedTask := v1beta1.PipelineTask{
+		Name:    "matrixed",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Params: []v1beta1.Param{{
+			Name:  "version",
+			Value: *v1beta1.NewArrayOrString("v1"),
+		}},
+		Matrix: []v1beta1.Param{{
+			Name:  "platform",
+			Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}},
+	}
+	makeInstance := func(name, platform string) *v1beta1.TaskRun {
+		tr := makeStarted(v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1beta1.TaskRunSpec{
+				Params: []v1beta1.Param{{
+					Name:  "version",
+					Value: *v1beta1.NewArrayOrString("v1"),
+				}, {
+					Name:  "platform",
+					Value: *v1beta1.NewArrayOrString(platform),
+				}},
+			},
+		})
+		return tr
+	}
+	state := PipelineRunState{{
+		PipelineTask: &matrixedTask,
+		TaskRunNames: []string{"pipelinerun-matrixed-0", "pipelinerun-matrixed-1"},
+		TaskRuns:     []*v1beta1.TaskRun{makeInstance("pipelinerun-matrixed-0", "linux"), nil},
+	}}
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"}}
+
+	got := state.GetTaskRunsStatus(pr)
+	want := map[string]*v1beta1.PipelineRunTaskRunStatus{
+		"pipelinerun-matrixed-0": {
+			PipelineTaskName: "matrixed",
+			Status:           &state[0].TaskRuns[0].Status,
+			MatrixParams: []v1beta1.Param{{
+				Name:  "platform",
+				Value: *v1beta1.NewArrayOrString("linux"),
+			}},
+		},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected TaskRuns status: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineRunState_GetTaskRunsStatus_Mutex(t *testing.T) {
+	waitingTask := v1beta1.PipelineTask{
+		Name:    "waiting",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Mutex:   "deploy",
+	}
+	runningTask := v1beta1.PipelineTask{
+		Name:    "running",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Mutex:   "test",
+	}
+	state := PipelineRunState{{
+		PipelineTask:    &waitingTask,
+		TaskRunName:     "pipelinerun-waiting",
+		WaitingForMutex: "deploy",
+	}, {
+		PipelineTask: &runningTask,
+		TaskRunName:  "pipelinerun-running",
+		TaskRun:      makeStarted(v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-running"}}),
+	}}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+				"pipelinerun-running": {PipelineTaskName: "running", WaitingForMutex: "test"},
+			},
+		}},
+	}
+
+	got := state.GetTaskRunsStatus(pr)
+	want := map[string]*v1beta1.PipelineRunTaskRunStatus{
+		"pipelinerun-waiting": {
+			PipelineTaskName: "waiting",
+			WaitingForMutex:  "deploy",
+		},
+		"pipelinerun-running": {
+			PipelineTaskName: "running",
+			Status:           &state[1].TaskRun.Status,
+		},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected TaskRuns status: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineRunState_ApplyReusedTasks(t *testing.T) {
+	reusedStatus := &v1beta1.TaskRunStatus{
+		Status: duckv1beta1.Status{
+			Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+		},
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			TaskRunResults: []v1beta1.TaskRunResult{{Name: "image", Value: "registry/image:tag"}},
+		},
+	}
+	state := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{Name: "build"},
+		TaskRunName:  "pipelinerun-build",
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{Name: "deploy"},
+		TaskRunName:  "pipelinerun-deploy",
+	}}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun", Namespace: "foo"},
+		Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+				"previous-build": {PipelineTaskName: "build", Status: reusedStatus},
+			},
+			ReusedTasks: []v1beta1.ReusedTask{{Name: "build", PipelineRunName: "previous", TaskRunName: "previous-build"}},
+		}},
+	}
+
+	state.ApplyReusedTasks(pr)
+
+	if state[0].TaskRunName != "previous-build" || state[0].TaskRun == nil || state[0].TaskRun.Name != "previous-build" {
+		t.Fatalf("expected TaskRun previous-build to be reused for build but got %q and %v", state[0].TaskRunName, state[0].TaskRun)
+	}
+	if d := cmp.Diff(*reusedStatus, state[0].TaskRun.Status); d != "" {
+		t.Errorf("Unexpected status of reused TaskRun: %s", diff.PrintWantGot(d))
+	}
+	if !state[0].IsSuccessful() {
+		t.Errorf("expected reused task to be successful")
+	}
+	if state[1].TaskRunName != "pipelinerun-deploy" || state[1].TaskRun != nil {
+		t.Errorf("expected deploy not to be reused but got %q and %v", state[1].TaskRunName, state[1].TaskRun)
+	}
+}
+
+func TestPipelineRunState_ApplyCacheHits(t *testing.T) {
+	cachedStatus := &v1beta1.TaskRunStatus{
+		Status: duckv1beta1.Status{
+			Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+				Reason: v1beta1.TaskRunReasonCacheHit.String(),
+			}},
+		},
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			TaskRunResults: []v1beta1.TaskRunResult{{Name: "image", Value: "registry/image:tag"}},
+		},
+	}
+	succeededStatus := &v1beta1.TaskRunStatus{
+		Status: duckv1beta1.Status{
+			Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+		},
+	}
+	state := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{Name: "build"},
+		TaskRunName:  "pipelinerun-build",
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{Name: "test"},
+		TaskRunName:  "pipelinerun-test",
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{Name: "deploy"},
+		TaskRunName:  "pipelinerun-deploy",
+	}}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun", Namespace: "foo"},
+		Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+				"pipelinerun-build": {PipelineTaskName: "build", Status: cachedStatus},
+				"pipelinerun-test":  {PipelineTaskName: "test", Status: succeededStatus},
+			},
+		}},
+	}
+
+	state.ApplyCacheHits(pr)
+
+	if state[0].TaskRun == nil || state[0].TaskRun.Name != "pipelinerun-build" {
+		t.Fatalf("expected the cached results to be reused for build but got %v", state[0].TaskRun)
+	}
+	if d := cmp.Diff(*cachedStatus, state[0].TaskRun.Status); d != "" {
+		t.Errorf("Unexpected status of cached TaskRun: %s", diff.PrintWantGot(d))
+	}
+	if !state[0].IsSuccessful() {
+		t.Errorf("expected cached task to be successful")
+	}
+	if state[1].TaskRun != nil {
+		t.Errorf("expected the TaskRun of test not to be set from the status but got %v", state[1].TaskRun)
+	}
+	if state[2].TaskRun != nil {
+		t.Errorf("expected deploy not to have a TaskRun but got %v", state[2].TaskRun)
+	}
+}
+
+func TestPipelineRunState_GetChildPipelineRunsStatus(t *testing.T) {
+	childTask := v1beta1.PipelineTask{
+		Name:        "child",
+		PipelineRef: &v1beta1.PipelineRef{Name: "child"},
+	}
+	notStartedTask := v1beta1.PipelineTask{
+		Name:        "not-started",
+		PipelineRef: &v1beta1.PipelineRef{Name: "child"},
+	}
+	child := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-child"}}
+	child.Status.SetCondition(&apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: v1beta1.PipelineRunReasonRunning.String(),
+	})
+	state := PipelineRunState{{
+		PipelineTask:         &childTask,
+		ChildPipelineRunName: "pipelinerun-child",
+		ChildPipelineRun:     child,
+	}, {
+		PipelineTask:         &notStartedTask,
+		ChildPipelineRunName: "pipelinerun-not-started",
+	}, {
+		PipelineTask: &pts[0],
+		TaskRunName:  "pipelinerun-mytask1",
+	}}
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"}}
+
+	got := state.GetChildPipelineRunsStatus(pr)
+	want := map[string]*v1beta1.PipelineRunChildPipelineRunStatus{
+		"pipelinerun-child": {
+			PipelineTaskName: "child",
+			Condition:        child.Status.GetCondition(apis.ConditionSucceeded),
+		},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected child PipelineRuns status: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineRunState_GetChildPipelineRunResults(t *testing.T) {
+	childPipelineRun := func(name string, status corev1.ConditionStatus) *v1beta1.PipelineRun {
+		child := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		child.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: status})
+		child.Status.PipelineResults = []v1beta1.PipelineRunResult{{Name: "foo", Value: name}}
+		return child
+	}
+	state := PipelineRunState{{
+		PipelineTask:         &v1beta1.PipelineTask{Name: "succeeded", PipelineRef: &v1beta1.PipelineRef{Name: "child"}},
+		ChildPipelineRunName: "pipelinerun-succeeded",
+		ChildPipelineRun:     childPipelineRun("pipelinerun-succeeded", corev1.ConditionTrue),
+	}, {
+		PipelineTask:         &v1beta1.PipelineTask{Name: "failed", PipelineRef: &v1beta1.PipelineRef{Name: "child"}},
+		ChildPipelineRunName: "pipelinerun-failed",
+		ChildPipelineRun:     childPipelineRun("pipelinerun-failed", corev1.ConditionFalse),
+	}, {
+		PipelineTask:         &v1beta1.PipelineTask{Name: "not-started", PipelineRef: &v1beta1.PipelineRef{Name: "child"}},
+		ChildPipelineRunName: "pipelinerun-not-started",
+	}}
+
+	want := map[string][]v1beta1.PipelineRunResult{
+		"succeeded": {{Name: "foo", Value: "pipelinerun-succeeded"}},
+	}
+	if d := cmp.Diff(want, state.GetChildPipelineRunResults()); d != "" {
+		t.Errorf("Unexpected child PipelineRun results: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineRunFacts_Approval(t *testing.T) {
+	approvalTask := func(onTimeout string) v1beta1.PipelineTask {
+		return v1beta1.PipelineTask{
+			Name: "approve",
+			Approval: &v1beta1.Approval{
+				Approvers: []v1beta1.Approver{{Kind: v1beta1.ApproverKindUser, Name: "alice"}},
+				OnTimeout: onTimeout,
+			},
+		}
+	}
+	deployTask := v1beta1.PipelineTask{
+		Name:     "deploy",
+		TaskRef:  &v1beta1.TaskRef{Name: "task"},
+		RunAfter: []string{"approve"},
+	}
+	makeState := func(onTimeout string, state v1beta1.ApprovalState) PipelineRunState {
+		pt := approvalTask(onTimeout)
+		rprt := &ResolvedPipelineRunTask{PipelineTask: &pt}
+		if state != "" {
+			rprt.Approval = &v1beta1.PipelineTaskApprovalStatus{Name: "approve", State: state}
+		}
+		return PipelineRunState{rprt, {
+			PipelineTask: &deployTask,
+			TaskRunName:  "pipelinerun-deploy",
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}}
+	}
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"}}
+
+	tcs := []struct {
+		name               string
+		state              PipelineRunState
+		expectedQueue      []string
+		expectedStatus     corev1.ConditionStatus
+		expectedReason     string
+		expectedSucceeded  int
+		expectedFailed     int
+		expectedSkipped    int
+		expectedIncomplete int
+		expectedSkipReason SkippingReason
+	}{{
+		name:               "approval not started",
+		state:              makeState("", ""),
+		expectedQueue:      []string{"approve"},
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1beta1.PipelineRunReasonRunning.String(),
+		expectedIncomplete: 2,
+		expectedSkipReason: None,
+	}, {
+		name:               "approval pending",
+		state:              makeState("", v1beta1.ApprovalStatePending),
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1beta1.PipelineRunReasonRunning.String(),
+		expectedIncomplete: 2,
+		expectedSkipReason: None,
+	}, {
+		name:               "approved",
+		state:              makeState("", v1beta1.ApprovalStateApproved),
+		expectedQueue:      []string{"deploy"},
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1beta1.PipelineRunReasonRunning.String(),
+		expectedSucceeded:  1,
+		expectedIncomplete: 1,
+		expectedSkipReason: None,
+	}, {
+		name:               "rejected",
+		state:              makeState("", v1beta1.ApprovalStateRejected),
+		expectedStatus:     corev1.ConditionFalse,
+		expectedReason:     v1beta1.PipelineRunReasonFailed.String(),
+		expectedFailed:     1,
+		expectedSkipped:    1,
+		expectedSkipReason: None,
+	}, {
+		name:               "timed out and failed",
+		state:              makeState(v1beta1.ApprovalOnTimeoutFail, v1beta1.ApprovalStateTimedOut),
+		expectedStatus:     corev1.ConditionFalse,
+		expectedReason:     v1beta1.PipelineRunReasonFailed.String(),
+		expectedFailed:     1,
+		expectedSkipped:    1,
+		expectedSkipReason: None,
+	}, {
+		name:               "timed out and skipped",
+		state:              makeState(v1beta1.ApprovalOnTimeoutSkip, v1beta1.ApprovalStateTimedOut),
+		expectedStatus:     corev1.ConditionTrue,
+		expectedReason:     v1beta1.PipelineRunReasonCompleted.String(),
+		expectedSkipped:    2,
+		expectedSkipReason: ApprovalTimedOutSkip,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dagFromState(tc.state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", tc.state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+			}
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Fatalf("Unexpected error getting DAG execution queue: %v", err)
+			}
+			var queueNames []string
+			for _, rprt := range queue {
+				queueNames = append(queueNames, rprt.PipelineTask.Name)
+			}
+			if d := cmp.Diff(tc.expectedQueue, queueNames); d != "" {
+				t.Errorf("Unexpected DAG execution queue: %s", diff.PrintWantGot(d))
+			}
+			if got := tc.state[0].Skip(&facts).SkippingReason; got != tc.expectedSkipReason {
+				t.Errorf("Expected approval to be skipped for %q but got %q", tc.expectedSkipReason, got)
+			}
+
+			c := facts.GetPipelineConditionStatus(pr, zap.NewNop().Sugar())
+			wantCondition := &apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: tc.expectedStatus,
+				Reason: tc.expectedReason,
+				Message: getExpectedMessage(pr.Name, "", tc.expectedStatus,
+					tc.expectedSucceeded, tc.expectedIncomplete, tc.expectedSkipped, tc.expectedFailed, 0),
+			}
+			if d := cmp.Diff(wantCondition, c); d != "" {
+				t.Errorf("Unexpected pipeline condition: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunState_GetApprovalsStatus(t *testing.T) {
+	approval := &v1beta1.Approval{Approvers: []v1beta1.Approver{{Kind: v1beta1.ApproverKindGroup, Name: "release-managers"}}}
+	approveStaging := v1beta1.PipelineTask{Name: "approve-staging", Approval: approval}
+	approveProd := v1beta1.PipelineTask{Name: "approve-prod", Approval: approval}
+	startTime := metav1.NewTime(time.Now())
+	state := PipelineRunState{{
+		PipelineTask: &approveStaging,
+		Approval: &v1beta1.PipelineTaskApprovalStatus{
+			Name:      "approve-staging",
+			State:     v1beta1.ApprovalStatePending,
+			StartTime: &startTime,
+		},
+	}, {
+		PipelineTask: &approveProd,
+	}, {
+		PipelineTask: &pts[0],
+		TaskRunName:  "pipelinerun-mytask1",
+	}}
+
+	want := []v1beta1.PipelineTaskApprovalStatus{{
+		Name:      "approve-staging",
+		State:     v1beta1.ApprovalStatePending,
+		StartTime: &startTime,
+	}}
+	if d := cmp.Diff(want, state.GetApprovalsStatus()); d != "" {
+		t.Errorf("Unexpected approvals status: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineRunFacts_TaskSpecStatus(t *testing.T) {
+	buildTask := v1beta1.PipelineTask{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "task"}}
+	testTask := v1beta1.PipelineTask{Name: "test", TaskRef: &v1beta1.TaskRef{Name: "task"}, RunAfter: []string{"build"}}
+	lintTask := v1beta1.PipelineTask{Name: "lint", TaskRef: &v1beta1.TaskRef{Name: "task"}}
+	// build is only retried once on a timeout
+	retriedBuildTask := buildTask
+	retriedBuildTask.Retries = 1
+	retriedBuildTask.RetryPolicy = &v1beta1.RetryPolicy{Reasons: []string{v1beta1.TaskRunReasonTimedOut.String()}}
+	buildTaskRun := v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-build"}}
+	makeState := func(specStatus v1beta1.PipelineTaskSpecStatus, build, lint *v1beta1.TaskRun) PipelineRunState {
+		return PipelineRunState{{
+			PipelineTask: &buildTask,
+			TaskRunName:  "pipelinerun-build",
+			TaskRun:      build,
+			SpecStatus:   specStatus,
+		}, {
+			PipelineTask: &testTask,
+			TaskRunName:  "pipelinerun-test",
+		}, {
+			PipelineTask: &lintTask,
+			TaskRunName:  "pipelinerun-lint",
+			TaskRun:      lint,
+		}}
+	}
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"}}
+
+	tcs := []struct {
+		name                string
+		state               PipelineRunState
+		expectedQueue       []string
+		expectedSkipReasons []SkippingReason
+		expectedStatus      corev1.ConditionStatus
+		expectedReason      string
+		expectedSucceeded   int
+		expectedFailed      int
+		expectedCancelled   int
+		expectedSkipped     int
+		expectedIncomplete  int
+	}{{
+		name:                "skipped before it started",
+		state:               makeState(v1beta1.PipelineTaskSpecStatusSkipped, nil, nil),
+		expectedQueue:       []string{"test", "lint"},
+		expectedSkipReasons: []SkippingReason{RequestedSkip, None, None},
+		expectedStatus:      corev1.ConditionUnknown,
+		expectedReason:      v1beta1.PipelineRunReasonRunning.String(),
+		expectedSkipped:     1,
+		expectedIncomplete:  2,
+	}, {
+		name:                "skipped after it started",
+		state:               makeState(v1beta1.PipelineTaskSpecStatusSkipped, makeStarted(buildTaskRun), nil),
+		expectedQueue:       []string{"lint"},
+		expectedSkipReasons: []SkippingReason{None, None, None},
+		expectedStatus:      corev1.ConditionUnknown,
+		expectedReason:      v1beta1.PipelineRunReasonRunning.String(),
+		expectedIncomplete:  3,
+	}, {
+		name:                "cancelled before it started",
+		state:               makeState(v1beta1.PipelineTaskSpecStatusCancelled, nil, nil),
+		expectedQueue:       []string{"lint"},
+		expectedSkipReasons: []SkippingReason{CancelledSkip, ParentTasksSkip, None},
+		expectedStatus:      corev1.ConditionUnknown,
+		expectedReason:      v1beta1.PipelineRunReasonRunning.String(),
+		expectedSkipped:     2,
+		expectedIncomplete:  1,
+	}, {
+		name:                "cancelled while running",
+		state:               makeState(v1beta1.PipelineTaskSpecStatusCancelled, withCancelled(makeFailed(buildTaskRun)), nil),
+		expectedQueue:       []string{"lint"},
+		expectedSkipReasons: []SkippingReason{None, ParentTasksCancelledSkip, None},
+		expectedStatus:      corev1.ConditionUnknown,
+		expectedReason:      v1beta1.PipelineRunReasonRunning.String(),
+		expectedCancelled:   1,
+		expectedSkipped:     1,
+		expectedIncomplete:  1,
+	}, {
+		name: "cancelled while running and other tasks done",
+		state: makeState(v1beta1.PipelineTaskSpecStatusCancelled, withCancelled(makeFailed(buildTaskRun)),
+			makeSucceeded(v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-lint"}})),
+		expectedSkipReasons: []SkippingReason{None, ParentTasksCancelledSkip, None},
+		expectedStatus:      corev1.ConditionTrue,
+		expectedReason:      v1beta1.PipelineRunReasonCompleted.String(),
+		expectedSucceeded:   1,
+		expectedCancelled:   1,
+		expectedSkipped:     1,
+	}, {
+		name:                "retry requested",
+		state:               makeState(v1beta1.PipelineTaskSpecStatusRetry, makeFailed(buildTaskRun), nil),
+		expectedQueue:       []string{"build", "lint"},
+		expectedSkipReasons: []SkippingReason{None, None, None},
+		expectedStatus:      corev1.ConditionUnknown,
+		expectedReason:      v1beta1.PipelineRunReasonRunning.String(),
+		expectedIncomplete:  3,
+	}, {
+		name:                "retried on request and failed again",
+		state:               makeState(v1beta1.PipelineTaskSpecStatusRetry, withRetries(makeFailed(buildTaskRun)), nil),
+		expectedSkipReasons: []SkippingReason{None, IsStoppingSkip, IsStoppingSkip},
+		expectedStatus:      corev1.ConditionFalse,
+		expectedReason:      v1beta1.PipelineRunReasonFailed.String(),
+		expectedFailed:      1,
+		expectedSkipped:     2,
+	}, {
+		name: "retry requested before the retries of the task are exhausted",
+		state: func() PipelineRunState {
+			state := makeState(v1beta1.PipelineTaskSpecStatusRetry, makeFailed(buildTaskRun), nil)
+			state[0].PipelineTask = &retriedBuildTask
+			return state
+		}(),
+		expectedSkipReasons: []SkippingReason{None, IsStoppingSkip, IsStoppingSkip},
+		expectedStatus:      corev1.ConditionFalse,
+		expectedReason:      v1beta1.PipelineRunReasonFailed.String(),
+		expectedFailed:      1,
+		expectedSkipped:     2,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dagFromState(tc.state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", tc.state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+			}
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Fatalf("Unexpected error getting DAG execution queue: %v", err)
+			}
+			var queueNames []string
+			for _, rprt := range queue {
+				if !rprt.Skip(&facts).IsSkipped {
+					queueNames = append(queueNames, rprt.PipelineTask.Name)
+				}
+			}
+			if d := cmp.Diff(tc.expectedQueue, queueNames); d != "" {
+				t.Errorf("Unexpected DAG execution queue: %s", diff.PrintWantGot(d))
+			}
+			var skipReasons []SkippingReason
+			for _, rprt := range tc.state {
+				skipReasons = append(skipReasons, rprt.Skip(&facts).SkippingReason)
+			}
+			if d := cmp.Diff(tc.expectedSkipReasons, skipReasons); d != "" {
+				t.Errorf("Unexpected skipping reasons: %s", diff.PrintWantGot(d))
+			}
+
+			c := facts.GetPipelineConditionStatus(pr, zap.NewNop().Sugar())
+			wantCondition := &apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: tc.expectedStatus,
+				Reason: tc.expectedReason,
+				Message: getExpectedMessage(pr.Name, "", tc.expectedStatus,
+					tc.expectedSucceeded, tc.expectedIncomplete, tc.expectedSkipped, tc.expectedFailed, tc.expectedCancelled),
+			}
+			if d := cmp.Diff(wantCondition, c); d != "" {
+				t.Errorf("Unexpected pipeline condition: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package resources
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 
@@ -35,6 +36,7 @@ type ResolvedResultRef struct {
 	ResultReference v1beta1.ResultRef
 	FromTaskRun     string
 	FromRun         string
+	FromPipelineRun string
 }
 
 // ResolveResultRef resolves any ResultReference that are found in the target ResolvedPipelineRunTask
@@ -134,19 +136,29 @@ func resolveResultRef(pipelineState PipelineRunState, resultRef *v1beta1.ResultR
 	if referencedPipelineTask == nil {
 		return nil, resultRef.PipelineTask, fmt.Errorf("could not find task %q referenced by result", resultRef.PipelineTask)
 	}
+	if referencedPipelineTask.IsMatrixed() {
+		return nil, resultRef.PipelineTask, fmt.Errorf("task %q referenced by result is matrixed", referencedPipelineTask.PipelineTask.Name)
+	}
 	if !referencedPipelineTask.IsSuccessful() {
 		return nil, resultRef.PipelineTask, fmt.Errorf("task %q referenced by result was not successful", referencedPipelineTask.PipelineTask.Name)
 	}
 
-	var runName, taskRunName, resultValue string
+	var runName, taskRunName, pipelineRunName, resultValue string
 	var err error
-	if referencedPipelineTask.IsCustomTask() {
+	switch {
+	case referencedPipelineTask.RunsPipeline():
+		pipelineRunName = referencedPipelineTask.ChildPipelineRun.Name
+		resultValue, err = findPipelineRunResultForParam(referencedPipelineTask.ChildPipelineRun, resultRef)
+		if err != nil {
+			return nil, resultRef.PipelineTask, err
+		}
+	case referencedPipelineTask.IsCustomTask():
 		runName = referencedPipelineTask.Run.Name
 		resultValue, err = findRunResultForParam(referencedPipelineTask.Run, resultRef)
 		if err != nil {
 			return nil, resultRef.PipelineTask, err
 		}
-	} else {
+	default:
 		taskRunName = referencedPipelineTask.TaskRun.Name
 		resultValue, err = findTaskResultForParam(referencedPipelineTask.TaskRun, resultRef)
 		if err != nil {
@@ -154,14 +166,78 @@ func resolveResultRef(pipelineState PipelineRunState, resultRef *v1beta1.ResultR
 		}
 	}
 
+	value, err := newResultValue(referencedPipelineTask, resultRef, resultValue)
+	if err != nil {
+		return nil, resultRef.PipelineTask, err
+	}
+
 	return &ResolvedResultRef{
-		Value:           *v1beta1.NewArrayOrString(resultValue),
+		Value:           value,
 		FromTaskRun:     taskRunName,
 		FromRun:         runName,
+		FromPipelineRun: pipelineRunName,
 		ResultReference: *resultRef,
 	}, "", nil
 }
 
+// newResultValue converts the value emitted for a result into the value of the given ResultRef:
+// the value of the key of an object result, the elements of an array result or the string itself.
+func newResultValue(rprt *ResolvedPipelineRunTask, resultRef *v1beta1.ResultRef, resultValue string) (v1beta1.ArrayOrString, error) {
+	if resultRef.Property != "" {
+		objectVal, err := v1beta1.ParseObjectResult(resultValue)
+		if err != nil {
+			return v1beta1.ArrayOrString{}, fmt.Errorf("invalid object result %s for task %s: %w", resultRef.Result, resultRef.PipelineTask, err)
+		}
+		propertyVal, ok := objectVal[resultRef.Property]
+		if !ok {
+			return v1beta1.ArrayOrString{}, fmt.Errorf("Could not find key %s in object result %s for task %s", resultRef.Property, resultRef.Result, resultRef.PipelineTask)
+		}
+		return *v1beta1.NewArrayOrString(propertyVal), nil
+	}
+	if resultsType, _ := getDeclaredResultsType(rprt, resultRef.Result); resultsType == v1beta1.ResultsTypeArray {
+		arrayVal, err := v1beta1.ParseArrayResult(resultValue)
+		if err != nil {
+			return v1beta1.ArrayOrString{}, fmt.Errorf("invalid array result %s for task %s: %w", resultRef.Result, resultRef.PipelineTask, err)
+		}
+		return v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: arrayVal}, nil
+	}
+	return *v1beta1.NewArrayOrString(resultValue), nil
+}
+
+// getDeclaredResultsType returns the type of the named result as declared by the Task or the Pipeline
+// executed by the given ResolvedPipelineRunTask, or false if the declaration of the result is not available.
+func getDeclaredResultsType(rprt *ResolvedPipelineRunTask, name string) (v1beta1.ResultsType, bool) {
+	switch {
+	case rprt.RunsPipeline():
+		spec := rprt.PipelineTask.PipelineSpec
+		if spec == nil && rprt.ChildPipelineRun != nil {
+			spec = rprt.ChildPipelineRun.Status.PipelineSpec
+		}
+		if spec == nil {
+			return "", false
+		}
+		for _, result := range spec.Results {
+			if result.Name == name {
+				return resultsTypeOrDefault(result.Type), true
+			}
+		}
+	case rprt.ResolvedTaskResources != nil && rprt.ResolvedTaskResources.TaskSpec != nil:
+		for _, result := range rprt.ResolvedTaskResources.TaskSpec.Results {
+			if result.Name == name {
+				return resultsTypeOrDefault(result.Type), true
+			}
+		}
+	}
+	return "", false
+}
+
+func resultsTypeOrDefault(resultsType v1beta1.ResultsType) v1beta1.ResultsType {
+	if resultsType == "" {
+		return v1beta1.ResultsTypeString
+	}
+	return resultsType
+}
+
 func findRunResultForParam(run *v1alpha1.Run, reference *v1beta1.ResultRef) (string, error) {
 	results := run.Status.Results
 	for _, result := range results {
@@ -172,6 +248,16 @@ func findRunResultForParam(run *v1alpha1.Run, reference *v1beta1.ResultRef) (str
 	return "", fmt.Errorf("Could not find result with name %s for task %s", reference.Result, reference.PipelineTask)
 }
 
+func findPipelineRunResultForParam(pipelineRun *v1beta1.PipelineRun, reference *v1beta1.ResultRef) (string, error) {
+	results := pipelineRun.Status.PipelineResults
+	for _, result := range results {
+		if result.Name == reference.Result {
+			return result.Value, nil
+		}
+	}
+	return "", fmt.Errorf("Could not find result with name %s for task %s", reference.Result, reference.PipelineTask)
+}
+
 func findTaskResultForParam(taskRun *v1beta1.TaskRun, reference *v1beta1.ResultRef) (string, error) {
 	results := taskRun.Status.TaskRunStatusFields.TaskRunResults
 	for _, result := range results {
@@ -185,14 +271,42 @@ func findTaskResultForParam(taskRun *v1beta1.TaskRun, reference *v1beta1.ResultR
 func (rs ResolvedResultRefs) getStringReplacements() map[string]string {
 	replacements := map[string]string{}
 	for _, r := range rs {
+		value := r.Value.StringVal
+		if r.Value.Type == v1beta1.ParamTypeArray {
+			// an array result which is not expanded is replaced by its JSON representation
+			b, err := json.Marshal(r.Value.ArrayVal)
+			if err != nil {
+				continue
+			}
+			value = string(b)
+		}
 		for _, target := range r.getReplaceTarget() {
-			replacements[target] = r.Value.StringVal
+			replacements[target] = value
+		}
+	}
+	return replacements
+}
+
+// getArrayReplacements returns the replacements for array results which are expanded into
+// the elements of an array using $(tasks.<taskName>.results.<resultName>[*])
+func (rs ResolvedResultRefs) getArrayReplacements() map[string][]string {
+	replacements := map[string][]string{}
+	for _, r := range rs {
+		if r.Value.Type != v1beta1.ParamTypeArray {
+			continue
 		}
+		target := fmt.Sprintf("%s.%s.%s.%s[*]", v1beta1.ResultTaskPart, r.ResultReference.PipelineTask, v1beta1.ResultResultPart, r.ResultReference.Result)
+		replacements[target] = r.Value.ArrayVal
 	}
 	return replacements
 }
 
 func (r *ResolvedResultRef) getReplaceTarget() []string {
+	if r.ResultReference.Property != "" {
+		return []string{
+			fmt.Sprintf("%s.%s.%s.%s.%s", v1beta1.ResultTaskPart, r.ResultReference.PipelineTask, v1beta1.ResultResultPart, r.ResultReference.Result, r.ResultReference.Property),
+		}
+	}
 	return []string{
 		fmt.Sprintf("%s.%s.%s.%s", v1beta1.ResultTaskPart, r.ResultReference.PipelineTask, v1beta1.ResultResultPart, r.ResultReference.Result),
 		fmt.Sprintf("%s.%s.%s[%q]", v1beta1.ResultTaskPart, r.ResultReference.PipelineTask, v1beta1.ResultResultPart, r.ResultReference.Result),
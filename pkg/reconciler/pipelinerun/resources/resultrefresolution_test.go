@@ -11,6 +11,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -122,6 +123,46 @@ var pipelineRunState = PipelineRunState{{
 	},
 }}
 
+var childPipelineRunState = PipelineRunState{{
+	ChildPipelineRunName: "aPipelineRun",
+	ChildPipelineRun: &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "aPipelineRun"},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{successCondition},
+			},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineResults: []v1beta1.PipelineRunResult{{
+					Name:  "aResult",
+					Value: "aResultValue",
+				}},
+			},
+		},
+	},
+	PipelineTask: &v1beta1.PipelineTask{
+		Name:        "aPipelineTask",
+		PipelineRef: &v1beta1.PipelineRef{Name: "aPipeline"},
+	},
+}, {
+	PipelineTask: &v1beta1.PipelineTask{
+		Name:    "bTask",
+		TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+		Params: []v1beta1.Param{{
+			Name:  "bParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aPipelineTask.results.aResult)"),
+		}},
+	},
+}, {
+	PipelineTask: &v1beta1.PipelineTask{
+		Name:    "bTask",
+		TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+		Params: []v1beta1.Param{{
+			Name:  "bParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aPipelineTask.results.missingResult)"),
+		}},
+	},
+}}
+
 func TestTaskParamResolver_ResolveResultRefs(t *testing.T) {
 
 	for _, tt := range []struct {
@@ -630,6 +671,26 @@ func TestResolveResultRef(t *testing.T) {
 			FromRun: "aRun",
 		}},
 		wantErr: false,
+	}, {
+		name:             "Test successful result references resolution - params - child PipelineRun",
+		pipelineRunState: childPipelineRunState,
+		target:           childPipelineRunState[1],
+		want: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("aResultValue"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aPipelineTask",
+				Result:       "aResult",
+			},
+			FromPipelineRun: "aPipelineRun",
+		}},
+		wantErr: false,
+	}, {
+		name:             "Test unsuccessful result references resolution - params - child PipelineRun",
+		pipelineRunState: childPipelineRunState,
+		target:           childPipelineRunState[2],
+		want:             nil,
+		wantErr:          true,
+		wantPt:           "aPipelineTask",
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			got, pt, err := ResolveResultRef(tt.pipelineRunState, tt.target)
@@ -657,3 +718,92 @@ func TestResolveResultRef(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveResultRef_TypedResults(t *testing.T) {
+	typedResultsState := PipelineRunState{{
+		TaskRunName: "aTaskRun",
+		TaskRun: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "aTaskRun"},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{successCondition},
+				},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					TaskRunResults: []v1beta1.TaskRunResult{{
+						Name:  "anArray",
+						Value: `["a","b"]`,
+					}, {
+						Name:  "anObject",
+						Value: `{"url":"https://example.com","digest":"sha256:abc"}`,
+					}},
+				},
+			},
+		},
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "aTask",
+			TaskRef: &v1beta1.TaskRef{Name: "aTask"},
+		},
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskSpec: &v1beta1.TaskSpec{
+				Results: []v1beta1.TaskResult{{
+					Name: "anArray",
+					Type: v1beta1.ResultsTypeArray,
+				}, {
+					Name: "anObject",
+					Type: v1beta1.ResultsTypeObject,
+				}},
+			},
+		},
+	}}
+
+	for _, tt := range []struct {
+		name    string
+		param   v1beta1.Param
+		want    ResolvedResultRefs
+		wantErr bool
+	}{{
+		name:  "array result",
+		param: v1beta1.Param{Name: "p", Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.anArray[*])", "c")},
+		want: ResolvedResultRefs{{
+			Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"a", "b"}},
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "anArray",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+	}, {
+		name:  "object result property",
+		param: v1beta1.Param{Name: "p", Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.anObject.digest)")},
+		want: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("sha256:abc"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "anObject",
+				Property:     "digest",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+	}, {
+		name:    "missing object result property",
+		param:   v1beta1.Param{Name: "p", Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.anObject.missing)")},
+		wantErr: true,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ResolvedPipelineRunTask{
+				PipelineTask: &v1beta1.PipelineTask{
+					Name:    "bTask",
+					TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+					Params:  []v1beta1.Param{tt.param},
+				},
+			}
+			got, _, err := ResolveResultRef(typedResultsState, target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveResultRef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Fatalf("ResolveResultRef %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -35,10 +35,30 @@ func ValidatePipelineTaskResults(state PipelineRunState) error {
 				return fmt.Errorf("invalid result reference in pipeline task %q: %s", rprt.PipelineTask.Name, err)
 			}
 		}
+		for _, ref := range pipelineTaskArrayResultRefs(rprt.PipelineTask) {
+			if err := validateResultRefType(ref, v1beta1.ResultsTypeArray, ptMap); err != nil {
+				return fmt.Errorf("invalid result reference in pipeline task %q: %s", rprt.PipelineTask.Name, err)
+			}
+		}
 	}
 	return nil
 }
 
+// pipelineTaskArrayResultRefs returns the references which expand array results in the params and
+// when expressions of a PipelineTask e.g. $(tasks.<taskName>.results.<resultName>[*])
+func pipelineTaskArrayResultRefs(pt *v1beta1.PipelineTask) []*v1beta1.ResultRef {
+	var refs []*v1beta1.ResultRef
+	for _, p := range pt.Params {
+		expressions, _ := v1beta1.GetVarSubstitutionExpressionsForParam(p)
+		refs = append(refs, v1beta1.NewArrayResultRefs(expressions)...)
+	}
+	for _, we := range pt.WhenExpressions {
+		expressions, _ := we.GetVarSubstitutionExpressions()
+		refs = append(refs, v1beta1.NewArrayResultRefs(expressions)...)
+	}
+	return refs
+}
+
 // ValidatePipelineResults ensures that any result references used by PipelineResults
 // resolve to valid results. This prevents a situation where a PipelineResult references
 // a result in a PipelineTask that doesn't exist or where the user has either misspelled
@@ -47,11 +67,16 @@ func ValidatePipelineResults(ps *v1beta1.PipelineSpec, state PipelineRunState) e
 	ptMap := state.ToMap()
 	for _, result := range ps.Results {
 		expressions, _ := v1beta1.GetVarSubstitutionExpressionsForPipelineResult(result)
-		refs := v1beta1.NewResultRefs(expressions)
+		refs := v1beta1.NewPipelineResultRefs(expressions)
 		for _, ref := range refs {
 			if err := validateResultRef(ref, ptMap); err != nil {
 				return fmt.Errorf("invalid pipeline result %q: %s", result.Name, err)
 			}
+			if result.Type == v1beta1.ResultsTypeArray || result.Type == v1beta1.ResultsTypeObject {
+				if err := validateResultRefType(ref, result.Type, ptMap); err != nil {
+					return fmt.Errorf("invalid pipeline result %q: %s", result.Name, err)
+				}
+			}
 		}
 	}
 	return nil
@@ -71,21 +96,58 @@ func validateResultRef(ref *v1beta1.ResultRef, ptMap map[string]*ResolvedPipelin
 		// custom task executes.
 		return nil
 	}
-	if ptMap[ref.PipelineTask].ResolvedTaskResources == nil || ptMap[ref.PipelineTask].ResolvedTaskResources.TaskSpec == nil {
-		return fmt.Errorf("unable to validate result referencing pipeline task %q: task spec not found", ref.PipelineTask)
-	}
-	for _, taskResult := range ptMap[ref.PipelineTask].ResolvedTaskResources.TaskSpec.Results {
-		if taskResult.Name == ref.Result {
-			taskProvidesResult = true
-			break
+	if ptMap[ref.PipelineTask].RunsPipeline() {
+		if err := validatePipelineResultRef(ref, ptMap[ref.PipelineTask].PipelineTask); err != nil {
+			return err
+		}
+	} else {
+		if ptMap[ref.PipelineTask].ResolvedTaskResources == nil || ptMap[ref.PipelineTask].ResolvedTaskResources.TaskSpec == nil {
+			return fmt.Errorf("unable to validate result referencing pipeline task %q: task spec not found", ref.PipelineTask)
+		}
+		for _, taskResult := range ptMap[ref.PipelineTask].ResolvedTaskResources.TaskSpec.Results {
+			if taskResult.Name == ref.Result {
+				taskProvidesResult = true
+				break
+			}
+		}
+		if !taskProvidesResult {
+			return fmt.Errorf("%q is not a named result returned by pipeline task %q", ref.Result, ref.PipelineTask)
 		}
 	}
-	if !taskProvidesResult {
-		return fmt.Errorf("%q is not a named result returned by pipeline task %q", ref.Result, ref.PipelineTask)
+	if ref.Property != "" {
+		return validateResultRefType(ref, v1beta1.ResultsTypeObject, ptMap)
 	}
 	return nil
 }
 
+// validateResultRefType ensures that the result referenced by the ResultRef is declared with the given type.
+// Results whose declaration is not available, such as the results of custom tasks, are not validated.
+func validateResultRefType(ref *v1beta1.ResultRef, resultsType v1beta1.ResultsType, ptMap map[string]*ResolvedPipelineRunTask) error {
+	rprt, ok := ptMap[ref.PipelineTask]
+	if !ok {
+		return fmt.Errorf("referenced pipeline task %q does not exist", ref.PipelineTask)
+	}
+	if declared, ok := getDeclaredResultsType(rprt, ref.Result); ok && declared != resultsType {
+		return fmt.Errorf("%q returned by pipeline task %q is of type %q but is used as type %q", ref.Result, ref.PipelineTask, declared, resultsType)
+	}
+	return nil
+}
+
+// validatePipelineResultRef validates a ResultRef pointing to a pipeline task which runs a Pipeline.
+// Results can only be validated for an embedded pipelineSpec since a referenced Pipeline is
+// resolved by the reconciler of the child PipelineRun.
+func validatePipelineResultRef(ref *v1beta1.ResultRef, pt *v1beta1.PipelineTask) error {
+	if pt.PipelineSpec == nil {
+		return nil
+	}
+	for _, pipelineResult := range pt.PipelineSpec.Results {
+		if pipelineResult.Name == ref.Result {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not a named result returned by pipeline task %q", ref.Result, ref.PipelineTask)
+}
+
 // ValidateOptionalWorkspaces validates that any workspaces in the Pipeline that are
 // marked as optional are also marked optional in the Tasks that receive them. This
 // prevents a situation where a Task requires a workspace but a Pipeline does not offer
@@ -99,6 +161,10 @@ func ValidateOptionalWorkspaces(pipelineWorkspaces []v1beta1.PipelineWorkspaceDe
 	}
 
 	for _, rprt := range state {
+		if rprt.ResolvedTaskResources == nil || rprt.ResolvedTaskResources.TaskSpec == nil {
+			// the workspaces of custom tasks and child PipelineRuns can't be validated here
+			continue
+		}
 		for _, pws := range rprt.PipelineTask.Workspaces {
 			if optionalWorkspaces.Has(pws.Workspace) {
 				for _, tws := range rprt.ResolvedTaskResources.TaskSpec.Workspaces {
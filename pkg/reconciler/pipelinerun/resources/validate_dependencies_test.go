@@ -86,6 +86,43 @@ func TestValidatePipelineTaskResults_ValidStates(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		desc: "correct use of results of a pipeline task running a pipelineSpec",
+		state: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt1",
+				PipelineSpec: &v1beta1.PipelineSpec{
+					Results: []v1beta1.PipelineResult{{
+						Name:  "result",
+						Value: "$(tasks.foo.results.bar)",
+					}},
+				},
+			},
+		}, {
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt2",
+				Params: []v1beta1.Param{{
+					Name:  "p",
+					Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.result)"),
+				}},
+			},
+		}},
+	}, {
+		desc: "results of a pipeline task running a pipelineRef are not validated",
+		state: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:        "pt1",
+				PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			},
+		}, {
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt2",
+				Params: []v1beta1.Param{{
+					Name:  "p",
+					Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.a-child-pipeline-result)"),
+				}},
+			},
+		}},
 	}} {
 		t.Run(tc.desc, func(t *testing.T) {
 			if err := ValidatePipelineTaskResults(tc.state); err != nil {
@@ -193,6 +230,27 @@ func TestValidatePipelineTaskResults_IncorrectResultName(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		desc: "invalid result reference to a pipeline task running a pipelineSpec",
+		state: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt1",
+				PipelineSpec: &v1beta1.PipelineSpec{
+					Results: []v1beta1.PipelineResult{{
+						Name:  "not-the-result-youre-looking-for",
+						Value: "$(tasks.foo.results.bar)",
+					}},
+				},
+			},
+		}, {
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt2",
+				Params: []v1beta1.Param{{
+					Name:  "p1",
+					Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.result1)"),
+				}},
+			},
+		}},
 	}, {
 		desc: "invalid result reference in when expression",
 		state: PipelineRunState{pt1, {
@@ -244,6 +302,87 @@ func TestValidatePipelineTaskResults_MissingTaskSpec(t *testing.T) {
 	}
 }
 
+// TestValidatePipelineTaskResults_IncorrectResultType tests that a result variable which
+// uses a result as an array or object of another type is caught by the validatePipelineTaskResults func.
+func TestValidatePipelineTaskResults_IncorrectResultType(t *testing.T) {
+	pt1 := &ResolvedPipelineRunTask{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name: "pt1",
+		},
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskName: "t",
+			TaskSpec: &v1beta1.TaskSpec{
+				Results: []v1beta1.TaskResult{{
+					Name: "result1",
+				}, {
+					Name: "array-result",
+					Type: v1beta1.ResultsTypeArray,
+				}, {
+					Name: "object-result",
+					Type: v1beta1.ResultsTypeObject,
+				}},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc    string
+		state   PipelineRunState
+		wantErr string
+	}{{
+		desc: "correct use of array and object results",
+		state: PipelineRunState{pt1, {
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt2",
+				Params: []v1beta1.Param{{
+					Name:  "p1",
+					Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.array-result[*])", "foo"),
+				}, {
+					Name:  "p2",
+					Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.object-result.key)"),
+				}},
+			},
+		}},
+	}, {
+		desc: "string result expanded as an array",
+		state: PipelineRunState{pt1, {
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt2",
+				Params: []v1beta1.Param{{
+					Name:  "p1",
+					Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.result1[*])", "foo"),
+				}},
+			},
+		}},
+		wantErr: `"result1" returned by pipeline task "pt1" is of type "string" but is used as type "array"`,
+	}, {
+		desc: "key of an array result in when expression",
+		state: PipelineRunState{pt1, {
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt2",
+				WhenExpressions: []v1beta1.WhenExpression{{
+					Input:    "$(tasks.pt1.results.array-result.key)",
+					Operator: selection.In,
+					Values:   []string{"foo"},
+				}},
+			},
+		}},
+		wantErr: `"array-result" returned by pipeline task "pt1" is of type "array" but is used as type "object"`,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidatePipelineTaskResults(tc.state)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected error containing %q but got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
 // TestValidatePipelineResults_ValidStates tests that a pipeline results with
 // valid content and result variables do not trigger a validation error.
 func TestValidatePipelineResults_ValidStates(t *testing.T) {
@@ -281,6 +420,27 @@ func TestValidatePipelineResults_ValidStates(t *testing.T) {
 				},
 			},
 		}},
+	}, {
+		desc: "correct use of final task and result names",
+		spec: &v1beta1.PipelineSpec{
+			Results: []v1beta1.PipelineResult{{
+				Name:  "foo-result",
+				Value: "$(finally.final1.results.result1)",
+			}},
+		},
+		state: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "final1",
+			},
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskName: "t",
+				TaskSpec: &v1beta1.TaskSpec{
+					Results: []v1beta1.TaskResult{{
+						Name: "result1",
+					}},
+				},
+			},
+		}},
 	}} {
 		t.Run(tc.desc, func(t *testing.T) {
 			if err := ValidatePipelineResults(tc.spec, tc.state); err != nil {
@@ -17,10 +17,13 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/list"
+	"github.com/tektoncd/pipeline/pkg/matrix"
 )
 
 // ValidateParamTypesMatching validate that parameters in PipelineRun override corresponding parameters in Pipeline of the same type.
@@ -73,3 +76,27 @@ func ValidateRequiredParametersProvided(pipelineParameters *[]v1beta1.ParamSpec,
 	}
 	return nil
 }
+
+// ValidateObjectParamRequiredKeys validates that the object parameters provided by the PipelineRun provide
+// all the keys declared in the properties of the corresponding Pipeline parameters. A key which is not
+// provided is only allowed if the default value of the parameter provides it.
+func ValidateObjectParamRequiredKeys(pipelineParameters []v1beta1.ParamSpec, pipelineRunParameters []v1beta1.Param) error {
+	missings := v1beta1.MissingKeysObjectParamNames(pipelineParameters, pipelineRunParameters)
+	if len(missings) != 0 {
+		return fmt.Errorf("PipelineRun missing object keys for parameters: %v", missings)
+	}
+	return nil
+}
+
+// ValidateMatrixCombinationsCount validates that the matrixed PipelineTasks don't fan out into more
+// combinations than allowed by the defaults, once the parameters referenced in their matrix are
+// substituted.
+func ValidateMatrixCombinationsCount(ctx context.Context, p *v1beta1.PipelineSpec) error {
+	maxCombinations := config.FromContextOrDefaults(ctx).Defaults.DefaultMaxMatrixCombinationsCount
+	for _, pt := range append(append([]v1beta1.PipelineTask{}, p.Tasks...), p.Finally...) {
+		if count := matrix.Count(pt.Matrix); count > maxCombinations {
+			return fmt.Errorf("PipelineTask %s fans out into %d combinations, more than the maximum of %d", pt.Name, count, maxCombinations)
+		}
+	}
+	return nil
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"testing"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -188,3 +190,92 @@ func TestValidateRequiredParametersProvided_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateObjectParamRequiredKeys(t *testing.T) {
+	properties := map[string]v1beta1.PropertySpec{"url": {}, "revision": {}}
+	for _, tc := range []struct {
+		name    string
+		pp      []v1beta1.ParamSpec
+		prp     []v1beta1.Param
+		wantErr bool
+	}{{
+		name: "all keys provided",
+		pp: []v1beta1.ParamSpec{
+			{Name: "gitrepo", Type: v1beta1.ParamTypeObject, Properties: properties},
+		},
+		prp: []v1beta1.Param{
+			{Name: "gitrepo", Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "main"})},
+		},
+	}, {
+		name: "missing keys provided by the default",
+		pp: []v1beta1.ParamSpec{
+			{Name: "gitrepo", Type: v1beta1.ParamTypeObject, Properties: properties, Default: v1beta1.NewObject(map[string]string{"revision": "main"})},
+		},
+		prp: []v1beta1.Param{
+			{Name: "gitrepo", Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"})},
+		},
+	}, {
+		name: "object param not provided",
+		pp: []v1beta1.ParamSpec{
+			{Name: "gitrepo", Type: v1beta1.ParamTypeObject, Properties: properties},
+		},
+		prp: []v1beta1.Param{
+			{Name: "another-param", Value: *v1beta1.NewArrayOrString("foo")},
+		},
+	}, {
+		name: "missing keys",
+		pp: []v1beta1.ParamSpec{
+			{Name: "gitrepo", Type: v1beta1.ParamTypeObject, Properties: properties},
+		},
+		prp: []v1beta1.Param{
+			{Name: "gitrepo", Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"})},
+		},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateObjectParamRequiredKeys(tc.pp, tc.prp)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateObjectParamRequiredKeys() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMatrixCombinationsCount(t *testing.T) {
+	cfg := config.FromContextOrDefaults(context.Background())
+	cfg.Defaults.DefaultMaxMatrixCombinationsCount = 4
+	ctx := config.ToContext(context.Background(), cfg)
+	for _, tc := range []struct {
+		name    string
+		matrix  []v1beta1.Param
+		wantErr bool
+	}{{
+		name: "combinations within the maximum",
+		matrix: []v1beta1.Param{
+			{Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac")},
+			{Name: "browser", Value: *v1beta1.NewArrayOrString("chrome", "safari")},
+		},
+	}, {
+		name: "combinations beyond the maximum",
+		matrix: []v1beta1.Param{
+			{Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac", "windows")},
+			{Name: "browser", Value: *v1beta1.NewArrayOrString("chrome", "safari")},
+		},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "build"}}},
+				Finally: []v1beta1.PipelineTask{{
+					Name:    "test",
+					TaskRef: &v1beta1.TaskRef{Name: "test"},
+					Matrix:  tc.matrix,
+				}},
+			}
+			err := ValidateMatrixCombinationsCount(ctx, p)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateMatrixCombinationsCount() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
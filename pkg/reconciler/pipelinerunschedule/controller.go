@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerunschedule
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	pipelinerunscheduleinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/pipelinerunschedule"
+	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun"
+	pipelinerunschedulereconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/pipelinerunschedule"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+)
+
+// NewController instantiates a new controller.Impl from knative.dev/pkg/controller
+func NewController() func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		pipelineRunScheduleInformer := pipelinerunscheduleinformer.Get(ctx)
+		pipelineRunInformer := pipelineruninformer.Get(ctx)
+
+		c := &Reconciler{
+			PipelineClientSet: pipelineclient.Get(ctx),
+			pipelineRunLister: pipelineRunInformer.Lister(),
+		}
+		impl := pipelinerunschedulereconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
+			return controller.Options{
+				AgentName: pipeline.PipelineRunScheduleControllerName,
+			}
+		})
+
+		pipelineRunScheduleInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+		// Completed PipelineRuns update the active PipelineRuns and the history of their schedule
+		pipelineRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.FilterController(&v1alpha1.PipelineRunSchedule{}),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		})
+
+		return impl
+	}
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerunschedule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	pipelinerunschedulereconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/pipelinerunschedule"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/cron"
+	jsonpatch "gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+// ScheduleTimeContext is the variable replaced by the time a PipelineRun is scheduled at
+// in the values of its params.
+const ScheduleTimeContext = "context.schedule.time"
+
+// maxMissedSchedules is the number of missed times of a schedule beyond which a
+// PipelineRunSchedule stops creating PipelineRuns until a starting deadline skips them.
+const maxMissedSchedules = 100
+
+var errTooManyMissedSchedules = fmt.Errorf("more than %d times of the schedule were missed", maxMissedSchedules)
+
+var cancelPipelineRunPatchBytes []byte
+
+func init() {
+	var err error
+	cancelPipelineRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{{
+		Operation: "add",
+		Path:      "/spec/status",
+		Value:     v1beta1.PipelineRunSpecStatusCancelled,
+	}})
+	if err != nil {
+		log.Fatalf("failed to marshal PipelineRun cancel patch bytes: %v", err)
+	}
+}
+
+// Reconciler implements controller.Reconciler for PipelineRunSchedule resources.
+type Reconciler struct {
+	PipelineClientSet clientset.Interface
+
+	pipelineRunLister listers.PipelineRunLister
+}
+
+// Check that our Reconciler implements pipelinerunschedulereconciler.Interface
+var _ pipelinerunschedulereconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind creates the PipelineRun of the latest time of the schedule which has passed since
+// the last PipelineRun was scheduled, if any, and deletes the completed PipelineRuns exceeding the
+// history limits. It then requeues the PipelineRunSchedule for the next time of the schedule.
+func (c *Reconciler) ReconcileKind(ctx context.Context, s *v1alpha1.PipelineRunSchedule) pkgreconciler.Event {
+	logger := logging.FromContext(ctx)
+	s.Status.InitializeConditions()
+
+	schedule, location, err := parseSchedule(s)
+	if err != nil {
+		s.Status.MarkNotReady(v1alpha1.PipelineRunScheduleReasonInvalid,
+			"PipelineRunSchedule %s/%s has an invalid schedule: %v", s.Namespace, s.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
+	active, err := c.updateHistory(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	if s.Spec.Suspend {
+		s.Status.MarkNotReady(v1alpha1.PipelineRunScheduleReasonSuspended,
+			"PipelineRunSchedule %s/%s is suspended", s.Namespace, s.Name)
+		s.Status.NextScheduleTime = nil
+		return nil
+	}
+
+	now := time.Now().In(location)
+	earliest := s.CreationTimestamp.Time
+	if s.Status.LastScheduleTime != nil {
+		earliest = s.Status.LastScheduleTime.Time
+	}
+	if s.Spec.StartingDeadlineSeconds != nil {
+		if deadline := now.Add(-time.Duration(*s.Spec.StartingDeadlineSeconds) * time.Second); deadline.After(earliest) {
+			earliest = deadline
+		}
+	}
+	scheduled, err := getMostRecentScheduleTime(schedule, earliest.In(location), now)
+	if err != nil {
+		s.Status.MarkNotReady(v1alpha1.PipelineRunScheduleReasonTooManyMissedSchedules,
+			"PipelineRunSchedule %s/%s can't be scheduled: %v, set startingDeadlineSeconds to skip them", s.Namespace, s.Name, err)
+		s.Status.NextScheduleTime = nil
+		return nil
+	}
+	s.Status.MarkReady()
+
+	if !scheduled.IsZero() {
+		if err := c.runScheduled(ctx, s, scheduled, active); err != nil {
+			return err
+		}
+		s.Status.LastScheduleTime = &metav1.Time{Time: scheduled}
+	}
+
+	next := schedule.Next(now)
+	if next.IsZero() {
+		logger.Infof("PipelineRunSchedule %s/%s is never scheduled again", s.Namespace, s.Name)
+		s.Status.NextScheduleTime = nil
+		return nil
+	}
+	s.Status.NextScheduleTime = &metav1.Time{Time: next}
+	return controller.NewRequeueAfter(next.Sub(now))
+}
+
+// runScheduled creates the PipelineRun scheduled at the given time, according to the
+// concurrency policy of the PipelineRunSchedule.
+func (c *Reconciler) runScheduled(ctx context.Context, s *v1alpha1.PipelineRunSchedule, scheduled time.Time, active []*v1beta1.PipelineRun) error {
+	logger := logging.FromContext(ctx)
+	switch s.Spec.ConcurrencyPolicy {
+	case v1alpha1.ScheduleConcurrencyForbid:
+		if len(active) > 0 {
+			logger.Infof("Skipping the PipelineRun of PipelineRunSchedule %s/%s scheduled at %s: %d PipelineRuns are still running",
+				s.Namespace, s.Name, scheduled, len(active))
+			return nil
+		}
+	case v1alpha1.ScheduleConcurrencyReplace:
+		for _, pr := range active {
+			logger.Infof("Cancelling PipelineRun %s to replace it with the PipelineRun of PipelineRunSchedule %s scheduled at %s", pr.Name, s.Name, scheduled)
+			if _, err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(s.Namespace).Patch(ctx, pr.Name, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+				return fmt.Errorf("failed to cancel PipelineRun %s of PipelineRunSchedule %s: %w", pr.Name, s.Name, err)
+			}
+		}
+	}
+
+	pr := newPipelineRun(s, scheduled)
+	logger.Infof("Creating PipelineRun %s of PipelineRunSchedule %s/%s scheduled at %s", pr.Name, s.Namespace, s.Name, scheduled)
+	if _, err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(s.Namespace).Create(ctx, pr, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create PipelineRun %s of PipelineRunSchedule %s: %w", pr.Name, s.Name, err)
+	}
+	return nil
+}
+
+// updateHistory reports the running PipelineRuns of a PipelineRunSchedule in its status, and
+// deletes its oldest completed PipelineRuns exceeding its history limits. It returns the
+// running PipelineRuns.
+func (c *Reconciler) updateHistory(ctx context.Context, s *v1alpha1.PipelineRunSchedule) ([]*v1beta1.PipelineRun, error) {
+	logger := logging.FromContext(ctx)
+	prs, err := c.pipelineRunLister.PipelineRuns(s.Namespace).List(labels.SelectorFromSet(labels.Set{
+		pipeline.PipelineRunScheduleLabelKey: s.Name,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PipelineRuns of PipelineRunSchedule %s: %w", s.Name, err)
+	}
+
+	var active, succeeded, failed []*v1beta1.PipelineRun
+	for _, pr := range prs {
+		switch {
+		case !metav1.IsControlledBy(pr, s):
+		case !pr.IsDone():
+			active = append(active, pr)
+		case pr.Status.GetCondition(apis.ConditionSucceeded).IsTrue():
+			succeeded = append(succeeded, pr)
+		default:
+			failed = append(failed, pr)
+		}
+	}
+
+	sort.Slice(active, func(i, j int) bool { return active[i].Name < active[j].Name })
+	s.Status.Active = nil
+	for _, pr := range active {
+		s.Status.Active = append(s.Status.Active, corev1.ObjectReference{
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       pipeline.PipelineRunControllerName,
+			Namespace:  pr.Namespace,
+			Name:       pr.Name,
+			UID:        pr.UID,
+		})
+	}
+
+	for _, history := range []struct {
+		prs   []*v1beta1.PipelineRun
+		limit int
+	}{
+		{prs: succeeded, limit: s.GetSuccessfulRunsHistoryLimit()},
+		{prs: failed, limit: s.GetFailedRunsHistoryLimit()},
+	} {
+		prs := history.prs
+		if len(prs) <= history.limit {
+			continue
+		}
+		sort.Slice(prs, func(i, j int) bool { return completedBefore(prs[i], prs[j]) })
+		for _, pr := range prs[:len(prs)-history.limit] {
+			logger.Infof("Deleting PipelineRun %s exceeding the history limit of PipelineRunSchedule %s/%s", pr.Name, s.Namespace, s.Name)
+			if err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(s.Namespace).Delete(ctx, pr.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to delete PipelineRun %s of PipelineRunSchedule %s: %w", pr.Name, s.Name, err)
+			}
+		}
+	}
+	return active, nil
+}
+
+// newPipelineRun returns the PipelineRun of a PipelineRunSchedule scheduled at the given time.
+// Its name is derived from the time, so that it is only created once.
+func newPipelineRun(s *v1alpha1.PipelineRunSchedule, scheduled time.Time) *v1beta1.PipelineRun {
+	template := s.Spec.PipelineRunTemplate
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            kmeta.ChildName(s.Name, fmt.Sprintf("-%d", scheduled.Unix()/60)),
+			Namespace:       s.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(s)},
+			Labels:          make(map[string]string, len(template.Metadata.Labels)+1),
+			Annotations:     make(map[string]string, len(template.Metadata.Annotations)),
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	for key, value := range template.Metadata.Labels {
+		pr.Labels[key] = value
+	}
+	pr.Labels[pipeline.PipelineRunScheduleLabelKey] = s.Name
+	for key, value := range template.Metadata.Annotations {
+		pr.Annotations[key] = value
+	}
+
+	replacements := map[string]string{ScheduleTimeContext: scheduled.UTC().Format(time.RFC3339)}
+	for i := range pr.Spec.Params {
+		pr.Spec.Params[i].Value.ApplyReplacements(replacements, nil)
+	}
+	return pr
+}
+
+// parseSchedule returns the parsed schedule of a PipelineRunSchedule and its time zone.
+func parseSchedule(s *v1alpha1.PipelineRunSchedule) (cron.Schedule, *time.Location, error) {
+	schedule, err := cron.Parse(s.Spec.Schedule)
+	if err != nil {
+		return nil, nil, err
+	}
+	location, err := time.LoadLocation(s.Spec.TimeZone)
+	if err != nil {
+		return nil, nil, err
+	}
+	return schedule, location, nil
+}
+
+// getMostRecentScheduleTime returns the latest time of the schedule after earliest and no later
+// than now, or the zero time if there is none. Earlier times which were missed are skipped, unless
+// there are more than maxMissedSchedules of them.
+func getMostRecentScheduleTime(schedule cron.Schedule, earliest, now time.Time) (time.Time, error) {
+	var mostRecent time.Time
+	missed := 0
+	for t := schedule.Next(earliest); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		if missed++; missed > maxMissedSchedules {
+			return time.Time{}, errTooManyMissedSchedules
+		}
+		mostRecent = t
+	}
+	return mostRecent, nil
+}
+
+// completedBefore returns true if the first PipelineRun completed before the second one.
+func completedBefore(a, b *v1beta1.PipelineRun) bool {
+	if a.Status.CompletionTime == nil || b.Status.CompletionTime == nil {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Status.CompletionTime.Before(b.Status.CompletionTime)
+}
@@ -0,0 +1,446 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerunschedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/cron"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	cminformer "knative.dev/pkg/configmap/informer"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+
+	_ "knative.dev/pkg/system/testing" // Setup system.Namespace()
+)
+
+// getPipelineRunScheduleController returns an instance of the PipelineRunSchedule controller/reconciler
+// that has been seeded with d, where d represents the state of the system (existing resources) needed
+// for the test.
+func getPipelineRunScheduleController(t *testing.T, d test.Data) (test.Assets, func()) {
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	c, informers := test.SeedTestData(t, ctx, d)
+	configMapWatcher := cminformer.NewInformedWatcher(c.Kube, system.Namespace())
+	ctl := NewController()(ctx, configMapWatcher)
+	if la, ok := ctl.Reconciler.(reconciler.LeaderAware); ok {
+		la.Promote(reconciler.UniversalBucket(), func(reconciler.Bucket, types.NamespacedName) {})
+	}
+	return test.Assets{
+		Clients:    c,
+		Controller: ctl,
+		Informers:  informers,
+		Ctx:        ctx,
+	}, cancel
+}
+
+// reconcileSchedule reconciles the PipelineRunSchedule foo/nightly and returns its updated version,
+// the error returned by the reconciler and the PipelineRuns of the namespace.
+func reconcileSchedule(t *testing.T, d test.Data) (*v1alpha1.PipelineRunSchedule, error, []v1beta1.PipelineRun) {
+	t.Helper()
+	testAssets, cancel := getPipelineRunScheduleController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+
+	reconcileErr := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/nightly")
+	s, err := clients.Pipeline.TektonV1alpha1().PipelineRunSchedules("foo").Get(testAssets.Ctx, "nightly", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get PipelineRunSchedule: %v", err)
+	}
+	prs, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").List(testAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list PipelineRuns: %v", err)
+	}
+	return s, reconcileErr, prs.Items
+}
+
+func newSchedule(created time.Time, spec v1alpha1.PipelineRunScheduleSpec) *v1alpha1.PipelineRunSchedule {
+	if spec.PipelineRunTemplate.Spec.PipelineRef == nil {
+		spec.PipelineRunTemplate = v1alpha1.PipelineRunTemplate{
+			Metadata: v1beta1.PipelineTaskMetadata{
+				Labels:      map[string]string{"app": "nightly"},
+				Annotations: map[string]string{"owner": "release-team"},
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{Name: "release"},
+				Params: []v1beta1.Param{{
+					Name:  "date",
+					Value: *v1beta1.NewArrayOrString("$(context.schedule.time)"),
+				}, {
+					Name:  "version",
+					Value: *v1beta1.NewArrayOrString("nightly"),
+				}},
+			},
+		}
+	}
+	return &v1alpha1.PipelineRunSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "nightly",
+			Namespace:         "foo",
+			UID:               "schedule-uid",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: spec,
+	}
+}
+
+// newScheduledPipelineRun returns a PipelineRun of the PipelineRunSchedule with the given status.
+func newScheduledPipelineRun(s *v1alpha1.PipelineRunSchedule, name string, status corev1.ConditionStatus, completed time.Time) *v1beta1.PipelineRun {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       s.Namespace,
+			Labels:          map[string]string{pipeline.PipelineRunScheduleLabelKey: s.Name},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(s)},
+		},
+		Spec: v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "release"}},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: status}},
+			},
+		},
+	}
+	if status != corev1.ConditionUnknown {
+		pr.Status.CompletionTime = &metav1.Time{Time: completed}
+	}
+	return pr
+}
+
+func TestReconcile_CreatesScheduledPipelineRun(t *testing.T) {
+	now := time.Now()
+	s := newSchedule(now.Add(-90*time.Second), v1alpha1.PipelineRunScheduleSpec{
+		Schedule:          "* * * * *",
+		ConcurrencyPolicy: v1alpha1.ScheduleConcurrencyAllow,
+	})
+
+	reconciled, err, prs := reconcileSchedule(t, test.Data{PipelineRunSchedules: []*v1alpha1.PipelineRunSchedule{s}})
+	if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Fatalf("Expected the PipelineRunSchedule to be requeued but got %v", err)
+	}
+	if !reconciled.Status.GetCondition(apis.ConditionReady).IsTrue() {
+		t.Errorf("Expected the PipelineRunSchedule to be ready but got %v", reconciled.Status.GetCondition(apis.ConditionReady))
+	}
+	if reconciled.Status.LastScheduleTime == nil || reconciled.Status.NextScheduleTime == nil {
+		t.Fatalf("Expected the last and next schedule times to be set but got %v and %v",
+			reconciled.Status.LastScheduleTime, reconciled.Status.NextScheduleTime)
+	}
+	scheduled := reconciled.Status.LastScheduleTime.Time
+	if scheduled.Before(s.CreationTimestamp.Time) || scheduled.After(now.Add(time.Minute)) || scheduled.Second() != 0 {
+		t.Errorf("Unexpected last schedule time %s", scheduled)
+	}
+	if next := reconciled.Status.NextScheduleTime.Time; !next.Equal(scheduled.Add(time.Minute)) {
+		t.Errorf("Expected next schedule time %s but got %s", scheduled.Add(time.Minute), next)
+	}
+
+	if len(prs) != 1 {
+		t.Fatalf("Expected 1 PipelineRun to be created but got %d", len(prs))
+	}
+	want := newPipelineRun(s, scheduled)
+	if d := cmp.Diff(want.ObjectMeta, prs[0].ObjectMeta, cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion")); d != "" {
+		t.Errorf("Unexpected PipelineRun metadata %s", diff.PrintWantGot(d))
+	}
+	wantParams := []v1beta1.Param{{
+		Name:  "date",
+		Value: *v1beta1.NewArrayOrString(scheduled.UTC().Format(time.RFC3339)),
+	}, {
+		Name:  "version",
+		Value: *v1beta1.NewArrayOrString("nightly"),
+	}}
+	if d := cmp.Diff(wantParams, prs[0].Spec.Params); d != "" {
+		t.Errorf("Unexpected PipelineRun params %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_NothingScheduled(t *testing.T) {
+	now := time.Now()
+	s := newSchedule(now, v1alpha1.PipelineRunScheduleSpec{
+		Schedule: "0 0 1 1 *",
+		TimeZone: "America/New_York",
+	})
+
+	reconciled, err, prs := reconcileSchedule(t, test.Data{PipelineRunSchedules: []*v1alpha1.PipelineRunSchedule{s}})
+	ok, after := controller.IsRequeueKey(err)
+	if !ok {
+		t.Fatalf("Expected the PipelineRunSchedule to be requeued but got %v", err)
+	}
+	if len(prs) != 0 {
+		t.Errorf("Expected no PipelineRun to be created but got %d", len(prs))
+	}
+	if reconciled.Status.LastScheduleTime != nil {
+		t.Errorf("Expected no last schedule time but got %s", reconciled.Status.LastScheduleTime)
+	}
+	newYork, _ := time.LoadLocation("America/New_York")
+	next := reconciled.Status.NextScheduleTime.Time.In(newYork)
+	if next.Month() != time.January || next.Day() != 1 || next.Hour() != 0 || next.Minute() != 0 {
+		t.Errorf("Expected the next schedule time to be new year in New York but got %s", next)
+	}
+	if after <= 0 || after > 366*24*time.Hour {
+		t.Errorf("Unexpected requeue delay %s", after)
+	}
+}
+
+func TestReconcile_ConcurrencyPolicy(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name          string
+		policy        v1alpha1.ScheduleConcurrencyPolicy
+		wantCreated   bool
+		wantCancelled bool
+	}{{
+		name:        "allow",
+		policy:      v1alpha1.ScheduleConcurrencyAllow,
+		wantCreated: true,
+	}, {
+		name:   "forbid",
+		policy: v1alpha1.ScheduleConcurrencyForbid,
+	}, {
+		name:          "replace",
+		policy:        v1alpha1.ScheduleConcurrencyReplace,
+		wantCreated:   true,
+		wantCancelled: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSchedule(now.Add(-90*time.Second), v1alpha1.PipelineRunScheduleSpec{
+				Schedule:          "* * * * *",
+				ConcurrencyPolicy: tc.policy,
+			})
+			running := newScheduledPipelineRun(s, "nightly-running", corev1.ConditionUnknown, now)
+
+			reconciled, _, prs := reconcileSchedule(t, test.Data{
+				PipelineRunSchedules: []*v1alpha1.PipelineRunSchedule{s},
+				PipelineRuns:         []*v1beta1.PipelineRun{running},
+			})
+			if reconciled.Status.LastScheduleTime == nil {
+				t.Fatalf("Expected the last schedule time to be set")
+			}
+			wantActive := []corev1.ObjectReference{{
+				APIVersion: "tekton.dev/v1beta1",
+				Kind:       "PipelineRun",
+				Namespace:  "foo",
+				Name:       "nightly-running",
+			}}
+			if d := cmp.Diff(wantActive, reconciled.Status.Active); d != "" {
+				t.Errorf("Unexpected active PipelineRuns %s", diff.PrintWantGot(d))
+			}
+
+			created := newPipelineRun(s, reconciled.Status.LastScheduleTime.Time).Name
+			var gotCreated, gotCancelled bool
+			for _, pr := range prs {
+				switch pr.Name {
+				case created:
+					gotCreated = true
+				case running.Name:
+					gotCancelled = pr.IsCancelled()
+				}
+			}
+			if gotCreated != tc.wantCreated {
+				t.Errorf("Expected PipelineRun %s to be created: %t but got %t", created, tc.wantCreated, gotCreated)
+			}
+			if gotCancelled != tc.wantCancelled {
+				t.Errorf("Expected PipelineRun %s to be cancelled: %t but got %t", running.Name, tc.wantCancelled, gotCancelled)
+			}
+		})
+	}
+}
+
+func TestReconcile_Suspended(t *testing.T) {
+	now := time.Now()
+	s := newSchedule(now.Add(-90*time.Second), v1alpha1.PipelineRunScheduleSpec{
+		Schedule: "* * * * *",
+		Suspend:  true,
+	})
+
+	reconciled, err, prs := reconcileSchedule(t, test.Data{PipelineRunSchedules: []*v1alpha1.PipelineRunSchedule{s}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(prs) != 0 {
+		t.Errorf("Expected no PipelineRun to be created but got %d", len(prs))
+	}
+	condition := reconciled.Status.GetCondition(apis.ConditionReady)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != v1alpha1.PipelineRunScheduleReasonSuspended {
+		t.Errorf("Expected the PipelineRunSchedule to be suspended but got %v", condition)
+	}
+	if reconciled.Status.NextScheduleTime != nil {
+		t.Errorf("Expected no next schedule time but got %s", reconciled.Status.NextScheduleTime)
+	}
+}
+
+func TestReconcile_InvalidSchedule(t *testing.T) {
+	s := newSchedule(time.Now(), v1alpha1.PipelineRunScheduleSpec{
+		Schedule: "* * *",
+	})
+
+	reconciled, err, _ := reconcileSchedule(t, test.Data{PipelineRunSchedules: []*v1alpha1.PipelineRunSchedule{s}})
+	if !controller.IsPermanentError(err) {
+		t.Errorf("Expected a permanent error but got %v", err)
+	}
+	condition := reconciled.Status.GetCondition(apis.ConditionReady)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != v1alpha1.PipelineRunScheduleReasonInvalid {
+		t.Errorf("Expected the PipelineRunSchedule to be invalid but got %v", condition)
+	}
+}
+
+func TestReconcile_TooManyMissedSchedules(t *testing.T) {
+	now := time.Now()
+	s := newSchedule(now.Add(-3*time.Hour), v1alpha1.PipelineRunScheduleSpec{
+		Schedule: "* * * * *",
+	})
+
+	reconciled, err, prs := reconcileSchedule(t, test.Data{PipelineRunSchedules: []*v1alpha1.PipelineRunSchedule{s}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(prs) != 0 {
+		t.Errorf("Expected no PipelineRun to be created but got %d", len(prs))
+	}
+	condition := reconciled.Status.GetCondition(apis.ConditionReady)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != v1alpha1.PipelineRunScheduleReasonTooManyMissedSchedules {
+		t.Errorf("Expected the PipelineRunSchedule to have missed too many schedules but got %v", condition)
+	}
+}
+
+func TestReconcile_StartingDeadline(t *testing.T) {
+	now := time.Now()
+	deadline := int64(90)
+	s := newSchedule(now.Add(-3*time.Hour), v1alpha1.PipelineRunScheduleSpec{
+		Schedule:                "* * * * *",
+		StartingDeadlineSeconds: &deadline,
+	})
+
+	reconciled, err, prs := reconcileSchedule(t, test.Data{PipelineRunSchedules: []*v1alpha1.PipelineRunSchedule{s}})
+	if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Fatalf("Expected the PipelineRunSchedule to be requeued but got %v", err)
+	}
+	if !reconciled.Status.GetCondition(apis.ConditionReady).IsTrue() {
+		t.Errorf("Expected the PipelineRunSchedule to be ready but got %v", reconciled.Status.GetCondition(apis.ConditionReady))
+	}
+	if len(prs) != 1 {
+		t.Fatalf("Expected 1 PipelineRun to be created but got %d", len(prs))
+	}
+	if scheduled := reconciled.Status.LastScheduleTime.Time; scheduled.Before(now.Add(-time.Minute)) || scheduled.After(now) {
+		t.Errorf("Expected the last schedule time to be within the last minute but got %s", scheduled)
+	}
+}
+
+func TestReconcile_HistoryLimits(t *testing.T) {
+	now := time.Now()
+	successfulLimit, failedLimit := int32(2), int32(0)
+	s := newSchedule(now, v1alpha1.PipelineRunScheduleSpec{
+		Schedule:                   "0 0 1 1 *",
+		SuccessfulRunsHistoryLimit: &successfulLimit,
+		FailedRunsHistoryLimit:     &failedLimit,
+	})
+	other := newScheduledPipelineRun(s, "not-owned", corev1.ConditionTrue, now.Add(-4*time.Hour))
+	other.OwnerReferences = nil
+
+	_, _, prs := reconcileSchedule(t, test.Data{
+		PipelineRunSchedules: []*v1alpha1.PipelineRunSchedule{s},
+		PipelineRuns: []*v1beta1.PipelineRun{
+			newScheduledPipelineRun(s, "succeeded-1", corev1.ConditionTrue, now.Add(-3*time.Hour)),
+			newScheduledPipelineRun(s, "succeeded-2", corev1.ConditionTrue, now.Add(-time.Hour)),
+			newScheduledPipelineRun(s, "succeeded-3", corev1.ConditionTrue, now.Add(-2*time.Hour)),
+			newScheduledPipelineRun(s, "failed-1", corev1.ConditionFalse, now.Add(-time.Hour)),
+			newScheduledPipelineRun(s, "running", corev1.ConditionUnknown, now),
+			other,
+		},
+	})
+
+	var got []string
+	for _, pr := range prs {
+		got = append(got, pr.Name)
+	}
+	want := []string{"not-owned", "running", "succeeded-2", "succeeded-3"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected remaining PipelineRuns %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestGetMostRecentScheduleTime(t *testing.T) {
+	schedule, err := cron.Parse("0 * * * *")
+	if err != nil {
+		t.Fatalf("Failed to parse schedule: %v", err)
+	}
+	last := time.Date(2021, time.June, 1, 10, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name    string
+		now     time.Time
+		want    time.Time
+		wantErr error
+	}{{
+		name: "not yet scheduled",
+		now:  last.Add(59 * time.Minute),
+	}, {
+		name: "scheduled once",
+		now:  last.Add(time.Hour),
+		want: last.Add(time.Hour),
+	}, {
+		name: "missed schedules are skipped",
+		now:  last.Add(3*time.Hour + 30*time.Minute),
+		want: last.Add(3 * time.Hour),
+	}, {
+		name: "as many missed schedules as allowed",
+		now:  last.Add(maxMissedSchedules * time.Hour),
+		want: last.Add(maxMissedSchedules * time.Hour),
+	}, {
+		name:    "too many missed schedules",
+		now:     last.Add((maxMissedSchedules + 1) * time.Hour),
+		wantErr: errTooManyMissedSchedules,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := getMostRecentScheduleTime(schedule, last, tc.now)
+			if err != tc.wantErr {
+				t.Fatalf("Expected error %v but got %v", tc.wantErr, err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("Expected %s but got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNewPipelineRun_Name(t *testing.T) {
+	s := newSchedule(time.Now(), v1alpha1.PipelineRunScheduleSpec{Schedule: "@hourly"})
+	scheduled := time.Date(2021, time.June, 1, 10, 0, 0, 0, time.UTC)
+	first := newPipelineRun(s, scheduled)
+	if first.Name != newPipelineRun(s, scheduled).Name {
+		t.Errorf("Expected the PipelineRuns scheduled at the same time to have the same name")
+	}
+	if first.Name == newPipelineRun(s, scheduled.Add(time.Hour)).Name {
+		t.Errorf("Expected the PipelineRuns scheduled at different times to have different names")
+	}
+	if !labels.SelectorFromSet(labels.Set{pipeline.PipelineRunScheduleLabelKey: "nightly"}).Matches(labels.Set(first.Labels)) {
+		t.Errorf("Expected the PipelineRun to have the label of its schedule but got %v", first.Labels)
+	}
+}
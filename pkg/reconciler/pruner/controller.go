@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun"
+	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
+	pipelinerunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/pipelinerun"
+	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/taskrun"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// NewPipelineRunController instantiates a new controller.Impl from knative.dev/pkg/controller
+// deleting completed PipelineRuns
+func NewPipelineRunController() func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		pipelineRunInformer := pipelineruninformer.Get(ctx)
+		configStore := config.NewStore(logger.Named("config-store"))
+		configStore.WatchConfigs(cmw)
+
+		c := &PipelineRunReconciler{
+			KubeClientSet:     kubeclient.Get(ctx),
+			PipelineClientSet: pipelineclient.Get(ctx),
+			pipelineRunLister: pipelineRunInformer.Lister(),
+		}
+		impl := pipelinerunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
+			return controller.Options{
+				AgentName:         pipeline.PipelineRunPrunerControllerName,
+				ConfigStore:       configStore,
+				SkipStatusUpdates: true,
+			}
+		})
+
+		pipelineRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+		return impl
+	}
+}
+
+// NewTaskRunController instantiates a new controller.Impl from knative.dev/pkg/controller
+// deleting completed standalone TaskRuns
+func NewTaskRunController() func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		taskRunInformer := taskruninformer.Get(ctx)
+		configStore := config.NewStore(logger.Named("config-store"))
+		configStore.WatchConfigs(cmw)
+
+		c := &TaskRunReconciler{
+			KubeClientSet:     kubeclient.Get(ctx),
+			PipelineClientSet: pipelineclient.Get(ctx),
+			taskRunLister:     taskRunInformer.Lister(),
+		}
+		impl := taskrunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
+			return controller.Options{
+				AgentName:         pipeline.TaskRunPrunerControllerName,
+				ConfigStore:       configStore,
+				SkipStatusUpdates: true,
+			}
+		})
+
+		taskRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+		return impl
+	}
+}
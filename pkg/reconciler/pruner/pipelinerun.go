@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	pipelinerunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/pipelinerun"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+// PipelineRunReconciler deletes the completed PipelineRuns whose TTL expired, and the oldest
+// completed PipelineRuns of a Pipeline beyond the history limit.
+type PipelineRunReconciler struct {
+	KubeClientSet     kubernetes.Interface
+	PipelineClientSet clientset.Interface
+
+	pipelineRunLister listers.PipelineRunLister
+}
+
+// Check that our Reconciler implements pipelinerunreconciler.Interface
+var _ pipelinerunreconciler.Interface = (*PipelineRunReconciler)(nil)
+
+// ReconcileKind deletes a completed PipelineRun if its TTL expired, or requeues it until then,
+// and deletes the oldest completed PipelineRuns of its Pipeline beyond the history limit.
+// PipelineRuns created by another resource, e.g. a PipelineRunSchedule, are left to it.
+func (c *PipelineRunReconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun) pkgreconciler.Event {
+	if !pr.IsDone() || pr.Status.CompletionTime == nil || metav1.GetControllerOf(pr) != nil {
+		return nil
+	}
+	defaults, err := getDefaults(ctx, c.KubeClientSet, pr.Namespace)
+	if err != nil {
+		return err
+	}
+
+	ttl := pr.Spec.TTLSecondsAfterFinished
+	if ttl == nil {
+		ttl = defaults.DefaultTTLSecondsAfterFinished
+	}
+	wait, expires := getTimeToExpiration(ttl, pr.Status.CompletionTime.Time)
+	if expires && wait <= 0 {
+		logging.FromContext(ctx).Infof("Deleting PipelineRun %s/%s: its TTL of %ds expired", pr.Namespace, pr.Name, *ttl)
+		return c.delete(ctx, pr.Namespace, pr.Name)
+	}
+
+	if limit := defaults.DefaultRunsHistoryLimit; limit != nil && pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Name != "" {
+		if err := c.pruneHistory(ctx, pr.Namespace, pr.Spec.PipelineRef.Name, *limit); err != nil {
+			return err
+		}
+	}
+	if expires {
+		return controller.NewRequeueAfter(wait)
+	}
+	return nil
+}
+
+// pruneHistory deletes the oldest completed PipelineRuns of a Pipeline beyond the history limit.
+func (c *PipelineRunReconciler) pruneHistory(ctx context.Context, namespace, pipelineName string, limit int32) error {
+	prs, err := c.pipelineRunLister.PipelineRuns(namespace).List(labels.SelectorFromSet(labels.Set{
+		pipeline.PipelineLabelKey: pipelineName,
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to list PipelineRuns of Pipeline %s: %w", pipelineName, err)
+	}
+	var completed []completedRun
+	for _, pr := range prs {
+		if !pr.IsDone() || pr.Status.CompletionTime == nil || metav1.GetControllerOf(pr) != nil ||
+			pr.Spec.PipelineRef == nil || pr.Spec.PipelineRef.Name != pipelineName {
+			continue
+		}
+		completed = append(completed, completedRun{name: pr.Name, completionTime: pr.Status.CompletionTime.Time})
+	}
+	for _, run := range getRunsExceedingLimit(completed, limit) {
+		logging.FromContext(ctx).Infof("Deleting PipelineRun %s/%s: more than %d PipelineRuns of Pipeline %s completed after it",
+			namespace, run.name, limit, pipelineName)
+		if err := c.delete(ctx, namespace, run.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// delete deletes a PipelineRun along with its TaskRuns, their Pods and its PersistentVolumeClaims.
+func (c *PipelineRunReconciler) delete(ctx context.Context, namespace, name string) error {
+	err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &backgroundDeletion})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete PipelineRun %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/controller"
+)
+
+// newPipelineRun returns a PipelineRun of the Pipeline release, completed at the given time
+// unless it is zero.
+func newPipelineRun(name string, completed time.Time) *v1beta1.PipelineRun {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "foo",
+			Labels:    map[string]string{pipeline.PipelineLabelKey: "release"},
+		},
+		Spec: v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "release"}},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}},
+			},
+		},
+	}
+	if !completed.IsZero() {
+		pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+		pr.Status.CompletionTime = &metav1.Time{Time: completed}
+	}
+	return pr
+}
+
+func TestReconcilePipelineRun_TTL(t *testing.T) {
+	now := time.Now()
+	ttl := int32(600)
+	for _, tc := range []struct {
+		name         string
+		pr           *v1beta1.PipelineRun
+		defaults     map[string]string
+		namespace    map[string]string
+		wantDeleted  bool
+		wantRequeue  time.Duration
+		wantErrorMsg string
+	}{{
+		name: "no ttl",
+		pr:   newPipelineRun("pr", now.Add(-24*time.Hour)),
+	}, {
+		name:     "running",
+		pr:       newPipelineRun("pr", time.Time{}),
+		defaults: map[string]string{"default-ttl-seconds-after-finished": "0"},
+	}, {
+		name: "ttl of the PipelineRun expired",
+		pr: func() *v1beta1.PipelineRun {
+			pr := newPipelineRun("pr", now.Add(-time.Hour))
+			pr.Spec.TTLSecondsAfterFinished = &ttl
+			return pr
+		}(),
+		wantDeleted: true,
+	}, {
+		name: "ttl of the PipelineRun not expired",
+		pr: func() *v1beta1.PipelineRun {
+			pr := newPipelineRun("pr", now.Add(-5*time.Minute))
+			pr.Spec.TTLSecondsAfterFinished = &ttl
+			return pr
+		}(),
+		defaults:    map[string]string{"default-ttl-seconds-after-finished": "0"},
+		wantRequeue: 5 * time.Minute,
+	}, {
+		name:        "default ttl expired",
+		pr:          newPipelineRun("pr", now.Add(-time.Hour)),
+		defaults:    map[string]string{"default-ttl-seconds-after-finished": "3600"},
+		wantDeleted: true,
+	}, {
+		name:        "default ttl overridden in the namespace",
+		pr:          newPipelineRun("pr", now.Add(-time.Hour)),
+		defaults:    map[string]string{"default-ttl-seconds-after-finished": "60"},
+		namespace:   map[string]string{"default-ttl-seconds-after-finished": "7200"},
+		wantRequeue: time.Hour,
+	}, {
+		name: "created by another resource",
+		pr: func() *v1beta1.PipelineRun {
+			pr := newPipelineRun("pr", now.Add(-time.Hour))
+			pr.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "tekton.dev/v1alpha1",
+				Kind:       "PipelineRunSchedule",
+				Name:       "nightly",
+				Controller: &[]bool{true}[0],
+			}}
+			return pr
+		}(),
+		defaults: map[string]string{"default-ttl-seconds-after-finished": "0"},
+	}, {
+		name:         "invalid defaults in the namespace",
+		pr:           newPipelineRun("pr", now.Add(-time.Hour)),
+		namespace:    map[string]string{"default-ttl-seconds-after-finished": "soon"},
+		wantErrorMsg: `invalid defaults ConfigMap in namespace foo: failed parsing defaults config "default-ttl-seconds-after-finished": expected an integer >= 0 but got "soon"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{PipelineRuns: []*v1beta1.PipelineRun{tc.pr}}
+			if tc.namespace != nil {
+				d.ConfigMaps = append(d.ConfigMaps, namespaceDefaults(tc.namespace))
+			}
+			testAssets, cancel := getPrunerController(t, d, tc.defaults, NewPipelineRunController)
+			defer cancel()
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/pr")
+			if tc.wantErrorMsg != "" {
+				if err == nil || !controller.IsPermanentError(err) || err.Error() != tc.wantErrorMsg {
+					t.Errorf("Expected permanent error %q but got %v", tc.wantErrorMsg, err)
+				}
+			} else {
+				checkRequeue(t, err, tc.wantRequeue)
+			}
+
+			prs, err := testAssets.Clients.Pipeline.TektonV1beta1().PipelineRuns("foo").List(testAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list PipelineRuns: %v", err)
+			}
+			if deleted := len(prs.Items) == 0; deleted != tc.wantDeleted {
+				t.Errorf("Expected PipelineRun to be deleted: %t but got %t", tc.wantDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestReconcilePipelineRun_HistoryLimit(t *testing.T) {
+	now := time.Now()
+	embedded := newPipelineRun("embedded", now.Add(-5*time.Hour))
+	embedded.Spec = v1beta1.PipelineRunSpec{PipelineSpec: &v1beta1.PipelineSpec{}}
+	other := newPipelineRun("other", now.Add(-5*time.Hour))
+	other.Labels[pipeline.PipelineLabelKey] = "test"
+	other.Spec.PipelineRef.Name = "test"
+
+	testAssets, cancel := getPrunerController(t, test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{
+			newPipelineRun("release-1", now.Add(-4*time.Hour)),
+			newPipelineRun("release-2", now.Add(-2*time.Hour)),
+			newPipelineRun("release-3", now.Add(-3*time.Hour)),
+			newPipelineRun("release-4", now.Add(-time.Hour)),
+			newPipelineRun("release-5", time.Time{}),
+			embedded,
+			other,
+		},
+		ConfigMaps: []*corev1.ConfigMap{namespaceDefaults(map[string]string{"default-runs-history-limit": "2"})},
+	}, map[string]string{"default-runs-history-limit": "10"}, NewPipelineRunController)
+	defer cancel()
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/release-4"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	prs, err := testAssets.Clients.Pipeline.TektonV1beta1().PipelineRuns("foo").List(testAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list PipelineRuns: %v", err)
+	}
+	var got []string
+	for _, pr := range prs.Items {
+		got = append(got, pr.Name)
+	}
+	want := []string{"embedded", "other", "release-2", "release-4", "release-5"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected remaining PipelineRuns %s", diff.PrintWantGot(d))
+	}
+}
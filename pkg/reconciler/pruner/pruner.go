@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/system"
+)
+
+// backgroundDeletion deletes the TaskRuns, Pods and PersistentVolumeClaims owned by
+// a run after the run itself.
+var backgroundDeletion = metav1.DeletePropagationBackground
+
+// completedRun is a PipelineRun or a TaskRun which completed.
+type completedRun struct {
+	name           string
+	completionTime time.Time
+}
+
+// getDefaults returns the defaults applying to the runs of a namespace: the defaults of the
+// cluster, overridden by the defaults ConfigMap of the namespace if there is one. The ConfigMap
+// is read from the API server, rather than caching all the ConfigMaps of the cluster for it.
+func getDefaults(ctx context.Context, kubeClientSet kubernetes.Interface, namespace string) (*config.Defaults, error) {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	if namespace == system.Namespace() {
+		return defaults, nil
+	}
+	cm, err := kubeClientSet.CoreV1().ConfigMaps(namespace).Get(ctx, config.GetDefaultsConfigName(), metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		return defaults, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get defaults ConfigMap of namespace %s: %w", namespace, err)
+	}
+	overridden, err := defaults.WithNamespaceOverrides(cm.Data)
+	if err != nil {
+		return nil, controller.NewPermanentError(fmt.Errorf("invalid defaults ConfigMap in namespace %s: %w", namespace, err))
+	}
+	return overridden, nil
+}
+
+// getTimeToExpiration returns how long until a run which completed at the given time
+// expires. It returns false if the run never expires.
+func getTimeToExpiration(ttl *int32, completionTime time.Time) (time.Duration, bool) {
+	if ttl == nil {
+		return 0, false
+	}
+	return time.Until(completionTime.Add(time.Duration(*ttl) * time.Second)), true
+}
+
+// getRunsExceedingLimit returns the runs which completed first, beyond the number
+// of runs to keep.
+func getRunsExceedingLimit(runs []completedRun, limit int32) []completedRun {
+	if len(runs) <= int(limit) {
+		return nil
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		if runs[i].completionTime.Equal(runs[j].completionTime) {
+			return runs[i].name < runs[j].name
+		}
+		return runs[i].completionTime.Before(runs[j].completionTime)
+	})
+	return runs[:len(runs)-int(limit)]
+}
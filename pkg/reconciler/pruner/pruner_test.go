@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/configmap"
+	cminformer "knative.dev/pkg/configmap/informer"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+
+	_ "knative.dev/pkg/system/testing" // Setup system.Namespace()
+)
+
+// getPrunerController returns an instance of a pruner controller/reconciler that has been seeded with
+// d, where d represents the state of the system (existing resources) needed for the test, and whose
+// cluster-wide defaults are set from the given data of the defaults ConfigMap.
+func getPrunerController(t *testing.T, d test.Data, defaults map[string]string,
+	newController func() func(context.Context, configmap.Watcher) *controller.Impl) (test.Assets, func()) {
+	t.Helper()
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+		Data:       defaults,
+	})
+	for _, name := range []string{
+		config.GetFeatureFlagsConfigName(),
+		config.GetArtifactBucketConfigName(),
+		config.GetArtifactPVCConfigName(),
+		config.GetMetricsConfigName(),
+		config.GetConcurrencyConfigName(),
+	} {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: system.Namespace()},
+		})
+	}
+	c, informers := test.SeedTestData(t, ctx, d)
+	configMapWatcher := cminformer.NewInformedWatcher(c.Kube, system.Namespace())
+	ctl := newController()(ctx, configMapWatcher)
+	if la, ok := ctl.Reconciler.(reconciler.LeaderAware); ok {
+		la.Promote(reconciler.UniversalBucket(), func(reconciler.Bucket, types.NamespacedName) {})
+	}
+	if err := configMapWatcher.Start(ctx.Done()); err != nil {
+		t.Fatalf("error starting configmap watcher: %v", err)
+	}
+	return test.Assets{
+		Clients:    c,
+		Controller: ctl,
+		Informers:  informers,
+		Ctx:        ctx,
+	}, cancel
+}
+
+// namespaceDefaults returns a defaults ConfigMap of the namespace foo with the given data.
+func namespaceDefaults(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: "foo"},
+		Data:       data,
+	}
+}
+
+// checkRequeue checks that a reconciler requeued its resource after about the given delay,
+// or didn't requeue it if the delay is 0.
+func checkRequeue(t *testing.T, err error, want time.Duration) {
+	t.Helper()
+	requeued, after := controller.IsRequeueKey(err)
+	switch {
+	case want == 0 && err != nil:
+		t.Errorf("Unexpected error: %v", err)
+	case want == 0:
+	case !requeued:
+		t.Errorf("Expected a requeue after %s but got %v", want, err)
+	case after > want || after < want-time.Minute:
+		t.Errorf("Expected a requeue after %s but got %s", want, after)
+	}
+}
+
+func TestGetRunsExceedingLimit(t *testing.T) {
+	now := time.Now()
+	runs := []completedRun{
+		{name: "b", completionTime: now.Add(-time.Hour)},
+		{name: "c", completionTime: now},
+		{name: "a", completionTime: now.Add(-time.Hour)},
+		{name: "d", completionTime: now.Add(-2 * time.Hour)},
+	}
+	for _, tc := range []struct {
+		limit int32
+		want  []string
+	}{
+		{limit: 5},
+		{limit: 4},
+		{limit: 2, want: []string{"d", "a"}},
+		{limit: 0, want: []string{"d", "a", "b", "c"}},
+	} {
+		var got []string
+		for _, run := range getRunsExceedingLimit(append([]completedRun{}, runs...), tc.limit) {
+			got = append(got, run.name)
+		}
+		if d := cmp.Diff(tc.want, got); d != "" {
+			t.Errorf("Unexpected runs exceeding limit %d %s", tc.limit, diff.PrintWantGot(d))
+		}
+	}
+}
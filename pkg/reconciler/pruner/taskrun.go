@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/taskrun"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+// TaskRunReconciler deletes the completed standalone TaskRuns whose TTL expired, and the oldest
+// completed standalone TaskRuns of a Task beyond the history limit.
+type TaskRunReconciler struct {
+	KubeClientSet     kubernetes.Interface
+	PipelineClientSet clientset.Interface
+
+	taskRunLister listers.TaskRunLister
+}
+
+// Check that our Reconciler implements taskrunreconciler.Interface
+var _ taskrunreconciler.Interface = (*TaskRunReconciler)(nil)
+
+// ReconcileKind deletes a completed TaskRun if its TTL expired, or requeues it until then, and
+// deletes the oldest completed TaskRuns of its Task beyond the history limit. TaskRuns created
+// by another resource, e.g. a PipelineRun, are deleted along with it.
+func (c *TaskRunReconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkgreconciler.Event {
+	if !tr.IsDone() || tr.Status.CompletionTime == nil || metav1.GetControllerOf(tr) != nil {
+		return nil
+	}
+	defaults, err := getDefaults(ctx, c.KubeClientSet, tr.Namespace)
+	if err != nil {
+		return err
+	}
+
+	ttl := tr.Spec.TTLSecondsAfterFinished
+	if ttl == nil {
+		ttl = defaults.DefaultTTLSecondsAfterFinished
+	}
+	wait, expires := getTimeToExpiration(ttl, tr.Status.CompletionTime.Time)
+	if expires && wait <= 0 {
+		logging.FromContext(ctx).Infof("Deleting TaskRun %s/%s: its TTL of %ds expired", tr.Namespace, tr.Name, *ttl)
+		return c.delete(ctx, tr.Namespace, tr.Name)
+	}
+
+	if limit := defaults.DefaultRunsHistoryLimit; limit != nil && tr.Spec.TaskRef != nil && tr.Spec.TaskRef.Name != "" {
+		if err := c.pruneHistory(ctx, tr.Namespace, tr.Spec.TaskRef, *limit); err != nil {
+			return err
+		}
+	}
+	if expires {
+		return controller.NewRequeueAfter(wait)
+	}
+	return nil
+}
+
+// pruneHistory deletes the oldest completed standalone TaskRuns of a Task beyond the history limit.
+func (c *TaskRunReconciler) pruneHistory(ctx context.Context, namespace string, ref *v1beta1.TaskRef, limit int32) error {
+	labelKey := pipeline.TaskLabelKey
+	if ref.Kind == v1beta1.ClusterTaskKind {
+		labelKey = pipeline.ClusterTaskLabelKey
+	}
+	trs, err := c.taskRunLister.TaskRuns(namespace).List(labels.SelectorFromSet(labels.Set{labelKey: ref.Name}))
+	if err != nil {
+		return fmt.Errorf("failed to list TaskRuns of Task %s: %w", ref.Name, err)
+	}
+	var completed []completedRun
+	for _, tr := range trs {
+		if !tr.IsDone() || tr.Status.CompletionTime == nil || metav1.GetControllerOf(tr) != nil ||
+			tr.Spec.TaskRef == nil || tr.Spec.TaskRef.Name != ref.Name || tr.Spec.TaskRef.Kind != ref.Kind {
+			continue
+		}
+		completed = append(completed, completedRun{name: tr.Name, completionTime: tr.Status.CompletionTime.Time})
+	}
+	for _, run := range getRunsExceedingLimit(completed, limit) {
+		logging.FromContext(ctx).Infof("Deleting TaskRun %s/%s: more than %d TaskRuns of Task %s completed after it",
+			namespace, run.name, limit, ref.Name)
+		if err := c.delete(ctx, namespace, run.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// delete deletes a TaskRun along with its Pod and its PersistentVolumeClaims.
+func (c *TaskRunReconciler) delete(ctx context.Context, namespace, name string) error {
+	err := c.PipelineClientSet.TektonV1beta1().TaskRuns(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &backgroundDeletion})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete TaskRun %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/kmeta"
+)
+
+// newTaskRun returns a TaskRun of the Task build, completed at the given time unless it is zero.
+func newTaskRun(name string, completed time.Time) *v1beta1.TaskRun {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "foo",
+			Labels:    map[string]string{pipeline.TaskLabelKey: "build"},
+		},
+		Spec: v1beta1.TaskRunSpec{TaskRef: &v1beta1.TaskRef{Name: "build", Kind: v1beta1.NamespacedTaskKind}},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}},
+			},
+		},
+	}
+	if !completed.IsZero() {
+		tr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse})
+		tr.Status.CompletionTime = &metav1.Time{Time: completed}
+	}
+	return tr
+}
+
+func TestReconcileTaskRun_TTL(t *testing.T) {
+	now := time.Now()
+	ttl := int32(0)
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo", UID: "pr-uid"}}
+	for _, tc := range []struct {
+		name        string
+		tr          *v1beta1.TaskRun
+		defaults    map[string]string
+		wantDeleted bool
+		wantRequeue time.Duration
+	}{{
+		name: "no ttl",
+		tr:   newTaskRun("tr", now.Add(-24*time.Hour)),
+	}, {
+		name: "ttl of the TaskRun expired",
+		tr: func() *v1beta1.TaskRun {
+			tr := newTaskRun("tr", now)
+			tr.Spec.TTLSecondsAfterFinished = &ttl
+			return tr
+		}(),
+		defaults:    map[string]string{"default-ttl-seconds-after-finished": "3600"},
+		wantDeleted: true,
+	}, {
+		name:        "default ttl not expired",
+		tr:          newTaskRun("tr", now.Add(-time.Hour)),
+		defaults:    map[string]string{"default-ttl-seconds-after-finished": "86400"},
+		wantRequeue: 23 * time.Hour,
+	}, {
+		name: "TaskRun of a PipelineRun",
+		tr: func() *v1beta1.TaskRun {
+			tr := newTaskRun("tr", now.Add(-time.Hour))
+			tr.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(pr)}
+			return tr
+		}(),
+		defaults: map[string]string{"default-ttl-seconds-after-finished": "0"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getPrunerController(t, test.Data{TaskRuns: []*v1beta1.TaskRun{tc.tr}}, tc.defaults, NewTaskRunController)
+			defer cancel()
+
+			checkRequeue(t, testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/tr"), tc.wantRequeue)
+			trs, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(testAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list TaskRuns: %v", err)
+			}
+			if deleted := len(trs.Items) == 0; deleted != tc.wantDeleted {
+				t.Errorf("Expected TaskRun to be deleted: %t but got %t", tc.wantDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestReconcileTaskRun_HistoryLimit(t *testing.T) {
+	now := time.Now()
+	clusterTask := newTaskRun("cluster-task", now.Add(-5*time.Hour))
+	clusterTask.Labels = map[string]string{pipeline.ClusterTaskLabelKey: "build"}
+	clusterTask.Spec.TaskRef.Kind = v1beta1.ClusterTaskKind
+	owned := newTaskRun("owned", now.Add(-5*time.Hour))
+	owned.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(&v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo", UID: "pr-uid"},
+	})}
+
+	testAssets, cancel := getPrunerController(t, test.Data{
+		TaskRuns: []*v1beta1.TaskRun{
+			newTaskRun("build-1", now.Add(-3*time.Hour)),
+			newTaskRun("build-2", now.Add(-2*time.Hour)),
+			newTaskRun("build-3", now.Add(-time.Hour)),
+			clusterTask,
+			owned,
+		},
+	}, map[string]string{"default-runs-history-limit": "1"}, NewTaskRunController)
+	defer cancel()
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/build-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	trs, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(testAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list TaskRuns: %v", err)
+	}
+	var got []string
+	for _, tr := range trs.Items {
+		got = append(got, tr.Name)
+	}
+	want := []string{"build-3", "cluster-task", "owned"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected remaining TaskRuns %s", diff.PrintWantGot(d))
+	}
+}
@@ -52,11 +52,18 @@ func ApplyParameters(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun, defaults ...v1
 	// Set all the default stringReplacements
 	for _, p := range defaults {
 		if p.Default != nil {
-			if p.Default.Type == v1beta1.ParamTypeString {
+			switch p.Default.Type {
+			case v1beta1.ParamTypeString:
 				for _, pattern := range patterns {
 					stringReplacements[fmt.Sprintf(pattern, p.Name)] = p.Default.StringVal
 				}
-			} else {
+			case v1beta1.ParamTypeObject:
+				for _, pattern := range patterns {
+					for k, v := range p.Default.ObjectVal {
+						stringReplacements[fmt.Sprintf(pattern+".%s", p.Name, k)] = v
+					}
+				}
+			default:
 				for _, pattern := range patterns {
 					arrayReplacements[fmt.Sprintf(pattern, p.Name)] = p.Default.ArrayVal
 				}
@@ -65,11 +72,20 @@ func ApplyParameters(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun, defaults ...v1
 	}
 	// Set and overwrite params with the ones from the TaskRun
 	for _, p := range tr.Spec.Params {
-		if p.Value.Type == v1beta1.ParamTypeString {
+		switch p.Value.Type {
+		case v1beta1.ParamTypeString:
 			for _, pattern := range patterns {
 				stringReplacements[fmt.Sprintf(pattern, p.Name)] = p.Value.StringVal
 			}
-		} else {
+		case v1beta1.ParamTypeObject:
+			// the keys of an object are replaced one by one, so that the keys
+			// which are not provided keep the value of the default
+			for _, pattern := range patterns {
+				for k, v := range p.Value.ObjectVal {
+					stringReplacements[fmt.Sprintf(pattern+".%s", p.Name, k)] = v
+				}
+			}
+		default:
 			for _, pattern := range patterns {
 				arrayReplacements[fmt.Sprintf(pattern, p.Name)] = p.Value.ArrayVal
 			}
@@ -227,6 +243,24 @@ func ApplyStepExitCodePath(spec *v1beta1.TaskSpec) *v1beta1.TaskSpec {
 	return ApplyReplacements(spec, stringReplacements, map[string][]string{})
 }
 
+// ApplyStepResultPaths replaces the occurrences of $(step.results.<result-name>.path) in each step with the
+// path of the file where that step writes the result: pipeline.StepsDir/<step-name>/results/<result-name>
+func ApplyStepResultPaths(spec *v1beta1.TaskSpec) *v1beta1.TaskSpec {
+	spec = spec.DeepCopy()
+	for i, step := range spec.Steps {
+		if len(step.Results) == 0 {
+			continue
+		}
+		stringReplacements := map[string]string{}
+		for _, result := range step.Results {
+			stringReplacements[fmt.Sprintf("step.results.%s.path", result.Name)] =
+				filepath.Join(pipeline.StepsDir, pod.StepName(step.Name, i), pipeline.StepResultsDir, result.Name)
+		}
+		v1beta1.ApplyStepReplacements(&spec.Steps[i], stringReplacements, map[string][]string{})
+	}
+	return spec
+}
+
 // ApplyCredentialsPath applies a substitution of the key $(credentials.path) with the path that credentials
 // from annotated secrets are written to.
 func ApplyCredentialsPath(spec *v1beta1.TaskSpec, path string) *v1beta1.TaskSpec {
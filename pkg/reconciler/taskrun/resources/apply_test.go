@@ -575,6 +575,39 @@ func TestApplyParameters(t *testing.T) {
 	}
 }
 
+func TestApplyObjectParameters(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name:       "gitrepo",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{"url": {}, "revision": {}},
+			Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/catalog", "revision": "main"}),
+		}},
+		Steps: []v1beta1.Step{{Container: corev1.Container{
+			Name:  "clone",
+			Image: "alpine/git",
+			Args:  []string{"clone", "$(params.gitrepo.url)", "--branch", "$(params.gitrepo.revision)"},
+			Env:   []corev1.EnvVar{{Name: "URL", Value: "$(params[\"gitrepo\"].url)"}},
+		}}},
+	}
+	tr := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "gitrepo",
+				Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+			}},
+		},
+	}
+	want := applyMutation(ts, func(spec *v1beta1.TaskSpec) {
+		spec.Steps[0].Args = []string{"clone", "https://github.com/tektoncd/pipeline", "--branch", "main"}
+		spec.Steps[0].Env[0].Value = "https://github.com/tektoncd/pipeline"
+	})
+	got := resources.ApplyParameters(ts, tr, ts.Params...)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyParameters() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyResources(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1216,6 +1249,35 @@ func TestApplyStepExitCodePath(t *testing.T) {
 	}
 }
 
+func TestApplyStepResultPaths(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "build",
+				Image: "bash:latest",
+			},
+			Script:  "#!/usr/bin/env bash\necho -n sha256:1234 > $(step.results.digest.path)",
+			Results: []v1beta1.StepResult{{Name: "digest"}},
+		}, {
+			Container: corev1.Container{
+				Name:  "push",
+				Image: "bash:latest",
+				Args:  []string{"--output=$(step.results.url.path)"},
+				Env:   []corev1.EnvVar{{Name: "DIGEST", Value: "$(steps.build.results.digest)"}},
+			},
+			Results: []v1beta1.StepResult{{Name: "url"}},
+		}},
+	}
+	expected := applyMutation(ts, func(spec *v1beta1.TaskSpec) {
+		spec.Steps[0].Script = "#!/usr/bin/env bash\necho -n sha256:1234 > /tekton/steps/step-build/results/digest"
+		spec.Steps[1].Args = []string{"--output=/tekton/steps/step-push/results/url"}
+	})
+	got := resources.ApplyStepResultPaths(ts)
+	if d := cmp.Diff(expected, got); d != "" {
+		t.Errorf("ApplyStepResultPaths() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyCredentialsPath(t *testing.T) {
 	for _, tc := range []struct {
 		description string
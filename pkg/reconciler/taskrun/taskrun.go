@@ -142,6 +142,10 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 		}
 
 		pod, err := c.stopSidecars(ctx, tr)
+		// A failed attempt is retried even if its pod is gone, e.g. because it was evicted.
+		if tr.IsRetriable() && (err == nil || controller.IsPermanentError(err)) {
+			return c.retryTaskRun(ctx, tr, before)
+		}
 		if err != nil {
 			return err
 		}
@@ -218,6 +222,42 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 	}
 	return nil
 }
+
+// retryTaskRun starts a new attempt of a failed TaskRun once the backoff of its RetryPolicy
+// has elapsed. The status of the failed attempt is recorded in RetriesStatus and the status
+// is reset, so that a new pod is created for the TaskRun.
+func (c *Reconciler) retryTaskRun(ctx context.Context, tr *v1beta1.TaskRun, before *apis.Condition) error {
+	logger := logging.FromContext(ctx)
+	retriesDone := len(tr.Status.RetriesStatus)
+	if tr.Status.CompletionTime != nil {
+		if wait := time.Until(tr.Status.CompletionTime.Add(tr.Spec.RetryPolicy.Delay(retriesDone))); wait > 0 {
+			logger.Infof("Retrying taskrun %s in %s", tr.Name, wait)
+			return controller.NewRequeueAfter(wait)
+		}
+	}
+
+	if err := c.metrics.Retry(tr); err != nil {
+		logger.Warnf("Failed to log the metrics : %v", err)
+	}
+
+	newStatus := *tr.Status.DeepCopy()
+	newStatus.RetriesStatus = nil
+	tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, newStatus)
+	tr.Status.StartTime = nil
+	tr.Status.CompletionTime = nil
+	tr.Status.PodName = ""
+	tr.Status.Steps = nil
+	tr.Status.Sidecars = nil
+	tr.Status.TaskRunResults = nil
+	tr.Status.ResourcesResult = nil
+	tr.Status.SetCondition(&apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+	})
+	logger.Infof("Retrying taskrun %s (retry %d of %d)", tr.Name, retriesDone+1, tr.Spec.Retries)
+	return c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil)
+}
+
 func (c *Reconciler) stopSidecars(ctx context.Context, tr *v1beta1.TaskRun) (*corev1.Pod, error) {
 	logger := logging.FromContext(ctx)
 	// do not continue without knowing the associated pod
@@ -368,6 +408,14 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		return nil, nil, controller.NewPermanentError(err)
 	}
 
+	// The params of a TaskRun are only known once the results and matrix of its PipelineTask are
+	// substituted, so their constraints are checked here rather than when validating the PipelineRun.
+	if err := v1beta1.ValidateParamValues(rtr.TaskSpec.Params, tr.Spec.Params); err != nil {
+		logger.Errorf("TaskRun %q params are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
 	if err := c.updateTaskRunWithDefaultWorkspaces(ctx, tr, taskSpec); err != nil {
 		logger.Errorf("Failed to update taskrun %s with default workspace: %v", tr.Name, err)
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
@@ -474,12 +522,32 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 		}
 	}
 
+	// When the results are logged by the results sidecar, wait for it to be done
+	// so that they are read before the TaskRun is marked as done.
+	if podconvert.IsResultsSidecarPending(pod) {
+		logger.Infof("Waiting for the results of taskrun %s/%s to be logged", tr.Namespace, tr.Name)
+		return nil
+	}
+
 	// Convert the Pod's status to the equivalent TaskRun Status.
 	tr.Status, err = podconvert.MakeTaskRunStatus(logger, *tr, pod)
 	if err != nil {
 		return err
 	}
 
+	if tr.IsSuccessful() && podconvert.HasResultsSidecar(pod) {
+		maxResultSize := config.FromContextOrDefaults(ctx).FeatureFlags.MaxResultSize
+		if err := podconvert.AddResultsFromSidecarLogs(ctx, c.KubeClientSet, tr, pod, maxResultSize); err != nil {
+			logger.Errorf("Failed to read the results of taskrun %s: %v", tr.Name, err)
+			reason := podconvert.ReasonFailedResultsExtraction
+			if errors.Is(err, podconvert.ErrResultsTooLarge) {
+				reason = podconvert.ReasonResultsTooLarge
+			}
+			tr.Status.MarkResourceFailed(v1beta1.TaskRunReason(reason), err)
+			return controller.NewPermanentError(err)
+		}
+	}
+
 	logger.Infof("Successfully reconciled taskrun %s/%s with status: %#v", tr.Name, tr.Namespace, tr.Status.GetCondition(apis.ConditionSucceeded))
 	return nil
 }
@@ -682,6 +750,9 @@ func (c *Reconciler) createPod(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 	// Apply step exitCode path substitution
 	ts = resources.ApplyStepExitCodePath(ts)
 
+	// Apply step result path substitution
+	ts = resources.ApplyStepResultPaths(ts)
+
 	if validateErr := ts.Validate(ctx); validateErr != nil {
 		logger.Errorf("Failed to create a pod for taskrun: %s due to task validation error %v", tr.Name, validateErr)
 		return nil, validateErr
@@ -492,7 +492,7 @@ func getRunName(tr *v1beta1.TaskRun) string {
 }
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists, concurrencyExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -509,6 +509,9 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetMetricsConfigName() {
 			metricsExists = true
 		}
+		if cm.Name == config.GetConcurrencyConfigName() {
+			concurrencyExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -540,6 +543,12 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !concurrencyExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetConcurrencyConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
 
 // getTaskRunController returns an instance of the TaskRun controller/reconciler that has been seeded with
@@ -1663,8 +1672,22 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 			},
 		},
 	}
-	taskRuns := []*v1beta1.TaskRun{noTaskRun, withWrongRef}
-	tasks := []*v1beta1.Task{simpleTask}
+	enumTask := &v1beta1.Task{
+		ObjectMeta: objectMeta("test-enum-task", "foo"),
+		Spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "platform", Type: v1beta1.ParamTypeString, Enum: []string{"linux", "mac"}}},
+			Steps:  simpleTask.Spec.Steps,
+		},
+	}
+	withInvalidValue := &v1beta1.TaskRun{
+		ObjectMeta: objectMeta("taskrun-with-invalid-value", "foo"),
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "test-enum-task"},
+			Params:  []v1beta1.Param{{Name: "platform", Value: *v1beta1.NewArrayOrString("windows")}},
+		},
+	}
+	taskRuns := []*v1beta1.TaskRun{noTaskRun, withWrongRef, withInvalidValue}
+	tasks := []*v1beta1.Task{simpleTask, enumTask}
 
 	d := test.Data{
 		TaskRuns: taskRuns,
@@ -1694,6 +1717,15 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 			"Warning Failed",
 			"Warning InternalError",
 		},
+	}, {
+		name:    "task run with a param value not in the enum",
+		taskRun: withInvalidValue,
+		reason:  podconvert.ReasonFailedValidation,
+		wantEvents: []string{
+			"Normal Started",
+			"Warning Failed",
+			"Warning InternalError",
+		},
 	}}
 
 	for _, tc := range testcases {
@@ -1869,6 +1901,86 @@ func makePod(taskRun *v1beta1.TaskRun, task *v1beta1.Task) (*corev1.Pod, error)
 	return builder.Build(context.Background(), taskRun, task.Spec)
 }
 
+func TestReconcileResultsFromSidecarLogs(t *testing.T) {
+	taskRun := &v1beta1.TaskRun{
+		ObjectMeta: objectMeta("test-taskrun-sidecar-logs", "foo"),
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: simpleTask.Name,
+			},
+		},
+	}
+	pod, err := makePod(taskRun, simpleTask)
+	if err != nil {
+		t.Fatalf("MakePod: %v", err)
+	}
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "sidecar-tekton-log-results"})
+	pod.Status = corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  pod.Spec.Containers[0].Name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+		}, {
+			Name:  "sidecar-tekton-log-results",
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		}},
+	}
+	taskRun.Status = v1beta1.TaskRunStatus{
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			PodName: pod.Name,
+		},
+	}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		Tasks:    []*v1beta1.Task{simpleTask},
+		Pods:     []*corev1.Pod{pod},
+	}
+
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	// The steps are done but the results sidecar hasn't logged the results yet.
+	if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		if ok, _ := controller.IsRequeueKey(err); !ok {
+			t.Fatalf("Unexpected error when Reconcile(): %v", err)
+		}
+	}
+	newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if newTr.IsDone() {
+		t.Fatalf("Expected TaskRun %s to wait for the results sidecar but it is done: %v", taskRun.Name, newTr.Status.GetCondition(apis.ConditionSucceeded))
+	}
+
+	// The results sidecar logged the results.
+	pod.Status.ContainerStatuses[1].State = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	if _, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).UpdateStatus(testAssets.Ctx, pod, metav1.UpdateOptions{}); err != nil {
+		t.Errorf("Unexpected error while updating build: %v", err)
+	}
+	testAssets.Informers.TaskRun.Informer().GetIndexer().Add(newTr)
+
+	if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		if ok, _ := controller.IsRequeueKey(err); !ok {
+			t.Fatalf("Unexpected error when Reconcile(): %v", err)
+		}
+	}
+	newTr, err = clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error fetching taskrun: %v", err)
+	}
+	if d := cmp.Diff(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionTrue,
+		Reason:  v1beta1.TaskRunReasonSuccessful.String(),
+		Message: "All Steps have completed executing",
+	}, newTr.Status.GetCondition(apis.ConditionSucceeded), ignoreLastTransitionTime); d != "" {
+		t.Errorf("Did not get expected condition %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestReconcilePodUpdateStatus(t *testing.T) {
 	const taskLabel = "test-task"
 	taskRun := &v1beta1.TaskRun{
@@ -2014,6 +2126,108 @@ func TestReconcileOnCompletedTaskRun(t *testing.T) {
 	}
 }
 
+func TestReconcileRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		retryPolicy *v1beta1.RetryPolicy
+		wantRetried bool
+	}{{
+		name:        "failed taskrun is retried",
+		wantRetried: true,
+	}, {
+		name:        "failed taskrun is retried after the backoff",
+		retryPolicy: &v1beta1.RetryPolicy{Backoff: &metav1.Duration{Duration: time.Hour}},
+	}, {
+		name:        "failure reason is not retried",
+		retryPolicy: &v1beta1.RetryPolicy{Reasons: []string{v1beta1.TaskRunReasonTimedOut.String()}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			failedCondition := apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: v1beta1.TaskRunReasonFailed.String(),
+			}
+			taskRun := &v1beta1.TaskRun{
+				ObjectMeta: objectMeta("test-taskrun-retries", "foo"),
+				Spec: v1beta1.TaskRunSpec{
+					TaskRef: &v1beta1.TaskRef{
+						Name: simpleTask.Name,
+					},
+					Retries:     1,
+					RetryPolicy: tc.retryPolicy,
+				},
+				Status: v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: duckv1beta1.Conditions{failedCondition},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						StartTime:      &metav1.Time{Time: time.Now().Add(-time.Minute)},
+						CompletionTime: &metav1.Time{Time: time.Now()},
+					},
+				},
+			}
+			pod, err := makePod(taskRun, simpleTask)
+			if err != nil {
+				t.Fatalf("MakePod: %v", err)
+			}
+			pod.Status.Phase = corev1.PodFailed
+			taskRun.Status.PodName = pod.Name
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				Tasks:    []*v1beta1.Task{simpleTask},
+				Pods:     []*corev1.Pod{pod},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data: map[string]string{
+						"enable-api-fields": config.AlphaAPIFields,
+					},
+				}},
+			}
+
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+				if ok, _ := controller.IsRequeueKey(err); !ok {
+					t.Fatalf("Unexpected error when Reconcile(): %v", err)
+				}
+			}
+			newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+
+			if !tc.wantRetried {
+				if len(newTr.Status.RetriesStatus) != 0 {
+					t.Errorf("Expected TaskRun %s not to be retried but got retries status %v", taskRun.Name, newTr.Status.RetriesStatus)
+				}
+				if d := cmp.Diff(&failedCondition, newTr.Status.GetCondition(apis.ConditionSucceeded), ignoreLastTransitionTime); d != "" {
+					t.Errorf("Did not get expected condition %s", diff.PrintWantGot(d))
+				}
+				return
+			}
+
+			if len(newTr.Status.RetriesStatus) != 1 {
+				t.Fatalf("Expected TaskRun %s to have 1 retry status but got %d", taskRun.Name, len(newTr.Status.RetriesStatus))
+			}
+			if d := cmp.Diff(&failedCondition, newTr.Status.RetriesStatus[0].GetCondition(apis.ConditionSucceeded), ignoreLastTransitionTime); d != "" {
+				t.Errorf("Did not get expected condition for the failed attempt %s", diff.PrintWantGot(d))
+			}
+			if newTr.Status.RetriesStatus[0].PodName != pod.Name {
+				t.Errorf("Expected the failed attempt to record pod %s but got %q", pod.Name, newTr.Status.RetriesStatus[0].PodName)
+			}
+			if newTr.Status.PodName != "" || newTr.Status.StartTime != nil || newTr.Status.CompletionTime != nil {
+				t.Errorf("Expected the status of TaskRun %s to be reset for the new attempt but got %v", taskRun.Name, newTr.Status)
+			}
+			if c := newTr.Status.GetCondition(apis.ConditionSucceeded); c == nil || !c.IsUnknown() {
+				t.Errorf("Expected TaskRun %s to be running again but got condition %v", taskRun.Name, c)
+			}
+		})
+	}
+}
+
 func TestReconcileOnCancelledTaskRun(t *testing.T) {
 	taskRun := &v1beta1.TaskRun{
 		ObjectMeta: objectMeta("test-taskrun-run-cancelled", "foo"),
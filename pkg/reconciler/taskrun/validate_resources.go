@@ -119,6 +119,11 @@ func validateParams(ctx context.Context, paramSpecs []v1beta1.ParamSpec, params
 		return fmt.Errorf("param types don't match the user-specified type: %s", wrongTypeParamNames)
 	}
 
+	// Make sure the object params provide all the keys declared by the Task, unless the default value provides them.
+	if missingKeys := v1beta1.MissingKeysObjectParamNames(paramSpecs, params); len(missingKeys) != 0 {
+		return fmt.Errorf("missing keys for these object params: %v", missingKeys)
+	}
+
 	return nil
 }
 
@@ -144,6 +144,12 @@ func TestValidateResolvedTaskResources_ValidParams(t *testing.T) {
 					Name: "bar",
 					Type: v1beta1.ParamTypeString,
 				},
+				{
+					Name:       "gitrepo",
+					Type:       v1beta1.ParamTypeObject,
+					Properties: map[string]v1beta1.PropertySpec{"url": {}, "revision": {}},
+					Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "main"}),
+				},
 			},
 		},
 	}
@@ -156,6 +162,9 @@ func TestValidateResolvedTaskResources_ValidParams(t *testing.T) {
 	}, {
 		Name:  "bar",
 		Value: *v1beta1.NewArrayOrString("somethinggood"),
+	}, {
+		Name:  "gitrepo",
+		Value: *v1beta1.NewObject(map[string]string{"revision": "v0.1.0"}),
 	}}
 	if err := taskrun.ValidateResolvedTaskResources(ctx, p, rtr); err != nil {
 		t.Fatalf("Did not expect to see error when validating TaskRun with correct params but saw %v", err)
@@ -189,6 +198,18 @@ func TestValidateResolvedTaskResources_InvalidParams(t *testing.T) {
 					Name: "foo",
 					Type: v1beta1.ParamTypeString,
 				},
+				{
+					Name:       "gitrepo",
+					Type:       v1beta1.ParamTypeObject,
+					Properties: map[string]v1beta1.PropertySpec{"url": {}, "revision": {}},
+					Default:    v1beta1.NewObject(map[string]string{"revision": "main"}),
+				},
+				{
+					Name:    "env",
+					Type:    v1beta1.ParamTypeString,
+					Enum:    []string{"dev", "prod"},
+					Default: v1beta1.NewArrayOrString("dev"),
+				},
 			},
 		},
 	}
@@ -205,6 +226,18 @@ func TestValidateResolvedTaskResources_InvalidParams(t *testing.T) {
 			Name:  "foobar",
 			Value: *v1beta1.NewArrayOrString("somethingfun"),
 		}},
+	}, {
+		name: "missing-object-param-keys",
+		rtr: &resources.ResolvedTaskResources{
+			TaskSpec: &task.Spec,
+		},
+		params: []v1beta1.Param{{
+			Name:  "foo",
+			Value: *v1beta1.NewArrayOrString("somethingfun"),
+		}, {
+			Name:  "gitrepo",
+			Value: *v1beta1.NewObject(map[string]string{"revision": "v0.1.0"}),
+		}},
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
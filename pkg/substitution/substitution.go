@@ -134,6 +134,36 @@ func ValidateVariableIsolatedP(value, prefix string, vars sets.String) *apis.Fie
 	return nil
 }
 
+// ValidateVariableObjectKeysP verifies that variables for object parameters in the provided string reference one
+// of the keys declared for the object, e.g. $(params.foo.key), and never the object as a whole.
+func ValidateVariableObjectKeysP(value, prefix string, objectKeys map[string]sets.String) *apis.FieldError {
+	pattern := fmt.Sprintf(braceMatchingRegex, prefix, parameterSubstitution)
+	re := regexp.MustCompile(pattern)
+	for _, match := range re.FindAllStringSubmatch(value, -1) {
+		v := matchGroups(match, re)["var"]
+		parts := strings.SplitN(v, ".", 2)
+		keys, ok := objectKeys[strings.TrimSuffix(parts[0], "[*]")]
+		if !ok {
+			continue
+		}
+		if len(parts) == 1 || strings.HasSuffix(v, "[*]") {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("variable type invalid in %q", value),
+				// Empty path is required to make the `ViaField`, … work
+				Paths: []string{""},
+			}
+		}
+		if !keys.Has(parts[1]) {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("non-existent object key in %q", value),
+				// Empty path is required to make the `ViaField`, … work
+				Paths: []string{""},
+			}
+		}
+	}
+	return nil
+}
+
 // Extract a the first full string expressions found (e.g "$(input.params.foo)"). Return
 // "" and false if nothing is found.
 func extractExpressionFromString(s, prefix string) (string, bool) {
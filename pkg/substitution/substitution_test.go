@@ -116,6 +116,47 @@ func TestValidateVariables(t *testing.T) {
 	}
 }
 
+func TestValidateVariableObjectKeysP(t *testing.T) {
+	objectKeys := map[string]sets.String{"gitrepo": sets.NewString("url", "revision")}
+	for _, tc := range []struct {
+		name          string
+		input         string
+		expectedError *apis.FieldError
+	}{{
+		name:  "valid keys",
+		input: "git clone $(params.gitrepo.url) --branch $(params.gitrepo.revision) $(params.other)",
+	}, {
+		name:  "whole object",
+		input: "--repo=$(params.gitrepo)",
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "--repo=$(params.gitrepo)"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name:  "whole object with star notation",
+		input: "$(params.gitrepo[*])",
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo[*])"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name:  "undeclared key",
+		input: "$(params.gitrepo.depth)",
+		expectedError: &apis.FieldError{
+			Message: `non-existent object key in "$(params.gitrepo.depth)"`,
+			Paths:   []string{""},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := substitution.ValidateVariableObjectKeysP(tc.input, "params", objectKeys)
+
+			if d := cmp.Diff(got, tc.expectedError, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ValidateVariableObjectKeysP() error did not match expected error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestApplyReplacements(t *testing.T) {
 	type args struct {
 		input        string
@@ -46,6 +46,7 @@ var (
 	namespaceTag   = tag.MustNewKey("namespace")
 	statusTag      = tag.MustNewKey("status")
 	podTag         = tag.MustNewKey("pod")
+	reasonTag      = tag.MustNewKey("reason")
 
 	trDurationView      *view.View
 	prTRDurationView    *view.View
@@ -53,6 +54,7 @@ var (
 	runningTRsCountView *view.View
 	podLatencyView      *view.View
 	cloudEventsView     *view.View
+	trRetriesCountView  *view.View
 
 	trDuration = stats.Float64(
 		"taskrun_duration_seconds",
@@ -79,6 +81,10 @@ var (
 	cloudEvents = stats.Int64("cloudevent_count",
 		"number of cloud events sent including retries",
 		stats.UnitDimensionless)
+
+	trRetriesCount = stats.Float64("taskrun_retries_count",
+		"number of retries of failed taskruns",
+		stats.UnitDimensionless)
 )
 
 // Recorder is used to actually record TaskRun metrics
@@ -212,6 +218,12 @@ func viewRegister(cfg *config.Metrics) error {
 		Aggregation: view.Sum(),
 		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, append(trunTag, prunTag...)...),
 	}
+	trRetriesCountView = &view.View{
+		Description: trRetriesCount.Description(),
+		Measure:     trRetriesCount,
+		Aggregation: view.Count(),
+		TagKeys:     append([]tag.Key{reasonTag, namespaceTag}, trunTag...),
+	}
 	return view.Register(
 		trDurationView,
 		prTRDurationView,
@@ -219,6 +231,7 @@ func viewRegister(cfg *config.Metrics) error {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		trRetriesCountView,
 	)
 }
 
@@ -230,6 +243,7 @@ func viewUnregister() {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		trRetriesCountView,
 	)
 }
 
@@ -468,6 +482,40 @@ func (r *Recorder) CloudEvents(tr *v1beta1.TaskRun) error {
 	return nil
 }
 
+// Retry logs a retry of a failed TaskRun, tagged with the reason of its failure
+// returns an error if it fails to log the metrics
+func (r *Recorder) Retry(tr *v1beta1.TaskRun) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	taskName := "anonymous"
+	if tr.Spec.TaskRef != nil {
+		taskName = tr.Spec.TaskRef.Name
+	}
+
+	reason := ""
+	if cond := tr.Status.GetCondition(apis.ConditionSucceeded); cond != nil {
+		reason = cond.Reason
+	}
+
+	ctx, err := tag.New(
+		context.Background(),
+		append([]tag.Mutator{tag.Insert(namespaceTag, tr.Namespace),
+			tag.Insert(reasonTag, reason)},
+			r.insertTaskTag(taskName, tr.Name)...)...)
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, trRetriesCount.M(1))
+
+	return nil
+}
+
 func sentCloudEvents(tr *v1beta1.TaskRun) int64 {
 	var sent int64
 	for _, event := range tr.Status.CloudEvents {
@@ -71,6 +71,9 @@ func TestUninitializedMetrics(t *testing.T) {
 	if err := metrics.CloudEvents(&v1beta1.TaskRun{}); err == nil {
 		t.Error("Cloud Events recording expected to return error but got nil")
 	}
+	if err := metrics.Retry(&v1beta1.TaskRun{}); err == nil {
+		t.Error("Retries recording expected to return error but got nil")
+	}
 }
 
 func TestMetricsOnStore(t *testing.T) {
@@ -559,8 +562,47 @@ func TestRecordCloudEvents(t *testing.T) {
 	}
 }
 
+func TestRecordTaskRunRetries(t *testing.T) {
+	unregisterMetrics()
+
+	taskRun := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-1", Namespace: "ns"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "task-1"},
+			Retries: 2,
+		},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+					Reason: v1beta1.TaskRunReasonTimedOut.String(),
+				}},
+			},
+		},
+	}
+
+	ctx := getConfigContext()
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := metrics.Retry(taskRun); err != nil {
+			t.Fatalf("Retry: %v", err)
+		}
+	}
+	metricstest.CheckCountData(t, "taskrun_retries_count", map[string]string{
+		"task":      "task-1",
+		"taskrun":   "taskrun-1",
+		"namespace": "ns",
+		"reason":    "TaskRunTimeout",
+	}, 2)
+}
+
 func unregisterMetrics() {
-	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count")
+	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count", "taskrun_retries_count")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
@@ -30,6 +30,7 @@ import (
 	informersv1beta1 "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1beta1"
 	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
 	fakeconditioninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/condition/fake"
+	fakepipelinerunscheduleinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/pipelinerunschedule/fake"
 	fakeruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run/fake"
 	fakeclustertaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/clustertask/fake"
 	fakepipelineinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipeline/fake"
@@ -63,19 +64,20 @@ import (
 // Data represents the desired state of the system (i.e. existing resources) to seed controllers
 // with.
 type Data struct {
-	PipelineRuns      []*v1beta1.PipelineRun
-	Pipelines         []*v1beta1.Pipeline
-	TaskRuns          []*v1beta1.TaskRun
-	Tasks             []*v1beta1.Task
-	ClusterTasks      []*v1beta1.ClusterTask
-	PipelineResources []*v1alpha1.PipelineResource
-	Conditions        []*v1alpha1.Condition
-	Runs              []*v1alpha1.Run
-	Pods              []*corev1.Pod
-	Namespaces        []*corev1.Namespace
-	ConfigMaps        []*corev1.ConfigMap
-	ServiceAccounts   []*corev1.ServiceAccount
-	LimitRange        []*corev1.LimitRange
+	PipelineRuns         []*v1beta1.PipelineRun
+	Pipelines            []*v1beta1.Pipeline
+	TaskRuns             []*v1beta1.TaskRun
+	Tasks                []*v1beta1.Task
+	ClusterTasks         []*v1beta1.ClusterTask
+	PipelineResources    []*v1alpha1.PipelineResource
+	Conditions           []*v1alpha1.Condition
+	Runs                 []*v1alpha1.Run
+	PipelineRunSchedules []*v1alpha1.PipelineRunSchedule
+	Pods                 []*corev1.Pod
+	Namespaces           []*corev1.Namespace
+	ConfigMaps           []*corev1.ConfigMap
+	ServiceAccounts      []*corev1.ServiceAccount
+	LimitRange           []*corev1.LimitRange
 }
 
 // Clients holds references to clients which are useful for reconciler tests.
@@ -88,18 +90,19 @@ type Clients struct {
 
 // Informers holds references to informers which are useful for reconciler tests.
 type Informers struct {
-	PipelineRun      informersv1beta1.PipelineRunInformer
-	Pipeline         informersv1beta1.PipelineInformer
-	TaskRun          informersv1beta1.TaskRunInformer
-	Run              informersv1alpha1.RunInformer
-	Task             informersv1beta1.TaskInformer
-	ClusterTask      informersv1beta1.ClusterTaskInformer
-	PipelineResource resourceinformersv1alpha1.PipelineResourceInformer
-	Condition        informersv1alpha1.ConditionInformer
-	Pod              coreinformers.PodInformer
-	ConfigMap        coreinformers.ConfigMapInformer
-	ServiceAccount   coreinformers.ServiceAccountInformer
-	LimitRange       coreinformers.LimitRangeInformer
+	PipelineRun         informersv1beta1.PipelineRunInformer
+	Pipeline            informersv1beta1.PipelineInformer
+	TaskRun             informersv1beta1.TaskRunInformer
+	Run                 informersv1alpha1.RunInformer
+	PipelineRunSchedule informersv1alpha1.PipelineRunScheduleInformer
+	Task                informersv1beta1.TaskInformer
+	ClusterTask         informersv1beta1.ClusterTaskInformer
+	PipelineResource    resourceinformersv1alpha1.PipelineResourceInformer
+	Condition           informersv1alpha1.ConditionInformer
+	Pod                 coreinformers.PodInformer
+	ConfigMap           coreinformers.ConfigMapInformer
+	ServiceAccount      coreinformers.ServiceAccountInformer
+	LimitRange          coreinformers.LimitRangeInformer
 }
 
 // Assets holds references to the controller, logs, clients, and informers.
@@ -170,18 +173,19 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 	PrependResourceVersionReactor(&c.Pipeline.Fake)
 
 	i := Informers{
-		PipelineRun:      fakepipelineruninformer.Get(ctx),
-		Pipeline:         fakepipelineinformer.Get(ctx),
-		TaskRun:          faketaskruninformer.Get(ctx),
-		Run:              fakeruninformer.Get(ctx),
-		Task:             faketaskinformer.Get(ctx),
-		ClusterTask:      fakeclustertaskinformer.Get(ctx),
-		PipelineResource: fakeresourceinformer.Get(ctx),
-		Condition:        fakeconditioninformer.Get(ctx),
-		Pod:              fakefilteredpodinformer.Get(ctx, v1beta1.ManagedByLabelKey),
-		ConfigMap:        fakeconfigmapinformer.Get(ctx),
-		ServiceAccount:   fakeserviceaccountinformer.Get(ctx),
-		LimitRange:       fakelimitrangeinformer.Get(ctx),
+		PipelineRun:         fakepipelineruninformer.Get(ctx),
+		Pipeline:            fakepipelineinformer.Get(ctx),
+		TaskRun:             faketaskruninformer.Get(ctx),
+		Run:                 fakeruninformer.Get(ctx),
+		PipelineRunSchedule: fakepipelinerunscheduleinformer.Get(ctx),
+		Task:                faketaskinformer.Get(ctx),
+		ClusterTask:         fakeclustertaskinformer.Get(ctx),
+		PipelineResource:    fakeresourceinformer.Get(ctx),
+		Condition:           fakeconditioninformer.Get(ctx),
+		Pod:                 fakefilteredpodinformer.Get(ctx, v1beta1.ManagedByLabelKey),
+		ConfigMap:           fakeconfigmapinformer.Get(ctx),
+		ServiceAccount:      fakeserviceaccountinformer.Get(ctx),
+		LimitRange:          fakelimitrangeinformer.Get(ctx),
 	}
 
 	// Attach reactors that add resource mutations to the appropriate
@@ -243,6 +247,13 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Pipeline.PrependReactor("*", "pipelinerunschedules", AddToInformer(t, i.PipelineRunSchedule.Informer().GetIndexer()))
+	for _, s := range d.PipelineRunSchedules {
+		s := s.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().PipelineRunSchedules(s.Namespace).Create(ctx, s, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
 	c.Kube.PrependReactor("*", "pods", AddToInformer(t, i.Pod.Informer().GetIndexer()))
 	for _, p := range d.Pods {
 		p := p.DeepCopy() // Avoid assumptions that the informer's copy is modified.